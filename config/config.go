@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"vk_quests/pkg/logger"
 
@@ -10,9 +11,17 @@ import (
 
 type (
 	Config struct {
-		Port       string     `yaml:"port"`
-		Postgres   PG         `yaml:"postgres"`
-		LoggerInfo LoggerInfo `yaml:"logger"`
+		Port        string      `yaml:"port"`
+		Postgres    PG          `yaml:"postgres"`
+		LoggerInfo  LoggerInfo  `yaml:"logger"`
+		Idempotency Idempotency `yaml:"idempotency"`
+		Transfer    Transfer    `yaml:"transfer"`
+		Attempts    Attempts    `yaml:"attempts"`
+		Revoke      Revoke      `yaml:"revoke"`
+		Delete      Delete      `yaml:"delete"`
+		Streak      Streak      `yaml:"streak"`
+		Referral    Referral    `yaml:"referral"`
+		Admin       Admin       `yaml:"admin"`
 	}
 
 	LoggerInfo struct {
@@ -24,7 +33,41 @@ type (
 	}
 
 	PG struct {
-		URL string `yaml:"url"`
+		URL          string        `yaml:"url"`
+		QueryTimeout time.Duration `yaml:"query_timeout" env-default:"5s"` // deadline of database queries of one request
+	}
+
+	Idempotency struct {
+		TTL time.Duration `yaml:"ttl" env-default:"24h"` // how long result of request with idempotency key is stored
+	}
+
+	Transfer struct {
+		DailyLimit uint64 `yaml:"daily_limit" env-default:"0"` // max sum of transfers of one user during last day, 0 - no limit
+	}
+
+	Attempts struct {
+		DailyLimit uint32 `yaml:"daily_limit" env-default:"0"` // max attempts of one random quest by user during last day, 0 - no limit
+	}
+
+	Revoke struct {
+		Policy string `yaml:"policy" env-default:"reject"` // how spent reward of revoked completion is clawed back: reject, partial or negative
+	}
+
+	Delete struct {
+		Policy string `yaml:"policy" env-default:"soft"` // what deletion of user does with its data: soft, anonymize or hard
+	}
+
+	Streak struct {
+		Timezone string `yaml:"timezone" env-default:"UTC"` // IANA timezone of calendar days of streak quests
+	}
+
+	Referral struct {
+		QuestId uint64 `yaml:"quest_id" env-default:"0"` // quest paying referral bonus when invited user completes it, 0 - no bonus
+		Bonus   uint32 `yaml:"bonus" env-default:"0"`    // bonus paid to invited user and to its referrer
+	}
+
+	Admin struct {
+		Token string `yaml:"token" env:"ADMIN_TOKEN"` // token of admin-only operations, empty - they are forbidden
 	}
 )
 
@@ -18,9 +18,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/quest": {
+        "/boost": {
             "post": {
-                "description": "Добавляет задание включая его название(уникальное), описание, стоимость и тип. Есть обычная задание, которое выполняется как только вызывается метод, сигнализирующий о выполнении для пользователя задачи. И случайная задача, которая выполняется в с вероятностью 0,5.",
+                "description": "Добавляет акцию, умножающую награды за задания, выполненные в период её действия. Акцию можно ограничить заданием, категорией или типом заданий, без ограничений она действует на все задания. Если подходит несколько акций, применяется наибольший множитель.",
                 "consumes": [
                     "application/json"
                 ],
@@ -28,35 +28,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "quest"
+                    "boost"
                 ],
-                "summary": "Добавление задание.",
+                "summary": "Добавление акции.",
                 "parameters": [
                     {
-                        "description": "Информация о добавляемом фильме",
+                        "description": "Информация о добавляемой акции",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateQuest"
+                            "$ref": "#/definitions/request.CreateBoost"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Задание успешно добавлен в базу",
+                        "description": "Акция успешно добавлена в базу",
                         "schema": {
-                            "$ref": "#/definitions/response.Quest"
+                            "$ref": "#/definitions/response.Boost"
                         }
                     },
                     "400": {
-                        "description": "В теле запроса ошибка",
+                        "description": "В теле запроса ошибка или starts_at не раньше ends_at",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
                     },
-                    "409": {
-                        "description": "Задача с таким название уже существует",
+                    "422": {
+                        "description": "Задание или категория акции не найдены",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
@@ -66,27 +66,33 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             }
         },
-        "/quest/list": {
+        "/boost/list": {
             "get": {
-                "description": "Позволяет получить список заданий.",
+                "description": "Возвращает все акции, включая завершившиеся, упорядоченные по началу действия.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quest"
+                    "boost"
                 ],
-                "summary": "Получение списка заданий.",
+                "summary": "Получение списка акций.",
                 "responses": {
                     "200": {
-                        "description": "Список заданий успешно сформирован",
+                        "description": "Список акций",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.Quest"
+                                "$ref": "#/definitions/response.Boost"
                             }
                         }
                     },
@@ -95,47 +101,50 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             }
         },
-        "/quest/{quest_id}": {
+        "/boost/{boost_id}": {
             "get": {
-                "description": "Позволяет информацию о задании по его id.",
+                "description": "Возвращает информацию об акции по её id.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quest"
+                    "boost"
                 ],
-                "summary": "Получение задания.",
+                "summary": "Получение акции.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Уникальный идентификатор задания",
-                        "name": "quest_id",
+                        "description": "Уникальный идентификатор акции",
+                        "name": "boost_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Полученное задание",
+                        "description": "Полученная акция",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.Quest"
-                            }
+                            "$ref": "#/definitions/response.Boost"
                         }
                     },
                     "400": {
-                        "description": "В пути запросе ошибка",
+                        "description": "В пути запроса ошибка",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
                     },
                     "404": {
-                        "description": "Задание с указанным id не найдено",
+                        "description": "Акция с указанным id не найдена",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
@@ -145,11 +154,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             },
             "put": {
-                "description": "Обновляет данные об задании. Все переданные поля будут обновлены. Отсутствующие поля будут оставлены без изменений.",
+                "description": "Обновляет название, множитель, период действия и ограничения акции. Отсутствующие поля будут оставлены без изменений. Переданные quest_id, category_id и quest_type заменяют ограничения акции, а значения 0 и пустая строка снимают их.",
                 "consumes": [
                     "application/json"
                 ],
@@ -157,14 +172,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "quest"
+                    "boost"
                 ],
-                "summary": "Обновление данных об задании.",
+                "summary": "Обновление акции.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Уникальный идентификатор задания",
-                        "name": "quest_id",
+                        "description": "Уникальный идентификатор акции",
+                        "name": "boost_id",
                         "in": "path",
                         "required": true
                     },
@@ -174,25 +189,31 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateQuest"
+                            "$ref": "#/definitions/request.UpdateBoost"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Задание успешно обновлено в базе",
+                        "description": "Акция успешно обновлена в базе",
                         "schema": {
-                            "$ref": "#/definitions/response.Quest"
+                            "$ref": "#/definitions/response.Boost"
                         }
                     },
                     "400": {
-                        "description": "В теле запроса ошибка",
+                        "description": "В теле или пути запроса ошибка или starts_at не раньше ends_at",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
                     },
                     "404": {
-                        "description": "Задание с указанным id не найден",
+                        "description": "Акция с указанным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "422": {
+                        "description": "Задание или категория акции не найдены",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
@@ -202,30 +223,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Удаляет информацию о задании из системы по его id.",
+                "description": "Удаляет акцию по её id. Уже начисленные с множителем акции награды не меняются.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quest"
+                    "boost"
                 ],
-                "summary": "Удаление задания.",
+                "summary": "Удаление акции.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Уникальный идентификатор задания",
-                        "name": "quest_id",
+                        "description": "Уникальный идентификатор акции",
+                        "name": "boost_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Задание успешно удалено"
+                        "description": "Акция успешно удалена"
                     },
                     "400": {
                         "description": "В пути запроса ошибка",
@@ -234,7 +261,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Задание с указанным id не найдено",
+                        "description": "Акция с указанным id не найдена",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
@@ -244,13 +271,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             }
         },
-        "/user": {
+        "/category": {
             "post": {
-                "description": "Добавляет пользователя включая его имя. Баланс пользователя при создании 0.",
+                "description": "Добавляет категорию заданий с уникальным названием и описанием. Категории используются клиентом для группировки заданий по вкладкам.",
                 "consumes": [
                     "application/json"
                 ],
@@ -258,25 +291,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "category"
                 ],
-                "summary": "Добавление пользователя.",
+                "summary": "Добавление категории.",
                 "parameters": [
                     {
-                        "description": "Информация о добавляемом пользователе",
+                        "description": "Информация о добавляемой категории",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.User"
+                            "$ref": "#/definitions/request.CreateCategory"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Пользователь успешно добавлен в базу",
+                        "description": "Категория успешно добавлена в базу",
                         "schema": {
-                            "$ref": "#/definitions/response.User"
+                            "$ref": "#/definitions/response.Category"
                         }
                     },
                     "400": {
@@ -285,71 +318,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/operate.ModelError"
                         }
                     },
+                    "409": {
+                        "description": "Категория с таким названием уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             }
         },
-        "/user/complete": {
-            "post": {
-                "description": "Обрабатывает информацию о выполнение условии для определённого пользователя определённого задания по их идентификаторам.",
+        "/category/list": {
+            "get": {
+                "description": "Возвращает все категории заданий, упорядоченные по id.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
-                ],
-                "summary": "Сообщение о выполнение условии для определённого пользователя определённого задания.",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Уникальный идентификатор пользователи",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Уникальный идентификатор задачи",
-                        "name": "quest_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "category"
                 ],
+                "summary": "Получение списка категорий.",
                 "responses": {
                     "200": {
-                        "description": "Результат применения задания к пользователю. Если 'success' - то задача засчитана пользователю, иначе не засчитана",
+                        "description": "Список категорий",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.StatusApplyCost"
+                                "$ref": "#/definitions/response.Category"
                             }
                         }
                     },
-                    "400": {
-                        "description": "В параметрах запроса ошибка",
-                        "schema": {
-                            "$ref": "#/definitions/operate.ModelError"
-                        }
-                    },
-                    "404": {
-                        "description": "Пользователь или задача не найдены",
-                        "schema": {
-                            "$ref": "#/definitions/operate.ModelError"
-                        }
-                    },
-                    "409": {
-                        "description": "Данную задачу пользователь уже выполнил",
+                    "500": {
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
@@ -357,24 +374,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/list": {
+        "/category/{category_id}": {
             "get": {
-                "description": "Формирует список всех пользователей в системы.",
+                "description": "Возвращает информацию о категории по её id.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "category"
+                ],
+                "summary": "Получение категории.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор категории",
+                        "name": "category_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение списка пользователь.",
                 "responses": {
                     "200": {
-                        "description": "Список пользователей успешно сформирован",
+                        "description": "Полученная категория",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.User"
-                            }
+                            "$ref": "#/definitions/response.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория с указанным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
                         }
                     },
                     "500": {
@@ -382,13 +417,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
-            }
-        },
-        "/user/{user_id}": {
+            },
             "put": {
-                "description": "Обновляет имя пользователя по его id.",
+                "description": "Обновляет название и описание категории. Отсутствующие поля будут оставлены без изменений.",
                 "consumes": [
                     "application/json"
                 ],
@@ -396,14 +435,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "category"
                 ],
-                "summary": "Обновление данных об пользователе.",
+                "summary": "Обновление категории.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Уникальный идентификатор пользователя",
-                        "name": "user_id",
+                        "description": "Уникальный идентификатор категории",
+                        "name": "category_id",
                         "in": "path",
                         "required": true
                     },
@@ -413,25 +452,31 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.User"
+                            "$ref": "#/definitions/request.UpdateCategory"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пользователь успешно обновлен в базе",
+                        "description": "Категория успешно обновлена в базе",
                         "schema": {
-                            "$ref": "#/definitions/response.User"
+                            "$ref": "#/definitions/response.Category"
                         }
                     },
                     "400": {
-                        "description": "В теле запроса ошибка",
+                        "description": "В теле или пути запроса ошибка",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
                     },
                     "404": {
-                        "description": "Пользователь с указанным id не найден",
+                        "description": "Категория с указанным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Категория с таким названием уже существует",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
@@ -441,42 +486,45 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Удаляет информацию об пользователе по его id.",
+                "description": "Удаляет категорию по её id. Задания категории остаются, но перестают к ней относиться.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "category"
                 ],
-                "summary": "Удаление пользователя.",
+                "summary": "Удаление категории.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Уникальный идентификатор пользователя",
-                        "name": "user_id",
+                        "description": "Уникальный идентификатор категории",
+                        "name": "category_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пользователь успешно удалён",
-                        "schema": {
-                            "$ref": "#/definitions/response.User"
-                        }
+                        "description": "Категория успешно удалена"
                     },
                     "400": {
-                        "description": "В пути запросе ошибка",
+                        "description": "В пути запроса ошибка",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
                     },
                     "404": {
-                        "description": "Пользователь с указанным id не найден",
+                        "description": "Категория с указанным id не найдена",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
@@ -486,41 +534,72 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             }
         },
-        "/user/{user_id}/history": {
+        "/leaderboard": {
             "get": {
-                "description": "Формирует список выполненных заданий пользователя по его id. Если задача была удалена, то информация о ней не будет выводиться.",
+                "description": "Возвращает первые limit пользователей рейтинга с их местом и очками.\nМетрика earned - сумма наград за выполнение заданий за период window: всё время, текущую неделю или текущий месяц.\nМетрика balance - текущий баланс, поддерживается только для window=all.\nПользователи с равными очками делят место, удалённые пользователи в рейтинг не попадают.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "leaderboard"
                 ],
-                "summary": "Получение истории выполнения заданий пользователем.",
+                "summary": "Получение рейтинга пользователей.",
                 "parameters": [
                     {
+                        "enum": [
+                            "all",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Период рейтинга",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "earned",
+                            "balance"
+                        ],
+                        "type": "string",
+                        "default": "earned",
+                        "description": "Метрика рейтинга",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "Уникальный идентификатор пользователя",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "default": 50,
+                        "description": "Размер рейтинга",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список выполненных заданий пользователя сформирован",
+                        "description": "Рейтинг успешно сформирован",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.HistoryRecord"
+                                "$ref": "#/definitions/response.LeaderboardEntry"
                             }
                         }
                     },
                     "400": {
-                        "description": "В пути запроса ошибка",
+                        "description": "Некорректные параметры запроса",
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
@@ -530,102 +609,2798 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/operate.ModelError"
                         }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "operate.ModelError": {
-            "type": "object",
-            "properties": {
-                "error_message": {
-                    "type": "string"
-                }
-            }
-        },
-        "request.CreateQuest": {
-            "type": "object",
-            "properties": {
-                "cost": {
-                    "type": "integer",
-                    "format": "uint8",
-                    "maximum": 1000,
-                    "minimum": 0,
-                    "example": 9
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Random quest"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Task"
-                },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "usual",
-                        "random"
-                    ],
-                    "example": "random"
-                }
-            }
         },
-        "request.UpdateQuest": {
-            "type": "object",
-            "properties": {
-                "cost": {
-                    "type": "integer",
-                    "format": "uint8",
-                    "maximum": 1000,
-                    "minimum": 0,
-                    "example": 9
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Random quest"
-                },
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "usual",
-                        "random"
+        "/quest": {
+            "post": {
+                "description": "Добавляет задание включая его название(уникальное), описание, стоимость и тип. Есть обычная задание, которое выполняется как только вызывается метод, сигнализирующий о выполнении для пользователя задачи. И случайная задача, которая выполняется с вероятностью из поля probability (по умолчанию 0,5); если задано поле pity, задача гарантированно засчитывается после указанного числа неудачных попыток подряд. Также есть многошаговое задание, для которого необходимо передать упорядоченный список шагов: каждый вызов метода выполнения продвигает пользователя на один шаг, а награда начисляется после последнего шага. По умолчанию задание можно выполнить один раз: поле max_completions задаёт максимальное число выполнений (0 - без ограничений), а cooldown - минимальный перерыв между выполнениями в секундах. В поле prerequisites можно передать id заданий, которые пользователь должен выполнить до этого задания. Поле categories содержит id категорий задания, а tags - произвольные метки. Поля starts_at и ends_at в формате 02.01.2006 - 15:04:05 (UTC) задают период, в который задание засчитывается. Командное задание выполняется участниками команды: каждый участник делает один вклад, и когда вклад внесли target участников, награда начисляется всем внёсшим вклад - поровну (reward_split=equal, по умолчанию) или целиком каждому (reward_split=fixed).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Добавление задание.",
+                "parameters": [
+                    {
+                        "description": "Информация о добавляемом фильме",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateQuest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Задание успешно добавлен в базу",
+                        "schema": {
+                            "$ref": "#/definitions/response.Quest"
+                        }
+                    },
+                    "400": {
+                        "description": "В теле запроса ошибка, у многошагового задания нет шагов, у задания-счётчика нет цели, у задания-серии нет наград, у командного задания нет цели или starts_at не раньше ends_at",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Задача с таким название уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "422": {
+                        "description": "Одно из обязательных предварительных заданий не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/quest/list": {
+            "get": {
+                "description": "Формирует страницу списка заданий с фильтрацией и сортировкой.\nДля получения следующей страницы передайте next_cursor из ответа с теми же sort и order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Получение списка заданий.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "usual",
+                            "random",
+                            "staged",
+                            "counter",
+                            "streak",
+                            "team"
+                        ],
+                        "type": "string",
+                        "description": "Тип задания",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальная стоимость",
+                        "name": "min_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальная стоимость",
+                        "name": "max_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Префикс названия задания",
+                        "name": "name_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Только задания категории с указанным id",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Только задания с указанной меткой",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только задания, период выполнения которых включает текущий момент",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только архивные задания вместо неархивных",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "cost"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Поле сортировки",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Порядок сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Размер страницы",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница заданий успешно сформирована",
+                        "schema": {
+                            "$ref": "#/definitions/response.QuestsPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/quest/{quest_id}": {
+            "get": {
+                "description": "Позволяет информацию о задании по его id, в том числе архивного.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Получение задания.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор задания",
+                        "name": "quest_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Полученное задание",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.Quest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запросе ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Задание с указанным id не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные об задании. Все переданные поля будут обновлены. Отсутствующие поля будут оставлены без изменений. Переданный список prerequisites заменяет текущий, пустой список удаляет все предварительные задания. Так же заменяются списки categories и tags. Список, образующий цикл в цепочке заданий, отклоняется. Задание, тип которого меняется на streak без поля max_completions, получает неограниченное число выполнений.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Обновление данных об задании.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор задания",
+                        "name": "quest_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Информация об обновлении",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateQuest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Задание успешно обновлено в базе",
+                        "schema": {
+                            "$ref": "#/definitions/response.Quest"
+                        }
+                    },
+                    "400": {
+                        "description": "В теле запроса ошибка, у многошагового задания нет шагов, у задания-счётчика нет цели, у задания-серии нет наград, у командного задания нет цели или starts_at не раньше ends_at",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Задание с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Предварительные задания образуют цикл",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "422": {
+                        "description": "Одно из обязательных предварительных заданий не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Переносит задание в архив по его id. Архивное задание скрыто из списков и не может быть выполнено, а история его выполнений сохраняется. Задание можно вернуть из архива методом restore.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Архивация задания.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор задания",
+                        "name": "quest_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Задание успешно перенесено в архив"
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Задание с указанным id не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/quest/{quest_id}/purge": {
+            "delete": {
+                "description": "Удаляет архивное задание из системы по его id. Задание убирается из предварительных заданий других заданий, а в истории выполнений остаётся только его снимок. Метод доступен только администраторам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Окончательное удаление задания.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор задания",
+                        "name": "quest_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен администратора",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Задание успешно удалено"
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "403": {
+                        "description": "Неверный токен администратора",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Задание с указанным id не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Задание не перенесено в архив",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/quest/{quest_id}/restore": {
+            "post": {
+                "description": "Возвращает задание из архива по его id, после чего оно снова отображается в списках и может быть выполнено.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quest"
+                ],
+                "summary": "Восстановление задания из архива.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор задания",
+                        "name": "quest_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Задание успешно восстановлено",
+                        "schema": {
+                            "$ref": "#/definitions/response.Quest"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Задание с указанным id не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/team": {
+            "post": {
+                "description": "Добавляет команду с уникальным названием. Участники команды вместе выполняют командные задания.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Добавление команды.",
+                "parameters": [
+                    {
+                        "description": "Информация о добавляемой команде",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateTeam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Команда успешно добавлена в базу",
+                        "schema": {
+                            "$ref": "#/definitions/response.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "В теле запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Команда с таким названием уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/list": {
+            "get": {
+                "description": "Возвращает все команды без участников, упорядоченные по id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Получение списка команд.",
+                "responses": {
+                    "200": {
+                        "description": "Список команд",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.Team"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/{team_id}": {
+            "get": {
+                "description": "Возвращает команду по её id вместе с участниками, упорядоченными по времени вступления. Удалённые пользователи не возвращаются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Получение команды.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор команды",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Полученная команда",
+                        "schema": {
+                            "$ref": "#/definitions/response.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда с указанным id не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/{team_id}/member/{user_id}": {
+            "post": {
+                "description": "Добавляет пользователя в команду. Пользователь может состоять только в одной команде.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Добавление участника команды.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор команды",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Пользователь успешно добавлен в команду",
+                        "schema": {
+                            "$ref": "#/definitions/response.TeamMember"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда или пользователь не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет пользователя из команды. Его вклады в ещё не выполненные командные задания отменяются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Удаление участника команды.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор команды",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь успешно удалён из команды"
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "post": {
+                "description": "Добавляет пользователя включая его имя. Баланс пользователя при создании 0.\nПриглашённый пользователь передаёт реферальный код пригласившего referral_code. Когда приглашённый пользователь\nвпервые получает награду за задачу referral.quest_id из конфигурации, он и пригласивший получают бонус referral.bonus.\nПригласить может только существующий неудалённый пользователь, поэтому нельзя пригласить себя или замкнуть цепочку приглашений.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Добавление пользователя.",
+                "parameters": [
+                    {
+                        "description": "Информация о добавляемом пользователе",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Пользователь успешно добавлен в базу, ответ содержит его реферальный код",
+                        "schema": {
+                            "$ref": "#/definitions/response.User"
+                        }
+                    },
+                    "400": {
+                        "description": "В теле запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "422": {
+                        "description": "Пользователь с указанным реферальным кодом не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/complete": {
+            "post": {
+                "description": "Обрабатывает информацию о выполнение условии для определённого пользователя определённого задания по их идентификаторам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Сообщение о выполнение условии для определённого пользователя определённого задания.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователи",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор задачи",
+                        "name": "quest_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Количество, на которое продвигается задание-счётчик, по умолчанию 1",
+                        "name": "amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности. Повторный запрос с тем же ключом возвращает результат первого запроса без повторного выполнения задачи",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат применения задания к пользователю. Если 'success' - то задача засчитана пользователю, если 'in_progress' - то засчитан очередной шаг многошагового задания, накоплена часть цели задания-счётчика, продлена серия без награды или внесён вклад в командное задание, иначе не засчитана. Для многошаговых заданий, заданий-счётчиков, заданий-серий и командных заданий возвращается прогресс",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.StatusApplyCost"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "В параметрах запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "403": {
+                        "description": "Задача сейчас вне периода выполнения или пользователь, выполняющий командную задачу, не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь или задача не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже выполнил данную задачу максимальное число раз, уже продлил серию сегодня, уже внёс вклад в командную задачу или запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "410": {
+                        "description": "Задача перенесена в архив",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "412": {
+                        "description": "Пользователь не выполнил предварительные задания",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другими параметрами",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "429": {
+                        "description": "Задача выполнена повторно раньше окончания перерыва, в заголовке Retry-After указано число секунд до его окончания, или исчерпан дневной лимит попыток случайной задачи",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/list": {
+            "get": {
+                "description": "Формирует страницу списка пользователей с фильтрацией и сортировкой.\nДля получения следующей страницы передайте next_cursor из ответа с теми же sort и order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение списка пользователей.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Префикс имени пользователя",
+                        "name": "name_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальный баланс",
+                        "name": "min_balance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальный баланс",
+                        "name": "max_balance",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "balance"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Поле сортировки",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Порядок сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Размер страницы",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница пользователей успешно сформирована",
+                        "schema": {
+                            "$ref": "#/definitions/response.UsersPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}": {
+            "get": {
+                "description": "Возвращает информацию о пользователе по его id вместе со статистикой выполненных заданий:\nчислом выполнений, суммой наград, временем последнего выполнения и разбивкой по типам заданий.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение пользователя.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Полученный пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserStats"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запросе ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет имя пользователя по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Обновление данных об пользователе.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Информация об обновлении",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь успешно обновлен в базе",
+                        "schema": {
+                            "$ref": "#/definitions/response.User"
+                        }
+                    },
+                    "400": {
+                        "description": "В теле запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет пользователя по его id согласно политике удаления из конфигурации: soft - пользователь скрывается, но его данные сохраняются,\nanonymize - дополнительно стирается имя пользователя, hard - пользователь удаляется вместе с историей и журналом операций.\nУдалённый пользователь не находится остальными методами, кроме выгрузки данных.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Удаление пользователя.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь успешно удалён",
+                        "schema": {
+                            "$ref": "#/definitions/response.User"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запросе ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/anonymize": {
+            "post": {
+                "description": "Стирает имя пользователя по его id и удаляет его, история выполнений и журнал операций пользователя сохраняются.\nУдалённый ранее пользователь тоже обезличивается. Метод доступен только администраторам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Обезличивание пользователя.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен администратора",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь успешно обезличен",
+                        "schema": {
+                            "$ref": "#/definitions/response.User"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "403": {
+                        "description": "Неверный токен администратора",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/attempts": {
+            "get": {
+                "description": "Формирует страницу попыток пользователя выполнить случайные задания, упорядоченных по времени попытки.\nКаждая попытка содержит её результат (success) и выпавшее значение (roll), которое не указывается, если выполнение гарантировано полем pity задания.\nДля получения следующей страницы передайте next_cursor из ответа с тем же order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение попыток выполнения случайных заданий пользователем.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор задания",
+                        "name": "quest_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Порядок сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Размер страницы",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница попыток пользователя сформирована",
+                        "schema": {
+                            "$ref": "#/definitions/response.AttemptsPage"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути или параметрах запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/debit": {
+            "post": {
+                "description": "Списывает указанное число баллов с баланса пользователя по его id и записывает операцию в журнал транзакций.\nБаланс пользователя не может стать отрицательным. Метод доступен только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Списание баллов с баланса пользователя.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен администратора",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Сумма и причина списания",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Debit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Баллы успешно списаны",
+                        "schema": {
+                            "$ref": "#/definitions/response.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "В теле или пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "402": {
+                        "description": "На балансе пользователя недостаточно баллов",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "403": {
+                        "description": "Неверный токен администратора",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/export": {
+            "get": {
+                "description": "Формирует все данные, хранящиеся о пользователе: профиль, историю выполнений заданий и попытки выполнения случайных заданий.\nДанные удалённого пользователя тоже выгружаются, время удаления указывается в поле deleted_at. Метод доступен только администраторам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Выгрузка данных пользователя.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен администратора",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя сформированы",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserExport"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "403": {
+                        "description": "Неверный токен администратора",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/history": {
+            "get": {
+                "description": "Формирует страницу выполненных заданий пользователя по его id, упорядоченных по времени выполнения.\nКаждая запись содержит состояние задания и награду на момент выполнения (completed_quest и award),\nа также текущее состояние задания (quest), если оно не было удалено. Источник записи source - выполнение задания (quest)\nили бонус за приглашение (referral), полученный за выполнение задания приглашённым пользователем, а также\nисходящий (transfer_out) или входящий (transfer_in) перевод: у переводов нет completed_quest, award содержит\nсумму перевода, а counterparty_id - второго участника. Запись отзыва выполнения (revoke) содержит списанную\nсумму в award и причину отзыва в reason.\nДля получения следующей страницы передайте next_cursor из ответа с тем же order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение истории выполнения заданий пользователем.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода включительно в формате 02.01.2006 - 15:04:05",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода не включительно в формате 02.01.2006 - 15:04:05",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "usual",
+                            "random",
+                            "staged",
+                            "counter",
+                            "streak",
+                            "team"
+                        ],
+                        "type": "string",
+                        "description": "Тип задания",
+                        "name": "quest_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Порядок сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Размер страницы",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница выполненных заданий пользователя сформирована",
+                        "schema": {
+                            "$ref": "#/definitions/response.HistoryPage"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути или параметрах запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/progress": {
+            "get": {
+                "description": "Формирует список многошаговых заданий, которые пользователь начал выполнять, с номером последнего выполненного шага.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение прогресса пользователя по многошаговым заданиям.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Прогресс пользователя сформирован",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.Progress"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/quest/{quest_id}": {
+            "delete": {
+                "description": "Отменяет последнее выполнение задания пользователем: запись истории помечается отозванной и больше\nне учитывается в выполнениях задания, награда списывается с баланса транзакцией с причиной quest_revoke,\nа в историю добавляется запись отзыва (revoke) со списанной суммой и указанной причиной.\nЕсли пользователь уже потратил награду, поведение задаётся настройкой revoke.policy: reject - отказ,\npartial - списание только оставшегося баланса, negative - списание всей награды с уходом баланса в минус.\nОтзыв последнего выполнения реферальной задачи списывает и реферальные бонусы обоих пользователей\n(причина referral_revoke). Очки рейтинга уменьшаются на всю награду отозванного выполнения.\nМетод доступен только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Отзыв выполнения задания пользователем.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор задания",
+                        "name": "quest_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен администратора",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отзыва",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RevokeQuest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Выполнение задания отозвано",
+                        "schema": {
+                            "$ref": "#/definitions/response.Revocation"
+                        }
+                    },
+                    "400": {
+                        "description": "В теле или пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "402": {
+                        "description": "Награда уже потрачена, а политика отзыва reject",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "403": {
+                        "description": "Неверный токен администратора",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден или не выполнял задание",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/quests/available": {
+            "get": {
+                "description": "Формирует страницу заданий, которые пользователь может выполнить прямо сейчас: все предварительные задания выполнены,\nмаксимальное число выполнений не достигнуто, перерыв после последнего выполнения истёк и текущий момент входит в период выполнения задания. Задания упорядочены по id.\nДля получения следующей страницы передайте next_cursor из ответа.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение доступных пользователю заданий.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Размер страницы",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страница доступных заданий сформирована",
+                        "schema": {
+                            "$ref": "#/definitions/response.QuestsPage"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути или параметрах запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/rank": {
+            "get": {
+                "description": "Возвращает место и очки пользователя по его id в рейтинге с теми же window и metric, что и у /leaderboard.\nПользователь без наград за период имеет 0 очков.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Получение места пользователя в рейтинге.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Период рейтинга",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "earned",
+                            "balance"
+                        ],
+                        "type": "string",
+                        "default": "earned",
+                        "description": "Метрика рейтинга",
+                        "name": "metric",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Место пользователя в рейтинге",
+                        "schema": {
+                            "$ref": "#/definitions/response.LeaderboardEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/reconcile": {
+            "post": {
+                "description": "Пересчитывает баланс пользователя по журналу транзакций. Если сохранённый баланс отличается от суммы\nтранзакций, он заменяется суммой. В ответе возвращаются баланс до сверки и сумма транзакций.\nМетод доступен только администраторам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Сверка баланса пользователя с журналом транзакций.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Токен администратора",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сверка баланса выполнена",
+                        "schema": {
+                            "$ref": "#/definitions/response.Reconciliation"
+                        }
+                    },
+                    "400": {
+                        "description": "В пути запроса ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "403": {
+                        "description": "Неверный токен администратора",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь с указанным id не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{user_id}/transfer": {
+            "post": {
+                "description": "Списывает баллы с баланса пользователя по его id и начисляет их получателю в одной транзакции.\nОбе операции записываются в журнал транзакций и в историю отправителя и получателя.\nПеревод самому себе запрещён, а сумма переводов пользователя за последние сутки ограничена настройкой transfer.daily_limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Перевод баллов другому пользователю.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный идентификатор отправителя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Получатель и сумма перевода",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Transfer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Перевод выполнен, в ответе транзакция списания отправителя",
+                        "schema": {
+                            "$ref": "#/definitions/response.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "В теле или пути запроса ошибка, либо перевод самому себе",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "402": {
+                        "description": "На балансе отправителя недостаточно баллов",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "404": {
+                        "description": "Отправитель или получатель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "422": {
+                        "description": "Превышен суточный лимит переводов",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время выполнения запроса",
+                        "schema": {
+                            "$ref": "#/definitions/operate.ModelError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "operate.ModelError": {
+            "type": "object",
+            "properties": {
+                "error_message": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateBoost": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 2
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "09.12.2024 - 00:00:00"
+                },
+                "multiplier": {
+                    "type": "number",
+                    "format": "double",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Double points weekend"
+                },
+                "quest_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 1
+                },
+                "quest_type": {
+                    "type": "string",
+                    "enum": [
+                        "usual",
+                        "random",
+                        "staged",
+                        "counter",
+                        "streak",
+                        "team"
+                    ],
+                    "example": "usual"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "07.12.2024 - 00:00:00"
+                }
+            }
+        },
+        "request.CreateCategory": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Quests renewed every day"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Daily"
+                }
+            }
+        },
+        "request.CreateQuest": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "cooldown": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "minimum": 0,
+                    "example": 86400
+                },
+                "cost": {
+                    "type": "integer",
+                    "format": "uint8",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 9
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Random quest"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "01.01.2025 - 00:00:00"
+                },
+                "max_completions": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "milestones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.Milestone"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Task"
+                },
+                "pity": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "prerequisites": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "probability": {
+                    "type": "number",
+                    "format": "double",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 0.5
+                },
+                "reward_split": {
+                    "type": "string",
+                    "enum": [
+                        "equal",
+                        "fixed"
+                    ],
+                    "example": "equal"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "01.12.2024 - 00:00:00"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Open profile",
+                        "Fill name"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "daily",
+                        "social"
+                    ]
+                },
+                "target": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "usual",
+                        "random",
+                        "staged",
+                        "counter",
+                        "streak",
+                        "team"
+                    ],
+                    "example": "random"
+                }
+            }
+        },
+        "request.CreateTeam": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Wolves"
+                }
+            }
+        },
+        "request.CreateUser": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "User"
+                },
+                "referral_code": {
+                    "type": "string",
+                    "example": "3f9a1c07be"
+                }
+            }
+        },
+        "request.Debit": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "minimum": 1,
+                    "example": 15
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "shop purchase"
+                }
+            }
+        },
+        "request.Milestone": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "minimum": 1,
+                    "example": 7
+                },
+                "reward": {
+                    "type": "integer",
+                    "format": "uint8",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 50
+                }
+            }
+        },
+        "request.RevokeQuest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "fraudulent completion"
+                }
+            }
+        },
+        "request.Transfer": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "minimum": 1,
+                    "example": 15
+                },
+                "recipient_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 7
+                }
+            }
+        },
+        "request.UpdateBoost": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "CategoryId replaces category of boost, 0 removes restriction by category",
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 2
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "09.12.2024 - 00:00:00"
+                },
+                "multiplier": {
+                    "type": "number",
+                    "format": "double",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Double points weekend"
+                },
+                "quest_id": {
+                    "description": "QuestId replaces quest of boost, 0 removes restriction by quest",
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 1
+                },
+                "quest_type": {
+                    "description": "QuestType replaces type of quests of boost, empty string removes restriction by type",
+                    "type": "string",
+                    "enum": [
+                        "",
+                        "usual",
+                        "random",
+                        "staged",
+                        "counter",
+                        "streak",
+                        "team"
+                    ],
+                    "example": "usual"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "07.12.2024 - 00:00:00"
+                }
+            }
+        },
+        "request.UpdateCategory": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Quests renewed every day"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Daily"
+                }
+            }
+        },
+        "request.UpdateQuest": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Categories replaces quest categories, empty array removes them",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "cooldown": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "minimum": 0,
+                    "example": 86400
+                },
+                "cost": {
+                    "type": "integer",
+                    "format": "uint8",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 9
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Random quest"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "01.01.2025 - 00:00:00"
+                },
+                "max_completions": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "milestones": {
+                    "description": "Milestones replaces milestones of streak quest",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.Milestone"
+                    }
+                },
+                "pity": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "prerequisites": {
+                    "description": "Prerequisites replaces quest prerequisites, empty array removes them",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "probability": {
+                    "type": "number",
+                    "format": "double",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 0.5
+                },
+                "reward_split": {
+                    "type": "string",
+                    "enum": [
+                        "equal",
+                        "fixed"
+                    ],
+                    "example": "equal"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "01.12.2024 - 00:00:00"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Open profile",
+                        "Fill name"
+                    ]
+                },
+                "tags": {
+                    "description": "Tags replaces quest tags, empty array removes them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "daily",
+                        "social"
+                    ]
+                },
+                "target": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "usual",
+                        "random",
+                        "staged",
+                        "counter",
+                        "streak",
+                        "team"
                     ],
                     "example": "random"
                 }
             }
         },
-        "request.User": {
+        "request.User": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "User"
+                }
+            }
+        },
+        "response.Attempt": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "15.03.2024 - 10:21:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 12
+                },
+                "quest_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 3
+                },
+                "roll": {
+                    "type": "number",
+                    "format": "double",
+                    "example": 0.73
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "response.AttemptsPage": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Attempt"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiaWQiLCJvIjoiZGVzYyIsInYiOiIiLCJpIjoxMn0"
+                }
+            }
+        },
+        "response.Boost": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 2
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "09.12.2024 - 00:00:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 3
+                },
+                "multiplier": {
+                    "type": "number",
+                    "format": "double",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Double points weekend"
+                },
+                "quest_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 1
+                },
+                "quest_type": {
+                    "type": "string",
+                    "enum": [
+                        "usual",
+                        "random",
+                        "staged",
+                        "counter",
+                        "streak",
+                        "team"
+                    ],
+                    "example": "usual"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "07.12.2024 - 00:00:00"
+                }
+            }
+        },
+        "response.Category": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Quests renewed every day"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Daily"
+                }
+            }
+        },
+        "response.HistoryPage": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.HistoryRecord"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZCIsIm8iOiJkZXNjIiwidiI6IiIsImkiOjV9"
+                }
+            }
+        },
+        "response.HistoryRecord": {
+            "type": "object",
+            "properties": {
+                "award": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 18
+                },
+                "balance": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 5
+                },
+                "base_award": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 9
+                },
+                "completed_quest": {
+                    "$ref": "#/definitions/response.QuestSnapshot"
+                },
+                "counterparty_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 2
+                },
+                "created": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 5
+                },
+                "multiplier": {
+                    "type": "number",
+                    "format": "double",
+                    "example": 2
+                },
+                "quest": {
+                    "$ref": "#/definitions/response.Quest"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "fraudulent completion"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "quest",
+                        "referral",
+                        "transfer_in",
+                        "transfer_out",
+                        "revoke"
+                    ],
+                    "example": "quest"
+                }
+            }
+        },
+        "response.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "rank": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 1
+                },
+                "score": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 120
+                },
+                "user": {
+                    "$ref": "#/definitions/response.LeaderboardUser"
+                }
+            }
+        },
+        "response.LeaderboardUser": {
             "type": "object",
             "properties": {
+                "id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 5
+                },
                 "name": {
                     "type": "string",
                     "example": "User"
                 }
             }
         },
-        "response.HistoryRecord": {
+        "response.Milestone": {
             "type": "object",
             "properties": {
-                "balance": {
+                "days": {
                     "type": "integer",
-                    "format": "uint64",
-                    "example": 5
+                    "format": "uint32",
+                    "example": 7
                 },
-                "created": {
+                "reward": {
                     "type": "integer",
-                    "format": "uint64",
-                    "example": 5
-                },
+                    "format": "uint8",
+                    "example": 50
+                }
+            }
+        },
+        "response.Progress": {
+            "type": "object",
+            "properties": {
                 "quest": {
                     "$ref": "#/definitions/response.Quest"
+                },
+                "step": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 3
+                },
+                "updated": {
+                    "type": "string",
+                    "example": "15.04.2024 - 12:30:00"
                 }
             }
         },
         "response.Quest": {
             "type": "object",
             "properties": {
+                "archived_at": {
+                    "type": "string",
+                    "example": "01.02.2025 - 00:00:00"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "cooldown": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 86400
+                },
                 "cost": {
                     "type": "integer",
                     "format": "uint8",
@@ -637,11 +3412,123 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Random quest"
                 },
+                "ends_at": {
+                    "type": "string",
+                    "example": "01.01.2025 - 00:00:00"
+                },
                 "id": {
                     "type": "integer",
                     "format": "uint64",
                     "example": 5
                 },
+                "max_completions": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 1
+                },
+                "milestones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Milestone"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Task"
+                },
+                "pity": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 3
+                },
+                "prerequisites": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "probability": {
+                    "type": "number",
+                    "format": "double",
+                    "example": 0.5
+                },
+                "reward_split": {
+                    "type": "string",
+                    "enum": [
+                        "equal",
+                        "fixed"
+                    ],
+                    "example": "equal"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "01.12.2024 - 00:00:00"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Open profile",
+                        "Fill name"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "daily",
+                        "social"
+                    ]
+                },
+                "target": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 10
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "usual",
+                        "random",
+                        "staged",
+                        "counter",
+                        "streak",
+                        "team"
+                    ],
+                    "example": "random"
+                }
+            }
+        },
+        "response.QuestProgress": {
+            "type": "object",
+            "properties": {
+                "step": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 4
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 10
+                }
+            }
+        },
+        "response.QuestSnapshot": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Random quest"
+                },
                 "name": {
                     "type": "string",
                     "example": "Task"
@@ -650,41 +3537,326 @@ const docTemplate = `{
                     "type": "string",
                     "enum": [
                         "usual",
-                        "random"
+                        "random",
+                        "staged",
+                        "counter",
+                        "streak",
+                        "team"
                     ],
                     "example": "random"
                 }
             }
         },
+        "response.QuestsPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiaWQiLCJvIjoiYXNjIiwidiI6IiIsImkiOjV9"
+                },
+                "quests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Quest"
+                    }
+                }
+            }
+        },
+        "response.Reconciliation": {
+            "type": "object",
+            "properties": {
+                "cached_balance": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 30
+                },
+                "ledger_balance": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 25
+                },
+                "user_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 5
+                }
+            }
+        },
+        "response.Revocation": {
+            "type": "object",
+            "properties": {
+                "award": {
+                    "type": "integer",
+                    "format": "uint32",
+                    "example": 15
+                },
+                "completed": {
+                    "type": "string",
+                    "example": "14.03.2024 - 18:40:00"
+                },
+                "created": {
+                    "type": "string",
+                    "example": "15.03.2024 - 10:21:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 4
+                },
+                "quest_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 3
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "fraudulent completion"
+                },
+                "revoked_quest": {
+                    "$ref": "#/definitions/response.QuestSnapshot"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/response.Transaction"
+                }
+            }
+        },
         "response.StatusApplyCost": {
             "type": "object",
             "properties": {
+                "progress": {
+                    "description": "Progress is set for staged, counter and streak quests",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.QuestProgress"
+                        }
+                    ]
+                },
                 "status": {
                     "type": "string",
                     "enum": [
                         "success",
-                        "failure"
+                        "failure",
+                        "in_progress"
                     ],
                     "example": "success"
                 }
             }
         },
+        "response.Team": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "01.12.2024 - 00:00:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 2
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TeamMember"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Wolves"
+                }
+            }
+        },
+        "response.TeamMember": {
+            "type": "object",
+            "properties": {
+                "joined": {
+                    "type": "string",
+                    "example": "01.12.2024 - 00:00:00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Ivan"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 5
+                }
+            }
+        },
+        "response.Transaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 15
+                },
+                "balance": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 10
+                },
+                "counterparty_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 8
+                },
+                "created": {
+                    "type": "string",
+                    "example": "15.03.2024 - 10:21:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 7
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "credit",
+                        "debit"
+                    ],
+                    "example": "debit"
+                },
+                "quest_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 3
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "shop purchase"
+                }
+            }
+        },
+        "response.TypeStats": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 3
+                },
+                "earned": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 27
+                }
+            }
+        },
         "response.User": {
             "type": "object",
             "properties": {
                 "balance": {
+                    "type": "integer",
+                    "format": "int64",
+                    "example": 25
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "User"
+                },
+                "referral_code": {
+                    "type": "string",
+                    "example": "3f9a1c07be"
+                },
+                "referrer_id": {
                     "type": "integer",
                     "format": "uint64",
+                    "example": 2
+                }
+            }
+        },
+        "response.UserExport": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Attempt"
+                    }
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "01.02.2025 - 00:00:00"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.HistoryRecord"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/response.User"
+                }
+            }
+        },
+        "response.UserStats": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer",
+                    "format": "int64",
                     "example": 25
                 },
+                "by_type": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/response.TypeStats"
+                    }
+                },
+                "completed": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 3
+                },
+                "earned": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 27
+                },
                 "id": {
                     "type": "integer",
                     "format": "uint64",
                     "example": 5
                 },
+                "last_completion": {
+                    "type": "string",
+                    "example": "15.03.2024 - 10:21:00"
+                },
                 "name": {
                     "type": "string",
                     "example": "User"
+                },
+                "referral_code": {
+                    "type": "string",
+                    "example": "3f9a1c07be"
+                },
+                "referrer_id": {
+                    "type": "integer",
+                    "format": "uint64",
+                    "example": 2
+                }
+            }
+        },
+        "response.UsersPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiaWQiLCJvIjoiYXNjIiwidiI6IiIsImkiOjV9"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.User"
+                    }
                 }
             }
         }
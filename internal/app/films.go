@@ -5,19 +5,34 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
 	"vk_quests/config"
 	v1 "vk_quests/internal/delivery/http/v1"
 	"vk_quests/internal/delivery/http/v1/handlers"
+	"vk_quests/internal/delivery/middleware"
+	"vk_quests/internal/pkg/types"
+	br "vk_quests/internal/repository/boost"
+	cr "vk_quests/internal/repository/category"
+	ir "vk_quests/internal/repository/idempotency"
+	lbr "vk_quests/internal/repository/leaderboard"
+	lr "vk_quests/internal/repository/ledger"
 	qr "vk_quests/internal/repository/quest"
+	tr "vk_quests/internal/repository/team"
 	ur "vk_quests/internal/repository/user"
+	bu "vk_quests/internal/usecase/boost"
+	cu "vk_quests/internal/usecase/category"
+	lbu "vk_quests/internal/usecase/leaderboard"
 	qu "vk_quests/internal/usecase/quest"
+	tu "vk_quests/internal/usecase/team"
 	uu "vk_quests/internal/usecase/user"
 	"vk_quests/pkg/server"
 
 	_ "github.com/lib/pq"
+	// Timezones of config are available without system database
+	_ "time/tzdata"
 )
 
 func Run(cfg *config.Config) {
@@ -46,17 +61,58 @@ func Run(cfg *config.Config) {
 	// Repository
 	questRepository := qr.NewPostgresQuest(pg)
 	userRepository := ur.NewPostgresUser(pg)
+	idempotencyRepository := ir.NewPostgresIdempotency(pg)
+	ledgerRepository := lr.NewPostgresLedger(pg)
+	categoryRepository := cr.NewPostgresCategory(pg)
+	leaderboardRepository := lbr.NewPostgresLeaderboard(pg)
+	teamRepository := tr.NewPostgresTeam(pg)
+	boostRepository := br.NewPostgresBoost(pg)
 
 	// Use-cases
+	revokePolicy, err := lr.ParseRevokePolicy(cfg.Revoke.Policy)
+	if err != nil {
+		l.Fatal("[App] Init - revoke policy: %s", err)
+	}
+
+	deletePolicy, err := ur.ParseDeletePolicy(cfg.Delete.Policy)
+	if err != nil {
+		l.Fatal("[App] Init - delete policy: %s", err)
+	}
+
+	streakLocation, err := time.LoadLocation(cfg.Streak.Timezone)
+	if err != nil {
+		l.Fatal("[App] Init - streak timezone: %s", err)
+	}
+
 	questUsecase := qu.NewQuestUsecase(questRepository)
-	userUsecase := uu.NewUserUsecase(userRepository, questRepository)
+	categoryUsecase := cu.NewCategoryUsecase(categoryRepository)
+	leaderboardUsecase := lbu.NewLeaderboardUsecase(leaderboardRepository)
+	teamUsecase := tu.NewTeamUsecase(teamRepository)
+	boostUsecase := bu.NewBoostUsecase(boostRepository)
+	userUsecase := uu.NewUserUsecase(userRepository, ledgerRepository, idempotencyRepository, uu.Config{
+		KeyTTL:             cfg.Idempotency.TTL,
+		DailyTransferLimit: cfg.Transfer.DailyLimit,
+		DailyAttemptsLimit: cfg.Attempts.DailyLimit,
+		RevokePolicy:       revokePolicy,
+		DeletePolicy:       deletePolicy,
+		Location:           streakLocation,
+		Referral:           ur.Referral{QuestId: types.Id(cfg.Referral.QuestId), Bonus: types.Cost(cfg.Referral.Bonus)},
+	}, uu.NewRandom(time.Now().UnixNano()))
 
 	// Handlers
 	questHandlers := handlers.NewQuestHandlers(questUsecase)
 	userHandlers := handlers.NewUserHandlers(userUsecase)
+	categoryHandlers := handlers.NewCategoryHandlers(categoryUsecase)
+	leaderboardHandlers := handlers.NewLeaderboardHandlers(leaderboardUsecase)
+	teamHandlers := handlers.NewTeamHandlers(teamUsecase)
+	boostHandlers := handlers.NewBoostHandlers(boostUsecase)
 
 	// routes
-	router, err := v1.NewRouter("/api", l, prepareRoutes(userHandlers, questHandlers))
+	router, err := v1.NewRouter("/api", l,
+		prepareRoutes(userHandlers, questHandlers, categoryHandlers, leaderboardHandlers, teamHandlers,
+			boostHandlers, cfg.Admin.Token),
+		middleware.Deadline(cfg.Postgres.QueryTimeout),
+	)
 	if err != nil {
 		l.Fatal("[App] Init - init handler error: %s", err)
 	}
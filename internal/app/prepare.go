@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
@@ -13,6 +14,7 @@ import (
 	_ "vk_quests/docs"
 	v1 "vk_quests/internal/delivery/http/v1"
 	"vk_quests/internal/delivery/http/v1/handlers"
+	"vk_quests/internal/delivery/middleware"
 	"vk_quests/internal/pkg/prepare"
 	"vk_quests/pkg/logger"
 )
@@ -48,7 +50,9 @@ func prepareLogger(cfg config.LoggerInfo) (*logger.Logger, *os.File) {
 	return l, logFile
 }
 
-func prepareRoutes(userHandlers *handlers.UserHandlers, questHandlers *handlers.QuestHandlers) v1.Routes {
+func prepareRoutes(userHandlers *handlers.UserHandlers, questHandlers *handlers.QuestHandlers,
+	categoryHandlers *handlers.CategoryHandlers, leaderboardHandlers *handlers.LeaderboardHandlers,
+	teamHandlers *handlers.TeamHandlers, boostHandlers *handlers.BoostHandlers, adminToken string) v1.Routes {
 	return v1.Routes{
 		//"Index"
 		v1.Route{
@@ -71,6 +75,29 @@ func prepareRoutes(userHandlers *handlers.UserHandlers, questHandlers *handlers.
 			HandlerFunc: userHandlers.DeleteUser,
 		},
 
+		// "AnonymizeUser"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/user/:" + handlers.UserIdField + "/anonymize",
+			HandlerFunc: userHandlers.AnonymizeUser,
+			Middlewares: []gin.HandlerFunc{middleware.AdminOnly(adminToken)},
+		},
+
+		// "ExportUser"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/user/:" + handlers.UserIdField + "/export",
+			HandlerFunc: userHandlers.ExportUser,
+			Middlewares: []gin.HandlerFunc{middleware.AdminOnly(adminToken)},
+		},
+
+		// "GetUser"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/user/:" + handlers.UserIdField,
+			HandlerFunc: userHandlers.GetUser,
+		},
+
 		// "UpdateUser"
 		v1.Route{
 			Method:      http.MethodPut,
@@ -85,6 +112,58 @@ func prepareRoutes(userHandlers *handlers.UserHandlers, questHandlers *handlers.
 			HandlerFunc: userHandlers.GetUserHistory,
 		},
 
+		// "GetAvailableQuests"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/user/:" + handlers.UserIdField + "/quests/available",
+			HandlerFunc: userHandlers.GetAvailableQuests,
+		},
+
+		// "GetUserProgress"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/user/:" + handlers.UserIdField + "/progress",
+			HandlerFunc: userHandlers.GetUserProgress,
+		},
+
+		// "GetUserAttempts"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/user/:" + handlers.UserIdField + "/attempts",
+			HandlerFunc: userHandlers.GetUserAttempts,
+		},
+
+		// "RevokeQuest"
+		v1.Route{
+			Method:      http.MethodDelete,
+			Pattern:     "/user/:" + handlers.UserIdField + "/quest/:" + handlers.QuestIdField,
+			HandlerFunc: userHandlers.RevokeQuest,
+			Middlewares: []gin.HandlerFunc{middleware.AdminOnly(adminToken)},
+		},
+
+		// "Debit"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/user/:" + handlers.UserIdField + "/debit",
+			HandlerFunc: userHandlers.Debit,
+			Middlewares: []gin.HandlerFunc{middleware.AdminOnly(adminToken)},
+		},
+
+		// "Transfer"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/user/:" + handlers.UserIdField + "/transfer",
+			HandlerFunc: userHandlers.Transfer,
+		},
+
+		// "ReconcileBalance"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/user/:" + handlers.UserIdField + "/reconcile",
+			HandlerFunc: userHandlers.ReconcileBalance,
+			Middlewares: []gin.HandlerFunc{middleware.AdminOnly(adminToken)},
+		},
+
 		// "GetUsers"
 		v1.Route{
 			Method:      http.MethodGet,
@@ -106,11 +185,26 @@ func prepareRoutes(userHandlers *handlers.UserHandlers, questHandlers *handlers.
 			HandlerFunc: questHandlers.CreateQuest,
 		},
 
-		// "DeleteQuest"
+		// "ArchiveQuest"
 		v1.Route{
 			Method:      http.MethodDelete,
 			Pattern:     "/quest/:" + handlers.QuestIdField,
-			HandlerFunc: questHandlers.DeleteQuest,
+			HandlerFunc: questHandlers.ArchiveQuest,
+		},
+
+		// "RestoreQuest"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/quest/:" + handlers.QuestIdField + "/restore",
+			HandlerFunc: questHandlers.RestoreQuest,
+		},
+
+		// "PurgeQuest"
+		v1.Route{
+			Method:      http.MethodDelete,
+			Pattern:     "/quest/:" + handlers.QuestIdField + "/purge",
+			HandlerFunc: questHandlers.PurgeQuest,
+			Middlewares: []gin.HandlerFunc{middleware.AdminOnly(adminToken)},
 		},
 
 		// "UpdateQuest"
@@ -133,5 +227,124 @@ func prepareRoutes(userHandlers *handlers.UserHandlers, questHandlers *handlers.
 			Pattern:     "/quest/list",
 			HandlerFunc: questHandlers.GetQuests,
 		},
+
+		// "CreateCategory"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/category",
+			HandlerFunc: categoryHandlers.CreateCategory,
+		},
+
+		// "DeleteCategory"
+		v1.Route{
+			Method:      http.MethodDelete,
+			Pattern:     "/category/:" + handlers.CategoryIdField,
+			HandlerFunc: categoryHandlers.DeleteCategory,
+		},
+
+		// "UpdateCategory"
+		v1.Route{
+			Method:      http.MethodPut,
+			Pattern:     "/category/:" + handlers.CategoryIdField,
+			HandlerFunc: categoryHandlers.UpdateCategory,
+		},
+
+		// "GetCategory"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/category/:" + handlers.CategoryIdField,
+			HandlerFunc: categoryHandlers.GetCategory,
+		},
+
+		// "GetCategories"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/category/list",
+			HandlerFunc: categoryHandlers.GetCategories,
+		},
+
+		// "GetLeaderboard"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/leaderboard",
+			HandlerFunc: leaderboardHandlers.GetLeaderboard,
+		},
+
+		// "GetRank"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/user/:" + handlers.UserIdField + "/rank",
+			HandlerFunc: leaderboardHandlers.GetRank,
+		},
+
+		// "CreateTeam"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/team",
+			HandlerFunc: teamHandlers.CreateTeam,
+		},
+
+		// "GetTeams"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/team/list",
+			HandlerFunc: teamHandlers.GetTeams,
+		},
+
+		// "GetTeam"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/team/:" + handlers.TeamIdField,
+			HandlerFunc: teamHandlers.GetTeam,
+		},
+
+		// "AddMember"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/team/:" + handlers.TeamIdField + "/member/:" + handlers.UserIdField,
+			HandlerFunc: teamHandlers.AddMember,
+		},
+
+		// "RemoveMember"
+		v1.Route{
+			Method:      http.MethodDelete,
+			Pattern:     "/team/:" + handlers.TeamIdField + "/member/:" + handlers.UserIdField,
+			HandlerFunc: teamHandlers.RemoveMember,
+		},
+
+		// "CreateBoost"
+		v1.Route{
+			Method:      http.MethodPost,
+			Pattern:     "/boost",
+			HandlerFunc: boostHandlers.CreateBoost,
+		},
+
+		// "DeleteBoost"
+		v1.Route{
+			Method:      http.MethodDelete,
+			Pattern:     "/boost/:" + handlers.BoostIdField,
+			HandlerFunc: boostHandlers.DeleteBoost,
+		},
+
+		// "UpdateBoost"
+		v1.Route{
+			Method:      http.MethodPut,
+			Pattern:     "/boost/:" + handlers.BoostIdField,
+			HandlerFunc: boostHandlers.UpdateBoost,
+		},
+
+		// "GetBoost"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/boost/:" + handlers.BoostIdField,
+			HandlerFunc: boostHandlers.GetBoost,
+		},
+
+		// "GetBoosts"
+		v1.Route{
+			Method:      http.MethodGet,
+			Pattern:     "/boost/list",
+			HandlerFunc: boostHandlers.GetBoosts,
+		},
 	}
 }
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"vk_quests/internal/delivery/http/v1/model/request"
+	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/delivery/middleware"
+	"vk_quests/internal/pkg/types"
+	br "vk_quests/internal/repository/boost"
+	bu "vk_quests/internal/usecase/boost"
+	"vk_quests/pkg/operate"
+)
+
+const (
+	BoostIdField = "boost_id"
+)
+
+type BoostHandlers struct {
+	boosts bu.Usecase
+}
+
+func NewBoostHandlers(boosts bu.Usecase) *BoostHandlers {
+	return &BoostHandlers{boosts: boosts}
+}
+
+// CreateBoost
+//
+//	@Summary		Добавление акции.
+//	@Description	Добавляет акцию, умножающую награды за задания, выполненные в период её действия. Акцию можно ограничить заданием, категорией или типом заданий, без ограничений она действует на все задания. Если подходит несколько акций, применяется наибольший множитель.
+//	@Tags			boost
+//	@Accept			json
+//	@Param			request	body	request.CreateBoost	true	"Информация о добавляемой акции"
+//	@Produce		json
+//	@Success		201	{object}	response.Boost		"Акция успешно добавлена в базу"
+//	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка или starts_at не раньше ends_at"
+//	@Failure		422	{object}	operate.ModelError	"Задание или категория акции не найдены"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/boost [post]
+func (bh *BoostHandlers) CreateBoost(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение значения тела запроса
+	var createBoost request.CreateBoost
+	if code, err := parseRequestBody(c.Request.Body, &createBoost, request.ValidateCreateBoost, l); err != nil {
+		operate.SendError(c, err, code, l)
+		return
+	}
+
+	createdBoost, err := bh.boosts.CreateBoost(c.Request.Context(), createBoost.ToUsBoost())
+	if err != nil {
+		if errors.Is(err, br.ErrorInvalidWindow) {
+			operate.SendError(c, ErrorInvalidBoostWindow, http.StatusBadRequest, l)
+			l.Info(errors.Wrapf(err, "can't create boost"))
+			return
+		}
+		if errors.Is(err, br.ErrorQuestNotFound) {
+			operate.SendError(c, ErrorQuestNotFound, http.StatusUnprocessableEntity, l)
+			l.Info(errors.Wrapf(err, "can't create boost"))
+			return
+		}
+		if errors.Is(err, br.ErrorCategoryNotFound) {
+			operate.SendError(c, ErrorCategoryNotFound, http.StatusUnprocessableEntity, l)
+			l.Info(errors.Wrapf(err, "can't create boost"))
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't create boost"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusCreated, response.FromUsBoost(createdBoost), l)
+}
+
+// DeleteBoost
+//
+//	@Summary		Удаление акции.
+//	@Description	Удаляет акцию по её id. Уже начисленные с множителем акции награды не меняются.
+//	@Tags			boost
+//	@Param			boost_id	path	uint64	true	"Уникальный идентификатор акции"
+//	@Produce		json
+//	@Success		200	"Акция успешно удалена"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Акция с указанным id не найдена"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/boost/{boost_id} [delete]
+func (bh *BoostHandlers) DeleteBoost(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(BoostIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get boost id"), http.StatusBadRequest, l)
+		return
+	}
+
+	if err = bh.boosts.DeleteBoost(c.Request.Context(), types.Id(id)); err != nil {
+		if errors.Is(err, br.ErrorBoostNotFound) {
+			operate.SendError(c, ErrorBoostNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't delete boost"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, nil, l)
+}
+
+// GetBoost
+//
+//	@Summary		Получение акции.
+//	@Description	Возвращает информацию об акции по её id.
+//	@Tags			boost
+//	@Param			boost_id	path	uint64	true	"Уникальный идентификатор акции"
+//	@Produce		json
+//	@Success		200	{object}	response.Boost		"Полученная акция"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Акция с указанным id не найдена"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/boost/{boost_id} [get]
+func (bh *BoostHandlers) GetBoost(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(BoostIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get boost id"), http.StatusBadRequest, l)
+		return
+	}
+
+	boost, err := bh.boosts.GetBoost(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, br.ErrorBoostNotFound) {
+			operate.SendError(c, ErrorBoostNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get boost"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsBoost(boost), l)
+}
+
+// UpdateBoost
+//
+//	@Summary		Обновление акции.
+//	@Description	Обновляет название, множитель, период действия и ограничения акции. Отсутствующие поля будут оставлены без изменений. Переданные quest_id, category_id и quest_type заменяют ограничения акции, а значения 0 и пустая строка снимают их.
+//	@Tags			boost
+//	@Accept			json
+//	@Param			boost_id	path	uint64				true	"Уникальный идентификатор акции"
+//	@Param			request		body	request.UpdateBoost	true	"Информация об обновлении"
+//	@Produce		json
+//	@Success		200	{object}	response.Boost		"Акция успешно обновлена в базе"
+//	@Failure		400	{object}	operate.ModelError	"В теле или пути запроса ошибка или starts_at не раньше ends_at"
+//	@Failure		404	{object}	operate.ModelError	"Акция с указанным id не найдена"
+//	@Failure		422	{object}	operate.ModelError	"Задание или категория акции не найдены"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/boost/{boost_id} [put]
+func (bh *BoostHandlers) UpdateBoost(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(BoostIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get boost id"), http.StatusBadRequest, l)
+		return
+	}
+
+	// Получение значения тела запроса
+	var updateBoost request.UpdateBoost
+	if code, err := parseRequestBody(c.Request.Body, &updateBoost, request.ValidateUpdateBoost, l); err != nil {
+		operate.SendError(c, err, code, l)
+		return
+	}
+
+	updatedBoost, err := bh.boosts.UpdateBoost(c.Request.Context(), types.Id(id), updateBoost.ToUsUpdateBoost())
+	if err != nil {
+		if errors.Is(err, br.ErrorBoostNotFound) {
+			operate.SendError(c, ErrorBoostNotFound, http.StatusNotFound, l)
+			return
+		}
+		if errors.Is(err, br.ErrorInvalidWindow) {
+			operate.SendError(c, ErrorInvalidBoostWindow, http.StatusBadRequest, l)
+			return
+		}
+		if errors.Is(err, br.ErrorQuestNotFound) {
+			operate.SendError(c, ErrorQuestNotFound, http.StatusUnprocessableEntity, l)
+			return
+		}
+		if errors.Is(err, br.ErrorCategoryNotFound) {
+			operate.SendError(c, ErrorCategoryNotFound, http.StatusUnprocessableEntity, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't update boost"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsBoost(updatedBoost), l)
+}
+
+// GetBoosts
+//
+//	@Summary		Получение списка акций.
+//	@Description	Возвращает все акции, включая завершившиеся, упорядоченные по началу действия.
+//	@Tags			boost
+//	@Produce		json
+//	@Success		200	{array}		response.Boost		"Список акций"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/boost/list [get]
+func (bh *BoostHandlers) GetBoosts(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	boosts, err := bh.boosts.GetBoosts(c.Request.Context())
+	if err != nil {
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get boosts"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsBoosts(boosts), l)
+}
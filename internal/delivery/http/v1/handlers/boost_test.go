@@ -0,0 +1,539 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	stdtime "time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+
+	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	br "vk_quests/internal/repository/boost"
+	bu "vk_quests/internal/usecase/boost"
+	mub "vk_quests/internal/usecase/boost/mocks"
+)
+
+type BoostHandlersSuite struct {
+	suite.Suite
+	handlers  *BoostHandlers
+	mockBoost *mub.BoostUsecase
+	gmc       *gomock.Controller
+}
+
+func (bhs *BoostHandlersSuite) BeforeEach(t provider.T) {
+	bhs.gmc = gomock.NewController(t)
+	bhs.mockBoost = mub.NewBoostUsecase(bhs.gmc)
+	bhs.handlers = NewBoostHandlers(bhs.mockBoost)
+}
+
+func (bhs *BoostHandlersSuite) AfterEach(t provider.T) {
+	bhs.gmc.Finish()
+}
+
+var (
+	boostStartsAt = time.FormattedTime{Time: stdtime.Date(2024, 12, 7, 0, 0, 0, 0, stdtime.UTC)}
+	boostEndsAt   = time.FormattedTime{Time: stdtime.Date(2024, 12, 9, 0, 0, 0, 0, stdtime.UTC)}
+)
+
+func (bhs *BoostHandlersSuite) TestCreateBoostHandler(t provider.T) {
+	t.Title("CreateBoost handler of boost handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/", addEmptyLogger(bhs.handlers.CreateBoost))
+
+	t.NewStep("Init test data")
+	tp := types.USUAL
+	newBoost := &bu.Boost{
+		Name:       "Double points weekend",
+		Multiplier: 2,
+		StartsAt:   boostStartsAt,
+		EndsAt:     boostEndsAt,
+		QuestType:  &tp,
+	}
+	boost := *newBoost
+	boost.ID = 1
+	body := `{"name": "Double points weekend", "multiplier": 2, "starts_at": "07.12.2024 - 00:00:00",
+		"ends_at": "09.12.2024 - 00:00:00", "quest_type": "usual"}`
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().CreateBoost(gomock.Any(), newBoost).Return(&boost, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var bst response.Boost
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&bst))
+		t.Require().EqualValues(response.Boost{
+			ID:         1,
+			Name:       "Double points weekend",
+			Multiplier: 2,
+			StartsAt:   boostStartsAt,
+			EndsAt:     boostEndsAt,
+			QuestType:  &tp,
+		}, bst)
+	})
+
+	t.WithNewStep("Invalid window error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().CreateBoost(gomock.Any(), newBoost).Return(nil, br.ErrorInvalidWindow).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Category not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().CreateBoost(gomock.Any(), newBoost).Return(nil, br.ErrorCategoryNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Quest not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().CreateBoost(gomock.Any(), newBoost).Return(nil, br.ErrorQuestNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().CreateBoost(gomock.Any(), newBoost).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Multiplier out of range error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(`{"name": "Boost", "multiplier": 0.5,
+			"starts_at": "07.12.2024 - 00:00:00", "ends_at": "09.12.2024 - 00:00:00"}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect body error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(`{"name": "Boost", "multiplier": 2}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (bhs *BoostHandlersSuite) TestDeleteBoostHandler(t provider.T) {
+	t.Title("DeleteBoost handler of boost handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+BoostIdField, addEmptyLogger(bhs.handlers.DeleteBoost))
+
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().DeleteBoost(gomock.Any(), id).Return(nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Boost not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().DeleteBoost(gomock.Any(), id).Return(br.ErrorBoostNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().DeleteBoost(gomock.Any(), id).Return(testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/weekend", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (bhs *BoostHandlersSuite) TestGetBoostHandler(t provider.T) {
+	t.Title("GetBoost handler of boost handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+BoostIdField, addEmptyLogger(bhs.handlers.GetBoost))
+
+	t.NewStep("Init test data")
+	questId := types.Id(5)
+	boost := &bu.Boost{
+		ID:         1,
+		Name:       "Quest of the day",
+		Multiplier: 1.5,
+		StartsAt:   boostStartsAt,
+		EndsAt:     boostEndsAt,
+		QuestId:    &questId,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().GetBoost(gomock.Any(), boost.ID).Return(boost, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var bst response.Boost
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&bst))
+		t.Require().EqualValues(*response.FromUsBoost(boost), bst)
+	})
+
+	t.WithNewStep("Boost not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().GetBoost(gomock.Any(), boost.ID).Return(nil, br.ErrorBoostNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().GetBoost(gomock.Any(), boost.ID).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/weekend", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (bhs *BoostHandlersSuite) TestUpdateBoostHandler(t provider.T) {
+	t.Title("UpdateBoost handler of boost handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+BoostIdField, addEmptyLogger(bhs.handlers.UpdateBoost))
+
+	t.NewStep("Init test data")
+	multiplier := 3.0
+	boost := &bu.Boost{
+		ID:         1,
+		Name:       "Triple points weekend",
+		Multiplier: multiplier,
+		StartsAt:   boostStartsAt,
+		EndsAt:     boostEndsAt,
+	}
+	updateBoost := &bu.UpdateBoost{Multiplier: &multiplier}
+	body := `{"multiplier": 3}`
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().UpdateBoost(gomock.Any(), boost.ID, updateBoost).Return(boost, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Boost not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().UpdateBoost(gomock.Any(), boost.ID, updateBoost).
+			Return(nil, br.ErrorBoostNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Invalid window error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		endsAt := time.FormattedTime{Time: boostStartsAt.Add(-stdtime.Hour)}
+		bhs.mockBoost.EXPECT().UpdateBoost(gomock.Any(), boost.ID, &bu.UpdateBoost{EndsAt: &endsAt}).
+			Return(nil, br.ErrorInvalidWindow).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"ends_at": "06.12.2024 - 23:00:00"}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Correct scope execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		questId, noCategory, anyType := types.Id(3), types.Id(0), types.QuestType("")
+		bhs.mockBoost.EXPECT().UpdateBoost(gomock.Any(), boost.ID, &bu.UpdateBoost{
+			QuestId:    &questId,
+			CategoryId: &noCategory,
+			QuestType:  &anyType,
+		}).Return(boost, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1",
+			strings.NewReader(`{"quest_id": 3, "category_id": 0, "quest_type": ""}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Quest not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		questId := types.Id(3)
+		bhs.mockBoost.EXPECT().UpdateBoost(gomock.Any(), boost.ID, &bu.UpdateBoost{QuestId: &questId}).
+			Return(nil, br.ErrorQuestNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"quest_id": 3}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().UpdateBoost(gomock.Any(), boost.ID, updateBoost).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/weekend", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect body error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"multiplier": 11}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (bhs *BoostHandlersSuite) TestGetBoostsHandler(t provider.T) {
+	t.Title("GetBoosts handler of boost handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/", addEmptyLogger(bhs.handlers.GetBoosts))
+
+	t.NewStep("Init test data")
+	boosts := []bu.Boost{
+		{ID: 1, Name: "Double points weekend", Multiplier: 2, StartsAt: boostStartsAt, EndsAt: boostEndsAt},
+		{ID: 2, Name: "Happy hour", Multiplier: 1.5, StartsAt: boostStartsAt, EndsAt: boostEndsAt},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().GetBoosts(gomock.Any()).Return(boosts, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var res []response.Boost
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&res))
+		t.Require().EqualValues(response.FromUsBoosts(boosts), res)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bhs.mockBoost.EXPECT().GetBoosts(gomock.Any()).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func TestRunBoostHandlersSuite(t *testing.T) {
+	suite.RunSuite(t, new(BoostHandlersSuite))
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"vk_quests/internal/delivery/http/v1/model/request"
+	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/delivery/middleware"
+	"vk_quests/internal/pkg/types"
+	cr "vk_quests/internal/repository/category"
+	cu "vk_quests/internal/usecase/category"
+	"vk_quests/pkg/operate"
+)
+
+const (
+	CategoryIdField = "category_id"
+)
+
+type CategoryHandlers struct {
+	categories cu.Usecase
+}
+
+func NewCategoryHandlers(categories cu.Usecase) *CategoryHandlers {
+	return &CategoryHandlers{categories: categories}
+}
+
+// CreateCategory
+//
+//	@Summary		Добавление категории.
+//	@Description	Добавляет категорию заданий с уникальным названием и описанием. Категории используются клиентом для группировки заданий по вкладкам.
+//	@Tags			category
+//	@Accept			json
+//	@Param			request	body	request.CreateCategory	true	"Информация о добавляемой категории"
+//	@Produce		json
+//	@Success		201	{object}	response.Category	"Категория успешно добавлена в базу"
+//	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка"
+//	@Failure		409	{object}	operate.ModelError	"Категория с таким названием уже существует"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/category [post]
+func (ch *CategoryHandlers) CreateCategory(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение значения тела запроса
+	var createCategory request.CreateCategory
+	if code, err := parseRequestBody(c.Request.Body, &createCategory, request.ValidateCreateCategory, l); err != nil {
+		operate.SendError(c, err, code, l)
+		return
+	}
+
+	createdCategory, err := ch.categories.CreateCategory(c.Request.Context(), createCategory.ToUsCategory())
+	if err != nil {
+		if errors.Is(err, cr.ErrorCategoryNameAlreadyExists) {
+			operate.SendError(c, ErrorCategoryNameAlreadyExists, http.StatusConflict, l)
+			l.Info(errors.Wrapf(err, "can't create category"))
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't create category"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusCreated, response.FromUsCategory(createdCategory), l)
+}
+
+// DeleteCategory
+//
+//	@Summary		Удаление категории.
+//	@Description	Удаляет категорию по её id. Задания категории остаются, но перестают к ней относиться.
+//	@Tags			category
+//	@Param			category_id	path	uint64	true	"Уникальный идентификатор категории"
+//	@Produce		json
+//	@Success		200	"Категория успешно удалена"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Категория с указанным id не найдена"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/category/{category_id} [delete]
+func (ch *CategoryHandlers) DeleteCategory(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(CategoryIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get category id"), http.StatusBadRequest, l)
+		return
+	}
+
+	if err = ch.categories.DeleteCategory(c.Request.Context(), types.Id(id)); err != nil {
+		if errors.Is(err, cr.ErrorCategoryNotFound) {
+			operate.SendError(c, ErrorCategoryNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't delete category"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, nil, l)
+}
+
+// GetCategory
+//
+//	@Summary		Получение категории.
+//	@Description	Возвращает информацию о категории по её id.
+//	@Tags			category
+//	@Param			category_id	path	uint64	true	"Уникальный идентификатор категории"
+//	@Produce		json
+//	@Success		200	{object}	response.Category	"Полученная категория"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Категория с указанным id не найдена"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/category/{category_id} [get]
+func (ch *CategoryHandlers) GetCategory(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(CategoryIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get category id"), http.StatusBadRequest, l)
+		return
+	}
+
+	category, err := ch.categories.GetCategory(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, cr.ErrorCategoryNotFound) {
+			operate.SendError(c, ErrorCategoryNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get category"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsCategory(category), l)
+}
+
+// UpdateCategory
+//
+//	@Summary		Обновление категории.
+//	@Description	Обновляет название и описание категории. Отсутствующие поля будут оставлены без изменений.
+//	@Tags			category
+//	@Accept			json
+//	@Param			category_id	path	uint64					true	"Уникальный идентификатор категории"
+//	@Param			request		body	request.UpdateCategory	true	"Информация об обновлении"
+//	@Produce		json
+//	@Success		200	{object}	response.Category	"Категория успешно обновлена в базе"
+//	@Failure		400	{object}	operate.ModelError	"В теле или пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Категория с указанным id не найдена"
+//	@Failure		409	{object}	operate.ModelError	"Категория с таким названием уже существует"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/category/{category_id} [put]
+func (ch *CategoryHandlers) UpdateCategory(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(CategoryIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get category id"), http.StatusBadRequest, l)
+		return
+	}
+
+	// Получение значения тела запроса
+	var updateCategory request.UpdateCategory
+	if code, err := parseRequestBody(c.Request.Body, &updateCategory, request.ValidateUpdateCategory, l); err != nil {
+		operate.SendError(c, err, code, l)
+		return
+	}
+
+	updatedCategory, err := ch.categories.UpdateCategory(c.Request.Context(), types.Id(id), updateCategory.ToUsUpdateCategory())
+	if err != nil {
+		if errors.Is(err, cr.ErrorCategoryNotFound) {
+			operate.SendError(c, ErrorCategoryNotFound, http.StatusNotFound, l)
+			return
+		}
+		if errors.Is(err, cr.ErrorCategoryNameAlreadyExists) {
+			operate.SendError(c, ErrorCategoryNameAlreadyExists, http.StatusConflict, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't update category"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsCategory(updatedCategory), l)
+}
+
+// GetCategories
+//
+//	@Summary		Получение списка категорий.
+//	@Description	Возвращает все категории заданий, упорядоченные по id.
+//	@Tags			category
+//	@Produce		json
+//	@Success		200	{array}		response.Category	"Список категорий"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/category/list [get]
+func (ch *CategoryHandlers) GetCategories(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	categories, err := ch.categories.GetCategories(c.Request.Context())
+	if err != nil {
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get categories"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsCategories(categories), l)
+}
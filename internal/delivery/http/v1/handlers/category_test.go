@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+
+	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/pkg/types"
+	cr "vk_quests/internal/repository/category"
+	cu "vk_quests/internal/usecase/category"
+	muc "vk_quests/internal/usecase/category/mocks"
+)
+
+type CategoryHandlersSuite struct {
+	suite.Suite
+	handlers     *CategoryHandlers
+	mockCategory *muc.CategoryUsecase
+	gmc          *gomock.Controller
+}
+
+func (chs *CategoryHandlersSuite) BeforeEach(t provider.T) {
+	chs.gmc = gomock.NewController(t)
+	chs.mockCategory = muc.NewCategoryUsecase(chs.gmc)
+	chs.handlers = NewCategoryHandlers(chs.mockCategory)
+}
+
+func (chs *CategoryHandlersSuite) AfterEach(t provider.T) {
+	chs.gmc.Finish()
+}
+
+func (chs *CategoryHandlersSuite) TestCreateCategoryHandler(t provider.T) {
+	t.Title("CreateCategory handler of category handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/", addEmptyLogger(chs.handlers.CreateCategory))
+
+	t.NewStep("Init test data")
+	newCategory := &cu.Category{
+		Name:        "Daily",
+		Description: "daily quests",
+	}
+	category := &cu.Category{
+		ID:          1,
+		Name:        newCategory.Name,
+		Description: newCategory.Description,
+	}
+	body := `{"name": "Daily", "description": "daily quests"}`
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().CreateCategory(gomock.Any(), newCategory).Return(category, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var ctg response.Category
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&ctg))
+		t.Require().EqualValues(response.Category{ID: 1, Name: "Daily", Description: "daily quests"}, ctg)
+	})
+
+	t.WithNewStep("Category name already exists error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().CreateCategory(gomock.Any(), newCategory).Return(nil, cr.ErrorCategoryNameAlreadyExists).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusConflict, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().CreateCategory(gomock.Any(), newCategory).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect body error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(`{"name": ""}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (chs *CategoryHandlersSuite) TestDeleteCategoryHandler(t provider.T) {
+	t.Title("DeleteCategory handler of category handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+CategoryIdField, addEmptyLogger(chs.handlers.DeleteCategory))
+
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().DeleteCategory(gomock.Any(), id).Return(nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Category not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().DeleteCategory(gomock.Any(), id).Return(cr.ErrorCategoryNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().DeleteCategory(gomock.Any(), id).Return(testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/daily", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (chs *CategoryHandlersSuite) TestGetCategoryHandler(t provider.T) {
+	t.Title("GetCategory handler of category handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+CategoryIdField, addEmptyLogger(chs.handlers.GetCategory))
+
+	t.NewStep("Init test data")
+	category := &cu.Category{
+		ID:   1,
+		Name: "Daily",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().GetCategory(gomock.Any(), category.ID).Return(category, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var ctg response.Category
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&ctg))
+		t.Require().EqualValues(response.Category{ID: 1, Name: "Daily"}, ctg)
+	})
+
+	t.WithNewStep("Category not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().GetCategory(gomock.Any(), category.ID).Return(nil, cr.ErrorCategoryNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().GetCategory(gomock.Any(), category.ID).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/daily", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (chs *CategoryHandlersSuite) TestUpdateCategoryHandler(t provider.T) {
+	t.Title("UpdateCategory handler of category handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+CategoryIdField, addEmptyLogger(chs.handlers.UpdateCategory))
+
+	t.NewStep("Init test data")
+	name := "Weekly"
+	category := &cu.Category{
+		ID:   1,
+		Name: name,
+	}
+	updateCategory := &cu.UpdateCategory{Name: &name}
+	body := `{"name": "Weekly"}`
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().UpdateCategory(gomock.Any(), category.ID, updateCategory).Return(category, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Category not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().UpdateCategory(gomock.Any(), category.ID, updateCategory).
+			Return(nil, cr.ErrorCategoryNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Category name already exists error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().UpdateCategory(gomock.Any(), category.ID, updateCategory).
+			Return(nil, cr.ErrorCategoryNameAlreadyExists).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusConflict, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().UpdateCategory(gomock.Any(), category.ID, updateCategory).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/daily", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect body error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"name": 1}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (chs *CategoryHandlersSuite) TestGetCategoriesHandler(t provider.T) {
+	t.Title("GetCategories handler of category handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/", addEmptyLogger(chs.handlers.GetCategories))
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().GetCategories(gomock.Any()).
+			Return([]cu.Category{{ID: 1, Name: "Daily"}, {ID: 2, Name: "Social"}}, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var categories []response.Category
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&categories))
+		t.Require().EqualValues([]response.Category{{ID: 1, Name: "Daily"}, {ID: 2, Name: "Social"}}, categories)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chs.mockCategory.EXPECT().GetCategories(gomock.Any()).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func TestRunCategoryHandlersSuite(t *testing.T) {
+	suite.RunSuite(t, new(CategoryHandlersSuite))
+}
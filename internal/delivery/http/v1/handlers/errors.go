@@ -1,15 +1,77 @@
 package handlers
 
-import "github.com/pkg/errors"
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"vk_quests/pkg/logger"
+	"vk_quests/pkg/operate"
+)
 
 var (
 	ErrorCannotReadBody       = errors.New("can't read body")
 	ErrorIncorrectBodyContent = errors.New("incorrect body content")
 	ErrorUnknownError         = errors.New("unknown error, try again later")
+	ErrorRequestTimeout       = errors.New("request timeout, try again later")
 	ErrorIncorrectQueryParam  = errors.New("invalid query parameter")
+	ErrorInvalidCursor        = errors.New("invalid cursor")
+
+	ErrorIncorrectIdempotencyKey = errors.New("invalid idempotency key")
 
 	ErrorUserAlreadyCompleteQuest = errors.New("user already complete quest")
 	ErrorQuestNameAlreadyExists   = errors.New("quest with this name already exists")
 	ErrorQuestNotFound            = errors.New("quest not found")
 	ErrorUserNotFound             = errors.New("user not found")
+	ErrorStagedQuestNoSteps       = errors.New("staged quest must have at least one step")
+	ErrorCounterQuestNoTarget     = errors.New("counter quest must have positive target")
+	ErrorStreakQuestNoMilestones  = errors.New("streak quest must have at least one milestone")
+	ErrorTeamQuestNoTarget        = errors.New("team quest must have positive target")
+	ErrorStreakAlreadyExtended    = errors.New("streak is already extended today")
+	ErrorQuestCooldownActive      = errors.New("cooldown active")
+	ErrorIdempotencyKeyInProgress = errors.New("request with this idempotency key is in progress")
+	ErrorIdempotencyKeyMismatch   = errors.New("idempotency key is used with other parameters")
+	ErrorInsufficientFunds        = errors.New("insufficient funds")
+	ErrorRecipientNotFound        = errors.New("recipient not found")
+	ErrorSelfTransfer             = errors.New("transfer to yourself is not allowed")
+	ErrorTransferLimitExceeded    = errors.New("daily transfer limit exceeded")
+	ErrorCompletionNotFound       = errors.New("user has not completed quest")
+
+	ErrorPrerequisiteNotFound      = errors.New("prerequisite quest not found")
+	ErrorPrerequisiteCycle         = errors.New("prerequisites form a cycle")
+	ErrorPrerequisitesNotCompleted = errors.New("prerequisite quests are not completed")
+
+	ErrorInvalidQuestWindow = errors.New("quest must start before it ends")
+	ErrorQuestNotActive     = errors.New("quest is not active now")
+	ErrorQuestArchived      = errors.New("quest is archived")
+	ErrorQuestNotArchived   = errors.New("quest must be archived before purge")
+
+	ErrorAttemptsLimitReached = errors.New("daily limit of quest attempts reached")
+
+	ErrorCategoryNotFound          = errors.New("category not found")
+	ErrorCategoryNameAlreadyExists = errors.New("category with this name already exists")
+
+	ErrorTeamNotFound          = errors.New("team not found")
+	ErrorTeamNameAlreadyExists = errors.New("team with this name already exists")
+	ErrorUserAlreadyInTeam     = errors.New("user is already member of team")
+	ErrorMemberNotFound        = errors.New("user is not member of team")
+	ErrorUserNotInTeam         = errors.New("user is not member of any team")
+	ErrorAlreadyContributed    = errors.New("user already contributed to team quest")
+
+	ErrorReferralCodeNotFound = errors.New("referral code not found")
+
+	ErrorBoostNotFound      = errors.New("boost not found")
+	ErrorInvalidBoostWindow = errors.New("boost must start before it ends")
 )
+
+// sendServerError sends 504 if request deadline is exceeded, otherwise 500.
+func sendServerError(c *gin.Context, err error, l logger.Interface) {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		operate.SendError(c, ErrorRequestTimeout, http.StatusGatewayTimeout, l)
+		return
+	}
+
+	operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+}
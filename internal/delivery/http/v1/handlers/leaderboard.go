@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"vk_quests/internal/delivery/http/v1/model/request"
+	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/delivery/middleware"
+	"vk_quests/internal/pkg/types"
+	lbr "vk_quests/internal/repository/leaderboard"
+	lbu "vk_quests/internal/usecase/leaderboard"
+	"vk_quests/pkg/operate"
+)
+
+type LeaderboardHandlers struct {
+	leaderboards lbu.Usecase
+}
+
+func NewLeaderboardHandlers(leaderboards lbu.Usecase) *LeaderboardHandlers {
+	return &LeaderboardHandlers{leaderboards: leaderboards}
+}
+
+// GetLeaderboard
+//
+//	@Summary		Получение рейтинга пользователей.
+//	@Description	Возвращает первые limit пользователей рейтинга с их местом и очками.
+//	@Description	Метрика earned - сумма наград за выполнение заданий за период window: всё время, текущую неделю или текущий месяц.
+//	@Description	Метрика balance - текущий баланс, поддерживается только для window=all.
+//	@Description	Пользователи с равными очками делят место, удалённые пользователи в рейтинг не попадают.
+//	@Tags			leaderboard
+//	@Produce		json
+//	@Param			window	query		string						false	"Период рейтинга"	Enums(all, week, month)	default(all)
+//	@Param			metric	query		string						false	"Метрика рейтинга"	Enums(earned, balance)	default(earned)
+//	@Param			limit	query		uint64						false	"Размер рейтинга"	minimum(1)				maximum(1000)	default(50)
+//	@Success		200		{array}		response.LeaderboardEntry	"Рейтинг успешно сформирован"
+//	@Failure		400		{object}	operate.ModelError			"Некорректные параметры запроса"
+//	@Failure		500		{object}	operate.ModelError			"Ошибка сервера"
+//	@Failure		504		{object}	operate.ModelError			"Превышено время выполнения запроса"
+//	@Router			/leaderboard [get]
+func (lh *LeaderboardHandlers) GetLeaderboard(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	leaderboard := &request.Leaderboard{}
+	if err := c.ShouldBindQuery(leaderboard); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "can't parse leaderboard query"))
+		return
+	}
+	if err := leaderboard.Validate(); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "incorrect leaderboard query"))
+		return
+	}
+
+	entries, err := lh.leaderboards.GetLeaderboard(c.Request.Context(), leaderboard.ToUsLeaderboardQuery())
+	if err != nil {
+		if errors.Is(err, lbr.ErrorUnsupportedWindow) {
+			operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+			l.Error(errors.Wrapf(err, "can't get leaderboard"))
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get leaderboard"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsEntries(entries), l)
+}
+
+// GetRank
+//
+//	@Summary		Получение места пользователя в рейтинге.
+//	@Description	Возвращает место и очки пользователя по его id в рейтинге с теми же window и metric, что и у /leaderboard.
+//	@Description	Пользователь без наград за период имеет 0 очков.
+//	@Tags			leaderboard
+//	@Param			user_id	path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Param			window	query	string	false	"Период рейтинга"	Enums(all, week, month)	default(all)
+//	@Param			metric	query	string	false	"Метрика рейтинга"	Enums(earned, balance)	default(earned)
+//	@Produce		json
+//	@Success		200	{object}	response.LeaderboardEntry	"Место пользователя в рейтинге"
+//	@Failure		400	{object}	operate.ModelError			"Некорректные параметры запроса"
+//	@Failure		404	{object}	operate.ModelError			"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError			"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError			"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/rank [get]
+func (lh *LeaderboardHandlers) GetRank(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	rank := &request.Rank{}
+	if err := c.ShouldBindQuery(rank); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "can't parse rank query"))
+		return
+	}
+	if err := rank.Validate(); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "incorrect rank query"))
+		return
+	}
+
+	entry, err := lh.leaderboards.GetRank(c.Request.Context(), types.Id(id), rank.ToUsWindow(), rank.ToUsMetric())
+	if err != nil {
+		switch {
+		case errors.Is(err, lbr.ErrorUserNotFound):
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+		case errors.Is(err, lbr.ErrorUnsupportedWindow):
+			operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+			l.Error(errors.Wrapf(err, "can't get rank"))
+		default:
+			sendServerError(c, err, l)
+			l.Error(errors.Wrapf(err, "can't get rank"))
+		}
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsEntry(entry), l)
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+
+	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/pkg/types"
+	lbr "vk_quests/internal/repository/leaderboard"
+	lbu "vk_quests/internal/usecase/leaderboard"
+	mul "vk_quests/internal/usecase/leaderboard/mocks"
+)
+
+type LeaderboardHandlersSuite struct {
+	suite.Suite
+	handlers        *LeaderboardHandlers
+	mockLeaderboard *mul.LeaderboardUsecase
+	gmc             *gomock.Controller
+}
+
+func (lhs *LeaderboardHandlersSuite) BeforeEach(t provider.T) {
+	lhs.gmc = gomock.NewController(t)
+	lhs.mockLeaderboard = mul.NewLeaderboardUsecase(lhs.gmc)
+	lhs.handlers = NewLeaderboardHandlers(lhs.mockLeaderboard)
+}
+
+func (lhs *LeaderboardHandlersSuite) AfterEach(t provider.T) {
+	lhs.gmc.Finish()
+}
+
+func (lhs *LeaderboardHandlersSuite) TestGetLeaderboardHandler(t provider.T) {
+	t.Title("GetLeaderboard handler of leaderboard handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.GET("/", addEmptyLogger(lhs.handlers.GetLeaderboard))
+
+	t.NewStep("Init test data")
+	entries := []lbu.Entry{
+		{Rank: 1, UserId: 3, Name: "First", Score: 40},
+		{Rank: 2, UserId: 1, Name: "Second", Score: 25},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lhs.mockLeaderboard.EXPECT().GetLeaderboard(gomock.Any(), &lbu.LeaderboardQuery{
+			Window: types.LeaderboardWeek,
+			Metric: types.LeaderboardEarned,
+			Limit:  2,
+		}).Return(entries, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/?window=week&limit=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var res []response.LeaderboardEntry
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&res))
+		t.Require().EqualValues([]response.LeaderboardEntry{
+			{Rank: 1, User: response.LeaderboardUser{ID: 3, Name: "First"}, Score: 40},
+			{Rank: 2, User: response.LeaderboardUser{ID: 1, Name: "Second"}, Score: 25},
+		}, res)
+	})
+
+	t.WithNewStep("Correct default params execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lhs.mockLeaderboard.EXPECT().GetLeaderboard(gomock.Any(), &lbu.LeaderboardQuery{
+			Window: types.LeaderboardAllTime,
+			Metric: types.LeaderboardEarned,
+		}).Return(entries, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	for _, query := range []string{
+		"window=year",
+		"metric=streak",
+		"metric=balance&window=week",
+		"limit=1001",
+		"limit=top",
+	} {
+		t.WithNewStep("Incorrect query params "+query+" execute", func(t provider.StepCtx) {
+			t.NewStep("Init http")
+			req, err := initRequest(http.MethodGet, "/?"+query, nil, nil)
+			t.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+
+			t.NewStep("Check result")
+			r.ServeHTTP(recorder, req)
+
+			t.Require().Equal(http.StatusBadRequest, recorder.Code)
+		})
+	}
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lhs.mockLeaderboard.EXPECT().GetLeaderboard(gomock.Any(), gomock.Any()).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/?metric=balance", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func (lhs *LeaderboardHandlersSuite) TestGetRankHandler(t provider.T) {
+	t.Title("GetRank handler of leaderboard handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.GET("/:"+UserIdField, addEmptyLogger(lhs.handlers.GetRank))
+
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	entry := &lbu.Entry{Rank: 2, UserId: userId, Name: "Second", Score: 25}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lhs.mockLeaderboard.EXPECT().GetRank(gomock.Any(), userId, types.LeaderboardMonth, types.LeaderboardEarned).
+			Return(entry, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/1?window=month", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var res response.LeaderboardEntry
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&res))
+		t.Require().EqualValues(response.LeaderboardEntry{
+			Rank:  2,
+			User:  response.LeaderboardUser{ID: userId, Name: "Second"},
+			Score: 25,
+		}, res)
+	})
+
+	t.WithNewStep("User not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lhs.mockLeaderboard.EXPECT().GetRank(gomock.Any(), userId, types.LeaderboardAllTime, types.LeaderboardBalance).
+			Return(nil, lbr.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/1?metric=balance", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lhs.mockLeaderboard.EXPECT().GetRank(gomock.Any(), userId, types.LeaderboardAllTime, types.LeaderboardEarned).
+			Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect query params execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/1?metric=balance&window=month", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect user id execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/first", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestRunLeaderboardHandlersSuite(t *testing.T) {
+	suite.RunSuite(t, new(LeaderboardHandlersSuite))
+}
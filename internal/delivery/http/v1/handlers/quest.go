@@ -10,6 +10,7 @@ import (
 	"vk_quests/internal/delivery/http/v1/model/request"
 	"vk_quests/internal/delivery/http/v1/model/response"
 	"vk_quests/internal/delivery/middleware"
+	"vk_quests/internal/pkg/page"
 	"vk_quests/internal/pkg/types"
 	qr "vk_quests/internal/repository/quest"
 	qu "vk_quests/internal/usecase/quest"
@@ -31,15 +32,17 @@ func NewQuestHandlers(quests qu.Usecase) *QuestHandlers {
 // CreateQuest
 //
 //	@Summary		Добавление задание.
-//	@Description	Добавляет задание включая его название(уникальное), описание, стоимость и тип. Есть обычная задание, которое выполняется как только вызывается метод, сигнализирующий о выполнении для пользователя задачи. И случайная задача, которая выполняется в с вероятностью 0,5.
+//	@Description	Добавляет задание включая его название(уникальное), описание, стоимость и тип. Есть обычная задание, которое выполняется как только вызывается метод, сигнализирующий о выполнении для пользователя задачи. И случайная задача, которая выполняется с вероятностью из поля probability (по умолчанию 0,5); если задано поле pity, задача гарантированно засчитывается после указанного числа неудачных попыток подряд. Также есть многошаговое задание, для которого необходимо передать упорядоченный список шагов: каждый вызов метода выполнения продвигает пользователя на один шаг, а награда начисляется после последнего шага. По умолчанию задание можно выполнить один раз: поле max_completions задаёт максимальное число выполнений (0 - без ограничений), а cooldown - минимальный перерыв между выполнениями в секундах. В поле prerequisites можно передать id заданий, которые пользователь должен выполнить до этого задания. Поле categories содержит id категорий задания, а tags - произвольные метки. Поля starts_at и ends_at в формате 02.01.2006 - 15:04:05 (UTC) задают период, в который задание засчитывается. Командное задание выполняется участниками команды: каждый участник делает один вклад, и когда вклад внесли target участников, награда начисляется всем внёсшим вклад - поровну (reward_split=equal, по умолчанию) или целиком каждому (reward_split=fixed).
 //	@Tags			quest
 //	@Accept			json
 //	@Param			request	body	request.CreateQuest	true	"Информация о добавляемом фильме"
 //	@Produce		json
 //	@Success		201	{object}	response.Quest		"Задание успешно добавлен в базу"
-//	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка"
+//	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка, у многошагового задания нет шагов, у задания-счётчика нет цели, у задания-серии нет наград, у командного задания нет цели или starts_at не раньше ends_at"
 //	@Failure		409	{object}	operate.ModelError	"Задача с таким название уже существует"
+//	@Failure		422	{object}	operate.ModelError	"Одно из обязательных предварительных заданий не найдено"
 //	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/quest [post]
 func (qh *QuestHandlers) CreateQuest(c *gin.Context) {
 	l := middleware.GetLogger(c)
@@ -51,14 +54,49 @@ func (qh *QuestHandlers) CreateQuest(c *gin.Context) {
 		return
 	}
 
-	createdQuest, err := qh.quests.CreateQuest(createQuest.ToUsQuest())
+	createdQuest, err := qh.quests.CreateQuest(c.Request.Context(), createQuest.ToUsQuest())
 	if err != nil {
 		if errors.Is(err, qr.ErrorQuestNameAlreadyExists) {
 			operate.SendError(c, ErrorQuestNameAlreadyExists, http.StatusConflict, l)
 			l.Info(errors.Wrapf(err, "can't create quest"))
 			return
 		}
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+		if errors.Is(err, qr.ErrorStagedQuestNoSteps) {
+			operate.SendError(c, ErrorStagedQuestNoSteps, http.StatusBadRequest, l)
+			l.Info(errors.Wrapf(err, "can't create quest"))
+			return
+		}
+		if errors.Is(err, qr.ErrorCounterQuestNoTarget) {
+			operate.SendError(c, ErrorCounterQuestNoTarget, http.StatusBadRequest, l)
+			l.Info(errors.Wrapf(err, "can't create quest"))
+			return
+		}
+		if errors.Is(err, qr.ErrorStreakQuestNoMilestones) {
+			operate.SendError(c, ErrorStreakQuestNoMilestones, http.StatusBadRequest, l)
+			l.Info(errors.Wrapf(err, "can't create quest"))
+			return
+		}
+		if errors.Is(err, qr.ErrorTeamQuestNoTarget) {
+			operate.SendError(c, ErrorTeamQuestNoTarget, http.StatusBadRequest, l)
+			l.Info(errors.Wrapf(err, "can't create quest"))
+			return
+		}
+		if errors.Is(err, qr.ErrorInvalidWindow) {
+			operate.SendError(c, ErrorInvalidQuestWindow, http.StatusBadRequest, l)
+			l.Info(errors.Wrapf(err, "can't create quest"))
+			return
+		}
+		if errors.Is(err, qr.ErrorPrerequisiteNotFound) {
+			operate.SendError(c, ErrorPrerequisiteNotFound, http.StatusUnprocessableEntity, l)
+			l.Info(errors.Wrapf(err, "can't create quest"))
+			return
+		}
+		if errors.Is(err, qr.ErrorCategoryNotFound) {
+			operate.SendError(c, ErrorCategoryNotFound, http.StatusUnprocessableEntity, l)
+			l.Info(errors.Wrapf(err, "can't create quest"))
+			return
+		}
+		sendServerError(c, err, l)
 		l.Error(errors.Wrapf(err, "can't create quest"))
 		return
 	}
@@ -66,19 +104,96 @@ func (qh *QuestHandlers) CreateQuest(c *gin.Context) {
 	operate.SendStatus(c, http.StatusCreated, response.FromUsQuest(createdQuest), l)
 }
 
-// DeleteQuest
+// ArchiveQuest
 //
-//	@Summary		Удаление задания.
-//	@Description	Удаляет информацию о задании из системы по его id.
+//	@Summary		Архивация задания.
+//	@Description	Переносит задание в архив по его id. Архивное задание скрыто из списков и не может быть выполнено, а история его выполнений сохраняется. Задание можно вернуть из архива методом restore.
 //	@Tags			quest
 //	@Param			quest_id	path	uint64	true	"Уникальный идентификатор задания"
 //	@Produce		json
-//	@Success		200	"Задание успешно удалено"
+//	@Success		200	"Задание успешно перенесено в архив"
 //	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
 //	@Failure		404	{object}	operate.ModelError	"Задание с указанным id не найдено"
 //	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/quest/{quest_id} [delete]
-func (qh *QuestHandlers) DeleteQuest(c *gin.Context) {
+func (qh *QuestHandlers) ArchiveQuest(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(QuestIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get quest id"), http.StatusBadRequest, l)
+		return
+	}
+
+	if err = qh.quests.ArchiveQuest(c.Request.Context(), types.Id(id)); err != nil {
+		if errors.Is(err, qr.ErrorQuestNotFound) {
+			operate.SendError(c, ErrorQuestNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't archive quest"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, nil, l)
+}
+
+// RestoreQuest
+//
+//	@Summary		Восстановление задания из архива.
+//	@Description	Возвращает задание из архива по его id, после чего оно снова отображается в списках и может быть выполнено.
+//	@Tags			quest
+//	@Param			quest_id	path	uint64	true	"Уникальный идентификатор задания"
+//	@Produce		json
+//	@Success		200	{object}	response.Quest		"Задание успешно восстановлено"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Задание с указанным id не найдено"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/quest/{quest_id}/restore [post]
+func (qh *QuestHandlers) RestoreQuest(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(QuestIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get quest id"), http.StatusBadRequest, l)
+		return
+	}
+
+	restoredQuest, err := qh.quests.RestoreQuest(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, qr.ErrorQuestNotFound) {
+			operate.SendError(c, ErrorQuestNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't restore quest"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsQuest(restoredQuest), l)
+}
+
+// PurgeQuest
+//
+//	@Summary		Окончательное удаление задания.
+//	@Description	Удаляет архивное задание из системы по его id. Задание убирается из предварительных заданий других заданий, а в истории выполнений остаётся только его снимок. Метод доступен только администраторам.
+//	@Tags			quest
+//	@Param			quest_id		path	uint64	true	"Уникальный идентификатор задания"
+//	@Param			X-Admin-Token	header	string	true	"Токен администратора"
+//	@Produce		json
+//	@Success		200	"Задание успешно удалено"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		403	{object}	operate.ModelError	"Неверный токен администратора"
+//	@Failure		404	{object}	operate.ModelError	"Задание с указанным id не найдено"
+//	@Failure		409	{object}	operate.ModelError	"Задание не перенесено в архив"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/quest/{quest_id}/purge [delete]
+func (qh *QuestHandlers) PurgeQuest(c *gin.Context) {
 	l := middleware.GetLogger(c)
 
 	// Получение уникального идентификатора
@@ -88,23 +203,28 @@ func (qh *QuestHandlers) DeleteQuest(c *gin.Context) {
 		return
 	}
 
-	if err = qh.quests.DeleteQuest(types.Id(id)); err != nil {
+	if err = qh.quests.PurgeQuest(c.Request.Context(), types.Id(id)); err != nil {
 		if errors.Is(err, qr.ErrorQuestNotFound) {
 			operate.SendError(c, ErrorQuestNotFound, http.StatusNotFound, l)
 			return
 		}
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
-		l.Error(errors.Wrapf(err, "can't delete quest"))
+		if errors.Is(err, qr.ErrorQuestNotArchived) {
+			operate.SendError(c, ErrorQuestNotArchived, http.StatusConflict, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't purge quest"))
 		return
 	}
 
+	l.Warn("quest with id %d is purged", id)
 	operate.SendStatus(c, http.StatusOK, nil, l)
 }
 
 // GetQuest
 //
 //	@Summary		Получение задания.
-//	@Description	Позволяет информацию о задании по его id.
+//	@Description	Позволяет информацию о задании по его id, в том числе архивного.
 //	@Tags			quest
 //	@Param			quest_id	path	uint64	true	"Уникальный идентификатор задания"
 //	@Produce		json
@@ -112,6 +232,7 @@ func (qh *QuestHandlers) DeleteQuest(c *gin.Context) {
 //	@Failure		400	{object}	operate.ModelError	"В пути запросе ошибка"
 //	@Failure		404	{object}	operate.ModelError	"Задание с указанным id не найдено"
 //	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/quest/{quest_id} [get]
 func (qh *QuestHandlers) GetQuest(c *gin.Context) {
 	l := middleware.GetLogger(c)
@@ -123,14 +244,14 @@ func (qh *QuestHandlers) GetQuest(c *gin.Context) {
 		return
 	}
 
-	quests, err := qh.quests.GetQuest(types.Id(id))
+	quests, err := qh.quests.GetQuest(c.Request.Context(), types.Id(id))
 	if err != nil {
 		if errors.Is(err, qr.ErrorQuestNotFound) {
 			operate.SendError(c, ErrorQuestNotFound, http.StatusNotFound, l)
 			l.Error(errors.Wrapf(err, "can't get quests"))
 			return
 		}
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+		sendServerError(c, err, l)
 		l.Error(errors.Wrapf(err, "can't get quests"))
 		return
 	}
@@ -141,16 +262,19 @@ func (qh *QuestHandlers) GetQuest(c *gin.Context) {
 // UpdateQuest
 //
 //	@Summary		Обновление данных об задании.
-//	@Description	Обновляет данные об задании. Все переданные поля будут обновлены. Отсутствующие поля будут оставлены без изменений.
+//	@Description	Обновляет данные об задании. Все переданные поля будут обновлены. Отсутствующие поля будут оставлены без изменений. Переданный список prerequisites заменяет текущий, пустой список удаляет все предварительные задания. Так же заменяются списки categories и tags. Список, образующий цикл в цепочке заданий, отклоняется. Задание, тип которого меняется на streak без поля max_completions, получает неограниченное число выполнений.
 //	@Tags			quest
 //	@Accept			json
 //	@Param			quest_id	path	uint64				true	"Уникальный идентификатор задания"
 //	@Param			request		body	request.UpdateQuest	true	"Информация об обновлении"
 //	@Produce		json
 //	@Success		200	{object}	response.Quest		"Задание успешно обновлено в базе"
-//	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка"
+//	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка, у многошагового задания нет шагов, у задания-счётчика нет цели, у задания-серии нет наград, у командного задания нет цели или starts_at не раньше ends_at"
 //	@Failure		404	{object}	operate.ModelError	"Задание с указанным id не найден"
+//	@Failure		409	{object}	operate.ModelError	"Предварительные задания образуют цикл"
+//	@Failure		422	{object}	operate.ModelError	"Одно из обязательных предварительных заданий не найдено"
 //	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/quest/{quest_id} [put]
 func (qh *QuestHandlers) UpdateQuest(c *gin.Context) {
 	l := middleware.GetLogger(c)
@@ -169,14 +293,46 @@ func (qh *QuestHandlers) UpdateQuest(c *gin.Context) {
 		return
 	}
 
-	updatedQuest, err := qh.quests.UpdateQuest(types.Id(id), updateQuest.ToUsUpdateQuest())
+	updatedQuest, err := qh.quests.UpdateQuest(c.Request.Context(), types.Id(id), updateQuest.ToUsUpdateQuest())
 	if err != nil {
 		if errors.Is(err, qr.ErrorQuestNotFound) {
 			operate.SendError(c, ErrorQuestNotFound, http.StatusNotFound, l)
 			return
 		}
+		if errors.Is(err, qr.ErrorStagedQuestNoSteps) {
+			operate.SendError(c, ErrorStagedQuestNoSteps, http.StatusBadRequest, l)
+			return
+		}
+		if errors.Is(err, qr.ErrorCounterQuestNoTarget) {
+			operate.SendError(c, ErrorCounterQuestNoTarget, http.StatusBadRequest, l)
+			return
+		}
+		if errors.Is(err, qr.ErrorStreakQuestNoMilestones) {
+			operate.SendError(c, ErrorStreakQuestNoMilestones, http.StatusBadRequest, l)
+			return
+		}
+		if errors.Is(err, qr.ErrorTeamQuestNoTarget) {
+			operate.SendError(c, ErrorTeamQuestNoTarget, http.StatusBadRequest, l)
+			return
+		}
+		if errors.Is(err, qr.ErrorInvalidWindow) {
+			operate.SendError(c, ErrorInvalidQuestWindow, http.StatusBadRequest, l)
+			return
+		}
+		if errors.Is(err, qr.ErrorPrerequisiteNotFound) {
+			operate.SendError(c, ErrorPrerequisiteNotFound, http.StatusUnprocessableEntity, l)
+			return
+		}
+		if errors.Is(err, qr.ErrorCategoryNotFound) {
+			operate.SendError(c, ErrorCategoryNotFound, http.StatusUnprocessableEntity, l)
+			return
+		}
+		if errors.Is(err, qr.ErrorPrerequisiteCycle) {
+			operate.SendError(c, ErrorPrerequisiteCycle, http.StatusConflict, l)
+			return
+		}
 
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+		sendServerError(c, err, l)
 		l.Error(errors.Wrapf(err, "can't update quest"))
 		return
 	}
@@ -187,21 +343,54 @@ func (qh *QuestHandlers) UpdateQuest(c *gin.Context) {
 // GetQuests
 //
 //	@Summary		Получение списка заданий.
-//	@Description	Позволяет получить список заданий.
+//	@Description	Формирует страницу списка заданий с фильтрацией и сортировкой.
+//	@Description	Для получения следующей страницы передайте next_cursor из ответа с теми же sort и order.
 //	@Tags			quest
 //	@Produce		json
-//	@Success		200	{array}		response.Quest		"Список заданий успешно сформирован"
-//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Param			type		query		string				false	"Тип задания"	Enums(usual, random, staged, counter, streak, team)
+//	@Param			min_cost	query		uint32				false	"Минимальная стоимость"
+//	@Param			max_cost	query		uint32				false	"Максимальная стоимость"
+//	@Param			name_prefix	query		string				false	"Префикс названия задания"
+//	@Param			category	query		uint64				false	"Только задания категории с указанным id"
+//	@Param			tag			query		string				false	"Только задания с указанной меткой"
+//	@Param			active		query		bool				false	"Только задания, период выполнения которых включает текущий момент"
+//	@Param			archived	query		bool				false	"Только архивные задания вместо неархивных"
+//	@Param			sort		query		string				false	"Поле сортировки"		Enums(id, name, cost)	default(id)
+//	@Param			order		query		string				false	"Порядок сортировки"	Enums(asc, desc)		default(asc)
+//	@Param			limit		query		uint64				false	"Размер страницы"		minimum(1)				maximum(1000)	default(50)
+//	@Param			cursor		query		string				false	"Курсор следующей страницы"
+//	@Success		200			{object}	response.QuestsPage	"Страница заданий успешно сформирована"
+//	@Failure		400			{object}	operate.ModelError	"Некорректные параметры запроса"
+//	@Failure		500			{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504			{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/quest/list [get]
 func (qh *QuestHandlers) GetQuests(c *gin.Context) {
 	l := middleware.GetLogger(c)
 
-	quests, err := qh.quests.GetQuests()
+	listQuests := &request.ListQuests{}
+	if err := c.ShouldBindQuery(listQuests); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "can't parse list quests query"))
+		return
+	}
+	if err := listQuests.Validate(); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "incorrect list quests query"))
+		return
+	}
+
+	questsPage, err := qh.quests.GetQuests(c.Request.Context(), listQuests.ToUsQuestsQuery())
 	if err != nil {
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+		if errors.Is(err, page.ErrorInvalidCursor) {
+			operate.SendError(c, ErrorInvalidCursor, http.StatusBadRequest, l)
+			l.Error(errors.Wrapf(err, "can't get quests"))
+			return
+		}
+
+		sendServerError(c, err, l)
 		l.Error(errors.Wrapf(err, "can't get quests"))
 		return
 	}
 
-	operate.SendStatus(c, http.StatusOK, response.FromUsQuests(quests), l)
+	operate.SendStatus(c, http.StatusOK, response.FromUsQuestsPage(questsPage), l)
 }
@@ -6,13 +6,17 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"go.uber.org/mock/gomock"
 
+	"vk_quests/internal/delivery/http/v1/model/request"
 	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
 	qr "vk_quests/internal/repository/quest"
 	qu "vk_quests/internal/usecase/quest"
@@ -50,7 +54,14 @@ func (qhs *QuestHandlersSuite) TestGetQuestsHandler(t provider.T) {
 		Cost:        10,
 		Type:        types.USUAL,
 	}
-	quests := []qu.Quest{*quest, *quest, *quest}
+	questsPage := &qu.QuestsPage{
+		Quests:     []qu.Quest{*quest, *quest, *quest},
+		NextCursor: "cursor",
+	}
+	defaultQuery := &qu.QuestsQuery{
+		Sort:  types.QuestsSortID,
+		Order: page.Asc,
+	}
 
 	responseQuest := &response.Quest{
 		ID:          quest.ID,
@@ -60,11 +71,14 @@ func (qhs *QuestHandlersSuite) TestGetQuestsHandler(t provider.T) {
 		Type:        quest.Type,
 	}
 
-	responseQuests := []response.Quest{*responseQuest, *responseQuest, *responseQuest}
+	responseQuestsPage := &response.QuestsPage{
+		Quests:     []response.Quest{*responseQuest, *responseQuest, *responseQuest},
+		NextCursor: "cursor",
+	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().GetQuests().Return(quests, nil).Times(1)
+		qhs.mockQuest.EXPECT().GetQuests(gomock.Any(), defaultQuery).Return(questsPage, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", nil, nil)
@@ -76,15 +90,89 @@ func (qhs *QuestHandlersSuite) TestGetQuestsHandler(t provider.T) {
 		r.ServeHTTP(recorder, req)
 
 		t.Require().Equal(http.StatusOK, recorder.Code)
-		var qsts []response.Quest
+		var qsts response.QuestsPage
 		dec := json.NewDecoder(recorder.Body)
 		t.Require().NoError(dec.Decode(&qsts))
-		t.Require().EqualValues(responseQuests, qsts)
+		t.Require().EqualValues(responseQuestsPage, &qsts)
+	})
+
+	t.WithNewStep("Query params execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		questType := types.RANDOM
+		minCost, maxCost := types.Cost(5), types.Cost(20)
+		categoryId := types.Id(3)
+		qhs.mockQuest.EXPECT().GetQuests(gomock.Any(), &qu.QuestsQuery{
+			Type:       &questType,
+			MinCost:    &minCost,
+			MaxCost:    &maxCost,
+			NamePrefix: "Qu",
+			CategoryId: &categoryId,
+			Tag:        "daily",
+			Active:     true,
+			Archived:   true,
+			Sort:       types.QuestsSortCost,
+			Order:      page.Desc,
+			Cursor:     "cursor",
+			Limit:      2,
+		}).Return(questsPage, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost,
+			"/?type=random&min_cost=5&max_cost=20&name_prefix=Qu&category=3&tag=daily&active=true&archived=true&sort=cost&order=desc&limit=2&cursor=cursor", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	for _, query := range []string{
+		"type=daily",
+		"min_cost=-1",
+		"min_cost=20&max_cost=5",
+		"sort=balance",
+		"order=up",
+		"limit=top",
+		"active=maybe",
+		"archived=maybe",
+		"category=-1",
+	} {
+		t.WithNewStep("Incorrect query params "+query+" execute", func(t provider.StepCtx) {
+			t.NewStep("Init http")
+			req, err := initRequest(http.MethodPost, "/?"+query, nil, nil)
+			t.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+
+			t.NewStep("Check result")
+			r.ServeHTTP(recorder, req)
+
+			t.Require().Equal(http.StatusBadRequest, recorder.Code)
+		})
+	}
+
+	t.WithNewStep("Invalid cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().GetQuests(gomock.Any(), gomock.Any()).Return(nil, page.ErrorInvalidCursor).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?cursor=top", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
 	})
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().GetQuests().Return(nil, testError).Times(1)
+		qhs.mockQuest.EXPECT().GetQuests(gomock.Any(), defaultQuery).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", nil, nil)
@@ -124,7 +212,7 @@ func (qhs *QuestHandlersSuite) TestGetQuestHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().GetQuest(quest.ID).Return(quest, nil).Times(1)
+		qhs.mockQuest.EXPECT().GetQuest(gomock.Any(), quest.ID).Return(quest, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -144,7 +232,165 @@ func (qhs *QuestHandlersSuite) TestGetQuestHandler(t provider.T) {
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().GetQuest(quest.ID).Return(nil, testError).Times(1)
+		qhs.mockQuest.EXPECT().GetQuest(gomock.Any(), quest.ID).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Quest not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().GetQuest(gomock.Any(), quest.ID).Return(nil, qr.ErrorQuestNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/sus", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (qhs *QuestHandlersSuite) TestArchiveQuestHandler(t provider.T) {
+	t.Title("ArchiveQuest handler of quest handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+QuestIdField, addEmptyLogger(qhs.handlers.ArchiveQuest))
+
+	t.NewStep("Init test data")
+	quest := &qu.Quest{
+		ID:          1,
+		Name:        "Quest",
+		Description: "good Quest",
+		Cost:        10,
+		Type:        types.USUAL,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().ArchiveQuest(gomock.Any(), quest.ID).Return(nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().ArchiveQuest(gomock.Any(), quest.ID).Return(testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Quest not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().ArchiveQuest(gomock.Any(), quest.ID).Return(qr.ErrorQuestNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/sus", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (qhs *QuestHandlersSuite) TestRestoreQuestHandler(t provider.T) {
+	t.Title("RestoreQuest handler of quest handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+QuestIdField, addEmptyLogger(qhs.handlers.RestoreQuest))
+
+	t.NewStep("Init test data")
+	quest := &qu.Quest{
+		ID:          1,
+		Name:        "Quest",
+		Description: "good Quest",
+		Cost:        10,
+		Type:        types.USUAL,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().RestoreQuest(gomock.Any(), quest.ID).Return(quest, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var qst response.Quest
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&qst))
+		t.Require().EqualValues(response.FromUsQuest(quest), &qst)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().RestoreQuest(gomock.Any(), quest.ID).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -160,7 +406,7 @@ func (qhs *QuestHandlersSuite) TestGetQuestHandler(t provider.T) {
 
 	t.WithNewStep("Quest not found error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().GetQuest(quest.ID).Return(nil, qr.ErrorQuestNotFound).Times(1)
+		qhs.mockQuest.EXPECT().RestoreQuest(gomock.Any(), quest.ID).Return(nil, qr.ErrorQuestNotFound).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -188,11 +434,11 @@ func (qhs *QuestHandlersSuite) TestGetQuestHandler(t provider.T) {
 	})
 }
 
-func (qhs *QuestHandlersSuite) TestDeleteQuestHandler(t provider.T) {
-	t.Title("DeleteQuest handler of quest handlers")
+func (qhs *QuestHandlersSuite) TestPurgeQuestHandler(t provider.T) {
+	t.Title("PurgeQuest handler of quest handlers")
 	t.NewStep("Init gin routes")
 	r := gin.New()
-	r.POST("/:"+QuestIdField, addEmptyLogger(qhs.handlers.DeleteQuest))
+	r.POST("/:"+QuestIdField, addEmptyLogger(qhs.handlers.PurgeQuest))
 
 	t.NewStep("Init test data")
 	quest := &qu.Quest{
@@ -205,7 +451,7 @@ func (qhs *QuestHandlersSuite) TestDeleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().DeleteQuest(quest.ID).Return(nil).Times(1)
+		qhs.mockQuest.EXPECT().PurgeQuest(gomock.Any(), quest.ID).Return(nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -221,7 +467,7 @@ func (qhs *QuestHandlersSuite) TestDeleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().DeleteQuest(quest.ID).Return(testError).Times(1)
+		qhs.mockQuest.EXPECT().PurgeQuest(gomock.Any(), quest.ID).Return(testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -237,7 +483,7 @@ func (qhs *QuestHandlersSuite) TestDeleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Quest not found error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().DeleteQuest(quest.ID).Return(qr.ErrorQuestNotFound).Times(1)
+		qhs.mockQuest.EXPECT().PurgeQuest(gomock.Any(), quest.ID).Return(qr.ErrorQuestNotFound).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -251,6 +497,22 @@ func (qhs *QuestHandlersSuite) TestDeleteQuestHandler(t provider.T) {
 		t.Require().Equal(http.StatusNotFound, recorder.Code)
 	})
 
+	t.WithNewStep("Quest not archived error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().PurgeQuest(gomock.Any(), quest.ID).Return(qr.ErrorQuestNotArchived).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusConflict, recorder.Code)
+	})
+
 	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/sus", nil, nil)
@@ -281,10 +543,12 @@ func (qhs *QuestHandlersSuite) TestCreateQuestHandler(t provider.T) {
 	}
 
 	newQuest := &qu.Quest{
-		Name:        quest.Name,
-		Description: quest.Description,
-		Cost:        quest.Cost,
-		Type:        quest.Type,
+		Name:           quest.Name,
+		Description:    quest.Description,
+		Cost:           quest.Cost,
+		Type:           quest.Type,
+		MaxCompletions: request.DefaultMaxCompletions,
+		Probability:    request.DefaultProbability,
 	}
 
 	body := `
@@ -306,7 +570,7 @@ func (qhs *QuestHandlersSuite) TestCreateQuestHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().CreateQuest(newQuest).Return(quest, nil).Times(1)
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(quest, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
@@ -326,7 +590,7 @@ func (qhs *QuestHandlersSuite) TestCreateQuestHandler(t provider.T) {
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().CreateQuest(newQuest).Return(nil, testError).Times(1)
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
@@ -342,7 +606,7 @@ func (qhs *QuestHandlersSuite) TestCreateQuestHandler(t provider.T) {
 
 	t.WithNewStep("Quest name already exists error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().CreateQuest(newQuest).Return(nil, qr.ErrorQuestNameAlreadyExists).Times(1)
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, qr.ErrorQuestNameAlreadyExists).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
@@ -356,6 +620,429 @@ func (qhs *QuestHandlersSuite) TestCreateQuestHandler(t provider.T) {
 		t.Require().Equal(http.StatusConflict, recorder.Code)
 	})
 
+	t.WithNewStep("Correct repeatable quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		repeatableQuest := &qu.Quest{
+			Name:           quest.Name,
+			Description:    quest.Description,
+			Cost:           quest.Cost,
+			Type:           quest.Type,
+			MaxCompletions: 0,
+			Cooldown:       time.Hour,
+			Probability:    request.DefaultProbability,
+		}
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), repeatableQuest).Return(quest, nil).Times(1)
+
+		t.NewStep("Init http")
+		repeatableBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "usual",
+				"max_completions": 0,
+				"cooldown": 3600
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(repeatableBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+	})
+
+	t.WithNewStep("Correct quest with prerequisites execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		chainedQuest := &qu.Quest{
+			Name:           quest.Name,
+			Description:    quest.Description,
+			Cost:           quest.Cost,
+			Type:           quest.Type,
+			MaxCompletions: request.DefaultMaxCompletions,
+			Probability:    request.DefaultProbability,
+			Prerequisites:  []types.Id{2, 3},
+		}
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), chainedQuest).Return(quest, nil).Times(1)
+
+		t.NewStep("Init http")
+		chainedBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "usual",
+				"prerequisites": [2, 3]
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(chainedBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+	})
+
+	t.WithNewStep("Correct quest with categories and tags execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		labeledQuest := &qu.Quest{
+			Name:           quest.Name,
+			Description:    quest.Description,
+			Cost:           quest.Cost,
+			Type:           quest.Type,
+			MaxCompletions: request.DefaultMaxCompletions,
+			Probability:    request.DefaultProbability,
+			Categories:     []types.Id{1, 2},
+			Tags:           []string{"daily"},
+		}
+		resultQuest := *quest
+		resultQuest.Categories, resultQuest.Tags = labeledQuest.Categories, labeledQuest.Tags
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), labeledQuest).Return(&resultQuest, nil).Times(1)
+
+		t.NewStep("Init http")
+		labeledBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "usual",
+				"categories": [1, 2],
+				"tags": ["daily"]
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(labeledBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var qst response.Quest
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&qst))
+		t.Require().EqualValues([]types.Id{1, 2}, qst.Categories)
+		t.Require().EqualValues([]string{"daily"}, qst.Tags)
+	})
+
+	t.WithNewStep("Empty tag execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(`
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "usual",
+				"tags": [""]
+			}
+		`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Category not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, qr.ErrorCategoryNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Correct quest with availability window execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		startsAt := pkgtime.MustParse("01.12.2024 - 00:00:00")
+		endsAt := pkgtime.MustParse("01.01.2025 - 00:00:00")
+		seasonalQuest := &qu.Quest{
+			Name:           quest.Name,
+			Description:    quest.Description,
+			Cost:           quest.Cost,
+			Type:           quest.Type,
+			MaxCompletions: request.DefaultMaxCompletions,
+			Probability:    request.DefaultProbability,
+			StartsAt:       &startsAt,
+			EndsAt:         &endsAt,
+		}
+		createdQuest := *quest
+		createdQuest.StartsAt, createdQuest.EndsAt = &startsAt, &endsAt
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), seasonalQuest).Return(&createdQuest, nil).Times(1)
+
+		t.NewStep("Init http")
+		seasonalBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "usual",
+				"starts_at": "01.12.2024 - 00:00:00",
+				"ends_at": "01.01.2025 - 00:00:00"
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(seasonalBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var qst response.Quest
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&qst))
+		t.Require().Equal(startsAt.Time, qst.StartsAt.Time)
+		t.Require().Equal(endsAt.Time, qst.EndsAt.Time)
+	})
+
+	t.WithNewStep("Invalid availability window error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, qr.ErrorInvalidWindow).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect availability window format execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		incorrectBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "usual",
+				"starts_at": "2024-12-01T00:00:00Z"
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(incorrectBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Correct random quest with probability execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		randomQuest := &qu.Quest{
+			Name:           quest.Name,
+			Description:    quest.Description,
+			Cost:           quest.Cost,
+			Type:           types.RANDOM,
+			MaxCompletions: request.DefaultMaxCompletions,
+			Probability:    0.25,
+			Pity:           3,
+		}
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), randomQuest).Return(quest, nil).Times(1)
+
+		t.NewStep("Init http")
+		randomBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "random",
+				"probability": 0.25,
+				"pity": 3
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(randomBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+	})
+
+	t.WithNewStep("Correct streak quest with milestones execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		streakQuest := &qu.Quest{
+			Name:        quest.Name,
+			Description: quest.Description,
+			Cost:        quest.Cost,
+			Type:        types.STREAK,
+			Probability: request.DefaultProbability,
+			Milestones:  []qu.Milestone{{Days: 3, Reward: 10}, {Days: 7, Reward: 50}},
+		}
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), streakQuest).Return(quest, nil).Times(1)
+
+		t.NewStep("Init http")
+		streakBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "streak",
+				"milestones": [{"days": 3, "reward": 10}, {"days": 7, "reward": 50}]
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(streakBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect milestone execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		incorrectBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "streak",
+				"milestones": [{"days": 0, "reward": 10}]
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(incorrectBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect probability execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		incorrectBody := `
+			{
+				"name": "Quest",
+				"description": "good Quest",
+				"cost": 10,
+				"type": "random",
+				"probability": 1.5
+			}
+		`
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(incorrectBody), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Prerequisite not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, qr.ErrorPrerequisiteNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Staged quest without steps error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, qr.ErrorStagedQuestNoSteps).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Counter quest without target error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, qr.ErrorCounterQuestNoTarget).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Team quest without target error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, qr.ErrorTeamQuestNoTarget).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Streak quest without milestones error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().CreateQuest(gomock.Any(), newQuest).Return(nil, qr.ErrorStreakQuestNoMilestones).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
 	t.WithNewStep("Incorrect body error execute", func(t provider.StepCtx) {
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", errReader(1), nil)
@@ -420,7 +1107,7 @@ func (qhs *QuestHandlersSuite) TestUpdateQuestHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().UpdateQuest(quest.ID, updateQuest).Return(quest, nil).Times(1)
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, updateQuest).Return(quest, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
@@ -440,7 +1127,7 @@ func (qhs *QuestHandlersSuite) TestUpdateQuestHandler(t provider.T) {
 
 	t.WithNewStep("Correct no changes execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().UpdateQuest(quest.ID, nilUpdateQuest).Return(quest, nil).Times(1)
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, nilUpdateQuest).Return(quest, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(nilBody), nil)
@@ -460,7 +1147,7 @@ func (qhs *QuestHandlersSuite) TestUpdateQuestHandler(t provider.T) {
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().UpdateQuest(quest.ID, updateQuest).Return(nil, testError).Times(1)
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, updateQuest).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
@@ -476,7 +1163,7 @@ func (qhs *QuestHandlersSuite) TestUpdateQuestHandler(t provider.T) {
 
 	t.WithNewStep("Quest not found error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qhs.mockQuest.EXPECT().UpdateQuest(quest.ID, updateQuest).Return(nil, qr.ErrorQuestNotFound).Times(1)
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, updateQuest).Return(nil, qr.ErrorQuestNotFound).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
@@ -490,6 +1177,88 @@ func (qhs *QuestHandlersSuite) TestUpdateQuestHandler(t provider.T) {
 		t.Require().Equal(http.StatusNotFound, recorder.Code)
 	})
 
+	t.WithNewStep("Clear prerequisites execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, &qu.UpdateQuest{Prerequisites: []types.Id{}}).
+			Return(quest, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"prerequisites": []}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Prerequisite not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, updateQuest).Return(nil, qr.ErrorPrerequisiteNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Replace categories and tags execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, &qu.UpdateQuest{Categories: []types.Id{}, Tags: []string{"daily"}}).
+			Return(quest, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"categories": [], "tags": ["daily"]}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Category not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, updateQuest).Return(nil, qr.ErrorCategoryNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Prerequisite cycle error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qhs.mockQuest.EXPECT().UpdateQuest(gomock.Any(), quest.ID, updateQuest).Return(nil, qr.ErrorPrerequisiteCycle).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusConflict, recorder.Code)
+	})
+
 	t.WithNewStep("Incorrect query param execute", func(t provider.StepCtx) {
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/qwerty", strings.NewReader(body), nil)
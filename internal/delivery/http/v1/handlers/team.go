@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"vk_quests/internal/delivery/http/v1/model/request"
+	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/delivery/middleware"
+	"vk_quests/internal/pkg/types"
+	tr "vk_quests/internal/repository/team"
+	tu "vk_quests/internal/usecase/team"
+	"vk_quests/pkg/operate"
+)
+
+const (
+	TeamIdField = "team_id"
+)
+
+type TeamHandlers struct {
+	teams tu.Usecase
+}
+
+func NewTeamHandlers(teams tu.Usecase) *TeamHandlers {
+	return &TeamHandlers{teams: teams}
+}
+
+// CreateTeam
+//
+//	@Summary		Добавление команды.
+//	@Description	Добавляет команду с уникальным названием. Участники команды вместе выполняют командные задания.
+//	@Tags			team
+//	@Accept			json
+//	@Param			request	body	request.CreateTeam	true	"Информация о добавляемой команде"
+//	@Produce		json
+//	@Success		201	{object}	response.Team		"Команда успешно добавлена в базу"
+//	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка"
+//	@Failure		409	{object}	operate.ModelError	"Команда с таким названием уже существует"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/team [post]
+func (th *TeamHandlers) CreateTeam(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение значения тела запроса
+	var createTeam request.CreateTeam
+	if code, err := parseRequestBody(c.Request.Body, &createTeam, request.ValidateCreateTeam, l); err != nil {
+		operate.SendError(c, err, code, l)
+		return
+	}
+
+	createdTeam, err := th.teams.CreateTeam(c.Request.Context(), createTeam.Name)
+	if err != nil {
+		if errors.Is(err, tr.ErrorTeamNameAlreadyExists) {
+			operate.SendError(c, ErrorTeamNameAlreadyExists, http.StatusConflict, l)
+			l.Info(errors.Wrapf(err, "can't create team"))
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't create team"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusCreated, response.FromUsTeam(createdTeam), l)
+}
+
+// GetTeams
+//
+//	@Summary		Получение списка команд.
+//	@Description	Возвращает все команды без участников, упорядоченные по id.
+//	@Tags			team
+//	@Produce		json
+//	@Success		200	{array}		response.Team		"Список команд"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/team/list [get]
+func (th *TeamHandlers) GetTeams(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	teams, err := th.teams.GetTeams(c.Request.Context())
+	if err != nil {
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get teams"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsTeams(teams), l)
+}
+
+// GetTeam
+//
+//	@Summary		Получение команды.
+//	@Description	Возвращает команду по её id вместе с участниками, упорядоченными по времени вступления. Удалённые пользователи не возвращаются.
+//	@Tags			team
+//	@Param			team_id	path	uint64	true	"Уникальный идентификатор команды"
+//	@Produce		json
+//	@Success		200	{object}	response.Team		"Полученная команда"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Команда с указанным id не найдена"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/team/{team_id} [get]
+func (th *TeamHandlers) GetTeam(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(TeamIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get team id"), http.StatusBadRequest, l)
+		return
+	}
+
+	team, err := th.teams.GetTeam(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, tr.ErrorTeamNotFound) {
+			operate.SendError(c, ErrorTeamNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get team"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsTeam(team), l)
+}
+
+// parseMemberPath returns ids of team and user from path of membership request.
+func parseMemberPath(c *gin.Context) (types.Id, types.Id, error) {
+	teamId, err := strconv.ParseUint(c.Param(TeamIdField), 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "try get team id")
+	}
+
+	userId, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "try get user id")
+	}
+
+	return types.Id(teamId), types.Id(userId), nil
+}
+
+// AddMember
+//
+//	@Summary		Добавление участника команды.
+//	@Description	Добавляет пользователя в команду. Пользователь может состоять только в одной команде.
+//	@Tags			team
+//	@Param			team_id	path	uint64	true	"Уникальный идентификатор команды"
+//	@Param			user_id	path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Produce		json
+//	@Success		201	{object}	response.TeamMember	"Пользователь успешно добавлен в команду"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Команда или пользователь не найдены"
+//	@Failure		409	{object}	operate.ModelError	"Пользователь уже состоит в команде"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/team/{team_id}/member/{user_id} [post]
+func (th *TeamHandlers) AddMember(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	teamId, userId, err := parseMemberPath(c)
+	if err != nil {
+		operate.SendError(c, err, http.StatusBadRequest, l)
+		return
+	}
+
+	member, err := th.teams.AddMember(c.Request.Context(), teamId, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, tr.ErrorTeamNotFound):
+			operate.SendError(c, ErrorTeamNotFound, http.StatusNotFound, l)
+		case errors.Is(err, tr.ErrorUserNotFound):
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+		case errors.Is(err, tr.ErrorUserAlreadyInTeam):
+			operate.SendError(c, ErrorUserAlreadyInTeam, http.StatusConflict, l)
+		default:
+			sendServerError(c, err, l)
+			l.Error(errors.Wrapf(err, "can't add member to team"))
+			return
+		}
+		l.Info(errors.Wrapf(err, "can't add member to team"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusCreated, response.FromUsTeamMember(member), l)
+}
+
+// RemoveMember
+//
+//	@Summary		Удаление участника команды.
+//	@Description	Удаляет пользователя из команды. Его вклады в ещё не выполненные командные задания отменяются.
+//	@Tags			team
+//	@Param			team_id	path	uint64	true	"Уникальный идентификатор команды"
+//	@Param			user_id	path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Produce		json
+//	@Success		200	"Пользователь успешно удалён из команды"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Пользователь не состоит в команде"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/team/{team_id}/member/{user_id} [delete]
+func (th *TeamHandlers) RemoveMember(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	teamId, userId, err := parseMemberPath(c)
+	if err != nil {
+		operate.SendError(c, err, http.StatusBadRequest, l)
+		return
+	}
+
+	if err = th.teams.RemoveMember(c.Request.Context(), teamId, userId); err != nil {
+		if errors.Is(err, tr.ErrorMemberNotFound) {
+			operate.SendError(c, ErrorMemberNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't remove member from team"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, nil, l)
+}
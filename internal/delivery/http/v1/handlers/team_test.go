@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+
+	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/pkg/types"
+	tr "vk_quests/internal/repository/team"
+	tu "vk_quests/internal/usecase/team"
+	mut "vk_quests/internal/usecase/team/mocks"
+)
+
+type TeamHandlersSuite struct {
+	suite.Suite
+	handlers *TeamHandlers
+	mockTeam *mut.TeamUsecase
+	gmc      *gomock.Controller
+}
+
+func (ths *TeamHandlersSuite) BeforeEach(t provider.T) {
+	ths.gmc = gomock.NewController(t)
+	ths.mockTeam = mut.NewTeamUsecase(ths.gmc)
+	ths.handlers = NewTeamHandlers(ths.mockTeam)
+}
+
+func (ths *TeamHandlersSuite) AfterEach(t provider.T) {
+	ths.gmc.Finish()
+}
+
+func (ths *TeamHandlersSuite) TestCreateTeamHandler(t provider.T) {
+	t.Title("CreateTeam handler of team handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/", addEmptyLogger(ths.handlers.CreateTeam))
+
+	t.NewStep("Init test data")
+	body := `{"name": "Wolves"}`
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().CreateTeam(gomock.Any(), "Wolves").Return(&tu.Team{ID: 1, Name: "Wolves"}, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var team response.Team
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&team))
+		t.Require().EqualValues(types.Id(1), team.ID)
+		t.Require().Equal("Wolves", team.Name)
+	})
+
+	t.WithNewStep("Team name already exists error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().CreateTeam(gomock.Any(), "Wolves").Return(nil, tr.ErrorTeamNameAlreadyExists).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusConflict, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().CreateTeam(gomock.Any(), "Wolves").Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect body error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", strings.NewReader(`{"name": ""}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (ths *TeamHandlersSuite) TestGetTeamsHandler(t provider.T) {
+	t.Title("GetTeams handler of team handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.GET("/", addEmptyLogger(ths.handlers.GetTeams))
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().GetTeams(gomock.Any()).
+			Return([]tu.Team{{ID: 1, Name: "Wolves"}, {ID: 2, Name: "Foxes"}}, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var teams []response.Team
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&teams))
+		t.Require().Len(teams, 2)
+		t.Require().Equal("Foxes", teams[1].Name)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().GetTeams(gomock.Any()).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func (ths *TeamHandlersSuite) TestGetTeamHandler(t provider.T) {
+	t.Title("GetTeam handler of team handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.GET("/:"+TeamIdField, addEmptyLogger(ths.handlers.GetTeam))
+
+	t.NewStep("Init test data")
+	teamId := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().GetTeam(gomock.Any(), teamId).
+			Return(&tu.Team{ID: teamId, Name: "Wolves", Members: []tu.Member{{UserId: 2, Name: "Bob"}}}, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var team response.Team
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&team))
+		t.Require().Len(team.Members, 1)
+		t.Require().EqualValues(types.Id(2), team.Members[0].UserId)
+	})
+
+	t.WithNewStep("Team not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().GetTeam(gomock.Any(), teamId).Return(nil, tr.ErrorTeamNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect team id error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodGet, "/team", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (ths *TeamHandlersSuite) TestAddMemberHandler(t provider.T) {
+	t.Title("AddMember handler of team handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+TeamIdField+"/:"+UserIdField, addEmptyLogger(ths.handlers.AddMember))
+
+	t.NewStep("Init test data")
+	teamId, userId := types.Id(1), types.Id(2)
+
+	errorCodes := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"Team not found", tr.ErrorTeamNotFound, http.StatusNotFound},
+		{"User not found", tr.ErrorUserNotFound, http.StatusNotFound},
+		{"User already in team", tr.ErrorUserAlreadyInTeam, http.StatusConflict},
+		{"Usecase", testError, http.StatusInternalServerError},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().AddMember(gomock.Any(), teamId, userId).
+			Return(&tu.Member{UserId: userId, Name: "Bob"}, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1/2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var member response.TeamMember
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&member))
+		t.Require().EqualValues(userId, member.UserId)
+	})
+
+	for _, tc := range errorCodes {
+		t.WithNewStep(tc.name+" error execute", func(t provider.StepCtx) {
+			t.NewStep("Init mock")
+			ths.mockTeam.EXPECT().AddMember(gomock.Any(), teamId, userId).Return(nil, tc.err).Times(1)
+
+			t.NewStep("Init http")
+			req, err := initRequest(http.MethodPost, "/1/2", nil, nil)
+			t.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+
+			t.NewStep("Check result")
+			r.ServeHTTP(recorder, req)
+
+			t.Require().Equal(tc.code, recorder.Code)
+		})
+	}
+
+	t.WithNewStep("Incorrect user id error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1/user", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (ths *TeamHandlersSuite) TestRemoveMemberHandler(t provider.T) {
+	t.Title("RemoveMember handler of team handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.DELETE("/:"+TeamIdField+"/:"+UserIdField, addEmptyLogger(ths.handlers.RemoveMember))
+
+	t.NewStep("Init test data")
+	teamId, userId := types.Id(1), types.Id(2)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().RemoveMember(gomock.Any(), teamId, userId).Return(nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	t.WithNewStep("Member not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().RemoveMember(gomock.Any(), teamId, userId).Return(tr.ErrorMemberNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		ths.mockTeam.EXPECT().RemoveMember(gomock.Any(), teamId, userId).Return(testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
+func TestRunTeamHandlersSuite(t *testing.T) {
+	suite.RunSuite(t, new(TeamHandlersSuite))
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -10,14 +12,24 @@ import (
 	"vk_quests/internal/delivery/http/v1/model/request"
 	"vk_quests/internal/delivery/http/v1/model/response"
 	"vk_quests/internal/delivery/middleware"
+	"vk_quests/internal/pkg/page"
 	"vk_quests/internal/pkg/types"
+	lr "vk_quests/internal/repository/ledger"
 	qr "vk_quests/internal/repository/quest"
 	ur "vk_quests/internal/repository/user"
 	uu "vk_quests/internal/usecase/user"
+	"vk_quests/pkg/logger"
 	"vk_quests/pkg/operate"
 )
 
-const UserIdField = "user_id"
+const (
+	UserIdField          = "user_id"
+	AmountField          = "amount"
+	RetryAfterHeader     = "Retry-After"
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	MaxIdempotencyKeyLength = 255
+)
 
 type UserHandlers struct {
 	users uu.Usecase
@@ -31,27 +43,36 @@ func NewUserHandlers(users uu.Usecase) *UserHandlers {
 //
 //	@Summary		Добавление пользователя.
 //	@Description	Добавляет пользователя включая его имя. Баланс пользователя при создании 0.
+//	@Description	Приглашённый пользователь передаёт реферальный код пригласившего referral_code. Когда приглашённый пользователь
+//	@Description	впервые получает награду за задачу referral.quest_id из конфигурации, он и пригласивший получают бонус referral.bonus.
+//	@Description	Пригласить может только существующий неудалённый пользователь, поэтому нельзя пригласить себя или замкнуть цепочку приглашений.
 //	@Tags			user
 //	@Accept			json
-//	@Param			request	body	request.User	true	"Информация о добавляемом пользователе"
+//	@Param			request	body	request.CreateUser	true	"Информация о добавляемом пользователе"
 //	@Produce		json
-//	@Success		201	{object}	response.User		"Пользователь успешно добавлен в базу"
+//	@Success		201	{object}	response.User		"Пользователь успешно добавлен в базу, ответ содержит его реферальный код"
 //	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка"
+//	@Failure		422	{object}	operate.ModelError	"Пользователь с указанным реферальным кодом не найден"
 //	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/user [post]
 func (uh *UserHandlers) CreateUser(c *gin.Context) {
 	l := middleware.GetLogger(c)
 
 	// Получение значения тела запроса
-	var createUser request.User
-	if code, err := parseRequestBody(c.Request.Body, &createUser, request.ValidateUser, l); err != nil {
+	var createUser request.CreateUser
+	if code, err := parseRequestBody(c.Request.Body, &createUser, request.ValidateCreateUser, l); err != nil {
 		operate.SendError(c, err, code, l)
 		return
 	}
 
-	createdUser, err := uh.users.CreateUser(createUser.Name)
+	createdUser, err := uh.users.CreateUser(c.Request.Context(), createUser.Name, createUser.ReferralCode)
 	if err != nil {
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+		if errors.Is(err, ur.ErrorReferralCodeNotFound) {
+			operate.SendError(c, ErrorReferralCodeNotFound, http.StatusUnprocessableEntity, l)
+			return
+		}
+		sendServerError(c, err, l)
 		l.Error(errors.Wrapf(err, "can't create film"))
 		return
 	}
@@ -62,7 +83,9 @@ func (uh *UserHandlers) CreateUser(c *gin.Context) {
 // DeleteUser
 //
 //	@Summary		Удаление пользователя.
-//	@Description	Удаляет информацию об пользователе по его id.
+//	@Description	Удаляет пользователя по его id согласно политике удаления из конфигурации: soft - пользователь скрывается, но его данные сохраняются,
+//	@Description	anonymize - дополнительно стирается имя пользователя, hard - пользователь удаляется вместе с историей и журналом операций.
+//	@Description	Удалённый пользователь не находится остальными методами, кроме выгрузки данных.
 //	@Tags			user
 //	@Param			user_id	path	uint64	true	"Уникальный идентификатор пользователя"
 //	@Produce		json
@@ -70,6 +93,7 @@ func (uh *UserHandlers) CreateUser(c *gin.Context) {
 //	@Failure		400	{object}	operate.ModelError	"В пути запросе ошибка"
 //	@Failure		404	{object}	operate.ModelError	"Пользователь с указанным id не найден"
 //	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/user/{user_id} [delete]
 func (uh *UserHandlers) DeleteUser(c *gin.Context) {
 	l := middleware.GetLogger(c)
@@ -81,13 +105,13 @@ func (uh *UserHandlers) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	user, err := uh.users.DeleteUser(types.Id(id))
+	user, err := uh.users.DeleteUser(c.Request.Context(), types.Id(id))
 	if err != nil {
 		if errors.Is(err, ur.ErrorUserNotFound) {
 			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
 			return
 		}
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+		sendServerError(c, err, l)
 		l.Error(errors.Wrapf(err, "can't delete user"))
 		return
 	}
@@ -95,6 +119,87 @@ func (uh *UserHandlers) DeleteUser(c *gin.Context) {
 	operate.SendStatus(c, http.StatusOK, response.FromUsUser(user), l)
 }
 
+// AnonymizeUser
+//
+//	@Summary		Обезличивание пользователя.
+//	@Description	Стирает имя пользователя по его id и удаляет его, история выполнений и журнал операций пользователя сохраняются.
+//	@Description	Удалённый ранее пользователь тоже обезличивается. Метод доступен только администраторам.
+//	@Tags			user
+//	@Param			user_id			path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Param			X-Admin-Token	header	string	true	"Токен администратора"
+//	@Produce		json
+//	@Success		200	{object}	response.User		"Пользователь успешно обезличен"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		403	{object}	operate.ModelError	"Неверный токен администратора"
+//	@Failure		404	{object}	operate.ModelError	"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/anonymize [post]
+func (uh *UserHandlers) AnonymizeUser(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	user, err := uh.users.AnonymizeUser(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, ur.ErrorUserNotFound) {
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't anonymize user"))
+		return
+	}
+
+	l.Warn("user with id %d is anonymized", id)
+	operate.SendStatus(c, http.StatusOK, response.FromUsUser(user), l)
+}
+
+// ExportUser
+//
+//	@Summary		Выгрузка данных пользователя.
+//	@Description	Формирует все данные, хранящиеся о пользователе: профиль, историю выполнений заданий и попытки выполнения случайных заданий.
+//	@Description	Данные удалённого пользователя тоже выгружаются, время удаления указывается в поле deleted_at. Метод доступен только администраторам.
+//	@Tags			user
+//	@Param			user_id			path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Param			X-Admin-Token	header	string	true	"Токен администратора"
+//	@Produce		json
+//	@Success		200	{object}	response.UserExport	"Данные пользователя сформированы"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		403	{object}	operate.ModelError	"Неверный токен администратора"
+//	@Failure		404	{object}	operate.ModelError	"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/export [get]
+func (uh *UserHandlers) ExportUser(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	export, err := uh.users.ExportUser(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, ur.ErrorUserNotFound) {
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't export user"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsExport(export), l)
+}
+
 // UpdateUser
 //
 //	@Summary		Обновление данных об пользователе.
@@ -108,6 +213,7 @@ func (uh *UserHandlers) DeleteUser(c *gin.Context) {
 //	@Failure		400	{object}	operate.ModelError	"В теле запроса ошибка"
 //	@Failure		404	{object}	operate.ModelError	"Пользователь с указанным id не найден"
 //	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/user/{user_id} [put]
 func (uh *UserHandlers) UpdateUser(c *gin.Context) {
 	l := middleware.GetLogger(c)
@@ -126,13 +232,13 @@ func (uh *UserHandlers) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	updatedUser, err := uh.users.UpdateUser(types.Id(id), updateUser.Name)
+	updatedUser, err := uh.users.UpdateUser(c.Request.Context(), types.Id(id), updateUser.Name)
 	if err != nil {
 		if errors.Is(err, ur.ErrorUserNotFound) {
 			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
 			return
 		}
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+		sendServerError(c, err, l)
 		l.Error(errors.Wrapf(err, "can't update user"))
 		return
 	}
@@ -142,36 +248,117 @@ func (uh *UserHandlers) UpdateUser(c *gin.Context) {
 
 // GetUsers
 //
-//	@Summary		Получение списка пользователь.
-//	@Description	Формирует список всех пользователей в системы.
+//	@Summary		Получение списка пользователей.
+//	@Description	Формирует страницу списка пользователей с фильтрацией и сортировкой.
+//	@Description	Для получения следующей страницы передайте next_cursor из ответа с теми же sort и order.
 //	@Tags			user
 //	@Produce		json
-//	@Success		200	{array}		response.User		"Список пользователей успешно сформирован"
-//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Param			name_prefix	query		string				false	"Префикс имени пользователя"
+//	@Param			min_balance	query		int64				false	"Минимальный баланс"
+//	@Param			max_balance	query		int64				false	"Максимальный баланс"
+//	@Param			sort		query		string				false	"Поле сортировки"		Enums(id, name, balance)	default(id)
+//	@Param			order		query		string				false	"Порядок сортировки"	Enums(asc, desc)			default(asc)
+//	@Param			limit		query		uint64				false	"Размер страницы"		minimum(1)					maximum(1000)	default(50)
+//	@Param			cursor		query		string				false	"Курсор следующей страницы"
+//	@Success		200			{object}	response.UsersPage	"Страница пользователей успешно сформирована"
+//	@Failure		400			{object}	operate.ModelError	"Некорректные параметры запроса"
+//	@Failure		500			{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504			{object}	operate.ModelError	"Превышено время выполнения запроса"
 //	@Router			/user/list [get]
 func (uh *UserHandlers) GetUsers(c *gin.Context) {
 	l := middleware.GetLogger(c)
 
-	users, err := uh.users.GetUsers()
+	listUsers := &request.ListUsers{}
+	if err := c.ShouldBindQuery(listUsers); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "can't parse list users query"))
+		return
+	}
+	if err := listUsers.Validate(); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "incorrect list users query"))
+		return
+	}
+
+	usersPage, err := uh.users.GetUsers(c.Request.Context(), listUsers.ToUsUsersQuery())
 	if err != nil {
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+		if errors.Is(err, page.ErrorInvalidCursor) {
+			operate.SendError(c, ErrorInvalidCursor, http.StatusBadRequest, l)
+			l.Error(errors.Wrapf(err, "can't get users"))
+			return
+		}
+
+		sendServerError(c, err, l)
 		l.Error(errors.Wrapf(err, "can't get users"))
 		return
 	}
 
-	operate.SendStatus(c, http.StatusOK, response.FromUsUsers(users), l)
+	operate.SendStatus(c, http.StatusOK, response.FromUsUsersPage(usersPage), l)
+}
+
+// GetUser
+//
+//	@Summary		Получение пользователя.
+//	@Description	Возвращает информацию о пользователе по его id вместе со статистикой выполненных заданий:
+//	@Description	числом выполнений, суммой наград, временем последнего выполнения и разбивкой по типам заданий.
+//	@Tags			user
+//	@Param			user_id	path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Produce		json
+//	@Success		200	{object}	response.UserStats	"Полученный пользователь"
+//	@Failure		400	{object}	operate.ModelError	"В пути запросе ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/user/{user_id} [get]
+func (uh *UserHandlers) GetUser(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	stats, err := uh.users.GetUser(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, ur.ErrorUserNotFound) {
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get user"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsUserStats(stats), l)
 }
 
 // GetUserHistory
 //
 //	@Summary		Получение истории выполнения заданий пользователем.
-//	@Description	Формирует список выполненных заданий пользователя по его id. Если задача была удалена, то информация о ней не будет выводиться.
+//	@Description	Формирует страницу выполненных заданий пользователя по его id, упорядоченных по времени выполнения.
+//	@Description	Каждая запись содержит состояние задания и награду на момент выполнения (completed_quest и award),
+//	@Description	а также текущее состояние задания (quest), если оно не было удалено. Источник записи source - выполнение задания (quest)
+//	@Description	или бонус за приглашение (referral), полученный за выполнение задания приглашённым пользователем, а также
+//	@Description	исходящий (transfer_out) или входящий (transfer_in) перевод: у переводов нет completed_quest, award содержит
+//	@Description	сумму перевода, а counterparty_id - второго участника. Запись отзыва выполнения (revoke) содержит списанную
+//	@Description	сумму в award и причину отзыва в reason.
+//	@Description	Для получения следующей страницы передайте next_cursor из ответа с тем же order.
 //	@Tags			user
-//	@Param			user_id	path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Param			user_id		path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Param			from		query	string	false	"Начало периода включительно в формате 02.01.2006 - 15:04:05"
+//	@Param			to			query	string	false	"Конец периода не включительно в формате 02.01.2006 - 15:04:05"
+//	@Param			quest_type	query	string	false	"Тип задания"			Enums(usual, random, staged, counter, streak, team)
+//	@Param			order		query	string	false	"Порядок сортировки"	Enums(asc, desc)	default(desc)
+//	@Param			limit		query	uint64	false	"Размер страницы"		minimum(1)			maximum(1000)	default(50)
+//	@Param			cursor		query	string	false	"Курсор следующей страницы"
 //	@Produce		json
-//	@Success		200	{array}		response.HistoryRecord	"Список выполненных заданий пользователя сформирован"
-//	@Failure		400	{object}	operate.ModelError		"В пути запроса ошибка"
+//	@Success		200	{object}	response.HistoryPage	"Страница выполненных заданий пользователя сформирована"
+//	@Failure		400	{object}	operate.ModelError		"В пути или параметрах запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError		"Пользователь с указанным id не найден"
 //	@Failure		500	{object}	operate.ModelError		"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError		"Превышено время выполнения запроса"
 //	@Router			/user/{user_id}/history [get]
 func (uh *UserHandlers) GetUserHistory(c *gin.Context) {
 	l := middleware.GetLogger(c)
@@ -183,14 +370,420 @@ func (uh *UserHandlers) GetUserHistory(c *gin.Context) {
 		return
 	}
 
-	history, err := uh.users.GetUserHistory(types.Id(id))
+	listHistory := &request.ListHistory{}
+	if err := c.ShouldBindQuery(listHistory); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "can't parse list history query"))
+		return
+	}
+	if err := listHistory.Validate(); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "incorrect list history query"))
+		return
+	}
+
+	historyPage, err := uh.users.GetUserHistory(c.Request.Context(), types.Id(id), listHistory.ToUsHistoryQuery())
 	if err != nil {
-		operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
-		l.Error(errors.Wrapf(err, "can't get users"))
+		if errors.Is(err, page.ErrorInvalidCursor) {
+			operate.SendError(c, ErrorInvalidCursor, http.StatusBadRequest, l)
+			l.Error(errors.Wrapf(err, "can't get history"))
+			return
+		}
+		if errors.Is(err, ur.ErrorUserNotFound) {
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+			return
+		}
+
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get history"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsHistoryPage(historyPage), l)
+}
+
+// GetAvailableQuests
+//
+//	@Summary		Получение доступных пользователю заданий.
+//	@Description	Формирует страницу заданий, которые пользователь может выполнить прямо сейчас: все предварительные задания выполнены,
+//	@Description	максимальное число выполнений не достигнуто, перерыв после последнего выполнения истёк и текущий момент входит в период выполнения задания. Задания упорядочены по id.
+//	@Description	Для получения следующей страницы передайте next_cursor из ответа.
+//	@Tags			user
+//	@Param			user_id	path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Param			limit	query	uint64	false	"Размер страницы"	minimum(1)	maximum(1000)	default(50)
+//	@Param			cursor	query	string	false	"Курсор следующей страницы"
+//	@Produce		json
+//	@Success		200	{object}	response.QuestsPage	"Страница доступных заданий сформирована"
+//	@Failure		400	{object}	operate.ModelError	"В пути или параметрах запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/quests/available [get]
+func (uh *UserHandlers) GetAvailableQuests(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	listAvailable := &request.ListAvailableQuests{}
+	if err := c.ShouldBindQuery(listAvailable); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "can't parse list available quests query"))
+		return
+	}
+	if err := listAvailable.Validate(); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "incorrect list available quests query"))
+		return
+	}
+
+	questsPage, err := uh.users.GetAvailableQuests(c.Request.Context(), types.Id(id), listAvailable.ToUsAvailableQuestsQuery())
+	if err != nil {
+		if errors.Is(err, page.ErrorInvalidCursor) {
+			operate.SendError(c, ErrorInvalidCursor, http.StatusBadRequest, l)
+			l.Error(errors.Wrapf(err, "can't get available quests"))
+			return
+		}
+		if errors.Is(err, ur.ErrorUserNotFound) {
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+			return
+		}
+
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get available quests"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsQuestsPage(questsPage), l)
+}
+
+// GetUserProgress
+//
+//	@Summary		Получение прогресса пользователя по многошаговым заданиям.
+//	@Description	Формирует список многошаговых заданий, которые пользователь начал выполнять, с номером последнего выполненного шага.
+//	@Tags			user
+//	@Param			user_id	path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Produce		json
+//	@Success		200	{array}		response.Progress	"Прогресс пользователя сформирован"
+//	@Failure		400	{object}	operate.ModelError	"В пути запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError	"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/progress [get]
+func (uh *UserHandlers) GetUserProgress(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	progress, err := uh.users.GetUserProgress(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, ur.ErrorUserNotFound) {
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get user progress"))
 		return
 	}
 
-	operate.SendStatus(c, http.StatusOK, response.FromUsHistory(history), l)
+	operate.SendStatus(c, http.StatusOK, response.FromUsProgress(progress), l)
+}
+
+// GetUserAttempts
+//
+//	@Summary		Получение попыток выполнения случайных заданий пользователем.
+//	@Description	Формирует страницу попыток пользователя выполнить случайные задания, упорядоченных по времени попытки.
+//	@Description	Каждая попытка содержит её результат (success) и выпавшее значение (roll), которое не указывается, если выполнение гарантировано полем pity задания.
+//	@Description	Для получения следующей страницы передайте next_cursor из ответа с тем же order.
+//	@Tags			user
+//	@Param			user_id		path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Param			quest_id	query	uint64	false	"Уникальный идентификатор задания"
+//	@Param			order		query	string	false	"Порядок сортировки"	Enums(asc, desc)	default(desc)
+//	@Param			limit		query	uint64	false	"Размер страницы"		minimum(1)			maximum(1000)	default(50)
+//	@Param			cursor		query	string	false	"Курсор следующей страницы"
+//	@Produce		json
+//	@Success		200	{object}	response.AttemptsPage	"Страница попыток пользователя сформирована"
+//	@Failure		400	{object}	operate.ModelError		"В пути или параметрах запроса ошибка"
+//	@Failure		404	{object}	operate.ModelError		"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError		"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError		"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/attempts [get]
+func (uh *UserHandlers) GetUserAttempts(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	listAttempts := &request.ListAttempts{}
+	if err := c.ShouldBindQuery(listAttempts); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "can't parse list attempts query"))
+		return
+	}
+	if err := listAttempts.Validate(); err != nil {
+		operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+		l.Error(errors.Wrapf(err, "incorrect list attempts query"))
+		return
+	}
+
+	attemptsPage, err := uh.users.GetUserAttempts(c.Request.Context(), types.Id(id), listAttempts.ToUsAttemptsQuery())
+	if err != nil {
+		if errors.Is(err, page.ErrorInvalidCursor) {
+			operate.SendError(c, ErrorInvalidCursor, http.StatusBadRequest, l)
+			l.Error(errors.Wrapf(err, "can't get attempts"))
+			return
+		}
+		if errors.Is(err, ur.ErrorUserNotFound) {
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+			return
+		}
+
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't get attempts"))
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsAttemptsPage(attemptsPage), l)
+}
+
+// RevokeQuest
+//
+//	@Summary		Отзыв выполнения задания пользователем.
+//	@Description	Отменяет последнее выполнение задания пользователем: запись истории помечается отозванной и больше
+//	@Description	не учитывается в выполнениях задания, награда списывается с баланса транзакцией с причиной quest_revoke,
+//	@Description	а в историю добавляется запись отзыва (revoke) со списанной суммой и указанной причиной.
+//	@Description	Если пользователь уже потратил награду, поведение задаётся настройкой revoke.policy: reject - отказ,
+//	@Description	partial - списание только оставшегося баланса, negative - списание всей награды с уходом баланса в минус.
+//	@Description	Отзыв последнего выполнения реферальной задачи списывает и реферальные бонусы обоих пользователей
+//	@Description	(причина referral_revoke). Очки рейтинга уменьшаются на всю награду отозванного выполнения.
+//	@Description	Метод доступен только администраторам.
+//	@Tags			user
+//	@Accept			json
+//	@Param			user_id			path	uint64				true	"Уникальный идентификатор пользователя"
+//	@Param			quest_id		path	uint64				true	"Уникальный идентификатор задания"
+//	@Param			X-Admin-Token	header	string				true	"Токен администратора"
+//	@Param			request			body	request.RevokeQuest	true	"Причина отзыва"
+//	@Produce		json
+//	@Success		200	{object}	response.Revocation	"Выполнение задания отозвано"
+//	@Failure		400	{object}	operate.ModelError	"В теле или пути запроса ошибка"
+//	@Failure		402	{object}	operate.ModelError	"Награда уже потрачена, а политика отзыва reject"
+//	@Failure		403	{object}	operate.ModelError	"Неверный токен администратора"
+//	@Failure		404	{object}	operate.ModelError	"Пользователь не найден или не выполнял задание"
+//	@Failure		500	{object}	operate.ModelError	"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError	"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/quest/{quest_id} [delete]
+func (uh *UserHandlers) RevokeQuest(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	userId, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	// Получение уникального идентификатора
+	questId, err := strconv.ParseUint(c.Param(QuestIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get quest id"), http.StatusBadRequest, l)
+		return
+	}
+
+	// Получение значения тела запроса
+	var revoke request.RevokeQuest
+	if code, err := parseRequestBody(c.Request.Body, &revoke, request.ValidateRevokeQuest, l); err != nil {
+		operate.SendError(c, err, code, l)
+		return
+	}
+
+	revocation, err := uh.users.RevokeQuest(c.Request.Context(), types.Id(userId), types.Id(questId), revoke.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, ur.ErrorUserNotFound):
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+		case errors.Is(err, ur.ErrorCompletionNotFound):
+			operate.SendError(c, ErrorCompletionNotFound, http.StatusNotFound, l)
+		case errors.Is(err, lr.ErrorInsufficientFunds):
+			operate.SendError(c, ErrorInsufficientFunds, http.StatusPaymentRequired, l)
+		default:
+			sendServerError(c, err, l)
+			l.Error(errors.Wrapf(err, "can't revoke quest with id %d from user with id %d", questId, userId))
+		}
+		return
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsRevocation(revocation), l)
+}
+
+// Debit
+//
+//	@Summary		Списание баллов с баланса пользователя.
+//	@Description	Списывает указанное число баллов с баланса пользователя по его id и записывает операцию в журнал транзакций.
+//	@Description	Баланс пользователя не может стать отрицательным. Метод доступен только администраторам.
+//	@Tags			user
+//	@Accept			json
+//	@Param			user_id			path	uint64			true	"Уникальный идентификатор пользователя"
+//	@Param			X-Admin-Token	header	string			true	"Токен администратора"
+//	@Param			request			body	request.Debit	true	"Сумма и причина списания"
+//	@Produce		json
+//	@Success		201	{object}	response.Transaction	"Баллы успешно списаны"
+//	@Failure		400	{object}	operate.ModelError		"В теле или пути запроса ошибка"
+//	@Failure		402	{object}	operate.ModelError		"На балансе пользователя недостаточно баллов"
+//	@Failure		403	{object}	operate.ModelError		"Неверный токен администратора"
+//	@Failure		404	{object}	operate.ModelError		"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError		"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError		"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/debit [post]
+func (uh *UserHandlers) Debit(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	// Получение значения тела запроса
+	var debit request.Debit
+	if code, err := parseRequestBody(c.Request.Body, &debit, request.ValidateDebit, l); err != nil {
+		operate.SendError(c, err, code, l)
+		return
+	}
+
+	transaction, err := uh.users.Debit(c.Request.Context(), types.Id(id), debit.Amount, debit.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, lr.ErrorUserNotFound):
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+		case errors.Is(err, lr.ErrorInsufficientFunds):
+			operate.SendError(c, ErrorInsufficientFunds, http.StatusPaymentRequired, l)
+		default:
+			sendServerError(c, err, l)
+			l.Error(errors.Wrapf(err, "can't debit user with id %d", id))
+		}
+		return
+	}
+
+	operate.SendStatus(c, http.StatusCreated, response.FromUsTransaction(transaction), l)
+}
+
+// Transfer
+//
+//	@Summary		Перевод баллов другому пользователю.
+//	@Description	Списывает баллы с баланса пользователя по его id и начисляет их получателю в одной транзакции.
+//	@Description	Обе операции записываются в журнал транзакций и в историю отправителя и получателя.
+//	@Description	Перевод самому себе запрещён, а сумма переводов пользователя за последние сутки ограничена настройкой transfer.daily_limit.
+//	@Tags			user
+//	@Accept			json
+//	@Param			user_id	path	uint64				true	"Уникальный идентификатор отправителя"
+//	@Param			request	body	request.Transfer	true	"Получатель и сумма перевода"
+//	@Produce		json
+//	@Success		201	{object}	response.Transaction	"Перевод выполнен, в ответе транзакция списания отправителя"
+//	@Failure		400	{object}	operate.ModelError		"В теле или пути запроса ошибка, либо перевод самому себе"
+//	@Failure		402	{object}	operate.ModelError		"На балансе отправителя недостаточно баллов"
+//	@Failure		404	{object}	operate.ModelError		"Отправитель или получатель не найден"
+//	@Failure		422	{object}	operate.ModelError		"Превышен суточный лимит переводов"
+//	@Failure		500	{object}	operate.ModelError		"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError		"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/transfer [post]
+func (uh *UserHandlers) Transfer(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	// Получение значения тела запроса
+	var transfer request.Transfer
+	if code, err := parseRequestBody(c.Request.Body, &transfer, request.ValidateTransfer, l); err != nil {
+		operate.SendError(c, err, code, l)
+		return
+	}
+
+	res, err := uh.users.Transfer(c.Request.Context(), types.Id(id), transfer.RecipientId, transfer.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, uu.ErrorSelfTransfer):
+			operate.SendError(c, ErrorSelfTransfer, http.StatusBadRequest, l)
+		case errors.Is(err, lr.ErrorUserNotFound):
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+		case errors.Is(err, lr.ErrorRecipientNotFound):
+			operate.SendError(c, ErrorRecipientNotFound, http.StatusNotFound, l)
+		case errors.Is(err, lr.ErrorInsufficientFunds):
+			operate.SendError(c, ErrorInsufficientFunds, http.StatusPaymentRequired, l)
+		case errors.Is(err, lr.ErrorTransferLimitExceeded):
+			operate.SendError(c, ErrorTransferLimitExceeded, http.StatusUnprocessableEntity, l)
+		default:
+			sendServerError(c, err, l)
+			l.Error(errors.Wrapf(err, "can't transfer from user with id %d to user with id %d", id, transfer.RecipientId))
+		}
+		return
+	}
+
+	operate.SendStatus(c, http.StatusCreated, response.FromUsTransaction(&res.Debit), l)
+}
+
+// ReconcileBalance
+//
+//	@Summary		Сверка баланса пользователя с журналом транзакций.
+//	@Description	Пересчитывает баланс пользователя по журналу транзакций. Если сохранённый баланс отличается от суммы
+//	@Description	транзакций, он заменяется суммой. В ответе возвращаются баланс до сверки и сумма транзакций.
+//	@Description	Метод доступен только администраторам.
+//	@Tags			user
+//	@Param			user_id			path	uint64	true	"Уникальный идентификатор пользователя"
+//	@Param			X-Admin-Token	header	string	true	"Токен администратора"
+//	@Produce		json
+//	@Success		200	{object}	response.Reconciliation	"Сверка баланса выполнена"
+//	@Failure		400	{object}	operate.ModelError		"В пути запроса ошибка"
+//	@Failure		403	{object}	operate.ModelError		"Неверный токен администратора"
+//	@Failure		404	{object}	operate.ModelError		"Пользователь с указанным id не найден"
+//	@Failure		500	{object}	operate.ModelError		"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError		"Превышено время выполнения запроса"
+//	@Router			/user/{user_id}/reconcile [post]
+func (uh *UserHandlers) ReconcileBalance(c *gin.Context) {
+	l := middleware.GetLogger(c)
+
+	// Получение уникального идентификатора
+	id, err := strconv.ParseUint(c.Param(UserIdField), 10, 64)
+	if err != nil {
+		operate.SendError(c, errors.Wrapf(err, "try get user id"), http.StatusBadRequest, l)
+		return
+	}
+
+	reconciliation, err := uh.users.ReconcileBalance(c.Request.Context(), types.Id(id))
+	if err != nil {
+		if errors.Is(err, lr.ErrorUserNotFound) {
+			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
+			return
+		}
+		sendServerError(c, err, l)
+		l.Error(errors.Wrapf(err, "can't reconcile balance of user with id %d", id))
+		return
+	}
+
+	if reconciliation.Cached != reconciliation.Ledger {
+		l.Warn(errors.Errorf("balance %d of user with id %d differed from ledger balance %d",
+			reconciliation.Cached, id, reconciliation.Ledger))
+	}
+
+	operate.SendStatus(c, http.StatusOK, response.FromUsReconciliation(reconciliation), l)
 }
 
 // CompleteQuest
@@ -198,14 +791,22 @@ func (uh *UserHandlers) GetUserHistory(c *gin.Context) {
 //	@Summary		Сообщение о выполнение условии для определённого пользователя определённого задания.
 //	@Description	Обрабатывает информацию о выполнение условии для определённого пользователя определённого задания по их идентификаторам.
 //	@Tags			user
-//	@Param			user_id		query	uint64	true	"Уникальный идентификатор пользователи"
-//	@Param			quest_id	query	uint64	true	"Уникальный идентификатор задачи"
+//	@Param			user_id			query	uint64	true	"Уникальный идентификатор пользователи"
+//	@Param			quest_id		query	uint64	true	"Уникальный идентификатор задачи"
+//	@Param			amount			query	uint32	false	"Количество, на которое продвигается задание-счётчик, по умолчанию 1"	minimum(1)
+//	@Param			Idempotency-Key	header	string	false	"Ключ идемпотентности. Повторный запрос с тем же ключом возвращает результат первого запроса без повторного выполнения задачи"
 //	@Produce		json
-//	@Success		200	{array}		response.StatusApplyCost	"Результат применения задания к пользователю. Если 'success' - то задача засчитана пользователю, иначе не засчитана"
+//	@Success		200	{array}		response.StatusApplyCost	"Результат применения задания к пользователю. Если 'success' - то задача засчитана пользователю, если 'in_progress' - то засчитан очередной шаг многошагового задания, накоплена часть цели задания-счётчика, продлена серия без награды или внесён вклад в командное задание, иначе не засчитана. Для многошаговых заданий, заданий-счётчиков, заданий-серий и командных заданий возвращается прогресс"
 //	@Failure		400	{object}	operate.ModelError			"В параметрах запроса ошибка"
+//	@Failure		403	{object}	operate.ModelError			"Задача сейчас вне периода выполнения или пользователь, выполняющий командную задачу, не состоит в команде"
 //	@Failure		404	{object}	operate.ModelError			"Пользователь или задача не найдены"
-//	@Failure		409	{object}	operate.ModelError			"Данную задачу пользователь уже выполнил"
+//	@Failure		409	{object}	operate.ModelError			"Пользователь уже выполнил данную задачу максимальное число раз, уже продлил серию сегодня, уже внёс вклад в командную задачу или запрос с этим ключом идемпотентности ещё выполняется"
+//	@Failure		410	{object}	operate.ModelError			"Задача перенесена в архив"
+//	@Failure		412	{object}	operate.ModelError			"Пользователь не выполнил предварительные задания"
+//	@Failure		422	{object}	operate.ModelError			"Ключ идемпотентности уже использован с другими параметрами"
+//	@Failure		429	{object}	operate.ModelError			"Задача выполнена повторно раньше окончания перерыва, в заголовке Retry-After указано число секунд до его окончания, или исчерпан дневной лимит попыток случайной задачи"
 //	@Failure		500	{object}	operate.ModelError			"Ошибка сервера"
+//	@Failure		504	{object}	operate.ModelError			"Превышено время выполнения запроса"
 //	@Router			/user/complete [post]
 func (uh *UserHandlers) CompleteQuest(c *gin.Context) {
 	l := middleware.GetLogger(c)
@@ -226,18 +827,71 @@ func (uh *UserHandlers) CompleteQuest(c *gin.Context) {
 		return
 	}
 
-	if err = uh.users.ApplyQuests(types.Id(questId), types.Id(userId)); err != nil {
+	// Получение количества для задания-счётчика
+	amount := uint64(1)
+	if value := c.Query(AmountField); value != "" {
+		amount, err = strconv.ParseUint(value, 10, 32)
+		if err != nil || amount == 0 {
+			operate.SendError(c, ErrorIncorrectQueryParam, http.StatusBadRequest, l)
+			l.Error(errors.Errorf("try get amount %q", value))
+			return
+		}
+	}
+
+	// Получение ключа идемпотентности
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if len(key) > MaxIdempotencyKeyLength {
+		operate.SendError(c, ErrorIncorrectIdempotencyKey, http.StatusBadRequest, l)
+		l.Error(errors.Errorf("idempotency key length %d exceeds %d", len(key), MaxIdempotencyKeyLength))
+		return
+	}
+
+	var progress *uu.QuestProgress
+	if key != "" {
+		progress, err = uh.users.ApplyQuestsIdempotent(c.Request.Context(), key, types.Id(questId), types.Id(userId),
+			uint32(amount))
+	} else {
+		progress, err = uh.users.ApplyQuests(c.Request.Context(), types.Id(questId), types.Id(userId), uint32(amount))
+	}
+
+	if err != nil {
 		switch {
 		case errors.Is(err, uu.QuestNotApplied):
 			operate.SendStatus(c, http.StatusOK, &response.StatusApplyCost{Status: response.Failure}, l)
+		case errors.Is(err, uu.QuestStepApplied):
+			operate.SendStatus(c, http.StatusOK, &response.StatusApplyCost{
+				Status:   response.InProgress,
+				Progress: response.FromUsQuestProgress(progress),
+			}, l)
+			return
 		case errors.Is(err, qr.ErrorQuestNotFound):
 			operate.SendError(c, ErrorQuestNotFound, http.StatusNotFound, l)
 		case errors.Is(err, ur.ErrorUserNotFound):
 			operate.SendError(c, ErrorUserNotFound, http.StatusNotFound, l)
 		case errors.Is(err, ur.ErrorUserAlreadyCompleteQuest):
 			operate.SendError(c, ErrorUserAlreadyCompleteQuest, http.StatusConflict, l)
+		case errors.Is(err, ur.ErrorStreakAlreadyExtended):
+			operate.SendError(c, ErrorStreakAlreadyExtended, http.StatusConflict, l)
+		case errors.Is(err, ur.ErrorAlreadyContributed):
+			operate.SendError(c, ErrorAlreadyContributed, http.StatusConflict, l)
+		case errors.Is(err, ur.ErrorUserNotInTeam):
+			operate.SendError(c, ErrorUserNotInTeam, http.StatusForbidden, l)
+		case errors.Is(err, uu.ErrorQuestCooldownActive):
+			sendCooldownError(c, err, l)
+		case errors.Is(err, uu.ErrorAttemptsLimitReached):
+			operate.SendError(c, ErrorAttemptsLimitReached, http.StatusTooManyRequests, l)
+		case errors.Is(err, uu.ErrorQuestNotActive):
+			operate.SendError(c, ErrorQuestNotActive, http.StatusForbidden, l)
+		case errors.Is(err, uu.ErrorQuestArchived):
+			operate.SendError(c, ErrorQuestArchived, http.StatusGone, l)
+		case errors.Is(err, uu.ErrorPrerequisitesNotCompleted):
+			operate.SendError(c, ErrorPrerequisitesNotCompleted, http.StatusPreconditionFailed, l)
+		case errors.Is(err, uu.ErrorIdempotencyKeyInProgress):
+			operate.SendError(c, ErrorIdempotencyKeyInProgress, http.StatusConflict, l)
+		case errors.Is(err, uu.ErrorIdempotencyKeyMismatch):
+			operate.SendError(c, ErrorIdempotencyKeyMismatch, http.StatusUnprocessableEntity, l)
 		default:
-			operate.SendError(c, ErrorUnknownError, http.StatusInternalServerError, l)
+			sendServerError(c, err, l)
 			l.Error(errors.Wrapf(err, "can't apply quest with id %d to user with id %d", questId, userId))
 			return
 		}
@@ -245,5 +899,27 @@ func (uh *UserHandlers) CompleteQuest(c *gin.Context) {
 		return
 	}
 
-	operate.SendStatus(c, http.StatusOK, &response.StatusApplyCost{Status: response.Success}, l)
+	operate.SendStatus(c, http.StatusOK, &response.StatusApplyCost{
+		Status:   response.Success,
+		Progress: response.FromUsQuestProgress(progress),
+	}, l)
+}
+
+func sendCooldownError(c *gin.Context, err error, l logger.Interface) {
+	retryAfter := time.Duration(0)
+
+	var cooldownErr *uu.CooldownError
+	if errors.As(err, &cooldownErr) {
+		retryAfter = cooldownErr.RetryAfter
+	}
+
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+
+	c.Header(RetryAfterHeader, strconv.FormatInt(seconds, 10))
+	operate.SendError(
+		c,
+		errors.Errorf("%s, retry after %d seconds", ErrorQuestCooldownActive, seconds),
+		http.StatusTooManyRequests,
+		l,
+	)
 }
@@ -1,19 +1,26 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
 	"go.uber.org/mock/gomock"
 
 	"vk_quests/internal/delivery/http/v1/model/response"
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
+	lr "vk_quests/internal/repository/ledger"
 	qr "vk_quests/internal/repository/quest"
 	ur "vk_quests/internal/repository/user"
 	qu "vk_quests/internal/usecase/quest"
@@ -50,7 +57,14 @@ func (uhs *UserHandlersSuite) TestGetUsersHandler(t provider.T) {
 		Name:    "User",
 		Balance: 10,
 	}
-	users := []uu.User{*user, *user, *user}
+	usersPage := &uu.UsersPage{
+		Users:      []uu.User{*user, *user, *user},
+		NextCursor: "cursor",
+	}
+	defaultQuery := &uu.UsersQuery{
+		Sort:  types.UsersSortID,
+		Order: page.Asc,
+	}
 
 	responseUser := &response.User{
 		ID:      user.ID,
@@ -58,11 +72,14 @@ func (uhs *UserHandlersSuite) TestGetUsersHandler(t provider.T) {
 		Balance: user.Balance,
 	}
 
-	responseUsers := []response.User{*responseUser, *responseUser, *responseUser}
+	responseUsersPage := &response.UsersPage{
+		Users:      []response.User{*responseUser, *responseUser, *responseUser},
+		NextCursor: "cursor",
+	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().GetUsers().Return(users, nil).Times(1)
+		uhs.mockUser.EXPECT().GetUsers(gomock.Any(), defaultQuery).Return(usersPage, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", nil, nil)
@@ -74,15 +91,78 @@ func (uhs *UserHandlersSuite) TestGetUsersHandler(t provider.T) {
 		r.ServeHTTP(recorder, req)
 
 		t.Require().Equal(http.StatusOK, recorder.Code)
-		var usrs []response.User
+		var usrs response.UsersPage
 		dec := json.NewDecoder(recorder.Body)
 		t.Require().NoError(dec.Decode(&usrs))
-		t.Require().EqualValues(responseUsers, usrs)
+		t.Require().EqualValues(responseUsersPage, &usrs)
+	})
+
+	t.WithNewStep("Query params execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		minBalance, maxBalance := int64(5), int64(20)
+		uhs.mockUser.EXPECT().GetUsers(gomock.Any(), &uu.UsersQuery{
+			NamePrefix: "Us",
+			MinBalance: &minBalance,
+			MaxBalance: &maxBalance,
+			Sort:       types.UsersSortBalance,
+			Order:      page.Desc,
+			Cursor:     "cursor",
+			Limit:      2,
+		}).Return(usersPage, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost,
+			"/?name_prefix=Us&min_balance=5&max_balance=20&sort=balance&order=desc&limit=2&cursor=cursor", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	for _, query := range []string{
+		"min_balance=top",
+		"min_balance=20&max_balance=5",
+		"sort=created",
+		"order=up",
+		"limit=1001",
+	} {
+		t.WithNewStep("Incorrect query params "+query+" execute", func(t provider.StepCtx) {
+			t.NewStep("Init http")
+			req, err := initRequest(http.MethodPost, "/?"+query, nil, nil)
+			t.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+
+			t.NewStep("Check result")
+			r.ServeHTTP(recorder, req)
+
+			t.Require().Equal(http.StatusBadRequest, recorder.Code)
+		})
+	}
+
+	t.WithNewStep("Invalid cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUsers(gomock.Any(), gomock.Any()).Return(nil, page.ErrorInvalidCursor).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?cursor=top", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
 	})
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().GetUsers().Return(nil, testError).Times(1)
+		uhs.mockUser.EXPECT().GetUsers(gomock.Any(), defaultQuery).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", nil, nil)
@@ -95,6 +175,23 @@ func (uhs *UserHandlersSuite) TestGetUsersHandler(t provider.T) {
 
 		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
 	})
+
+	t.WithNewStep("Request deadline exceeded execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUsers(gomock.Any(), defaultQuery).
+			Return(nil, errors.Wrap(context.DeadlineExceeded, "can't get users")).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusGatewayTimeout, recorder.Code)
+	})
 }
 
 func (uhs *UserHandlersSuite) TestDeleteUserHandler(t provider.T) {
@@ -118,7 +215,90 @@ func (uhs *UserHandlersSuite) TestDeleteUserHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().DeleteUser(user.ID).Return(user, nil).Times(1)
+		uhs.mockUser.EXPECT().DeleteUser(gomock.Any(), user.ID).Return(user, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var usr response.User
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&usr))
+		t.Require().EqualValues(responseUser, &usr)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().DeleteUser(gomock.Any(), user.ID).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("User not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().DeleteUser(gomock.Any(), user.ID).Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/sus", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestAnonymizeUserHandler(t provider.T) {
+	t.Title("AnonymizeUser handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.AnonymizeUser))
+
+	t.NewStep("Init test data")
+	user := &uu.User{
+		ID:      1,
+		Balance: 20,
+	}
+
+	responseUser := &response.User{
+		ID:      user.ID,
+		Balance: user.Balance,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().AnonymizeUser(gomock.Any(), user.ID).Return(user, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -138,7 +318,106 @@ func (uhs *UserHandlersSuite) TestDeleteUserHandler(t provider.T) {
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().DeleteUser(user.ID).Return(nil, testError).Times(1)
+		uhs.mockUser.EXPECT().AnonymizeUser(gomock.Any(), user.ID).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("User not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().AnonymizeUser(gomock.Any(), user.ID).Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/sus", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestExportUserHandler(t provider.T) {
+	t.Title("ExportUser handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.ExportUser))
+
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	questId := types.Id(2)
+	roll := 0.4
+	deletedAt := pkgtime.MustParse("01.02.2025 - 00:00:00")
+	created := pkgtime.MustParse("15.01.2025 - 10:00:00")
+	snapshot := &uu.QuestSnapshot{Name: "Quest", Description: "good Quest", Type: types.USUAL}
+
+	export := &uu.Export{
+		User:      uu.User{ID: userId, Balance: 10},
+		DeletedAt: &deletedAt,
+		History:   []uu.HistoryRecord{{Snapshot: snapshot, Award: 10, Created: created, Balance: 10}},
+		Attempts:  []uu.AttemptRecord{{ID: 3, QuestId: &questId, Roll: &roll, Created: created}},
+	}
+
+	responseExport := &response.UserExport{
+		User:      response.User{ID: userId, Balance: 10},
+		DeletedAt: &deletedAt,
+		History: []response.HistoryRecord{{
+			Snapshot: &response.QuestSnapshot{Name: snapshot.Name, Description: snapshot.Description, Type: snapshot.Type},
+			Award:    10,
+			Created:  created,
+			Balance:  10,
+		}},
+		Attempts: []response.Attempt{{ID: 3, QuestId: &questId, Roll: &roll, Created: created}},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ExportUser(gomock.Any(), userId).Return(export, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var res response.UserExport
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&res))
+		t.Require().EqualValues(responseExport, &res)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ExportUser(gomock.Any(), userId).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -154,7 +433,7 @@ func (uhs *UserHandlersSuite) TestDeleteUserHandler(t provider.T) {
 
 	t.WithNewStep("User not found error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().DeleteUser(user.ID).Return(nil, ur.ErrorUserNotFound).Times(1)
+		uhs.mockUser.EXPECT().ExportUser(gomock.Any(), userId).Return(nil, ur.ErrorUserNotFound).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -209,7 +488,7 @@ func (uhs *UserHandlersSuite) TestCreateUserHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().CreateUser(user.Name).Return(user, nil).Times(1)
+		uhs.mockUser.EXPECT().CreateUser(gomock.Any(), user.Name, "").Return(user, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
@@ -229,7 +508,7 @@ func (uhs *UserHandlersSuite) TestCreateUserHandler(t provider.T) {
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().CreateUser(user.Name).Return(nil, testError).Times(1)
+		uhs.mockUser.EXPECT().CreateUser(gomock.Any(), user.Name, "").Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", strings.NewReader(body), nil)
@@ -243,6 +522,61 @@ func (uhs *UserHandlersSuite) TestCreateUserHandler(t provider.T) {
 		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
 	})
 
+	t.WithNewStep("Referred user execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		referrerId := types.Id(2)
+		referredUser := *user
+		referredUser.ReferrerId = &referrerId
+		uhs.mockUser.EXPECT().CreateUser(gomock.Any(), user.Name, "a1b2c3d4e5").Return(&referredUser, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/",
+			strings.NewReader(`{"name": "User", "referral_code": "a1b2c3d4e5"}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var usr response.User
+		t.Require().NoError(json.NewDecoder(recorder.Body).Decode(&usr))
+		t.Require().Equal(&referrerId, usr.ReferrerId)
+	})
+
+	t.WithNewStep("Referral code not found error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().CreateUser(gomock.Any(), user.Name, "unknown").
+			Return(nil, ur.ErrorReferralCodeNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/",
+			strings.NewReader(`{"name": "User", "referral_code": "unknown"}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Empty referral code error execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/",
+			strings.NewReader(`{"name": "User", "referral_code": ""}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
 	t.WithNewStep("Incorrect body error execute", func(t provider.StepCtx) {
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/", errReader(1), nil)
@@ -284,7 +618,7 @@ func (uhs *UserHandlersSuite) TestUpdateUserHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().UpdateUser(user.ID, user.Name).Return(user, nil).Times(1)
+		uhs.mockUser.EXPECT().UpdateUser(gomock.Any(), user.ID, user.Name).Return(user, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
@@ -304,7 +638,7 @@ func (uhs *UserHandlersSuite) TestUpdateUserHandler(t provider.T) {
 
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().UpdateUser(user.ID, user.Name).Return(nil, testError).Times(1)
+		uhs.mockUser.EXPECT().UpdateUser(gomock.Any(), user.ID, user.Name).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
@@ -320,7 +654,7 @@ func (uhs *UserHandlersSuite) TestUpdateUserHandler(t provider.T) {
 
 	t.WithNewStep("User not found error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().UpdateUser(user.ID, user.Name).Return(nil, ur.ErrorUserNotFound).Times(1)
+		uhs.mockUser.EXPECT().UpdateUser(gomock.Any(), user.ID, user.Name).Return(nil, ur.ErrorUserNotFound).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
@@ -361,45 +695,45 @@ func (uhs *UserHandlersSuite) TestUpdateUserHandler(t provider.T) {
 	})
 }
 
-func (uhs *UserHandlersSuite) TestGetHistoryHandler(t provider.T) {
-	t.Title("GetUserHistory handler of user handlers")
+func (uhs *UserHandlersSuite) TestGetUserHandler(t provider.T) {
+	t.Title("GetUser handler of user handlers")
 	t.NewStep("Init gin routes")
 	r := gin.New()
-	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.GetUserHistory))
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.GetUser))
 
 	t.NewStep("Init test data")
 	userId := types.Id(1)
-	history := []uu.HistoryRecord{
-		{
-			Quest: &qu.Quest{
-				ID:   1,
-				Name: "Not null",
-			},
-			Balance: 30,
+	stats := &uu.UserStats{
+		User: uu.User{
+			ID:      userId,
+			Name:    "User",
+			Balance: 40,
 		},
-		{
-			Quest:   nil,
-			Balance: 25,
+		Completed: 3,
+		Earned:    40,
+		ByType: map[types.QuestType]uu.TypeStats{
+			types.USUAL:  {Completed: 2, Earned: 30},
+			types.STAGED: {Completed: 1, Earned: 10},
 		},
 	}
 
-	responseHistory := []response.HistoryRecord{
-		{
-			Quest: &response.Quest{
-				ID:   history[0].Quest.ID,
-				Name: history[0].Quest.Name,
-			},
-			Balance: history[0].Balance,
+	responseStats := &response.UserStats{
+		User: response.User{
+			ID:      stats.User.ID,
+			Name:    stats.User.Name,
+			Balance: stats.User.Balance,
 		},
-		{
-			Quest:   nil,
-			Balance: history[1].Balance,
+		Completed: stats.Completed,
+		Earned:    stats.Earned,
+		ByType: map[string]response.TypeStats{
+			"usual":  {Completed: 2, Earned: 30},
+			"staged": {Completed: 1, Earned: 10},
 		},
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().GetUserHistory(userId).Return(history, nil).Times(1)
+		uhs.mockUser.EXPECT().GetUser(gomock.Any(), userId).Return(stats, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -411,15 +745,15 @@ func (uhs *UserHandlersSuite) TestGetHistoryHandler(t provider.T) {
 		r.ServeHTTP(recorder, req)
 
 		t.Require().Equal(http.StatusOK, recorder.Code)
-		var hist []response.HistoryRecord
+		var usr response.UserStats
 		dec := json.NewDecoder(recorder.Body)
-		t.Require().NoError(dec.Decode(&hist))
-		t.Require().EqualValues(responseHistory, hist)
+		t.Require().NoError(dec.Decode(&usr))
+		t.Require().EqualValues(responseStats, &usr)
 	})
 
-	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().GetUserHistory(userId).Return(nil, testError).Times(1)
+		uhs.mockUser.EXPECT().GetUser(gomock.Any(), userId).Return(nil, ur.ErrorUserNotFound).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/1", nil, nil)
@@ -430,10 +764,1288 @@ func (uhs *UserHandlersSuite) TestGetHistoryHandler(t provider.T) {
 		t.NewStep("Check result")
 		r.ServeHTTP(recorder, req)
 
-		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
 	})
 
-	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUser(gomock.Any(), userId).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/qwerty", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestGetHistoryHandler(t provider.T) {
+	t.Title("GetUserHistory handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.GetUserHistory))
+
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	recipientId := types.Id(3)
+	history := []uu.HistoryRecord{
+		{
+			Snapshot: &uu.QuestSnapshot{
+				Name:        "Old name",
+				Description: "old description",
+				Type:        types.USUAL,
+			},
+			Award:      10,
+			BaseAward:  5,
+			Multiplier: 2,
+			Quest: &qu.Quest{
+				ID:   1,
+				Name: "Not null",
+			},
+			Balance: 30,
+		},
+		{
+			Award:          5,
+			BaseAward:      5,
+			Multiplier:     1,
+			Source:         types.SourceTransferOut,
+			CounterpartyId: &recipientId,
+			Quest:          nil,
+			Balance:        25,
+		},
+	}
+
+	historyPage := &uu.HistoryPage{
+		Records:    history,
+		NextCursor: "cursor",
+	}
+	defaultQuery := &uu.HistoryQuery{
+		Order: page.Desc,
+	}
+
+	responseHistory := []response.HistoryRecord{
+		{
+			Snapshot: &response.QuestSnapshot{
+				Name:        history[0].Snapshot.Name,
+				Description: history[0].Snapshot.Description,
+				Type:        history[0].Snapshot.Type,
+			},
+			Award:      history[0].Award,
+			BaseAward:  history[0].BaseAward,
+			Multiplier: history[0].Multiplier,
+			Quest: &response.Quest{
+				ID:   history[0].Quest.ID,
+				Name: history[0].Quest.Name,
+			},
+			Balance: history[0].Balance,
+		},
+		{
+			Award:          history[1].Award,
+			BaseAward:      history[1].BaseAward,
+			Multiplier:     history[1].Multiplier,
+			Source:         history[1].Source,
+			CounterpartyId: &recipientId,
+			Quest:          nil,
+			Balance:        history[1].Balance,
+		},
+	}
+
+	responseHistoryPage := &response.HistoryPage{
+		History:    responseHistory,
+		NextCursor: "cursor",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserHistory(gomock.Any(), userId, defaultQuery).Return(historyPage, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var hist response.HistoryPage
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&hist))
+		t.Require().EqualValues(responseHistoryPage, &hist)
+	})
+
+	t.WithNewStep("Query params execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 2, 1, 12, 30, 0, 0, time.UTC)
+		questType := types.STAGED
+		uhs.mockUser.EXPECT().GetUserHistory(gomock.Any(), userId, &uu.HistoryQuery{
+			From:      &from,
+			To:        &to,
+			QuestType: &questType,
+			Order:     page.Asc,
+			Cursor:    "cursor",
+			Limit:     2,
+		}).Return(historyPage, nil).Times(1)
+
+		t.NewStep("Init http")
+		params := url.Values{}
+		params.Set("from", "01.01.2024 - 00:00:00")
+		params.Set("to", "01.02.2024 - 12:30:00")
+		params.Set("quest_type", "staged")
+		params.Set("order", "asc")
+		params.Set("limit", "2")
+		params.Set("cursor", "cursor")
+		req, err := initRequest(http.MethodPost, "/1?"+params.Encode(), nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	for _, query := range []string{
+		"from=2024-01-01",
+		"from=02.01.2024+-+00:00:00&to=01.01.2024+-+00:00:00",
+		"quest_type=daily",
+		"order=up",
+		"limit=1001",
+	} {
+		t.WithNewStep("Incorrect query params "+query+" execute", func(t provider.StepCtx) {
+			t.NewStep("Init http")
+			req, err := initRequest(http.MethodPost, "/1?"+query, nil, nil)
+			t.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+
+			t.NewStep("Check result")
+			r.ServeHTTP(recorder, req)
+
+			t.Require().Equal(http.StatusBadRequest, recorder.Code)
+		})
+	}
+
+	t.WithNewStep("Invalid cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserHistory(gomock.Any(), userId, gomock.Any()).
+			Return(nil, page.ErrorInvalidCursor).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1?cursor=top", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserHistory(gomock.Any(), userId, defaultQuery).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserHistory(gomock.Any(), userId, defaultQuery).Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/qwerty", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestGetAttemptsHandler(t provider.T) {
+	t.Title("GetUserAttempts handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.GetUserAttempts))
+
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	questId := types.Id(4)
+	roll := 0.8
+	attempts := []uu.AttemptRecord{
+		{
+			ID:      2,
+			QuestId: &questId,
+			Success: false,
+			Roll:    &roll,
+		},
+		{
+			ID:      1,
+			QuestId: nil,
+			Success: true,
+		},
+	}
+
+	attemptsPage := &uu.AttemptsPage{
+		Attempts:   attempts,
+		NextCursor: "cursor",
+	}
+	defaultQuery := &uu.AttemptsQuery{
+		Order: page.Desc,
+	}
+
+	responseAttemptsPage := &response.AttemptsPage{
+		Attempts: []response.Attempt{
+			{
+				ID:      attempts[0].ID,
+				QuestId: &questId,
+				Success: false,
+				Roll:    &roll,
+			},
+			{
+				ID:      attempts[1].ID,
+				QuestId: nil,
+				Success: true,
+			},
+		},
+		NextCursor: "cursor",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserAttempts(gomock.Any(), userId, defaultQuery).Return(attemptsPage, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var res response.AttemptsPage
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&res))
+		t.Require().EqualValues(responseAttemptsPage, &res)
+	})
+
+	t.WithNewStep("Query params execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserAttempts(gomock.Any(), userId, &uu.AttemptsQuery{
+			QuestId: &questId,
+			Order:   page.Asc,
+			Cursor:  "cursor",
+			Limit:   2,
+		}).Return(attemptsPage, nil).Times(1)
+
+		t.NewStep("Init http")
+		params := url.Values{}
+		params.Set("quest_id", "4")
+		params.Set("order", "asc")
+		params.Set("limit", "2")
+		params.Set("cursor", "cursor")
+		req, err := initRequest(http.MethodPost, "/1?"+params.Encode(), nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	for _, query := range []string{
+		"quest_id=first",
+		"order=up",
+		"limit=1001",
+	} {
+		t.WithNewStep("Incorrect query params "+query+" execute", func(t provider.StepCtx) {
+			t.NewStep("Init http")
+			req, err := initRequest(http.MethodPost, "/1?"+query, nil, nil)
+			t.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+
+			t.NewStep("Check result")
+			r.ServeHTTP(recorder, req)
+
+			t.Require().Equal(http.StatusBadRequest, recorder.Code)
+		})
+	}
+
+	t.WithNewStep("Invalid cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserAttempts(gomock.Any(), userId, gomock.Any()).
+			Return(nil, page.ErrorInvalidCursor).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1?cursor=top", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserAttempts(gomock.Any(), userId, defaultQuery).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserAttempts(gomock.Any(), userId, defaultQuery).Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/qwerty", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestGetAvailableQuestsHandler(t provider.T) {
+	t.Title("GetAvailableQuests handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.GetAvailableQuests))
+
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	questsPage := &qu.QuestsPage{
+		Quests: []qu.Quest{
+			{ID: 2, Name: "First", Type: types.USUAL},
+			{ID: 4, Name: "Second", Type: types.USUAL, Prerequisites: []types.Id{2}},
+		},
+		NextCursor: "cursor",
+	}
+	responseQuestsPage := &response.QuestsPage{
+		Quests: []response.Quest{
+			{ID: 2, Name: "First", Type: types.USUAL},
+			{ID: 4, Name: "Second", Type: types.USUAL, Prerequisites: []types.Id{2}},
+		},
+		NextCursor: "cursor",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetAvailableQuests(gomock.Any(), userId, &uu.AvailableQuestsQuery{}).
+			Return(questsPage, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var quests response.QuestsPage
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&quests))
+		t.Require().EqualValues(responseQuestsPage, &quests)
+	})
+
+	t.WithNewStep("Query params execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetAvailableQuests(gomock.Any(), userId, &uu.AvailableQuestsQuery{
+			Cursor: "cursor",
+			Limit:  2,
+		}).Return(questsPage, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1?limit=2&cursor=cursor", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+	})
+
+	for _, query := range []string{"limit=-1", "limit=1001"} {
+		t.WithNewStep("Incorrect query params "+query+" execute", func(t provider.StepCtx) {
+			t.NewStep("Init http")
+			req, err := initRequest(http.MethodPost, "/1?"+query, nil, nil)
+			t.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+
+			t.NewStep("Check result")
+			r.ServeHTTP(recorder, req)
+
+			t.Require().Equal(http.StatusBadRequest, recorder.Code)
+		})
+	}
+
+	t.WithNewStep("Invalid cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetAvailableQuests(gomock.Any(), userId, gomock.Any()).
+			Return(nil, page.ErrorInvalidCursor).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1?cursor=top", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetAvailableQuests(gomock.Any(), userId, gomock.Any()).
+			Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetAvailableQuests(gomock.Any(), userId, gomock.Any()).
+			Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/qwerty", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestGetProgressHandler(t provider.T) {
+	t.Title("GetUserProgress handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.GetUserProgress))
+
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	progress := []uu.Progress{
+		{
+			Quest: &qu.Quest{
+				ID:    1,
+				Name:  "Staged",
+				Type:  types.STAGED,
+				Steps: []string{"first", "second"},
+			},
+			Step: 1,
+		},
+	}
+
+	responseProgress := []response.Progress{
+		{
+			Quest: &response.Quest{
+				ID:    progress[0].Quest.ID,
+				Name:  progress[0].Quest.Name,
+				Type:  progress[0].Quest.Type,
+				Steps: progress[0].Quest.Steps,
+			},
+			Step:  progress[0].Step,
+			Total: 2,
+		},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserProgress(gomock.Any(), userId).Return(progress, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var res []response.Progress
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&res))
+		t.Require().EqualValues(responseProgress, res)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserProgress(gomock.Any(), userId).Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().GetUserProgress(gomock.Any(), userId).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/qwerty", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestRevokeQuestHandler(t provider.T) {
+	t.Title("RevokeQuest handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.DELETE("/:"+UserIdField+"/quest/:"+QuestIdField, addEmptyLogger(uhs.handlers.RevokeQuest))
+
+	t.NewStep("Init test data")
+	completed := pkgtime.FormattedTime{Time: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	created := pkgtime.FormattedTime{Time: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}
+	userId, questId := types.Id(1), types.Id(2)
+
+	revocation := &uu.Revocation{
+		ID:      4,
+		QuestId: questId,
+		Snapshot: uu.QuestSnapshot{
+			Name:        "Quest",
+			Description: "usual quest",
+			Type:        types.USUAL,
+		},
+		Award:     15,
+		Completed: completed,
+		Reason:    "fraud",
+		Transaction: uu.Transaction{
+			ID:      3,
+			UserId:  userId,
+			Kind:    string(lr.Debit),
+			Amount:  15,
+			Reason:  lr.QuestRevoke,
+			QuestId: &questId,
+			Balance: -5,
+			Created: created,
+		},
+		Created: created,
+	}
+
+	body := `
+		{
+			"reason": "fraud"
+		}
+	`
+
+	responseRevocation := &response.Revocation{
+		ID:      revocation.ID,
+		QuestId: questId,
+		Snapshot: response.QuestSnapshot{
+			Name:        revocation.Snapshot.Name,
+			Description: revocation.Snapshot.Description,
+			Type:        revocation.Snapshot.Type,
+		},
+		Award:     revocation.Award,
+		Completed: completed,
+		Reason:    revocation.Reason,
+		Transaction: response.Transaction{
+			ID:      revocation.Transaction.ID,
+			Kind:    revocation.Transaction.Kind,
+			Amount:  revocation.Transaction.Amount,
+			Reason:  revocation.Transaction.Reason,
+			QuestId: &questId,
+			Balance: revocation.Transaction.Balance,
+			Created: created,
+		},
+		Created: created,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().RevokeQuest(gomock.Any(), userId, questId, revocation.Reason).
+			Return(revocation, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/quest/2", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var res response.Revocation
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&res))
+		t.Require().EqualValues(responseRevocation, &res)
+	})
+
+	t.WithNewStep("Insufficient funds execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().RevokeQuest(gomock.Any(), userId, questId, revocation.Reason).
+			Return(nil, lr.ErrorInsufficientFunds).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/quest/2", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusPaymentRequired, recorder.Code)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().RevokeQuest(gomock.Any(), userId, questId, revocation.Reason).
+			Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/quest/2", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Completion not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().RevokeQuest(gomock.Any(), userId, questId, revocation.Reason).
+			Return(nil, ur.ErrorCompletionNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/quest/2", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().RevokeQuest(gomock.Any(), userId, questId, revocation.Reason).
+			Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/quest/2", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Missing reason execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/quest/2", strings.NewReader(`{}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect user id execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/qwerty/quest/2", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect quest id execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodDelete, "/1/quest/qwerty", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestDebitHandler(t provider.T) {
+	t.Title("Debit handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.Debit))
+
+	t.NewStep("Init test data")
+	created := pkgtime.FormattedTime{Time: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}
+	transaction := &uu.Transaction{
+		ID:      2,
+		UserId:  1,
+		Kind:    string(lr.Debit),
+		Amount:  15,
+		Reason:  "shop",
+		Balance: 5,
+		Created: created,
+	}
+
+	body := `
+		{
+			"amount": 15,
+			"reason": "shop"
+		}
+	`
+
+	responseTransaction := &response.Transaction{
+		ID:      transaction.ID,
+		Kind:    transaction.Kind,
+		Amount:  transaction.Amount,
+		Reason:  transaction.Reason,
+		Balance: transaction.Balance,
+		Created: created,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Debit(gomock.Any(), transaction.UserId, transaction.Amount, transaction.Reason).
+			Return(transaction, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var res response.Transaction
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&res))
+		t.Require().EqualValues(responseTransaction, &res)
+	})
+
+	t.WithNewStep("Insufficient funds execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Debit(gomock.Any(), transaction.UserId, transaction.Amount, transaction.Reason).
+			Return(nil, lr.ErrorInsufficientFunds).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusPaymentRequired, recorder.Code)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Debit(gomock.Any(), transaction.UserId, transaction.Amount, transaction.Reason).
+			Return(nil, lr.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Debit(gomock.Any(), transaction.UserId, transaction.Amount, transaction.Reason).
+			Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Zero amount execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"amount": 0, "reason": "shop"}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Missing reason execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"amount": 15}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/qwerty", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestTransferHandler(t provider.T) {
+	t.Title("Transfer handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.Transfer))
+
+	t.NewStep("Init test data")
+	created := pkgtime.FormattedTime{Time: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}
+	from, to := types.Id(1), types.Id(2)
+	amount := uint64(15)
+	transfer := &uu.Transfer{
+		Debit: uu.Transaction{
+			ID:             3,
+			UserId:         from,
+			Kind:           string(lr.Debit),
+			Amount:         amount,
+			Reason:         lr.TransferReason,
+			CounterpartyId: &to,
+			Balance:        5,
+			Created:        created,
+		},
+		Credit: uu.Transaction{
+			ID:             4,
+			UserId:         to,
+			Kind:           string(lr.Credit),
+			Amount:         amount,
+			Reason:         lr.TransferReason,
+			CounterpartyId: &from,
+			Balance:        40,
+			Created:        created,
+		},
+	}
+
+	body := `
+		{
+			"recipient_id": 2,
+			"amount": 15
+		}
+	`
+
+	responseTransaction := &response.Transaction{
+		ID:             transfer.Debit.ID,
+		Kind:           transfer.Debit.Kind,
+		Amount:         amount,
+		Reason:         lr.TransferReason,
+		CounterpartyId: &to,
+		Balance:        transfer.Debit.Balance,
+		Created:        created,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Transfer(gomock.Any(), from, to, amount).Return(transfer, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusCreated, recorder.Code)
+		var res response.Transaction
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&res))
+		t.Require().EqualValues(responseTransaction, &res)
+	})
+
+	t.WithNewStep("Self transfer execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Transfer(gomock.Any(), from, to, amount).Return(nil, uu.ErrorSelfTransfer).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Transfer(gomock.Any(), from, to, amount).Return(nil, lr.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Recipient not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Transfer(gomock.Any(), from, to, amount).Return(nil, lr.ErrorRecipientNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Insufficient funds execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Transfer(gomock.Any(), from, to, amount).Return(nil, lr.ErrorInsufficientFunds).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusPaymentRequired, recorder.Code)
+	})
+
+	t.WithNewStep("Transfer limit exceeded execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Transfer(gomock.Any(), from, to, amount).Return(nil, lr.ErrorTransferLimitExceeded).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().Transfer(gomock.Any(), from, to, amount).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Zero amount execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"recipient_id": 2, "amount": 0}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Missing recipient execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", strings.NewReader(`{"amount": 15}`), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/qwerty", strings.NewReader(body), nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func (uhs *UserHandlersSuite) TestReconcileBalanceHandler(t provider.T) {
+	t.Title("ReconcileBalance handler of user handlers")
+	t.NewStep("Init gin routes")
+	r := gin.New()
+	r.POST("/:"+UserIdField, addEmptyLogger(uhs.handlers.ReconcileBalance))
+
+	t.NewStep("Init test data")
+	reconciliation := &uu.Reconciliation{
+		UserId: 1,
+		Cached: 30,
+		Ledger: 25,
+	}
+
+	responseReconciliation := &response.Reconciliation{
+		UserId:        reconciliation.UserId,
+		CachedBalance: reconciliation.Cached,
+		LedgerBalance: reconciliation.Ledger,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ReconcileBalance(gomock.Any(), reconciliation.UserId).Return(reconciliation, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var res response.Reconciliation
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&res))
+		t.Require().EqualValues(responseReconciliation, &res)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ReconcileBalance(gomock.Any(), reconciliation.UserId).Return(nil, lr.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ReconcileBalance(gomock.Any(), reconciliation.UserId).Return(nil, testError).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/1", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	t.WithNewStep("Incorrect path param execute", func(t provider.StepCtx) {
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/qwerty", nil, nil)
 		t.Require().NoError(err)
@@ -459,7 +2071,7 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(nil).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, nil).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
@@ -479,7 +2091,7 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Correct execute quest not applied", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(uu.QuestNotApplied).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, uu.QuestNotApplied).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
@@ -497,9 +2109,92 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 		t.Require().EqualValues(response.Failure, status.Status)
 	})
 
+	t.WithNewStep("Correct execute quest step applied", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, uu.QuestStepApplied).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var status response.StatusApplyCost
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&status))
+		t.Require().EqualValues(response.InProgress, status.Status)
+	})
+
+	t.WithNewStep("Correct execute counter quest with amount", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(4)).
+			Return(&uu.QuestProgress{Step: 7, Total: 10}, uu.QuestStepApplied).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2&"+AmountField+"=4", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var status response.StatusApplyCost
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&status))
+		t.Require().EqualValues(response.StatusApplyCost{
+			Status:   response.InProgress,
+			Progress: &response.QuestProgress{Step: 7, Total: 10},
+		}, status)
+	})
+
+	t.WithNewStep("Correct execute counter quest target reached", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(3)).
+			Return(&uu.QuestProgress{Step: 10, Total: 10}, nil).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2&"+AmountField+"=3", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var status response.StatusApplyCost
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&status))
+		t.Require().EqualValues(response.StatusApplyCost{
+			Status:   response.Success,
+			Progress: &response.QuestProgress{Step: 10, Total: 10},
+		}, status)
+	})
+
+	t.WithNewStep("Incorrect amount query param execute", func(t provider.StepCtx) {
+		for _, amount := range []string{"0", "-1", "ar", "4294967296"} {
+			t.NewStep("Init http")
+			req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2&"+AmountField+"="+amount, nil, nil)
+			t.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+
+			t.NewStep("Check result")
+			r.ServeHTTP(recorder, req)
+
+			t.Require().Equal(http.StatusBadRequest, recorder.Code)
+		}
+	})
+
 	t.WithNewStep("User not found error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(ur.ErrorUserNotFound).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, ur.ErrorUserNotFound).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
@@ -515,7 +2210,7 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Quest not found error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(qr.ErrorQuestNotFound).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, qr.ErrorQuestNotFound).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
@@ -531,7 +2226,39 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Quest already complete for user error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(ur.ErrorUserAlreadyCompleteQuest).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, ur.ErrorUserAlreadyCompleteQuest).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusConflict, recorder.Code)
+	})
+
+	t.WithNewStep("Streak already extended today error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, ur.ErrorStreakAlreadyExtended).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusConflict, recorder.Code)
+	})
+
+	t.WithNewStep("Already contributed to team quest error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, ur.ErrorAlreadyContributed).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
@@ -545,9 +2272,107 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 		t.Require().Equal(http.StatusConflict, recorder.Code)
 	})
 
+	t.WithNewStep("User not in team error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, ur.ErrorUserNotInTeam).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusForbidden, recorder.Code)
+	})
+
+	t.WithNewStep("Quest cooldown active error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).
+			Return(nil, &uu.CooldownError{RetryAfter: 90*time.Second + time.Millisecond}).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusTooManyRequests, recorder.Code)
+		t.Require().Equal("91", recorder.Header().Get(RetryAfterHeader))
+	})
+
+	t.WithNewStep("Attempts limit reached error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, uu.ErrorAttemptsLimitReached).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusTooManyRequests, recorder.Code)
+	})
+
+	t.WithNewStep("Quest archived error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, uu.ErrorQuestArchived).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusGone, recorder.Code)
+	})
+
+	t.WithNewStep("Quest not active error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, uu.ErrorQuestNotActive).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusForbidden, recorder.Code)
+	})
+
+	t.WithNewStep("Prerequisites not completed error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, uu.ErrorPrerequisitesNotCompleted).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusPreconditionFailed, recorder.Code)
+	})
+
 	t.WithNewStep("Usecase error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(testError).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
@@ -561,9 +2386,80 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 		t.Require().Equal(http.StatusInternalServerError, recorder.Code)
 	})
 
+	t.WithNewStep("Correct execute with idempotency key", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuestsIdempotent(gomock.Any(), "key", questId, userId, uint32(1)).Return(nil, uu.QuestNotApplied).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+		req.Header.Set(IdempotencyKeyHeader, "key")
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusOK, recorder.Code)
+		var status response.StatusApplyCost
+		dec := json.NewDecoder(recorder.Body)
+		t.Require().NoError(dec.Decode(&status))
+		t.Require().EqualValues(response.Failure, status.Status)
+	})
+
+	t.WithNewStep("Request with idempotency key in progress error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuestsIdempotent(gomock.Any(), "key", questId, userId, uint32(1)).
+			Return(nil, uu.ErrorIdempotencyKeyInProgress).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+		req.Header.Set(IdempotencyKeyHeader, "key")
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusConflict, recorder.Code)
+	})
+
+	t.WithNewStep("Idempotency key used with other parameters error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uhs.mockUser.EXPECT().ApplyQuestsIdempotent(gomock.Any(), "key", questId, userId, uint32(1)).
+			Return(nil, uu.ErrorIdempotencyKeyMismatch).Times(1)
+
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+		req.Header.Set(IdempotencyKeyHeader, "key")
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.WithNewStep("Too long idempotency key execute", func(t provider.StepCtx) {
+		t.NewStep("Init http")
+		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=2", nil, nil)
+		t.Require().NoError(err)
+		req.Header.Set(IdempotencyKeyHeader, strings.Repeat("k", MaxIdempotencyKeyLength+1))
+
+		recorder := httptest.NewRecorder()
+
+		t.NewStep("Check result")
+		r.ServeHTTP(recorder, req)
+
+		t.Require().Equal(http.StatusBadRequest, recorder.Code)
+	})
+
 	t.WithNewStep("Incorrect user id query param execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(testError).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=ar&"+QuestIdField+"=2", nil, nil)
@@ -579,7 +2475,7 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Incorrect quest id query param execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(testError).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1&"+QuestIdField+"=ar", nil, nil)
@@ -595,7 +2491,7 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("User id query param not presented execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(testError).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+QuestIdField+"=2", nil, nil)
@@ -611,7 +2507,7 @@ func (uhs *UserHandlersSuite) TestCompleteQuestHandler(t provider.T) {
 
 	t.WithNewStep("Quest id query param not presented execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uhs.mockUser.EXPECT().ApplyQuests(questId, userId).Return(testError).Times(1)
+		uhs.mockUser.EXPECT().ApplyQuests(gomock.Any(), questId, userId, uint32(1)).Return(nil, testError).Times(1)
 
 		t.NewStep("Init http")
 		req, err := initRequest(http.MethodPost, "/?"+UserIdField+"=1", nil, nil)
@@ -0,0 +1,87 @@
+package request
+
+import (
+	"github.com/miladibra10/vjson"
+	"vk_quests/internal/pkg/evjson"
+	pkgtime "vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	bu "vk_quests/internal/usecase/boost"
+)
+
+// MaxMultiplier limits multiplier of boost, so one campaign can't flood balances of users
+const MaxMultiplier = 10
+
+type CreateBoost struct {
+	Name       string                `json:"name" swaggertype:"string" example:"Double points weekend"`
+	Multiplier float64               `json:"multiplier" swaggertype:"number" format:"double" example:"2" minimum:"1" maximum:"10"`
+	StartsAt   pkgtime.FormattedTime `json:"starts_at" swaggertype:"string" example:"07.12.2024 - 00:00:00"`
+	EndsAt     pkgtime.FormattedTime `json:"ends_at" swaggertype:"string" example:"09.12.2024 - 00:00:00"`
+	QuestId    *types.Id             `json:"quest_id,omitempty" swaggertype:"integer" format:"uint64" example:"1"`
+	CategoryId *types.Id             `json:"category_id,omitempty" swaggertype:"integer" format:"uint64" example:"2"`
+	QuestType  *types.QuestType      `json:"quest_type,omitempty" swaggertype:"string" enums:"usual,random,staged,counter,streak,team" example:"usual"`
+}
+
+func (c *CreateBoost) ToUsBoost() *bu.Boost {
+	return &bu.Boost{
+		Name:       c.Name,
+		Multiplier: c.Multiplier,
+		StartsAt:   c.StartsAt,
+		EndsAt:     c.EndsAt,
+		QuestId:    c.QuestId,
+		CategoryId: c.CategoryId,
+		QuestType:  c.QuestType,
+	}
+}
+
+func ValidateCreateBoost(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.String("name").MinLength(1).Required(),
+		vjson.Float("multiplier").Range(1, MaxMultiplier).Required(),
+		vjson.String("starts_at").Required(),
+		vjson.String("ends_at").Required(),
+		vjson.Integer("quest_id").Min(0),
+		vjson.Integer("category_id").Min(0),
+		vjson.String("quest_type").Choices(string(types.USUAL), string(types.RANDOM), string(types.STAGED),
+			string(types.COUNTER), string(types.STREAK), string(types.TEAM)),
+	)
+	return schema.ValidateBytes(data)
+}
+
+type UpdateBoost struct {
+	Name       *string                `json:"name,omitempty" swaggertype:"string" example:"Double points weekend"`
+	Multiplier *float64               `json:"multiplier,omitempty" swaggertype:"number" format:"double" example:"2" minimum:"1" maximum:"10"`
+	StartsAt   *pkgtime.FormattedTime `json:"starts_at,omitempty" swaggertype:"string" example:"07.12.2024 - 00:00:00"`
+	EndsAt     *pkgtime.FormattedTime `json:"ends_at,omitempty" swaggertype:"string" example:"09.12.2024 - 00:00:00"`
+	// QuestId replaces quest of boost, 0 removes restriction by quest
+	QuestId *types.Id `json:"quest_id,omitempty" swaggertype:"integer" format:"uint64" example:"1"`
+	// CategoryId replaces category of boost, 0 removes restriction by category
+	CategoryId *types.Id `json:"category_id,omitempty" swaggertype:"integer" format:"uint64" example:"2"`
+	// QuestType replaces type of quests of boost, empty string removes restriction by type
+	QuestType *types.QuestType `json:"quest_type,omitempty" swaggertype:"string" enums:",usual,random,staged,counter,streak,team" example:"usual"`
+}
+
+func (u *UpdateBoost) ToUsUpdateBoost() *bu.UpdateBoost {
+	return &bu.UpdateBoost{
+		Name:       u.Name,
+		Multiplier: u.Multiplier,
+		StartsAt:   u.StartsAt,
+		EndsAt:     u.EndsAt,
+		QuestId:    u.QuestId,
+		CategoryId: u.CategoryId,
+		QuestType:  u.QuestType,
+	}
+}
+
+func ValidateUpdateBoost(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.String("name").MinLength(1),
+		vjson.Float("multiplier").Range(1, MaxMultiplier),
+		vjson.String("starts_at"),
+		vjson.String("ends_at"),
+		vjson.Integer("quest_id").Min(0),
+		vjson.Integer("category_id").Min(0),
+		vjson.String("quest_type").Choices("", string(types.USUAL), string(types.RANDOM), string(types.STAGED),
+			string(types.COUNTER), string(types.STREAK), string(types.TEAM)),
+	)
+	return schema.ValidateBytes(data)
+}
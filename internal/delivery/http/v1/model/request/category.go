@@ -0,0 +1,47 @@
+package request
+
+import (
+	"github.com/miladibra10/vjson"
+	"vk_quests/internal/pkg/evjson"
+	cu "vk_quests/internal/usecase/category"
+)
+
+type CreateCategory struct {
+	Name        string `json:"name" swaggertype:"string" example:"Daily"`
+	Description string `json:"description,omitempty" swaggertype:"string" example:"Quests renewed every day"`
+}
+
+func (c *CreateCategory) ToUsCategory() *cu.Category {
+	return &cu.Category{
+		Name:        c.Name,
+		Description: c.Description,
+	}
+}
+
+func ValidateCreateCategory(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.String("name").MinLength(1).Required(),
+		vjson.String("description"),
+	)
+	return schema.ValidateBytes(data)
+}
+
+type UpdateCategory struct {
+	Name        *string `json:"name,omitempty" swaggertype:"string" example:"Daily"`
+	Description *string `json:"description,omitempty" swaggertype:"string" example:"Quests renewed every day"`
+}
+
+func (u *UpdateCategory) ToUsUpdateCategory() *cu.UpdateCategory {
+	return &cu.UpdateCategory{
+		Name:        u.Name,
+		Description: u.Description,
+	}
+}
+
+func ValidateUpdateCategory(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.String("name").MinLength(1),
+		vjson.String("description"),
+	)
+	return schema.ValidateBytes(data)
+}
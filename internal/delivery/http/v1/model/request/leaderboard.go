@@ -0,0 +1,68 @@
+package request
+
+import (
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+	lbu "vk_quests/internal/usecase/leaderboard"
+)
+
+type Rank struct {
+	Window string `form:"window"`
+	Metric string `form:"metric"`
+}
+
+func (r *Rank) Validate() error {
+	switch types.LeaderboardWindow(r.Window) {
+	case "", types.LeaderboardAllTime, types.LeaderboardWeek, types.LeaderboardMonth:
+	default:
+		return errors.Errorf("unknown window %q", r.Window)
+	}
+
+	switch types.LeaderboardMetric(r.Metric) {
+	case "", types.LeaderboardEarned:
+	case types.LeaderboardBalance:
+		// Balance is current, so it can't be split by windows
+		if r.ToUsWindow() != types.LeaderboardAllTime {
+			return errors.Errorf("window %q is not supported by balance metric", r.Window)
+		}
+	default:
+		return errors.Errorf("unknown metric %q", r.Metric)
+	}
+
+	return nil
+}
+
+func (r *Rank) ToUsWindow() types.LeaderboardWindow {
+	if r.Window == "" {
+		return types.LeaderboardAllTime
+	}
+	return types.LeaderboardWindow(r.Window)
+}
+
+func (r *Rank) ToUsMetric() types.LeaderboardMetric {
+	if r.Metric == "" {
+		return types.LeaderboardEarned
+	}
+	return types.LeaderboardMetric(r.Metric)
+}
+
+type Leaderboard struct {
+	Rank
+	Limit uint64 `form:"limit"`
+}
+
+func (l *Leaderboard) Validate() error {
+	if err := l.Rank.Validate(); err != nil {
+		return err
+	}
+
+	return validateList("", l.Limit)
+}
+
+func (l *Leaderboard) ToUsLeaderboardQuery() *lbu.LeaderboardQuery {
+	return &lbu.LeaderboardQuery{
+		Window: l.ToUsWindow(),
+		Metric: l.ToUsMetric(),
+		Limit:  l.Limit,
+	}
+}
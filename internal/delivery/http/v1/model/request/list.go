@@ -0,0 +1,28 @@
+package request
+
+import (
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/page"
+)
+
+// validateList checks parameters common for all lists.
+func validateList(order string, limit uint64) error {
+	switch page.Order(order) {
+	case "", page.Asc, page.Desc:
+	default:
+		return errors.Errorf("unknown order %q", order)
+	}
+
+	if limit > page.MaxLimit {
+		return errors.Errorf("limit is greater than %d", page.MaxLimit)
+	}
+
+	return nil
+}
+
+func listOrder(order string) page.Order {
+	if order == "" {
+		return page.Asc
+	}
+	return page.Order(order)
+}
@@ -1,25 +1,98 @@
 package request
 
 import (
+	"time"
+
 	"github.com/miladibra10/vjson"
+	"github.com/pkg/errors"
 	"vk_quests/internal/pkg/evjson"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
 	qu "vk_quests/internal/usecase/quest"
+	"vk_quests/pkg/slices"
 )
 
+// DefaultMaxCompletions is used when number of completions is not set on quest creation
+const DefaultMaxCompletions = 1
+
+// DefaultProbability is used when chance of random quest completion is not set on quest creation
+const DefaultProbability = 0.5
+
 type CreateQuest struct {
-	Name        string          `json:"name" swaggertype:"string" example:"Task"`
-	Description string          `json:"description" swaggertype:"string" example:"Random quest"`
-	Cost        types.Cost      `json:"cost" swaggertype:"integer" format:"uint8" example:"9" minimum:"0" maximum:"1000"`
-	Type        types.QuestType `json:"type" swaggertype:"string" enums:"usual,random" example:"random"`
+	Name           string                 `json:"name" swaggertype:"string" example:"Task"`
+	Description    string                 `json:"description" swaggertype:"string" example:"Random quest"`
+	Cost           types.Cost             `json:"cost" swaggertype:"integer" format:"uint8" example:"9" minimum:"0" maximum:"1000"`
+	Type           types.QuestType        `json:"type" swaggertype:"string" enums:"usual,random,staged,counter,streak,team" example:"random"`
+	Steps          []string               `json:"steps,omitempty" swaggertype:"array,string" example:"Open profile,Fill name"`
+	MaxCompletions *uint32                `json:"max_completions,omitempty" swaggertype:"integer" format:"uint32" example:"1" minimum:"0"`
+	Cooldown       uint64                 `json:"cooldown,omitempty" swaggertype:"integer" format:"uint64" example:"86400" minimum:"0"`
+	Prerequisites  []types.Id             `json:"prerequisites,omitempty" swaggertype:"array,integer" example:"1,2"`
+	StartsAt       *pkgtime.FormattedTime `json:"starts_at,omitempty" swaggertype:"string" example:"01.12.2024 - 00:00:00"`
+	EndsAt         *pkgtime.FormattedTime `json:"ends_at,omitempty" swaggertype:"string" example:"01.01.2025 - 00:00:00"`
+	Probability    *float64               `json:"probability,omitempty" swaggertype:"number" format:"double" example:"0.5" minimum:"0" maximum:"1"`
+	Pity           uint32                 `json:"pity,omitempty" swaggertype:"integer" format:"uint32" example:"3" minimum:"0"`
+	Target         uint32                 `json:"target,omitempty" swaggertype:"integer" format:"uint32" example:"10" minimum:"0"`
+	Milestones     []Milestone            `json:"milestones,omitempty"`
+	RewardSplit    types.RewardSplit      `json:"reward_split,omitempty" swaggertype:"string" enums:"equal,fixed" example:"equal"`
+	Categories     []types.Id             `json:"categories,omitempty" swaggertype:"array,integer" example:"1,2"`
+	Tags           []string               `json:"tags,omitempty" swaggertype:"array,string" example:"daily,social"`
+}
+
+// Milestone is reward paid when streak of user reaches given number of consecutive days.
+type Milestone struct {
+	Days   uint32     `json:"days" swaggertype:"integer" format:"uint32" example:"7" minimum:"1"`
+	Reward types.Cost `json:"reward" swaggertype:"integer" format:"uint8" example:"50" minimum:"0" maximum:"1000"`
+}
+
+func toUsMilestones(milestones []Milestone) []qu.Milestone {
+	if milestones == nil {
+		return nil
+	}
+
+	return slices.Map(milestones, func(m Milestone) qu.Milestone { return qu.Milestone(m) })
+}
+
+// milestonesField validates milestones of streak quest
+func milestonesField() vjson.Field {
+	return vjson.Array("milestones", vjson.Object("milestone", vjson.NewSchema(
+		vjson.Integer("days").Min(1).Required(),
+		vjson.Integer("reward").Range(0, 1000).Required(),
+	)))
 }
 
 func (c *CreateQuest) ToUsQuest() *qu.Quest {
+	maxCompletions := uint32(DefaultMaxCompletions)
+	switch {
+	case c.MaxCompletions != nil:
+		maxCompletions = *c.MaxCompletions
+	case c.Type == types.STREAK:
+		// Streak quests pay reward at every milestone, so their completions are unlimited by default
+		maxCompletions = 0
+	}
+
+	probability := DefaultProbability
+	if c.Probability != nil {
+		probability = *c.Probability
+	}
+
 	return &qu.Quest{
-		Name:        c.Name,
-		Description: c.Description,
-		Cost:        c.Cost,
-		Type:        c.Type,
+		Name:           c.Name,
+		Description:    c.Description,
+		Cost:           c.Cost,
+		Type:           c.Type,
+		Steps:          c.Steps,
+		MaxCompletions: maxCompletions,
+		Cooldown:       time.Duration(c.Cooldown) * time.Second,
+		Prerequisites:  c.Prerequisites,
+		StartsAt:       c.StartsAt,
+		EndsAt:         c.EndsAt,
+		Probability:    probability,
+		Pity:           c.Pity,
+		Target:         c.Target,
+		Milestones:     toUsMilestones(c.Milestones),
+		RewardSplit:    c.RewardSplit,
+		Categories:     c.Categories,
+		Tags:           c.Tags,
 	}
 }
 
@@ -28,22 +101,72 @@ func ValidateCreateQuest(data []byte) error {
 		vjson.String("name").Required(),
 		vjson.String("description").Required(),
 		vjson.Integer("cost").Range(0, 1000).Required(),
-		vjson.String("type").Choices(string(types.USUAL), string(types.RANDOM)).Required(),
+		vjson.String("type").Choices(string(types.USUAL), string(types.RANDOM), string(types.STAGED), string(types.COUNTER),
+			string(types.STREAK), string(types.TEAM)).Required(),
+		vjson.Array("steps", vjson.String("step").MinLength(1)),
+		vjson.Integer("max_completions").Min(0),
+		vjson.Integer("cooldown").Min(0),
+		vjson.Array("prerequisites", vjson.Integer("id").Min(0)),
+		vjson.String("starts_at"),
+		vjson.String("ends_at"),
+		vjson.Float("probability").Range(0, 1),
+		vjson.Integer("pity").Min(0),
+		vjson.Integer("target").Min(0),
+		milestonesField(),
+		vjson.String("reward_split").Choices(string(types.SplitEqual), string(types.SplitFixed)),
+		vjson.Array("categories", vjson.Integer("id").Min(0)),
+		vjson.Array("tags", vjson.String("tag").MinLength(1)),
 	)
 	return schema.ValidateBytes(data)
 }
 
 type UpdateQuest struct {
-	Description *string          `json:"description,omitempty" swaggertype:"string" example:"Random quest"`
-	Cost        *types.Cost      `json:"cost,omitempty" swaggertype:"integer" format:"uint8" example:"9" minimum:"0" maximum:"1000"`
-	Type        *types.QuestType `json:"type,omitempty" swaggertype:"string" enums:"usual,random" example:"random"`
+	Description    *string          `json:"description,omitempty" swaggertype:"string" example:"Random quest"`
+	Cost           *types.Cost      `json:"cost,omitempty" swaggertype:"integer" format:"uint8" example:"9" minimum:"0" maximum:"1000"`
+	Type           *types.QuestType `json:"type,omitempty" swaggertype:"string" enums:"usual,random,staged,counter,streak,team" example:"random"`
+	Steps          []string         `json:"steps,omitempty" swaggertype:"array,string" example:"Open profile,Fill name"`
+	MaxCompletions *uint32          `json:"max_completions,omitempty" swaggertype:"integer" format:"uint32" example:"1" minimum:"0"`
+	Cooldown       *uint64          `json:"cooldown,omitempty" swaggertype:"integer" format:"uint64" example:"86400" minimum:"0"`
+	// Prerequisites replaces quest prerequisites, empty array removes them
+	Prerequisites []types.Id             `json:"prerequisites,omitempty" swaggertype:"array,integer" example:"1,2"`
+	StartsAt      *pkgtime.FormattedTime `json:"starts_at,omitempty" swaggertype:"string" example:"01.12.2024 - 00:00:00"`
+	EndsAt        *pkgtime.FormattedTime `json:"ends_at,omitempty" swaggertype:"string" example:"01.01.2025 - 00:00:00"`
+	Probability   *float64               `json:"probability,omitempty" swaggertype:"number" format:"double" example:"0.5" minimum:"0" maximum:"1"`
+	Pity          *uint32                `json:"pity,omitempty" swaggertype:"integer" format:"uint32" example:"3" minimum:"0"`
+	Target        *uint32                `json:"target,omitempty" swaggertype:"integer" format:"uint32" example:"10" minimum:"0"`
+	// Milestones replaces milestones of streak quest
+	Milestones  []Milestone        `json:"milestones,omitempty"`
+	RewardSplit *types.RewardSplit `json:"reward_split,omitempty" swaggertype:"string" enums:"equal,fixed" example:"equal"`
+	// Categories replaces quest categories, empty array removes them
+	Categories []types.Id `json:"categories,omitempty" swaggertype:"array,integer" example:"1,2"`
+	// Tags replaces quest tags, empty array removes them
+	Tags []string `json:"tags,omitempty" swaggertype:"array,string" example:"daily,social"`
 }
 
 func (u *UpdateQuest) ToUsUpdateQuest() *qu.UpdateQuest {
+	var cooldown *time.Duration
+	if u.Cooldown != nil {
+		duration := time.Duration(*u.Cooldown) * time.Second
+		cooldown = &duration
+	}
+
 	return &qu.UpdateQuest{
-		Description: u.Description,
-		Cost:        u.Cost,
-		Type:        u.Type,
+		Description:    u.Description,
+		Cost:           u.Cost,
+		Type:           u.Type,
+		Steps:          u.Steps,
+		MaxCompletions: u.MaxCompletions,
+		Cooldown:       cooldown,
+		Prerequisites:  u.Prerequisites,
+		StartsAt:       u.StartsAt,
+		EndsAt:         u.EndsAt,
+		Probability:    u.Probability,
+		Pity:           u.Pity,
+		Target:         u.Target,
+		Milestones:     toUsMilestones(u.Milestones),
+		RewardSplit:    u.RewardSplit,
+		Categories:     u.Categories,
+		Tags:           u.Tags,
 	}
 }
 
@@ -52,8 +175,81 @@ func ValidateUpdateQuest(data []byte) error {
 		vjson.String("name"),
 		vjson.String("description"),
 		vjson.Integer("cost").Range(0, 1000),
-		vjson.String("type").Choices(string(types.USUAL), string(types.RANDOM)),
+		vjson.String("type").Choices(string(types.USUAL), string(types.RANDOM), string(types.STAGED), string(types.COUNTER),
+			string(types.STREAK), string(types.TEAM)),
+		vjson.Array("steps", vjson.String("step").MinLength(1)),
+		vjson.Integer("max_completions").Min(0),
+		vjson.Integer("cooldown").Min(0),
+		vjson.Array("prerequisites", vjson.Integer("id").Min(0)),
+		vjson.String("starts_at"),
+		vjson.String("ends_at"),
+		vjson.Float("probability").Range(0, 1),
+		vjson.Integer("pity").Min(0),
+		vjson.Integer("target").Min(0),
+		milestonesField(),
+		vjson.String("reward_split").Choices(string(types.SplitEqual), string(types.SplitFixed)),
+		vjson.Array("categories", vjson.Integer("id").Min(0)),
+		vjson.Array("tags", vjson.String("tag").MinLength(1)),
 	)
 
 	return schema.ValidateBytes(data)
 }
+
+type ListQuests struct {
+	Type       *types.QuestType `form:"type"`
+	MinCost    *types.Cost      `form:"min_cost"`
+	MaxCost    *types.Cost      `form:"max_cost"`
+	NamePrefix string           `form:"name_prefix"`
+	Category   *types.Id        `form:"category"`
+	Tag        string           `form:"tag"`
+	Active     bool             `form:"active"`
+	Archived   bool             `form:"archived"`
+	Sort       string           `form:"sort"`
+	Order      string           `form:"order"`
+	Cursor     string           `form:"cursor"`
+	Limit      uint64           `form:"limit"`
+}
+
+func (lq *ListQuests) Validate() error {
+	if lq.Type != nil {
+		switch *lq.Type {
+		case types.USUAL, types.RANDOM, types.STAGED, types.COUNTER, types.STREAK, types.TEAM:
+		default:
+			return errors.Errorf("unknown quest type %q", *lq.Type)
+		}
+	}
+
+	switch types.QuestsSort(lq.Sort) {
+	case "", types.QuestsSortID, types.QuestsSortName, types.QuestsSortCost:
+	default:
+		return errors.Errorf("unknown sort %q", lq.Sort)
+	}
+
+	if lq.MinCost != nil && lq.MaxCost != nil && *lq.MinCost > *lq.MaxCost {
+		return errors.New("min_cost is greater than max_cost")
+	}
+
+	return validateList(lq.Order, lq.Limit)
+}
+
+func (lq *ListQuests) ToUsQuestsQuery() *qu.QuestsQuery {
+	sort := types.QuestsSort(lq.Sort)
+	if sort == "" {
+		sort = types.QuestsSortID
+	}
+
+	return &qu.QuestsQuery{
+		Type:       lq.Type,
+		MinCost:    lq.MinCost,
+		MaxCost:    lq.MaxCost,
+		NamePrefix: lq.NamePrefix,
+		CategoryId: lq.Category,
+		Tag:        lq.Tag,
+		Active:     lq.Active,
+		Archived:   lq.Archived,
+		Sort:       sort,
+		Order:      listOrder(lq.Order),
+		Cursor:     lq.Cursor,
+		Limit:      lq.Limit,
+	}
+}
@@ -0,0 +1,17 @@
+package request
+
+import (
+	"github.com/miladibra10/vjson"
+	"vk_quests/internal/pkg/evjson"
+)
+
+type CreateTeam struct {
+	Name string `json:"name" swaggertype:"string" example:"Wolves"`
+}
+
+func ValidateCreateTeam(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.String("name").MinLength(1).Required(),
+	)
+	return schema.ValidateBytes(data)
+}
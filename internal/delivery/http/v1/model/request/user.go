@@ -1,8 +1,14 @@
 package request
 
 import (
+	stdtime "time"
+
 	"github.com/miladibra10/vjson"
+	"github.com/pkg/errors"
 	"vk_quests/internal/pkg/evjson"
+	"vk_quests/internal/pkg/page"
+	"vk_quests/internal/pkg/types"
+	uu "vk_quests/internal/usecase/user"
 )
 
 type User struct {
@@ -15,3 +21,178 @@ func ValidateUser(data []byte) error {
 	)
 	return schema.ValidateBytes(data)
 }
+
+type CreateUser struct {
+	Name         string `json:"name" swaggertype:"string" example:"User"`
+	ReferralCode string `json:"referral_code,omitempty" swaggertype:"string" example:"3f9a1c07be"`
+}
+
+func ValidateCreateUser(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.String("name").Required(),
+		vjson.String("referral_code").MinLength(1),
+	)
+	return schema.ValidateBytes(data)
+}
+
+type Debit struct {
+	Amount uint64 `json:"amount" swaggertype:"integer" format:"uint64" example:"15" minimum:"1"`
+	Reason string `json:"reason" swaggertype:"string" example:"shop purchase"`
+}
+
+func ValidateDebit(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.Integer("amount").Min(1).Required(),
+		vjson.String("reason").MinLength(1).Required(),
+	)
+	return schema.ValidateBytes(data)
+}
+
+type RevokeQuest struct {
+	Reason string `json:"reason" swaggertype:"string" example:"fraudulent completion"`
+}
+
+func ValidateRevokeQuest(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.String("reason").MinLength(1).Required(),
+	)
+	return schema.ValidateBytes(data)
+}
+
+type Transfer struct {
+	RecipientId types.Id `json:"recipient_id" swaggertype:"integer" format:"uint64" example:"7"`
+	Amount      uint64   `json:"amount" swaggertype:"integer" format:"uint64" example:"15" minimum:"1"`
+}
+
+func ValidateTransfer(data []byte) error {
+	schema := evjson.NewSchema(
+		vjson.Integer("recipient_id").Min(0).Required(),
+		vjson.Integer("amount").Min(1).Required(),
+	)
+	return schema.ValidateBytes(data)
+}
+
+type ListUsers struct {
+	NamePrefix string `form:"name_prefix"`
+	MinBalance *int64 `form:"min_balance"`
+	MaxBalance *int64 `form:"max_balance"`
+	Sort       string `form:"sort"`
+	Order      string `form:"order"`
+	Cursor     string `form:"cursor"`
+	Limit      uint64 `form:"limit"`
+}
+
+func (lu *ListUsers) Validate() error {
+	switch types.UsersSort(lu.Sort) {
+	case "", types.UsersSortID, types.UsersSortName, types.UsersSortBalance:
+	default:
+		return errors.Errorf("unknown sort %q", lu.Sort)
+	}
+
+	if lu.MinBalance != nil && lu.MaxBalance != nil && *lu.MinBalance > *lu.MaxBalance {
+		return errors.New("min_balance is greater than max_balance")
+	}
+
+	return validateList(lu.Order, lu.Limit)
+}
+
+func (lu *ListUsers) ToUsUsersQuery() *uu.UsersQuery {
+	sort := types.UsersSort(lu.Sort)
+	if sort == "" {
+		sort = types.UsersSortID
+	}
+
+	return &uu.UsersQuery{
+		NamePrefix: lu.NamePrefix,
+		MinBalance: lu.MinBalance,
+		MaxBalance: lu.MaxBalance,
+		Sort:       sort,
+		Order:      listOrder(lu.Order),
+		Cursor:     lu.Cursor,
+		Limit:      lu.Limit,
+	}
+}
+
+type ListAvailableQuests struct {
+	Cursor string `form:"cursor"`
+	Limit  uint64 `form:"limit"`
+}
+
+func (la *ListAvailableQuests) Validate() error {
+	return validateList("", la.Limit)
+}
+
+func (la *ListAvailableQuests) ToUsAvailableQuestsQuery() *uu.AvailableQuestsQuery {
+	return &uu.AvailableQuestsQuery{
+		Cursor: la.Cursor,
+		Limit:  la.Limit,
+	}
+}
+
+type ListHistory struct {
+	From      *stdtime.Time    `form:"from" time_format:"02.01.2006 - 15:04:05" time_utc:"1"`
+	To        *stdtime.Time    `form:"to" time_format:"02.01.2006 - 15:04:05" time_utc:"1"`
+	QuestType *types.QuestType `form:"quest_type"`
+	Order     string           `form:"order"`
+	Cursor    string           `form:"cursor"`
+	Limit     uint64           `form:"limit"`
+}
+
+func (lh *ListHistory) Validate() error {
+	if lh.QuestType != nil {
+		switch *lh.QuestType {
+		case types.USUAL, types.RANDOM, types.STAGED, types.COUNTER, types.STREAK, types.TEAM:
+		default:
+			return errors.Errorf("unknown quest type %q", *lh.QuestType)
+		}
+	}
+
+	if lh.From != nil && lh.To != nil && lh.From.After(*lh.To) {
+		return errors.New("from is after to")
+	}
+
+	return validateList(lh.Order, lh.Limit)
+}
+
+// ToUsHistoryQuery returns query of history, the latest records go first by default.
+func (lh *ListHistory) ToUsHistoryQuery() *uu.HistoryQuery {
+	order := page.Desc
+	if lh.Order != "" {
+		order = page.Order(lh.Order)
+	}
+
+	return &uu.HistoryQuery{
+		From:      lh.From,
+		To:        lh.To,
+		QuestType: lh.QuestType,
+		Order:     order,
+		Cursor:    lh.Cursor,
+		Limit:     lh.Limit,
+	}
+}
+
+type ListAttempts struct {
+	QuestId *types.Id `form:"quest_id"`
+	Order   string    `form:"order"`
+	Cursor  string    `form:"cursor"`
+	Limit   uint64    `form:"limit"`
+}
+
+func (la *ListAttempts) Validate() error {
+	return validateList(la.Order, la.Limit)
+}
+
+// ToUsAttemptsQuery returns query of attempts, the latest attempts go first by default.
+func (la *ListAttempts) ToUsAttemptsQuery() *uu.AttemptsQuery {
+	order := page.Desc
+	if la.Order != "" {
+		order = page.Order(la.Order)
+	}
+
+	return &uu.AttemptsQuery{
+		QuestId: la.QuestId,
+		Order:   order,
+		Cursor:  la.Cursor,
+		Limit:   la.Limit,
+	}
+}
@@ -0,0 +1,42 @@
+package response
+
+import (
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	bu "vk_quests/internal/usecase/boost"
+	"vk_quests/pkg/slices"
+)
+
+type Boost struct {
+	ID         types.Id           `json:"id" swaggertype:"integer" format:"uint64" example:"3"`
+	Name       string             `json:"name" swaggertype:"string" example:"Double points weekend"`
+	Multiplier float64            `json:"multiplier" swaggertype:"number" format:"double" example:"2"`
+	StartsAt   time.FormattedTime `json:"starts_at" swaggertype:"string" example:"07.12.2024 - 00:00:00"`
+	EndsAt     time.FormattedTime `json:"ends_at" swaggertype:"string" example:"09.12.2024 - 00:00:00"`
+	QuestId    *types.Id          `json:"quest_id,omitempty" swaggertype:"integer" format:"uint64" example:"1"`
+	CategoryId *types.Id          `json:"category_id,omitempty" swaggertype:"integer" format:"uint64" example:"2"`
+	QuestType  *types.QuestType   `json:"quest_type,omitempty" swaggertype:"string" enums:"usual,random,staged,counter,streak,team" example:"usual"`
+}
+
+func FromUsBoosts(boosts []bu.Boost) []Boost {
+	return slices.Map(boosts, func(boost bu.Boost) Boost {
+		return *FromUsBoost(&boost)
+	})
+}
+
+func FromUsBoost(boost *bu.Boost) *Boost {
+	if boost == nil {
+		return nil
+	}
+
+	return &Boost{
+		ID:         boost.ID,
+		Name:       boost.Name,
+		Multiplier: boost.Multiplier,
+		StartsAt:   boost.StartsAt,
+		EndsAt:     boost.EndsAt,
+		QuestId:    boost.QuestId,
+		CategoryId: boost.CategoryId,
+		QuestType:  boost.QuestType,
+	}
+}
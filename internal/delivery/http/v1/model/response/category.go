@@ -0,0 +1,31 @@
+package response
+
+import (
+	"vk_quests/internal/pkg/types"
+	cu "vk_quests/internal/usecase/category"
+	"vk_quests/pkg/slices"
+)
+
+type Category struct {
+	ID          types.Id `json:"id" swaggertype:"integer" format:"uint64" example:"3"`
+	Name        string   `json:"name" swaggertype:"string" example:"Daily"`
+	Description string   `json:"description" swaggertype:"string" example:"Quests renewed every day"`
+}
+
+func FromUsCategories(categories []cu.Category) []Category {
+	return slices.Map(categories, func(category cu.Category) Category {
+		return *FromUsCategory(&category)
+	})
+}
+
+func FromUsCategory(category *cu.Category) *Category {
+	if category == nil {
+		return nil
+	}
+
+	return &Category{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+	}
+}
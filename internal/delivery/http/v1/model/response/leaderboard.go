@@ -0,0 +1,39 @@
+package response
+
+import (
+	"vk_quests/internal/pkg/types"
+	lbu "vk_quests/internal/usecase/leaderboard"
+	"vk_quests/pkg/slices"
+)
+
+type LeaderboardUser struct {
+	ID   types.Id `json:"id" swaggertype:"integer" format:"uint64" example:"5"`
+	Name string   `json:"name" swaggertype:"string" example:"User"`
+}
+
+type LeaderboardEntry struct {
+	Rank  uint64          `json:"rank" swaggertype:"integer" format:"uint64" example:"1"`
+	User  LeaderboardUser `json:"user"`
+	Score int64           `json:"score" swaggertype:"integer" format:"int64" example:"120"`
+}
+
+func FromUsEntries(entries []lbu.Entry) []LeaderboardEntry {
+	return slices.Map(entries, func(entry lbu.Entry) LeaderboardEntry {
+		return *FromUsEntry(&entry)
+	})
+}
+
+func FromUsEntry(entry *lbu.Entry) *LeaderboardEntry {
+	if entry == nil {
+		return nil
+	}
+
+	return &LeaderboardEntry{
+		Rank: entry.Rank,
+		User: LeaderboardUser{
+			ID:   entry.UserId,
+			Name: entry.Name,
+		},
+		Score: entry.Score,
+	}
+}
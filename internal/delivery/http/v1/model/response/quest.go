@@ -1,17 +1,60 @@
 package response
 
 import (
+	"time"
+
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
 	qu "vk_quests/internal/usecase/quest"
 	"vk_quests/pkg/slices"
 )
 
 type Quest struct {
-	ID          types.Id        `json:"id" swaggertype:"integer" format:"uint64" example:"5"`
-	Name        string          `json:"name" swaggertype:"string" example:"Task"`
-	Description string          `json:"description" swaggertype:"string" example:"Random quest"`
-	Cost        types.Cost      `json:"cost" swaggertype:"integer" format:"uint8" example:"9" minimum:"0" maximum:"1000"`
-	Type        types.QuestType `json:"type" swaggertype:"string" enums:"usual,random" example:"random"`
+	ID             types.Id               `json:"id" swaggertype:"integer" format:"uint64" example:"5"`
+	Name           string                 `json:"name" swaggertype:"string" example:"Task"`
+	Description    string                 `json:"description" swaggertype:"string" example:"Random quest"`
+	Cost           types.Cost             `json:"cost" swaggertype:"integer" format:"uint8" example:"9" minimum:"0" maximum:"1000"`
+	Type           types.QuestType        `json:"type" swaggertype:"string" enums:"usual,random,staged,counter,streak,team" example:"random"`
+	Steps          []string               `json:"steps,omitempty" swaggertype:"array,string" example:"Open profile,Fill name"`
+	MaxCompletions uint32                 `json:"max_completions" swaggertype:"integer" format:"uint32" example:"1"`
+	Cooldown       uint64                 `json:"cooldown" swaggertype:"integer" format:"uint64" example:"86400"`
+	Prerequisites  []types.Id             `json:"prerequisites,omitempty" swaggertype:"array,integer" example:"1,2"`
+	StartsAt       *pkgtime.FormattedTime `json:"starts_at,omitempty" swaggertype:"string" example:"01.12.2024 - 00:00:00"`
+	EndsAt         *pkgtime.FormattedTime `json:"ends_at,omitempty" swaggertype:"string" example:"01.01.2025 - 00:00:00"`
+	Probability    float64                `json:"probability" swaggertype:"number" format:"double" example:"0.5"`
+	Pity           uint32                 `json:"pity" swaggertype:"integer" format:"uint32" example:"3"`
+	Target         uint32                 `json:"target" swaggertype:"integer" format:"uint32" example:"10"`
+	Milestones     []Milestone            `json:"milestones,omitempty"`
+	RewardSplit    types.RewardSplit      `json:"reward_split,omitempty" swaggertype:"string" enums:"equal,fixed" example:"equal"`
+	ArchivedAt     *pkgtime.FormattedTime `json:"archived_at,omitempty" swaggertype:"string" example:"01.02.2025 - 00:00:00"`
+	Categories     []types.Id             `json:"categories,omitempty" swaggertype:"array,integer" example:"1,2"`
+	Tags           []string               `json:"tags,omitempty" swaggertype:"array,string" example:"daily,social"`
+}
+
+// Milestone is reward paid when streak of user reaches given number of consecutive days.
+type Milestone struct {
+	Days   uint32     `json:"days" swaggertype:"integer" format:"uint32" example:"7"`
+	Reward types.Cost `json:"reward" swaggertype:"integer" format:"uint8" example:"50"`
+}
+
+func fromUsMilestones(milestones []qu.Milestone) []Milestone {
+	if milestones == nil {
+		return nil
+	}
+
+	return slices.Map(milestones, func(m qu.Milestone) Milestone { return Milestone(m) })
+}
+
+type QuestsPage struct {
+	Quests     []Quest `json:"quests"`
+	NextCursor string  `json:"next_cursor,omitempty" swaggertype:"string" example:"eyJzIjoiaWQiLCJvIjoiYXNjIiwidiI6IiIsImkiOjV9"`
+}
+
+func FromUsQuestsPage(questsPage *qu.QuestsPage) *QuestsPage {
+	return &QuestsPage{
+		Quests:     FromUsQuests(questsPage.Quests),
+		NextCursor: questsPage.NextCursor,
+	}
 }
 
 func FromUsQuests(quests []qu.Quest) []Quest {
@@ -26,10 +69,24 @@ func FromUsQuest(quest *qu.Quest) *Quest {
 	}
 
 	return &Quest{
-		ID:          quest.ID,
-		Name:        quest.Name,
-		Description: quest.Description,
-		Cost:        quest.Cost,
-		Type:        quest.Type,
+		ID:             quest.ID,
+		Name:           quest.Name,
+		Description:    quest.Description,
+		Cost:           quest.Cost,
+		Type:           quest.Type,
+		Steps:          quest.Steps,
+		MaxCompletions: quest.MaxCompletions,
+		Cooldown:       uint64(quest.Cooldown / time.Second),
+		Prerequisites:  quest.Prerequisites,
+		StartsAt:       quest.StartsAt,
+		EndsAt:         quest.EndsAt,
+		Probability:    quest.Probability,
+		Pity:           quest.Pity,
+		Target:         quest.Target,
+		Milestones:     fromUsMilestones(quest.Milestones),
+		RewardSplit:    quest.RewardSplit,
+		ArchivedAt:     quest.ArchivedAt,
+		Categories:     quest.Categories,
+		Tags:           quest.Tags,
 	}
 }
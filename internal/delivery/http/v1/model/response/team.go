@@ -0,0 +1,56 @@
+package response
+
+import (
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	tu "vk_quests/internal/usecase/team"
+	"vk_quests/pkg/slices"
+)
+
+type Team struct {
+	ID      types.Id           `json:"id" swaggertype:"integer" format:"uint64" example:"2"`
+	Name    string             `json:"name" swaggertype:"string" example:"Wolves"`
+	Created time.FormattedTime `json:"created" swaggertype:"string" example:"01.12.2024 - 00:00:00"`
+	Members []TeamMember       `json:"members,omitempty"`
+}
+
+type TeamMember struct {
+	UserId types.Id           `json:"user_id" swaggertype:"integer" format:"uint64" example:"5"`
+	Name   string             `json:"name" swaggertype:"string" example:"Ivan"`
+	Joined time.FormattedTime `json:"joined" swaggertype:"string" example:"01.12.2024 - 00:00:00"`
+}
+
+func FromUsTeams(teams []tu.Team) []Team {
+	return slices.Map(teams, func(team tu.Team) Team {
+		return *FromUsTeam(&team)
+	})
+}
+
+func FromUsTeam(team *tu.Team) *Team {
+	if team == nil {
+		return nil
+	}
+
+	res := &Team{
+		ID:      team.ID,
+		Name:    team.Name,
+		Created: team.Created,
+	}
+	if team.Members != nil {
+		res.Members = slices.Map(team.Members, func(member tu.Member) TeamMember { return *FromUsTeamMember(&member) })
+	}
+
+	return res
+}
+
+func FromUsTeamMember(member *tu.Member) *TeamMember {
+	if member == nil {
+		return nil
+	}
+
+	return &TeamMember{
+		UserId: member.UserId,
+		Name:   member.Name,
+		Joined: member.Joined,
+	}
+}
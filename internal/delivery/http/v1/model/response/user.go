@@ -8,9 +8,11 @@ import (
 )
 
 type User struct {
-	ID      types.Id `json:"id" swaggertype:"integer" format:"uint64" example:"5"`
-	Name    string   `json:"name" swaggertype:"string" example:"User"`
-	Balance uint64   `json:"balance" swaggertype:"integer" format:"uint64"  example:"25"`
+	ID           types.Id  `json:"id" swaggertype:"integer" format:"uint64" example:"5"`
+	Name         string    `json:"name" swaggertype:"string" example:"User"`
+	Balance      int64     `json:"balance" swaggertype:"integer" format:"int64"  example:"25"`
+	ReferralCode string    `json:"referral_code,omitempty" swaggertype:"string" example:"3f9a1c07be"`
+	ReferrerId   *types.Id `json:"referrer_id,omitempty" swaggertype:"integer" format:"uint64" example:"2"`
 }
 
 func FromUsUsers(users []uu.User) []User {
@@ -21,23 +23,114 @@ func FromUsUsers(users []uu.User) []User {
 
 func FromUsUser(user *uu.User) *User {
 	return &User{
-		ID:      user.ID,
-		Name:    user.Name,
-		Balance: user.Balance,
+		ID:           user.ID,
+		Name:         user.Name,
+		Balance:      user.Balance,
+		ReferralCode: user.ReferralCode,
+		ReferrerId:   user.ReferrerId,
 	}
 }
 
+// UserExport is bundle of all data stored about user.
+type UserExport struct {
+	User      User                `json:"user"`
+	DeletedAt *time.FormattedTime `json:"deleted_at,omitempty" swaggertype:"string" example:"01.02.2025 - 00:00:00"`
+	History   []HistoryRecord     `json:"history"`
+	Attempts  []Attempt           `json:"attempts"`
+}
+
+func FromUsExport(export *uu.Export) *UserExport {
+	return &UserExport{
+		User:      *FromUsUser(&export.User),
+		DeletedAt: export.DeletedAt,
+		History:   FromUsHistory(export.History),
+		Attempts:  FromUsAttempts(export.Attempts),
+	}
+}
+
+type TypeStats struct {
+	Completed uint64 `json:"completed" swaggertype:"integer" format:"uint64" example:"3"`
+	Earned    uint64 `json:"earned" swaggertype:"integer" format:"uint64" example:"27"`
+}
+
+type UserStats struct {
+	User
+	Completed      uint64               `json:"completed" swaggertype:"integer" format:"uint64" example:"3"`
+	Earned         uint64               `json:"earned" swaggertype:"integer" format:"uint64" example:"27"`
+	LastCompletion *time.FormattedTime  `json:"last_completion,omitempty" swaggertype:"string" example:"15.03.2024 - 10:21:00"`
+	ByType         map[string]TypeStats `json:"by_type"`
+}
+
+func FromUsUserStats(stats *uu.UserStats) *UserStats {
+	byType := make(map[string]TypeStats, len(stats.ByType))
+	for tp, typeStats := range stats.ByType {
+		byType[string(tp)] = TypeStats{
+			Completed: typeStats.Completed,
+			Earned:    typeStats.Earned,
+		}
+	}
+
+	return &UserStats{
+		User:           *FromUsUser(&stats.User),
+		Completed:      stats.Completed,
+		Earned:         stats.Earned,
+		LastCompletion: stats.LastCompletion,
+		ByType:         byType,
+	}
+}
+
+type UsersPage struct {
+	Users      []User `json:"users"`
+	NextCursor string `json:"next_cursor,omitempty" swaggertype:"string" example:"eyJzIjoiaWQiLCJvIjoiYXNjIiwidiI6IiIsImkiOjV9"`
+}
+
+func FromUsUsersPage(usersPage *uu.UsersPage) *UsersPage {
+	return &UsersPage{
+		Users:      FromUsUsers(usersPage.Users),
+		NextCursor: usersPage.NextCursor,
+	}
+}
+
+type QuestSnapshot struct {
+	Name        string          `json:"name" swaggertype:"string" example:"Task"`
+	Description string          `json:"description" swaggertype:"string" example:"Random quest"`
+	Type        types.QuestType `json:"type" swaggertype:"string" enums:"usual,random,staged,counter,streak,team" example:"random"`
+}
+
 type HistoryRecord struct {
-	Quest   *Quest             `json:"quest,omitempty"`
-	Created time.FormattedTime `json:"created" swaggertype:"integer" format:"uint64" example:"5"`
-	Balance uint64             `json:"balance" swaggertype:"integer" format:"uint64" example:"5"`
+	Snapshot       *QuestSnapshot      `json:"completed_quest,omitempty"`
+	Award          types.Cost          `json:"award" swaggertype:"integer" format:"uint32" example:"18"`
+	BaseAward      types.Cost          `json:"base_award" swaggertype:"integer" format:"uint32" example:"9"`
+	Multiplier     float64             `json:"multiplier" swaggertype:"number" format:"double" example:"2"`
+	Source         types.HistorySource `json:"source" swaggertype:"string" enums:"quest,referral,transfer_in,transfer_out,revoke" example:"quest"`
+	CounterpartyId *types.Id           `json:"counterparty_id,omitempty" swaggertype:"integer" format:"uint64" example:"2"`
+	Reason         string              `json:"reason,omitempty" swaggertype:"string" example:"fraudulent completion"`
+	Quest          *Quest              `json:"quest,omitempty"`
+	Created        time.FormattedTime  `json:"created" swaggertype:"integer" format:"uint64" example:"5"`
+	Balance        int64               `json:"balance" swaggertype:"integer" format:"int64" example:"5"`
 }
 
 func FromUsHistoryRecord(record *uu.HistoryRecord) *HistoryRecord {
+	var snapshot *QuestSnapshot
+	if record.Snapshot != nil {
+		snapshot = &QuestSnapshot{
+			Name:        record.Snapshot.Name,
+			Description: record.Snapshot.Description,
+			Type:        record.Snapshot.Type,
+		}
+	}
+
 	return &HistoryRecord{
-		Quest:   FromUsQuest(record.Quest),
-		Created: record.Created,
-		Balance: record.Balance,
+		Snapshot:       snapshot,
+		Award:          record.Award,
+		BaseAward:      record.BaseAward,
+		Multiplier:     record.Multiplier,
+		Source:         record.Source,
+		CounterpartyId: record.CounterpartyId,
+		Reason:         record.Reason,
+		Quest:          FromUsQuest(record.Quest),
+		Created:        record.Created,
+		Balance:        record.Balance,
 	}
 }
 
@@ -47,13 +140,168 @@ func FromUsHistory(history []uu.HistoryRecord) []HistoryRecord {
 	})
 }
 
+type HistoryPage struct {
+	History    []HistoryRecord `json:"history"`
+	NextCursor string          `json:"next_cursor,omitempty" swaggertype:"string" example:"eyJzIjoiY3JlYXRlZCIsIm8iOiJkZXNjIiwidiI6IiIsImkiOjV9"`
+}
+
+func FromUsHistoryPage(historyPage *uu.HistoryPage) *HistoryPage {
+	return &HistoryPage{
+		History:    FromUsHistory(historyPage.Records),
+		NextCursor: historyPage.NextCursor,
+	}
+}
+
+type Attempt struct {
+	ID      types.Id           `json:"id" swaggertype:"integer" format:"uint64" example:"12"`
+	QuestId *types.Id          `json:"quest_id,omitempty" swaggertype:"integer" format:"uint64" example:"3"`
+	Success bool               `json:"success" swaggertype:"boolean" example:"false"`
+	Roll    *float64           `json:"roll,omitempty" swaggertype:"number" format:"double" example:"0.73"`
+	Created time.FormattedTime `json:"created" swaggertype:"string" example:"15.03.2024 - 10:21:00"`
+}
+
+func FromUsAttempt(attempt *uu.AttemptRecord) *Attempt {
+	return &Attempt{
+		ID:      attempt.ID,
+		QuestId: attempt.QuestId,
+		Success: attempt.Success,
+		Roll:    attempt.Roll,
+		Created: attempt.Created,
+	}
+}
+
+func FromUsAttempts(attempts []uu.AttemptRecord) []Attempt {
+	return slices.Map(attempts, func(attempt uu.AttemptRecord) Attempt {
+		return *FromUsAttempt(&attempt)
+	})
+}
+
+type AttemptsPage struct {
+	Attempts   []Attempt `json:"attempts"`
+	NextCursor string    `json:"next_cursor,omitempty" swaggertype:"string" example:"eyJzIjoiaWQiLCJvIjoiZGVzYyIsInYiOiIiLCJpIjoxMn0"`
+}
+
+func FromUsAttemptsPage(attemptsPage *uu.AttemptsPage) *AttemptsPage {
+	return &AttemptsPage{
+		Attempts:   FromUsAttempts(attemptsPage.Attempts),
+		NextCursor: attemptsPage.NextCursor,
+	}
+}
+
 type Status string
 
 const (
-	Success Status = "success"
-	Failure Status = "failure"
+	Success    Status = "success"
+	Failure    Status = "failure"
+	InProgress Status = "in_progress"
 )
 
 type StatusApplyCost struct {
-	Status Status `json:"status" swaggertype:"string"  enums:"success,failure" example:"success"`
+	Status Status `json:"status" swaggertype:"string"  enums:"success,failure,in_progress" example:"success"`
+	// Progress is set for staged, counter and streak quests
+	Progress *QuestProgress `json:"progress,omitempty"`
+}
+
+type QuestProgress struct {
+	Step  uint32 `json:"step" swaggertype:"integer" format:"uint32" example:"4"`
+	Total uint32 `json:"total" swaggertype:"integer" format:"uint32" example:"10"`
+}
+
+func FromUsQuestProgress(progress *uu.QuestProgress) *QuestProgress {
+	if progress == nil {
+		return nil
+	}
+
+	return &QuestProgress{
+		Step:  progress.Step,
+		Total: progress.Total,
+	}
+}
+
+type Progress struct {
+	Quest   *Quest             `json:"quest"`
+	Step    uint32             `json:"step" swaggertype:"integer" format:"uint32" example:"1"`
+	Total   uint32             `json:"total" swaggertype:"integer" format:"uint32" example:"3"`
+	Updated time.FormattedTime `json:"updated" swaggertype:"string" example:"15.04.2024 - 12:30:00"`
+}
+
+func FromUsProgressRecord(record *uu.Progress) *Progress {
+	return &Progress{
+		Quest:   FromUsQuest(record.Quest),
+		Step:    record.Step,
+		Total:   record.Quest.Total(),
+		Updated: record.Updated,
+	}
+}
+
+func FromUsProgress(progress []uu.Progress) []Progress {
+	return slices.Map(progress, func(record uu.Progress) Progress {
+		return *FromUsProgressRecord(&record)
+	})
+}
+
+type Transaction struct {
+	ID             types.Id           `json:"id" swaggertype:"integer" format:"uint64" example:"7"`
+	Kind           string             `json:"kind" swaggertype:"string" enums:"credit,debit" example:"debit"`
+	Amount         uint64             `json:"amount" swaggertype:"integer" format:"uint64" example:"15"`
+	Reason         string             `json:"reason" swaggertype:"string" example:"shop purchase"`
+	QuestId        *types.Id          `json:"quest_id,omitempty" swaggertype:"integer" format:"uint64" example:"3"`
+	CounterpartyId *types.Id          `json:"counterparty_id,omitempty" swaggertype:"integer" format:"uint64" example:"8"`
+	Balance        int64              `json:"balance" swaggertype:"integer" format:"int64" example:"10"`
+	Created        time.FormattedTime `json:"created" swaggertype:"string" example:"15.03.2024 - 10:21:00"`
+}
+
+func FromUsTransaction(transaction *uu.Transaction) *Transaction {
+	return &Transaction{
+		ID:             transaction.ID,
+		Kind:           transaction.Kind,
+		Amount:         transaction.Amount,
+		Reason:         transaction.Reason,
+		QuestId:        transaction.QuestId,
+		CounterpartyId: transaction.CounterpartyId,
+		Balance:        transaction.Balance,
+		Created:        transaction.Created,
+	}
+}
+
+type Revocation struct {
+	ID          types.Id           `json:"id" swaggertype:"integer" format:"uint64" example:"4"`
+	QuestId     types.Id           `json:"quest_id" swaggertype:"integer" format:"uint64" example:"3"`
+	Snapshot    QuestSnapshot      `json:"revoked_quest"`
+	Award       types.Cost         `json:"award" swaggertype:"integer" format:"uint32" example:"15"`
+	Completed   time.FormattedTime `json:"completed" swaggertype:"string" example:"14.03.2024 - 18:40:00"`
+	Reason      string             `json:"reason" swaggertype:"string" example:"fraudulent completion"`
+	Transaction Transaction        `json:"transaction"`
+	Created     time.FormattedTime `json:"created" swaggertype:"string" example:"15.03.2024 - 10:21:00"`
+}
+
+func FromUsRevocation(revocation *uu.Revocation) *Revocation {
+	return &Revocation{
+		ID:      revocation.ID,
+		QuestId: revocation.QuestId,
+		Snapshot: QuestSnapshot{
+			Name:        revocation.Snapshot.Name,
+			Description: revocation.Snapshot.Description,
+			Type:        revocation.Snapshot.Type,
+		},
+		Award:       revocation.Award,
+		Completed:   revocation.Completed,
+		Reason:      revocation.Reason,
+		Transaction: *FromUsTransaction(&revocation.Transaction),
+		Created:     revocation.Created,
+	}
+}
+
+type Reconciliation struct {
+	UserId        types.Id `json:"user_id" swaggertype:"integer" format:"uint64" example:"5"`
+	CachedBalance int64    `json:"cached_balance" swaggertype:"integer" format:"int64" example:"30"`
+	LedgerBalance int64    `json:"ledger_balance" swaggertype:"integer" format:"int64" example:"25"`
+}
+
+func FromUsReconciliation(reconciliation *uu.Reconciliation) *Reconciliation {
+	return &Reconciliation{
+		UserId:        reconciliation.UserId,
+		CachedBalance: reconciliation.Cached,
+		LedgerBalance: reconciliation.Ledger,
+	}
 }
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 
@@ -20,25 +21,29 @@ type Route struct {
 
 type Routes []Route
 
-func NewRouter(root string, l logger.Interface, routes Routes) (*gin.Engine, error) {
+func NewRouter(root string, l logger.Interface, routes Routes, middlewares ...gin.HandlerFunc) (*gin.Engine, error) {
 	router := gin.New()
 
 	router.Use(middleware.CheckPanic, middleware.RequestLogger(l))
+	router.Use(middlewares...)
 	rt := router.Group(root)
 	v1 := rt.Group(version)
 
 	for _, route := range routes {
+		// Middlewares of route are called only for this route before its handler
+		handlers := append(slices.Clone(route.Middlewares), route.HandlerFunc)
+
 		switch route.Method {
 		case http.MethodGet:
-			v1.GET(route.Pattern, route.HandlerFunc).Use(route.Middlewares...)
+			v1.GET(route.Pattern, handlers...)
 		case http.MethodPost:
-			v1.POST(route.Pattern, route.HandlerFunc).Use(route.Middlewares...)
+			v1.POST(route.Pattern, handlers...)
 		case http.MethodPut:
-			v1.PUT(route.Pattern, route.HandlerFunc).Use(route.Middlewares...)
+			v1.PUT(route.Pattern, handlers...)
 		case http.MethodDelete:
-			v1.DELETE(route.Pattern, route.HandlerFunc).Use(route.Middlewares...)
+			v1.DELETE(route.Pattern, handlers...)
 		case http.MethodOptions:
-			v1.OPTIONS(route.Pattern, route.HandlerFunc).Use(route.Middlewares...)
+			v1.OPTIONS(route.Pattern, handlers...)
 		}
 	}
 
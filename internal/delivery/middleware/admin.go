@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"vk_quests/pkg/operate"
+)
+
+const AdminTokenHeader = "X-Admin-Token"
+
+var ErrorAdminOnly = errors.New("operation is allowed only to admins")
+
+// AdminOnly lets request through only if its admin token header equals token.
+// Empty token forbids all requests.
+func AdminOnly(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		given := c.GetHeader(AdminTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			operate.SendError(c, ErrorAdminOnly, http.StatusForbidden, GetLogger(c))
+			return
+		}
+
+		// Process request
+		c.Next()
+	}
+}
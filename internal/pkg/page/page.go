@@ -0,0 +1,77 @@
+package page
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 1000
+)
+
+// NormalizeLimit returns DefaultLimit for zero limit and MaxLimit for bigger limits.
+func NormalizeLimit(limit uint64) uint64 {
+	switch {
+	case limit == 0:
+		return DefaultLimit
+	case limit > MaxLimit:
+		return MaxLimit
+	default:
+		return limit
+	}
+}
+
+var ErrorInvalidCursor = errors.New("invalid cursor")
+
+type Order string
+
+const (
+	Asc  Order = "asc"
+	Desc Order = "desc"
+)
+
+// Cursor points to the last element of previous page. It is bound to sort field and order,
+// which were used to get the page.
+type Cursor struct {
+	Sort  string   `json:"s"`
+	Order Order    `json:"o"`
+	Value string   `json:"v"` // value of sort field of the last element
+	ID    types.Id `json:"i"`
+}
+
+// Encode returns opaque representation of cursor for clients.
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses cursor returned by Encode and checks that it was made for the same sort field and order.
+func DecodeCursor(cursor string, sort string, order Order) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Wrap(ErrorInvalidCursor, err.Error())
+	}
+
+	res := &Cursor{}
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, errors.Wrap(ErrorInvalidCursor, err.Error())
+	}
+
+	if res.Sort != sort || res.Order != order {
+		return nil, errors.Wrapf(ErrorInvalidCursor, "cursor is made for sort %s %s", res.Sort, res.Order)
+	}
+
+	return res, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// LikePrefix returns LIKE pattern matching strings with given prefix.
+func LikePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
@@ -0,0 +1,68 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Query builds list query with filters and keyset pagination.
+type Query struct {
+	// IDColumn is unique column used to order rows with equal sort values, "id" if empty.
+	IDColumn string
+
+	conditions []string
+	args       []any
+}
+
+// Where adds condition, %s in condition is replaced with placeholder of arg.
+func (q *Query) Where(condition string, arg any) {
+	q.args = append(q.args, arg)
+	q.conditions = append(q.conditions, fmt.Sprintf(condition, fmt.Sprintf("$%d", len(q.args))))
+}
+
+// Filter adds condition without args.
+func (q *Query) Filter(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// Build returns query for one page of base select ordered by sort column and id.
+// Sort column must not come from user input as is.
+func (q *Query) Build(base string, sort string, order Order, after *Cursor, limit uint64) (string, []any) {
+	id := q.IDColumn
+	if id == "" {
+		id = "id"
+	}
+
+	direction, compare := "ASC", ">"
+	if order == Desc {
+		direction, compare = "DESC", "<"
+	}
+
+	if after != nil {
+		if sort == id {
+			q.Where(id+" "+compare+" %s", after.ID)
+		} else {
+			q.args = append(q.args, after.Value, after.ID)
+			q.conditions = append(q.conditions,
+				fmt.Sprintf("(%s, %s) %s ($%d, $%d)", sort, id, compare, len(q.args)-1, len(q.args)))
+		}
+	}
+
+	query := strings.Builder{}
+	query.WriteString(base)
+	if len(q.conditions) > 0 {
+		query.WriteString(" WHERE ")
+		query.WriteString(strings.Join(q.conditions, " AND "))
+	}
+
+	if sort == id {
+		query.WriteString(fmt.Sprintf(" ORDER BY %s %s", id, direction))
+	} else {
+		query.WriteString(fmt.Sprintf(" ORDER BY %s %s, %s %s", sort, direction, id, direction))
+	}
+
+	q.args = append(q.args, limit)
+	query.WriteString(fmt.Sprintf(" LIMIT $%d", len(q.args)))
+
+	return query.String(), q.args
+}
@@ -7,8 +7,62 @@ type Cost uint32
 type QuestType string
 
 const (
-	RANDOM QuestType = "random"
-	USUAL  QuestType = "usual"
+	RANDOM  QuestType = "random"
+	USUAL   QuestType = "usual"
+	STAGED  QuestType = "staged"
+	COUNTER QuestType = "counter"
+	STREAK  QuestType = "streak"
+	TEAM    QuestType = "team"
+)
+
+// RewardSplit decides how reward of team quest is distributed between contributors.
+type RewardSplit string
+
+const (
+	SplitEqual RewardSplit = "equal" // reward is divided equally
+	SplitFixed RewardSplit = "fixed" // every contributor gets the whole reward
+)
+
+// HistorySource is the reason of balance change stored in history.
+type HistorySource string
+
+const (
+	SourceQuest       HistorySource = "quest"        // reward for quest completion
+	SourceReferral    HistorySource = "referral"     // referral bonus paid for completion of referral quest by referee
+	SourceTransferIn  HistorySource = "transfer_in"  // points received from another user
+	SourceTransferOut HistorySource = "transfer_out" // points sent to another user
+	SourceRevoke      HistorySource = "revoke"       // reward of revoked quest completion clawed back
 )
 
 type ContextField string
+
+type UsersSort string
+
+const (
+	UsersSortID      UsersSort = "id"
+	UsersSortName    UsersSort = "name"
+	UsersSortBalance UsersSort = "balance"
+)
+
+type QuestsSort string
+
+const (
+	QuestsSortID   QuestsSort = "id"
+	QuestsSortName QuestsSort = "name"
+	QuestsSortCost QuestsSort = "cost"
+)
+
+type LeaderboardWindow string
+
+const (
+	LeaderboardAllTime LeaderboardWindow = "all"
+	LeaderboardWeek    LeaderboardWindow = "week"
+	LeaderboardMonth   LeaderboardWindow = "month"
+)
+
+type LeaderboardMetric string
+
+const (
+	LeaderboardEarned  LeaderboardMetric = "earned"
+	LeaderboardBalance LeaderboardMetric = "balance"
+)
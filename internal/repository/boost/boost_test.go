@@ -0,0 +1,406 @@
+package boost
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	stdtime "time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	qr "vk_quests/internal/repository/quest"
+)
+
+var testError = errors.New("test error")
+
+type BoostRepositorySuite struct {
+	suite.Suite
+	boostRepository *PostgresBoost
+	db              *sqlx.DB
+	mock            sqlxmock.Sqlmock
+}
+
+func (brs *BoostRepositorySuite) BeforeEach(t provider.T) {
+	db, mock, err := sqlxmock.Newx(sqlxmock.QueryMatcherOption(sqlxmock.QueryMatcherEqual))
+	t.Require().NoError(err)
+	brs.boostRepository = NewPostgresBoost(db)
+	brs.db = db
+	brs.mock = mock
+}
+
+func (brs *BoostRepositorySuite) AfterEach(t provider.T) {
+	t.Require().NoError(brs.mock.ExpectationsWereMet())
+}
+
+var (
+	boostColumns = []string{"id", "name", "multiplier", "starts_at", "ends_at", "quest_id", "category_id", "quest_type"}
+	startsAt     = stdtime.Date(2024, 3, 2, 0, 0, 0, 0, stdtime.UTC)
+	endsAt       = stdtime.Date(2024, 3, 4, 0, 0, 0, 0, stdtime.UTC)
+)
+
+func testBoost() *Boost {
+	categoryId := types.Id(2)
+	tp := types.USUAL
+	return &Boost{
+		ID:         1,
+		Name:       "Double points weekend",
+		Multiplier: 2,
+		StartsAt:   time.FormattedTime{Time: startsAt},
+		EndsAt:     time.FormattedTime{Time: endsAt},
+		CategoryId: &categoryId,
+		QuestType:  &tp,
+	}
+}
+
+func boostRow(rows *sqlxmock.Rows, boost *Boost) *sqlxmock.Rows {
+	var tp any
+	if boost.QuestType != nil {
+		tp = string(*boost.QuestType)
+	}
+
+	return rows.AddRow(boost.ID, boost.Name, boost.Multiplier, boost.StartsAt.Time, boost.EndsAt.Time,
+		getNullId(boost.QuestId), getNullId(boost.CategoryId), tp)
+}
+
+func (brs *BoostRepositorySuite) TestCreateBoostFunction(t provider.T) {
+	t.Title("CreateBoost function of Boost repository")
+	t.NewStep("Init test data")
+	boost := testBoost()
+	args := []driver.Value{boost.Name, boost.Multiplier, startsAt, endsAt, sql.Null[types.Id]{},
+		getNullId(boost.CategoryId), sql.NullString{Valid: true, String: string(types.USUAL)}}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(createBoost).WithArgs(args...).
+			WillReturnRows(boostRow(sqlxmock.NewRows(boostColumns), boost))
+
+		t.NewStep("Check result")
+		newBoost, err := brs.boostRepository.CreateBoost(context.Background(), boost)
+		t.Require().NoError(err)
+		t.Require().EqualValues(boost, newBoost)
+	})
+
+	t.WithNewStep("Error invalid window execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(createBoost).WithArgs(args...).
+			WillReturnError(&pq.Error{Code: checkConflictCode, Constraint: windowConstraintName})
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.CreateBoost(context.Background(), boost)
+		t.Require().ErrorIs(err, ErrorInvalidWindow)
+	})
+
+	t.WithNewStep("Error category not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(createBoost).WithArgs(args...).
+			WillReturnError(&pq.Error{Code: foreignKeyConflictCode, Constraint: categoryIdConstraintName})
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.CreateBoost(context.Background(), boost)
+		t.Require().ErrorIs(err, ErrorCategoryNotFound)
+	})
+
+	t.WithNewStep("Error quest not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init test data")
+		questId := types.Id(5)
+		questBoost := &Boost{Name: boost.Name, Multiplier: 1.5, StartsAt: boost.StartsAt, EndsAt: boost.EndsAt,
+			QuestId: &questId}
+
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(createBoost).
+			WithArgs(questBoost.Name, questBoost.Multiplier, startsAt, endsAt, getNullId(&questId),
+				sql.Null[types.Id]{}, sql.NullString{}).
+			WillReturnError(&pq.Error{Code: foreignKeyConflictCode, Constraint: questIdConstraintName})
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.CreateBoost(context.Background(), questBoost)
+		t.Require().ErrorIs(err, ErrorQuestNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(createBoost).WithArgs(args...).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.CreateBoost(context.Background(), boost)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (brs *BoostRepositorySuite) TestUpdateBoostFunction(t provider.T) {
+	t.Title("UpdateBoost function of Boost repository")
+	t.NewStep("Init test data")
+	boost := testBoost()
+	multiplier := 3.0
+	boost.Multiplier = multiplier
+	update := &UpdateBoost{ID: boost.ID, Multiplier: &multiplier}
+	args := []driver.Value{boost.ID, sql.NullString{}, sql.NullFloat64{Valid: true, Float64: multiplier},
+		sql.Null[stdtime.Time]{}, sql.Null[stdtime.Time]{}, sql.Null[types.Id]{}, sql.Null[types.Id]{}, sql.NullString{}}
+
+	t.WithNewStep("Correct only multiplier execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(updateBoost).WithArgs(args...).
+			WillReturnRows(boostRow(sqlxmock.NewRows(boostColumns), boost))
+
+		t.NewStep("Check result")
+		updatedBoost, err := brs.boostRepository.UpdateBoost(context.Background(), update)
+		t.Require().NoError(err)
+		t.Require().EqualValues(boost, updatedBoost)
+	})
+
+	t.WithNewStep("Error boost not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(updateBoost).WithArgs(args...).WillReturnRows(sqlxmock.NewRows(boostColumns))
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.UpdateBoost(context.Background(), update)
+		t.Require().ErrorIs(err, ErrorBoostNotFound)
+	})
+
+	t.WithNewStep("Error invalid window execute", func(t provider.StepCtx) {
+		t.NewStep("Init test data")
+		ends := time.FormattedTime{Time: startsAt.Add(-stdtime.Hour)}
+
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(updateBoost).
+			WithArgs(boost.ID, sql.NullString{}, sql.NullFloat64{}, sql.Null[stdtime.Time]{},
+				sql.Null[stdtime.Time]{Valid: true, V: ends.Time}, sql.Null[types.Id]{}, sql.Null[types.Id]{}, sql.NullString{}).
+			WillReturnError(&pq.Error{Code: checkConflictCode, Constraint: windowConstraintName})
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.UpdateBoost(context.Background(), &UpdateBoost{ID: boost.ID, EndsAt: &ends})
+		t.Require().ErrorIs(err, ErrorInvalidWindow)
+	})
+
+	t.WithNewStep("Correct scope execute", func(t provider.StepCtx) {
+		t.NewStep("Init test data")
+		questId, noCategory, anyType := types.Id(3), types.Id(0), types.QuestType("")
+		scopedBoost := testBoost()
+		scopedBoost.QuestId, scopedBoost.CategoryId, scopedBoost.QuestType = &questId, nil, nil
+
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(updateBoost).
+			WithArgs(boost.ID, sql.NullString{}, sql.NullFloat64{}, sql.Null[stdtime.Time]{}, sql.Null[stdtime.Time]{},
+				getNullId(&questId), getNullId(&noCategory), sql.NullString{Valid: true, String: ""}).
+			WillReturnRows(boostRow(sqlxmock.NewRows(boostColumns), scopedBoost))
+
+		t.NewStep("Check result")
+		updatedBoost, err := brs.boostRepository.UpdateBoost(context.Background(), &UpdateBoost{
+			ID:         boost.ID,
+			QuestId:    &questId,
+			CategoryId: &noCategory,
+			QuestType:  &anyType,
+		})
+		t.Require().NoError(err)
+		t.Require().EqualValues(scopedBoost, updatedBoost)
+	})
+
+	t.WithNewStep("Error quest not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init test data")
+		questId := types.Id(3)
+
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(updateBoost).
+			WithArgs(boost.ID, sql.NullString{}, sql.NullFloat64{}, sql.Null[stdtime.Time]{}, sql.Null[stdtime.Time]{},
+				getNullId(&questId), sql.Null[types.Id]{}, sql.NullString{}).
+			WillReturnError(&pq.Error{Code: foreignKeyConflictCode, Constraint: questIdConstraintName})
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.UpdateBoost(context.Background(), &UpdateBoost{ID: boost.ID, QuestId: &questId})
+		t.Require().ErrorIs(err, ErrorQuestNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(updateBoost).WithArgs(args...).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.UpdateBoost(context.Background(), update)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (brs *BoostRepositorySuite) TestDeleteBoostFunction(t provider.T) {
+	t.Title("DeleteBoost function of Boost repository")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectExec(deleteBoost).WithArgs(id).WillReturnResult(sqlxmock.NewResult(0, 1))
+
+		t.NewStep("Check result")
+		t.Require().NoError(brs.boostRepository.DeleteBoost(context.Background(), id))
+	})
+
+	t.WithNewStep("Error boost not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectExec(deleteBoost).WithArgs(id).WillReturnResult(sqlxmock.NewResult(0, 0))
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(brs.boostRepository.DeleteBoost(context.Background(), id), ErrorBoostNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectExec(deleteBoost).WithArgs(id).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(brs.boostRepository.DeleteBoost(context.Background(), id), testError)
+	})
+
+	t.WithNewStep("Affected rows error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectExec(deleteBoost).WithArgs(id).WillReturnResult(sqlxmock.NewErrorResult(testError))
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(brs.boostRepository.DeleteBoost(context.Background(), id), testError)
+	})
+}
+
+func (brs *BoostRepositorySuite) TestGetBoostsFunction(t provider.T) {
+	t.Title("GetBoosts function of Boost repository")
+	t.NewStep("Init test data")
+	questId := types.Id(5)
+	boosts := []Boost{
+		*testBoost(),
+		{
+			ID:         2,
+			Name:       "Quest of the day",
+			Multiplier: 1.5,
+			StartsAt:   time.FormattedTime{Time: startsAt},
+			EndsAt:     time.FormattedTime{Time: startsAt.Add(24 * stdtime.Hour)},
+			QuestId:    &questId,
+		},
+	}
+
+	boostRows := func() *sqlxmock.Rows {
+		return boostRow(boostRow(sqlxmock.NewRows(boostColumns), &boosts[0]), &boosts[1])
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(getBoosts).WillReturnRows(boostRows())
+
+		t.NewStep("Check result")
+		res, err := brs.boostRepository.GetBoosts(context.Background())
+		t.Require().NoError(err)
+		t.Require().EqualValues(boosts, res)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(getBoosts).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.GetBoosts(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Row error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(getBoosts).WillReturnRows(boostRows().RowError(1, testError))
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.GetBoosts(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Scan error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(getBoosts).WillReturnRows(boostRows().AddRow("id", 1, 1, 1, 1, 1, 1, 1))
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.GetBoosts(context.Background())
+		t.Require().Error(err)
+	})
+}
+
+func (brs *BoostRepositorySuite) TestGetBoostFunction(t provider.T) {
+	t.Title("GetBoost function of Boost repository")
+	t.NewStep("Init test data")
+	boost := testBoost()
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(getBoost).WithArgs(boost.ID).
+			WillReturnRows(boostRow(sqlxmock.NewRows(boostColumns), boost))
+
+		t.NewStep("Check result")
+		res, err := brs.boostRepository.GetBoost(context.Background(), boost.ID)
+		t.Require().NoError(err)
+		t.Require().EqualValues(boost, res)
+	})
+
+	t.WithNewStep("Error boost not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(getBoost).WithArgs(boost.ID).WillReturnRows(sqlxmock.NewRows(boostColumns))
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.GetBoost(context.Background(), boost.ID)
+		t.Require().ErrorIs(err, ErrorBoostNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectQuery(getBoost).WithArgs(boost.ID).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := brs.boostRepository.GetBoost(context.Background(), boost.ID)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (brs *BoostRepositorySuite) TestGetMultiplierFunction(t provider.T) {
+	t.Title("GetMultiplier function of Boost repository")
+	t.NewStep("Init test data")
+	quest := &qr.Quest{ID: 5, Type: types.USUAL, Categories: []types.Id{2, 3}}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectBegin()
+		brs.mock.ExpectQuery(MultiplierQuery).WithArgs(quest.ID, quest.Type, pq.Array([]int64{2, 3})).
+			WillReturnRows(sqlxmock.NewRows([]string{"multiplier"}).AddRow(2.0))
+
+		t.NewStep("Check result")
+		tx, err := brs.db.Beginx()
+		t.Require().NoError(err)
+		multiplier, err := GetMultiplier(context.Background(), tx, quest)
+		t.Require().NoError(err)
+		t.Require().Equal(2.0, multiplier)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		brs.mock.ExpectBegin()
+		brs.mock.ExpectQuery(MultiplierQuery).WithArgs(quest.ID, quest.Type, pq.Array([]int64{2, 3})).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		tx, err := brs.db.Beginx()
+		t.Require().NoError(err)
+		_, err = GetMultiplier(context.Background(), tx, quest)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (brs *BoostRepositorySuite) TestMultiplyFunction(t provider.T) {
+	t.Title("Multiply function of Boost repository")
+
+	t.Require().Equal(types.Cost(10), Multiply(10, 1))
+	t.Require().Equal(types.Cost(20), Multiply(10, 2))
+	t.Require().Equal(types.Cost(8), Multiply(5, 1.5))
+	t.Require().Equal(types.Cost(0), Multiply(0, 3))
+}
+
+func TestRunBoostRepositorySuite(t *testing.T) {
+	suite.RunSuite(t, new(BoostRepositorySuite))
+}
@@ -0,0 +1,56 @@
+package boost
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+var (
+	ErrorBoostNotFound    = errors.New("boost with id not found")
+	ErrorInvalidWindow    = errors.New("boost must start before it ends")
+	ErrorQuestNotFound    = errors.New("quest with id not found")
+	ErrorCategoryNotFound = errors.New("category with id not found")
+)
+
+//go:generate mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=BoostRepository . Repository
+
+type Repository interface {
+	// CreateBoost
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorInvalidWindow
+	//   - ErrorQuestNotFound
+	//   - ErrorCategoryNotFound
+	CreateBoost(ctx context.Context, boost *Boost) (*Boost, error)
+
+	// UpdateBoost
+	// Zero quest or category id and empty quest type remove restriction of boost by them.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorBoostNotFound
+	//   - ErrorInvalidWindow
+	//   - ErrorQuestNotFound
+	//   - ErrorCategoryNotFound
+	UpdateBoost(ctx context.Context, boost *UpdateBoost) (*Boost, error)
+
+	// DeleteBoost
+	// Rewards already multiplied by boost are not changed.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorBoostNotFound
+	DeleteBoost(ctx context.Context, id types.Id) error
+
+	// GetBoosts
+	// Returns all boosts sorted by start of window and id.
+	// Returns Error:
+	//   - SQLError
+	GetBoosts(ctx context.Context) ([]Boost, error)
+
+	// GetBoost
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorBoostNotFound
+	GetBoost(ctx context.Context, id types.Id) (*Boost, error)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/repository/boost (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=BoostRepository . Repository
+//
+
+// Package mr is a generated GoMock package.
+package mr
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	boost "vk_quests/internal/repository/boost"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// BoostRepository is a mock of Repository interface.
+type BoostRepository struct {
+	ctrl     *gomock.Controller
+	recorder *BoostRepositoryMockRecorder
+}
+
+// BoostRepositoryMockRecorder is the mock recorder for BoostRepository.
+type BoostRepositoryMockRecorder struct {
+	mock *BoostRepository
+}
+
+// NewBoostRepository creates a new mock instance.
+func NewBoostRepository(ctrl *gomock.Controller) *BoostRepository {
+	mock := &BoostRepository{ctrl: ctrl}
+	mock.recorder = &BoostRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *BoostRepository) EXPECT() *BoostRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBoost mocks base method.
+func (m *BoostRepository) CreateBoost(arg0 context.Context, arg1 *boost.Boost) (*boost.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoost", arg0, arg1)
+	ret0, _ := ret[0].(*boost.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoost indicates an expected call of CreateBoost.
+func (mr *BoostRepositoryMockRecorder) CreateBoost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoost", reflect.TypeOf((*BoostRepository)(nil).CreateBoost), arg0, arg1)
+}
+
+// DeleteBoost mocks base method.
+func (m *BoostRepository) DeleteBoost(arg0 context.Context, arg1 types.Id) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoost", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoost indicates an expected call of DeleteBoost.
+func (mr *BoostRepositoryMockRecorder) DeleteBoost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoost", reflect.TypeOf((*BoostRepository)(nil).DeleteBoost), arg0, arg1)
+}
+
+// GetBoost mocks base method.
+func (m *BoostRepository) GetBoost(arg0 context.Context, arg1 types.Id) (*boost.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoost", arg0, arg1)
+	ret0, _ := ret[0].(*boost.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoost indicates an expected call of GetBoost.
+func (mr *BoostRepositoryMockRecorder) GetBoost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoost", reflect.TypeOf((*BoostRepository)(nil).GetBoost), arg0, arg1)
+}
+
+// GetBoosts mocks base method.
+func (m *BoostRepository) GetBoosts(arg0 context.Context) ([]boost.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoosts", arg0)
+	ret0, _ := ret[0].([]boost.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoosts indicates an expected call of GetBoosts.
+func (mr *BoostRepositoryMockRecorder) GetBoosts(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoosts", reflect.TypeOf((*BoostRepository)(nil).GetBoosts), arg0)
+}
+
+// UpdateBoost mocks base method.
+func (m *BoostRepository) UpdateBoost(arg0 context.Context, arg1 *boost.UpdateBoost) (*boost.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoost", arg0, arg1)
+	ret0, _ := ret[0].(*boost.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoost indicates an expected call of UpdateBoost.
+func (mr *BoostRepositoryMockRecorder) UpdateBoost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoost", reflect.TypeOf((*BoostRepository)(nil).UpdateBoost), arg0, arg1)
+}
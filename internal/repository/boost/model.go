@@ -0,0 +1,32 @@
+package boost
+
+import (
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+)
+
+// Boost multiplies rewards for quests completed during its window.
+// Nil scope fields don't restrict quests the boost applies to.
+type Boost struct {
+	ID         types.Id
+	Name       string
+	Multiplier float64
+	StartsAt   time.FormattedTime
+	EndsAt     time.FormattedTime
+	QuestId    *types.Id
+	CategoryId *types.Id
+	QuestType  *types.QuestType
+}
+
+// UpdateBoost holds new values of boost fields, nil fields are not changed.
+// Zero scope fields remove restriction of boost.
+type UpdateBoost struct {
+	ID         types.Id
+	Name       *string
+	Multiplier *float64
+	StartsAt   *time.FormattedTime
+	EndsAt     *time.FormattedTime
+	QuestId    *types.Id        // zero removes restriction by quest
+	CategoryId *types.Id        // zero removes restriction by category
+	QuestType  *types.QuestType // empty type removes restriction by type of quests
+}
@@ -0,0 +1,270 @@
+package boost
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	stdtime "time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	qr "vk_quests/internal/repository/quest"
+)
+
+// MultiplierQuery is exported, so repositories calling GetMultiplier inside their transactions can expect it in tests.
+// Boosts don't stack, the greatest multiplier of active boosts matching quest is applied.
+const MultiplierQuery = `
+		SELECT COALESCE(max(multiplier), 1)::float8 FROM boosts
+		WHERE starts_at <= now() AND ends_at > now()
+			AND (quest_id IS NULL OR quest_id = $1)
+			AND (quest_type IS NULL OR quest_type = $2)
+			AND (category_id IS NULL OR category_id = ANY($3))
+	`
+
+const (
+	createBoost = `
+		INSERT INTO boosts (name, multiplier, starts_at, ends_at, quest_id, category_id, quest_type)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, name, multiplier, starts_at, ends_at, quest_id, category_id, quest_type
+	`
+
+	// updateBoost doesn't change null fields, zero ids and empty type remove scope restrictions
+	updateBoost = `
+		UPDATE boosts SET name = COALESCE($2, name), multiplier = COALESCE($3, multiplier),
+			starts_at = COALESCE($4, starts_at), ends_at = COALESCE($5, ends_at),
+			quest_id = CASE WHEN $6::bigint IS NULL THEN quest_id ELSE NULLIF($6::bigint, 0) END,
+			category_id = CASE WHEN $7::bigint IS NULL THEN category_id ELSE NULLIF($7::bigint, 0) END,
+			quest_type = CASE WHEN $8::text IS NULL THEN quest_type ELSE NULLIF($8::text, '')::quest_type END
+		WHERE id = $1
+		RETURNING id, name, multiplier, starts_at, ends_at, quest_id, category_id, quest_type
+	`
+
+	deleteBoost = `
+		DELETE FROM boosts WHERE id = $1
+	`
+
+	getBoosts = `
+		SELECT id, name, multiplier, starts_at, ends_at, quest_id, category_id, quest_type FROM boosts
+		ORDER BY starts_at, id
+	`
+
+	getBoost = `
+		SELECT id, name, multiplier, starts_at, ends_at, quest_id, category_id, quest_type FROM boosts WHERE id = $1
+	`
+)
+
+const (
+	checkConflictCode        = "23514"
+	windowConstraintName     = "boosts_window_check"
+	foreignKeyConflictCode   = "23503"
+	questIdConstraintName    = "boosts_quest_id_fkey"
+	categoryIdConstraintName = "boosts_category_id_fkey"
+)
+
+type PostgresBoost struct {
+	db *sqlx.DB
+}
+
+func NewPostgresBoost(db *sqlx.DB) *PostgresBoost {
+	return &PostgresBoost{
+		db: db,
+	}
+}
+
+var _ = Repository(&PostgresBoost{})
+
+// GetMultiplier returns multiplier of reward for completion of quest at the time of transaction start.
+// Returns Error:
+//   - SQLError
+func GetMultiplier(ctx context.Context, tx *sqlx.Tx, quest *qr.Quest) (float64, error) {
+	categories := make([]int64, 0, len(quest.Categories))
+	for _, id := range quest.Categories {
+		categories = append(categories, int64(id))
+	}
+
+	multiplier := float64(1)
+	if err := tx.QueryRowxContext(ctx, MultiplierQuery, quest.ID, quest.Type, pq.Array(categories)).
+		Scan(&multiplier); err != nil {
+		return 0, errors.Wrapf(err, "can't get boost multiplier of quest with id %d", quest.ID)
+	}
+
+	return multiplier, nil
+}
+
+// Multiply returns award multiplied by boost multiplier and rounded to the nearest integer.
+func Multiply(award types.Cost, multiplier float64) types.Cost {
+	return types.Cost(math.Round(float64(award) * multiplier))
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBoost(row scanner, boost *Boost) error {
+	questId, categoryId := sql.Null[types.Id]{}, sql.Null[types.Id]{}
+	tp := sql.NullString{}
+	if err := row.Scan(&boost.ID, &boost.Name, &boost.Multiplier, &boost.StartsAt, &boost.EndsAt,
+		&questId, &categoryId, &tp); err != nil {
+		return err
+	}
+
+	boost.StartsAt.Time = boost.StartsAt.UTC()
+	boost.EndsAt.Time = boost.EndsAt.UTC()
+
+	boost.QuestId = nil
+	if questId.Valid {
+		boost.QuestId = &questId.V
+	}
+
+	boost.CategoryId = nil
+	if categoryId.Valid {
+		boost.CategoryId = &categoryId.V
+	}
+
+	boost.QuestType = nil
+	if tp.Valid {
+		questType := types.QuestType(tp.String)
+		boost.QuestType = &questType
+	}
+
+	return nil
+}
+
+func getNullId(id *types.Id) sql.Null[types.Id] {
+	if id == nil {
+		return sql.Null[types.Id]{}
+	}
+
+	return sql.Null[types.Id]{Valid: true, V: *id}
+}
+
+func getNullTime(value *time.FormattedTime) sql.Null[stdtime.Time] {
+	if value == nil {
+		return sql.Null[stdtime.Time]{}
+	}
+
+	return sql.Null[stdtime.Time]{Valid: true, V: value.Time}
+}
+
+func getNullType(tp *types.QuestType) sql.NullString {
+	if tp == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{Valid: true, String: string(*tp)}
+}
+
+func (pb *PostgresBoost) CreateBoost(ctx context.Context, boost *Boost) (*Boost, error) {
+	tp := getNullType(boost.QuestType)
+
+	newBoost := &Boost{}
+	if err := scanBoost(pb.db.QueryRowxContext(ctx, createBoost, boost.Name, boost.Multiplier,
+		boost.StartsAt.Time, boost.EndsAt.Time, getNullId(boost.QuestId), getNullId(boost.CategoryId), tp),
+		newBoost); err != nil {
+		return nil, errors.Wrap(checkConflictError(err), "can't create boost")
+	}
+
+	return newBoost, nil
+}
+
+func (pb *PostgresBoost) UpdateBoost(ctx context.Context, boost *UpdateBoost) (*Boost, error) {
+	name := sql.NullString{}
+	if boost.Name != nil {
+		name = sql.NullString{Valid: true, String: *boost.Name}
+	}
+
+	multiplier := sql.NullFloat64{}
+	if boost.Multiplier != nil {
+		multiplier = sql.NullFloat64{Valid: true, Float64: *boost.Multiplier}
+	}
+
+	updatedBoost := &Boost{}
+	if err := scanBoost(pb.db.QueryRowxContext(ctx, updateBoost, boost.ID, name, multiplier,
+		getNullTime(boost.StartsAt), getNullTime(boost.EndsAt), getNullId(boost.QuestId), getNullId(boost.CategoryId),
+		getNullType(boost.QuestType)), updatedBoost); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorBoostNotFound, "with id %d", boost.ID)
+		}
+
+		return nil, errors.Wrapf(checkConflictError(err), "can't update boost with id %d", boost.ID)
+	}
+
+	return updatedBoost, nil
+}
+
+func (pb *PostgresBoost) DeleteBoost(ctx context.Context, id types.Id) error {
+	res, err := pb.db.ExecContext(ctx, deleteBoost, id)
+	if err != nil {
+		return errors.Wrapf(err, "can't execute deleting query for boost %d", id)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "can't get affected rows for boost %d", id)
+	}
+
+	if affected == 0 {
+		return errors.Wrapf(ErrorBoostNotFound, "with id %d", id)
+	}
+
+	return nil
+}
+
+func (pb *PostgresBoost) GetBoosts(ctx context.Context) ([]Boost, error) {
+	rows, err := pb.db.QueryxContext(ctx, getBoosts)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't execute get boosts query")
+	}
+
+	boosts := make([]Boost, 0)
+
+	for rows.Next() {
+		var boost Boost
+
+		if err := scanBoost(rows, &boost); err != nil {
+			return nil, errors.Wrap(err, "can't scan get boosts query result")
+		}
+
+		boosts = append(boosts, boost)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "can't end scan get boosts query result")
+	}
+
+	return boosts, nil
+}
+
+func (pb *PostgresBoost) GetBoost(ctx context.Context, id types.Id) (*Boost, error) {
+	boost := &Boost{}
+	if err := scanBoost(pb.db.QueryRowxContext(ctx, getBoost, id), boost); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorBoostNotFound, "with id %d", id)
+		}
+
+		return nil, errors.Wrapf(err, "can't get boost with id %d", id)
+	}
+
+	return boost, nil
+}
+
+func checkConflictError(err error) error {
+	var e *pq.Error
+	if !errors.As(err, &e) {
+		return err
+	}
+
+	switch {
+	case e.Code == checkConflictCode && e.Constraint == windowConstraintName:
+		return ErrorInvalidWindow
+	case e.Code == foreignKeyConflictCode && e.Constraint == questIdConstraintName:
+		return ErrorQuestNotFound
+	case e.Code == foreignKeyConflictCode && e.Constraint == categoryIdConstraintName:
+		return ErrorCategoryNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,278 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"vk_quests/internal/pkg/types"
+)
+
+var testError = errors.New("test error")
+
+type CategoryRepositorySuite struct {
+	suite.Suite
+	categoryRepository *PostgresCategory
+	mock               sqlxmock.Sqlmock
+}
+
+func (crs *CategoryRepositorySuite) BeforeEach(t provider.T) {
+	db, mock, err := sqlxmock.Newx(sqlxmock.QueryMatcherOption(sqlxmock.QueryMatcherEqual))
+	t.Require().NoError(err)
+	crs.categoryRepository = NewPostgresCategory(db)
+	crs.mock = mock
+}
+
+func (crs *CategoryRepositorySuite) AfterEach(t provider.T) {
+	t.Require().NoError(crs.mock.ExpectationsWereMet())
+}
+
+var categoryColumns = []string{"id", "name", "description"}
+
+func (crs *CategoryRepositorySuite) TestCreateCategoryFunction(t provider.T) {
+	t.Title("CreateCategory function of Category repository")
+	t.NewStep("Init test data")
+	category := &Category{
+		ID:          1,
+		Name:        "Daily",
+		Description: "daily quests",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(createCategory).
+			WithArgs(category.Name, category.Description).
+			WillReturnRows(sqlxmock.NewRows(categoryColumns).AddRow(category.ID, category.Name, category.Description))
+
+		t.NewStep("Check result")
+		newCategory, err := crs.categoryRepository.CreateCategory(context.Background(), category)
+		t.Require().NoError(err)
+		t.Require().EqualValues(category, newCategory)
+	})
+
+	t.WithNewStep("Conflict name exists execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(createCategory).
+			WithArgs(category.Name, category.Description).
+			WillReturnRows(sqlxmock.NewRows(categoryColumns))
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.CreateCategory(context.Background(), category)
+		t.Require().ErrorIs(err, ErrorCategoryNameAlreadyExists)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(createCategory).
+			WithArgs(category.Name, category.Description).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.CreateCategory(context.Background(), category)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (crs *CategoryRepositorySuite) TestUpdateCategoryFunction(t provider.T) {
+	t.Title("UpdateCategory function of Category repository")
+	t.NewStep("Init test data")
+	name := "Weekly"
+	category := &Category{
+		ID:          1,
+		Name:        name,
+		Description: "daily quests",
+	}
+
+	t.WithNewStep("Correct only name execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(updateCategory).
+			WithArgs(category.ID, sql.NullString{Valid: true, String: name}, sql.NullString{}).
+			WillReturnRows(sqlxmock.NewRows(categoryColumns).AddRow(category.ID, category.Name, category.Description))
+
+		t.NewStep("Check result")
+		updatedCategory, err := crs.categoryRepository.UpdateCategory(context.Background(), &UpdateCategory{
+			ID:   category.ID,
+			Name: &name,
+		})
+		t.Require().NoError(err)
+		t.Require().EqualValues(category, updatedCategory)
+	})
+
+	t.WithNewStep("Error category not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(updateCategory).
+			WithArgs(category.ID, sql.NullString{Valid: true, String: name}, sql.NullString{}).
+			WillReturnRows(sqlxmock.NewRows(categoryColumns))
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.UpdateCategory(context.Background(), &UpdateCategory{
+			ID:   category.ID,
+			Name: &name,
+		})
+		t.Require().ErrorIs(err, ErrorCategoryNotFound)
+	})
+
+	t.WithNewStep("Conflict name exists execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(updateCategory).
+			WithArgs(category.ID, sql.NullString{Valid: true, String: name}, sql.NullString{}).
+			WillReturnError(&pq.Error{Code: uniqueConflictCode, Constraint: nameUniqueConstraintName})
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.UpdateCategory(context.Background(), &UpdateCategory{
+			ID:   category.ID,
+			Name: &name,
+		})
+		t.Require().ErrorIs(err, ErrorCategoryNameAlreadyExists)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(updateCategory).
+			WithArgs(category.ID, sql.NullString{}, sql.NullString{}).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.UpdateCategory(context.Background(), &UpdateCategory{ID: category.ID})
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (crs *CategoryRepositorySuite) TestDeleteCategoryFunction(t provider.T) {
+	t.Title("DeleteCategory function of Category repository")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectExec(deleteCategory).WithArgs(id).WillReturnResult(sqlxmock.NewResult(0, 1))
+
+		t.NewStep("Check result")
+		t.Require().NoError(crs.categoryRepository.DeleteCategory(context.Background(), id))
+	})
+
+	t.WithNewStep("Error category not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectExec(deleteCategory).WithArgs(id).WillReturnResult(sqlxmock.NewResult(0, 0))
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(crs.categoryRepository.DeleteCategory(context.Background(), id), ErrorCategoryNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectExec(deleteCategory).WithArgs(id).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(crs.categoryRepository.DeleteCategory(context.Background(), id), testError)
+	})
+
+	t.WithNewStep("Affected rows error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectExec(deleteCategory).WithArgs(id).WillReturnResult(sqlxmock.NewErrorResult(testError))
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(crs.categoryRepository.DeleteCategory(context.Background(), id), testError)
+	})
+}
+
+func (crs *CategoryRepositorySuite) TestGetCategoriesFunction(t provider.T) {
+	t.Title("GetCategories function of Category repository")
+	t.NewStep("Init test data")
+	categories := []Category{
+		{ID: 1, Name: "Daily", Description: "daily quests"},
+		{ID: 2, Name: "Social"},
+	}
+
+	categoryRows := func() *sqlxmock.Rows {
+		return sqlxmock.NewRows(categoryColumns).
+			AddRow(categories[0].ID, categories[0].Name, categories[0].Description).
+			AddRow(categories[1].ID, categories[1].Name, categories[1].Description)
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(getCategories).WillReturnRows(categoryRows())
+
+		t.NewStep("Check result")
+		res, err := crs.categoryRepository.GetCategories(context.Background())
+		t.Require().NoError(err)
+		t.Require().EqualValues(categories, res)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(getCategories).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.GetCategories(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Row error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(getCategories).WillReturnRows(categoryRows().RowError(1, testError))
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.GetCategories(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Scan error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(getCategories).WillReturnRows(categoryRows().AddRow("id", 1, 1))
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.GetCategories(context.Background())
+		t.Require().Error(err)
+	})
+}
+
+func (crs *CategoryRepositorySuite) TestGetCategoryFunction(t provider.T) {
+	t.Title("GetCategory function of Category repository")
+	t.NewStep("Init test data")
+	category := &Category{
+		ID:          1,
+		Name:        "Daily",
+		Description: "daily quests",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(getCategory).WithArgs(category.ID).
+			WillReturnRows(sqlxmock.NewRows(categoryColumns).AddRow(category.ID, category.Name, category.Description))
+
+		t.NewStep("Check result")
+		res, err := crs.categoryRepository.GetCategory(context.Background(), category.ID)
+		t.Require().NoError(err)
+		t.Require().EqualValues(category, res)
+	})
+
+	t.WithNewStep("Error category not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(getCategory).WithArgs(category.ID).WillReturnRows(sqlxmock.NewRows(categoryColumns))
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.GetCategory(context.Background(), category.ID)
+		t.Require().ErrorIs(err, ErrorCategoryNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		crs.mock.ExpectQuery(getCategory).WithArgs(category.ID).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := crs.categoryRepository.GetCategory(context.Background(), category.ID)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func TestRunCategoryRepositorySuite(t *testing.T) {
+	suite.RunSuite(t, new(CategoryRepositorySuite))
+}
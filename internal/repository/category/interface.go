@@ -0,0 +1,49 @@
+package category
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+var (
+	ErrorCategoryNotFound          = errors.New("category with id not found")
+	ErrorCategoryNameAlreadyExists = errors.New("category with name already exists")
+)
+
+//go:generate mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=CategoryRepository . Repository
+
+type Repository interface {
+	// CreateCategory
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorCategoryNameAlreadyExists
+	CreateCategory(ctx context.Context, category *Category) (*Category, error)
+
+	// UpdateCategory
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorCategoryNotFound
+	//   - ErrorCategoryNameAlreadyExists
+	UpdateCategory(ctx context.Context, category *UpdateCategory) (*Category, error)
+
+	// DeleteCategory
+	// Category is also removed from quests.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorCategoryNotFound
+	DeleteCategory(ctx context.Context, id types.Id) error
+
+	// GetCategories
+	// Returns all categories sorted by id.
+	// Returns Error:
+	//   - SQLError
+	GetCategories(ctx context.Context) ([]Category, error)
+
+	// GetCategory
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorCategoryNotFound
+	GetCategory(ctx context.Context, id types.Id) (*Category, error)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/repository/category (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=CategoryRepository . Repository
+//
+
+// Package mr is a generated GoMock package.
+package mr
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	category "vk_quests/internal/repository/category"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// CategoryRepository is a mock of Repository interface.
+type CategoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *CategoryRepositoryMockRecorder
+}
+
+// CategoryRepositoryMockRecorder is the mock recorder for CategoryRepository.
+type CategoryRepositoryMockRecorder struct {
+	mock *CategoryRepository
+}
+
+// NewCategoryRepository creates a new mock instance.
+func NewCategoryRepository(ctrl *gomock.Controller) *CategoryRepository {
+	mock := &CategoryRepository{ctrl: ctrl}
+	mock.recorder = &CategoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *CategoryRepository) EXPECT() *CategoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *CategoryRepository) CreateCategory(arg0 context.Context, arg1 *category.Category) (*category.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(*category.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *CategoryRepositoryMockRecorder) CreateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*CategoryRepository)(nil).CreateCategory), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *CategoryRepository) DeleteCategory(arg0 context.Context, arg1 types.Id) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *CategoryRepositoryMockRecorder) DeleteCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*CategoryRepository)(nil).DeleteCategory), arg0, arg1)
+}
+
+// GetCategories mocks base method.
+func (m *CategoryRepository) GetCategories(arg0 context.Context) ([]category.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", arg0)
+	ret0, _ := ret[0].([]category.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *CategoryRepositoryMockRecorder) GetCategories(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*CategoryRepository)(nil).GetCategories), arg0)
+}
+
+// GetCategory mocks base method.
+func (m *CategoryRepository) GetCategory(arg0 context.Context, arg1 types.Id) (*category.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(*category.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *CategoryRepositoryMockRecorder) GetCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*CategoryRepository)(nil).GetCategory), arg0, arg1)
+}
+
+// UpdateCategory mocks base method.
+func (m *CategoryRepository) UpdateCategory(arg0 context.Context, arg1 *category.UpdateCategory) (*category.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(*category.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *CategoryRepositoryMockRecorder) UpdateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*CategoryRepository)(nil).UpdateCategory), arg0, arg1)
+}
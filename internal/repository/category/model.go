@@ -0,0 +1,16 @@
+package category
+
+import "vk_quests/internal/pkg/types"
+
+type Category struct {
+	ID          types.Id
+	Name        string
+	Description string
+}
+
+// UpdateCategory holds new values of category fields, nil fields are not changed.
+type UpdateCategory struct {
+	ID          types.Id
+	Name        *string
+	Description *string
+}
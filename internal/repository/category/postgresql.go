@@ -0,0 +1,152 @@
+package category
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+const (
+	createCategory = `
+		INSERT INTO categories (name, description) VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING id, name, description
+	`
+
+	updateCategory = `
+		UPDATE categories SET name = COALESCE($2, name), description = COALESCE($3, description)
+		WHERE id = $1
+		RETURNING id, name, description
+	`
+
+	deleteCategory = `
+		DELETE FROM categories WHERE id = $1
+	`
+
+	getCategories = `
+		SELECT id, name, description FROM categories ORDER BY id
+	`
+
+	getCategory = `
+		SELECT id, name, description FROM categories WHERE id = $1
+	`
+)
+
+const (
+	uniqueConflictCode       = "23505"
+	nameUniqueConstraintName = "categories_name_key"
+)
+
+type PostgresCategory struct {
+	db *sqlx.DB
+}
+
+func NewPostgresCategory(db *sqlx.DB) *PostgresCategory {
+	return &PostgresCategory{
+		db: db,
+	}
+}
+
+var _ = Repository(&PostgresCategory{})
+
+func getNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{Valid: true, String: *s}
+}
+
+func (pc *PostgresCategory) CreateCategory(ctx context.Context, category *Category) (*Category, error) {
+	newCategory := &Category{}
+	if err := pc.db.QueryRowxContext(ctx, createCategory, category.Name, category.Description).
+		Scan(&newCategory.ID, &newCategory.Name, &newCategory.Description); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorCategoryNameAlreadyExists, "with name %q", category.Name)
+		}
+
+		return nil, errors.Wrap(err, "can't create category")
+	}
+
+	return newCategory, nil
+}
+
+func (pc *PostgresCategory) UpdateCategory(ctx context.Context, category *UpdateCategory) (*Category, error) {
+	updatedCategory := &Category{}
+	if err := pc.db.QueryRowxContext(ctx, updateCategory,
+		category.ID, getNullString(category.Name), getNullString(category.Description)).
+		Scan(&updatedCategory.ID, &updatedCategory.Name, &updatedCategory.Description); err != nil {
+		var e *pq.Error
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, errors.Wrapf(ErrorCategoryNotFound, "with id %d", category.ID)
+		case errors.As(err, &e) && e.Code == uniqueConflictCode && e.Constraint == nameUniqueConstraintName:
+			return nil, errors.Wrapf(ErrorCategoryNameAlreadyExists, "with name %q", *category.Name)
+		}
+
+		return nil, errors.Wrapf(err, "can't update category with id %d", category.ID)
+	}
+
+	return updatedCategory, nil
+}
+
+func (pc *PostgresCategory) DeleteCategory(ctx context.Context, id types.Id) error {
+	res, err := pc.db.ExecContext(ctx, deleteCategory, id)
+	if err != nil {
+		return errors.Wrapf(err, "can't execute deleting query for category %d", id)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "can't get affected rows for category %d", id)
+	}
+
+	if affected == 0 {
+		return errors.Wrapf(ErrorCategoryNotFound, "with id %d", id)
+	}
+
+	return nil
+}
+
+func (pc *PostgresCategory) GetCategories(ctx context.Context) ([]Category, error) {
+	rows, err := pc.db.QueryxContext(ctx, getCategories)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't execute get categories query")
+	}
+
+	categories := make([]Category, 0)
+
+	for rows.Next() {
+		var category Category
+
+		if err := rows.Scan(&category.ID, &category.Name, &category.Description); err != nil {
+			return nil, errors.Wrap(err, "can't scan get categories query result")
+		}
+
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "can't end scan get categories query result")
+	}
+
+	return categories, nil
+}
+
+func (pc *PostgresCategory) GetCategory(ctx context.Context, id types.Id) (*Category, error) {
+	category := &Category{}
+	if err := pc.db.QueryRowxContext(ctx, getCategory, id).
+		Scan(&category.ID, &category.Name, &category.Description); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorCategoryNotFound, "with id %d", id)
+		}
+
+		return nil, errors.Wrapf(err, "can't get category with id %d", id)
+	}
+
+	return category, nil
+}
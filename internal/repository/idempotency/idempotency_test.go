@@ -0,0 +1,221 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+var testError = errors.New("test error")
+
+type IdempotencyRepositorySuite struct {
+	suite.Suite
+	idempotencyRepository *PostgresIdempotency
+	mock                  sqlxmock.Sqlmock
+}
+
+func (irs *IdempotencyRepositorySuite) BeforeEach(t provider.T) {
+	db, mock, err := sqlxmock.Newx(sqlxmock.QueryMatcherOption(sqlxmock.QueryMatcherEqual))
+	t.Require().NoError(err)
+	irs.idempotencyRepository = NewPostgresIdempotency(db)
+	irs.mock = mock
+}
+
+func (irs *IdempotencyRepositorySuite) AfterEach(t provider.T) {
+	t.Require().NoError(irs.mock.ExpectationsWereMet())
+}
+
+func (irs *IdempotencyRepositorySuite) TestReserveKeyFunction(t provider.T) {
+	t.Title("ReserveKey function of Idempotency repository")
+	t.NewStep("Init test data")
+	ttl := time.Hour
+	key := &Key{
+		Key:     "key",
+		UserId:  1,
+		QuestId: 2,
+		Amount:  1,
+		Outcome: Pending,
+		Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	keyColumns := []string{
+		"key", "user_id", "quest_id", "amount", "outcome", "conflict", "step", "total", "created", "exists",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectQuery(reserveKey).
+			WithArgs(key.Key, key.UserId, key.QuestId, key.Amount, ttl.Seconds()).
+			WillReturnRows(sqlxmock.NewRows(keyColumns).
+				AddRow(key.Key, key.UserId, key.QuestId, key.Amount, key.Outcome, nil, 0, 0, key.Created, 0),
+			)
+
+		t.NewStep("Check result")
+		reservedKey, err := irs.idempotencyRepository.ReserveKey(context.Background(), key, ttl)
+		t.Require().NoError(err)
+		t.Require().EqualValues(key, reservedKey)
+	})
+
+	t.WithNewStep("Key already exists execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectQuery(reserveKey).
+			WithArgs(key.Key, key.UserId, key.QuestId, key.Amount, ttl.Seconds()).
+			WillReturnRows(sqlxmock.NewRows(keyColumns).
+				AddRow(key.Key, key.UserId, key.QuestId, key.Amount, StepApplied, nil, 2, 3, key.Created, 1),
+			)
+
+		t.NewStep("Check result")
+		storedKey, err := irs.idempotencyRepository.ReserveKey(context.Background(), key, ttl)
+		t.Require().ErrorIs(err, ErrorKeyExists)
+		t.Require().Equal(StepApplied, storedKey.Outcome)
+		t.Require().EqualValues(2, storedKey.Step)
+		t.Require().EqualValues(3, storedKey.Total)
+	})
+
+	t.WithNewStep("Key with conflict already exists execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectQuery(reserveKey).
+			WithArgs(key.Key, key.UserId, key.QuestId, key.Amount, ttl.Seconds()).
+			WillReturnRows(sqlxmock.NewRows(keyColumns).
+				AddRow(key.Key, key.UserId, key.QuestId, key.Amount, Conflict, ConflictStreakExtended, 0, 0, key.Created, 1),
+			)
+
+		t.NewStep("Check result")
+		storedKey, err := irs.idempotencyRepository.ReserveKey(context.Background(), key, ttl)
+		t.Require().ErrorIs(err, ErrorKeyExists)
+		t.Require().Equal(Conflict, storedKey.Outcome)
+		t.Require().Equal(ConflictStreakExtended, storedKey.Conflict)
+	})
+
+	t.WithNewStep("Key stored by concurrent request execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectQuery(reserveKey).
+			WithArgs(key.Key, key.UserId, key.QuestId, key.Amount, ttl.Seconds()).
+			WillReturnRows(sqlxmock.NewRows(keyColumns))
+
+		t.NewStep("Check result")
+		storedKey, err := irs.idempotencyRepository.ReserveKey(context.Background(), key, ttl)
+		t.Require().ErrorIs(err, ErrorKeyExists)
+		t.Require().Equal(Pending, storedKey.Outcome)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectQuery(reserveKey).
+			WithArgs(key.Key, key.UserId, key.QuestId, key.Amount, ttl.Seconds()).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := irs.idempotencyRepository.ReserveKey(context.Background(), key, ttl)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (irs *IdempotencyRepositorySuite) TestSetOutcomeFunction(t provider.T) {
+	t.Title("SetOutcome function of Idempotency repository")
+	t.NewStep("Init test data")
+	key := "key"
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectExec(setOutcome).
+			WithArgs(key, Success, ConflictReason(""), uint32(0), uint32(0)).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+
+		t.NewStep("Check result")
+		err := irs.idempotencyRepository.SetOutcome(context.Background(), key, Success, "", 0, 0)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Correct conflict execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectExec(setOutcome).
+			WithArgs(key, Conflict, ConflictContributed, uint32(0), uint32(0)).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+
+		t.NewStep("Check result")
+		err := irs.idempotencyRepository.SetOutcome(context.Background(), key, Conflict, ConflictContributed, 0, 0)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Key not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectExec(setOutcome).
+			WithArgs(key, Success, ConflictReason(""), uint32(0), uint32(0)).
+			WillReturnResult(sqlxmock.NewResult(0, 0))
+
+		t.NewStep("Check result")
+		err := irs.idempotencyRepository.SetOutcome(context.Background(), key, Success, "", 0, 0)
+		t.Require().ErrorIs(err, ErrorKeyNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectExec(setOutcome).
+			WithArgs(key, Success, ConflictReason(""), uint32(0), uint32(0)).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		err := irs.idempotencyRepository.SetOutcome(context.Background(), key, Success, "", 0, 0)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on get affected rows execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectExec(setOutcome).
+			WithArgs(key, Success, ConflictReason(""), uint32(0), uint32(0)).
+			WillReturnResult(sqlxmock.NewErrorResult(testError))
+
+		t.NewStep("Check result")
+		err := irs.idempotencyRepository.SetOutcome(context.Background(), key, Success, "", 0, 0)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (irs *IdempotencyRepositorySuite) TestDeleteKeyFunction(t provider.T) {
+	t.Title("DeleteKey function of Idempotency repository")
+	t.NewStep("Init test data")
+	key := "key"
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectExec(deleteKey).
+			WithArgs(key).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+
+		t.NewStep("Check result")
+		err := irs.idempotencyRepository.DeleteKey(context.Background(), key)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Key not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectExec(deleteKey).
+			WithArgs(key).
+			WillReturnResult(sqlxmock.NewResult(0, 0))
+
+		t.NewStep("Check result")
+		err := irs.idempotencyRepository.DeleteKey(context.Background(), key)
+		t.Require().ErrorIs(err, ErrorKeyNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		irs.mock.ExpectExec(deleteKey).
+			WithArgs(key).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		err := irs.idempotencyRepository.DeleteKey(context.Background(), key)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func TestRunIdempotencyRepositorySuite(t *testing.T) {
+	suite.RunSuite(t, new(IdempotencyRepositorySuite))
+}
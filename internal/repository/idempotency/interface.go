@@ -0,0 +1,38 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorKeyExists   = errors.New("idempotency key already exists")
+	ErrorKeyNotFound = errors.New("idempotency key not found")
+)
+
+//go:generate mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=IdempotencyRepository . Repository
+
+type Repository interface {
+	// ReserveKey
+	// Stores key with pending outcome. Key older than ttl is replaced by new one.
+	// If key is already stored and not expired, returns stored key with ErrorKeyExists.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorKeyExists
+	ReserveKey(ctx context.Context, key *Key, ttl time.Duration) (*Key, error)
+
+	// SetOutcome
+	// Stores outcome of request with key, reason of conflict for Conflict outcome and progress of quest after it.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorKeyNotFound
+	SetOutcome(ctx context.Context, key string, outcome Outcome, conflict ConflictReason, step, total uint32) error
+
+	// DeleteKey
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorKeyNotFound
+	DeleteKey(ctx context.Context, key string) error
+}
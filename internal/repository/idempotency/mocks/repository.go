@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/repository/idempotency (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=IdempotencyRepository . Repository
+//
+
+// Package mr is a generated GoMock package.
+package mr
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	idempotency "vk_quests/internal/repository/idempotency"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// IdempotencyRepository is a mock of Repository interface.
+type IdempotencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *IdempotencyRepositoryMockRecorder
+}
+
+// IdempotencyRepositoryMockRecorder is the mock recorder for IdempotencyRepository.
+type IdempotencyRepositoryMockRecorder struct {
+	mock *IdempotencyRepository
+}
+
+// NewIdempotencyRepository creates a new mock instance.
+func NewIdempotencyRepository(ctrl *gomock.Controller) *IdempotencyRepository {
+	mock := &IdempotencyRepository{ctrl: ctrl}
+	mock.recorder = &IdempotencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *IdempotencyRepository) EXPECT() *IdempotencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteKey mocks base method.
+func (m *IdempotencyRepository) DeleteKey(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKey indicates an expected call of DeleteKey.
+func (mr *IdempotencyRepositoryMockRecorder) DeleteKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKey", reflect.TypeOf((*IdempotencyRepository)(nil).DeleteKey), arg0, arg1)
+}
+
+// ReserveKey mocks base method.
+func (m *IdempotencyRepository) ReserveKey(arg0 context.Context, arg1 *idempotency.Key, arg2 time.Duration) (*idempotency.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*idempotency.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveKey indicates an expected call of ReserveKey.
+func (mr *IdempotencyRepositoryMockRecorder) ReserveKey(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveKey", reflect.TypeOf((*IdempotencyRepository)(nil).ReserveKey), arg0, arg1, arg2)
+}
+
+// SetOutcome mocks base method.
+func (m *IdempotencyRepository) SetOutcome(arg0 context.Context, arg1 string, arg2 idempotency.Outcome, arg3 idempotency.ConflictReason, arg4, arg5 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOutcome", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOutcome indicates an expected call of SetOutcome.
+func (mr *IdempotencyRepositoryMockRecorder) SetOutcome(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutcome", reflect.TypeOf((*IdempotencyRepository)(nil).SetOutcome), arg0, arg1, arg2, arg3, arg4, arg5)
+}
@@ -0,0 +1,39 @@
+package idempotency
+
+import (
+	"time"
+
+	"vk_quests/internal/pkg/types"
+)
+
+type Outcome string
+
+const (
+	Pending     Outcome = "pending" // request with key is being executed
+	Success     Outcome = "success"
+	Failure     Outcome = "failure"
+	StepApplied Outcome = "step"
+	Conflict    Outcome = "conflict"
+)
+
+// ConflictReason tells which conflict ended request with Conflict outcome,
+// so repeated request returns the same error.
+type ConflictReason string
+
+const (
+	ConflictCompleted      ConflictReason = "completed"       // quest is already completed
+	ConflictStreakExtended ConflictReason = "streak_extended" // streak is already extended on the day of request
+	ConflictContributed    ConflictReason = "contributed"     // user already contributed to team quest
+)
+
+type Key struct {
+	Key      string
+	UserId   types.Id
+	QuestId  types.Id
+	Amount   uint32
+	Outcome  Outcome
+	Conflict ConflictReason // empty unless Outcome is Conflict
+	Step     uint32         // progress of quest after request
+	Total    uint32         // zero means quest has no progress
+	Created  time.Time
+}
@@ -0,0 +1,120 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const (
+	reserveKey = `
+		WITH ins AS (
+			INSERT INTO idempotency_keys (key, user_id, quest_id, amount) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (key) DO UPDATE SET user_id = excluded.user_id, quest_id = excluded.quest_id,
+			                                amount = excluded.amount, outcome = 'pending', conflict = NULL,
+			                                step = 0, total = 0, created = now()
+				WHERE idempotency_keys.created < now() - make_interval(secs => $5)
+			RETURNING key, user_id, quest_id, amount, outcome, conflict, step, total, created
+		)
+		SELECT key, user_id, quest_id, amount, outcome, conflict, step, total, created, 0
+		FROM ins
+		UNION ALL
+		SELECT key, user_id, quest_id, amount, outcome, conflict, step, total, created, 1
+		FROM idempotency_keys WHERE key = $1 AND NOT EXISTS (SELECT 1 FROM ins)
+	`
+
+	setOutcome = `
+		UPDATE idempotency_keys SET outcome = $2, conflict = NULLIF($3, ''), step = $4, total = $5 WHERE key = $1
+	`
+
+	deleteKey = `
+		DELETE FROM idempotency_keys WHERE key = $1
+	`
+)
+
+type PostgresIdempotency struct {
+	db *sqlx.DB
+}
+
+func NewPostgresIdempotency(db *sqlx.DB) *PostgresIdempotency {
+	return &PostgresIdempotency{
+		db: db,
+	}
+}
+
+var _ = Repository(&PostgresIdempotency{})
+
+func (pi *PostgresIdempotency) ReserveKey(ctx context.Context, key *Key, ttl time.Duration) (*Key, error) {
+	storedKey := &Key{}
+	conflict := sql.Null[ConflictReason]{}
+	exists := 0
+	if err := pi.db.QueryRowxContext(ctx, reserveKey, key.Key, key.UserId, key.QuestId, key.Amount, ttl.Seconds()).
+		Scan(
+			&storedKey.Key,
+			&storedKey.UserId,
+			&storedKey.QuestId,
+			&storedKey.Amount,
+			&storedKey.Outcome,
+			&conflict,
+			&storedKey.Step,
+			&storedKey.Total,
+			&storedKey.Created,
+			&exists,
+		); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Key was stored by concurrent request after the query snapshot was taken,
+			// so that request is still being executed.
+			return &Key{
+				Key:     key.Key,
+				UserId:  key.UserId,
+				QuestId: key.QuestId,
+				Amount:  key.Amount,
+				Outcome: Pending,
+			}, ErrorKeyExists
+		}
+		return nil, errors.Wrapf(err, "can't reserve idempotency key %q", key.Key)
+	}
+
+	storedKey.Conflict = conflict.V
+
+	if exists == 1 {
+		return storedKey, ErrorKeyExists
+	}
+
+	return storedKey, nil
+}
+
+func (pi *PostgresIdempotency) SetOutcome(ctx context.Context, key string, outcome Outcome, conflict ConflictReason,
+	step, total uint32) error {
+	res, err := pi.db.ExecContext(ctx, setOutcome, key, outcome, conflict, step, total)
+	if err != nil {
+		return errors.Wrapf(err, "can't set outcome of idempotency key %q", key)
+	}
+
+	return checkAffected(res, key)
+}
+
+func (pi *PostgresIdempotency) DeleteKey(ctx context.Context, key string) error {
+	res, err := pi.db.ExecContext(ctx, deleteKey, key)
+	if err != nil {
+		return errors.Wrapf(err, "can't delete idempotency key %q", key)
+	}
+
+	return checkAffected(res, key)
+}
+
+func checkAffected(res sql.Result, key string) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "can't get affected rows for idempotency key %q", key)
+	}
+
+	if affected == 0 {
+		return ErrorKeyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package leaderboard
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+var (
+	ErrorUserNotFound      = errors.New("user with id not found")
+	ErrorUnsupportedWindow = errors.New("balance leaderboard is only all-time")
+)
+
+//go:generate mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=LeaderboardRepository . Repository
+
+type Repository interface {
+	// GetLeaderboard
+	// Returns top users by metric in window sorted by rank and id, deleted users are not ranked.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUnsupportedWindow
+	GetLeaderboard(ctx context.Context, query *LeaderboardQuery) ([]Entry, error)
+
+	// GetRank
+	// Returns position of user by metric in window, user without earned points has zero score.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	//   - ErrorUnsupportedWindow
+	GetRank(ctx context.Context, userId types.Id, window types.LeaderboardWindow,
+		metric types.LeaderboardMetric) (*Entry, error)
+}
@@ -0,0 +1,247 @@
+package leaderboard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	stdtime "time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"vk_quests/internal/pkg/types"
+)
+
+var testError = errors.New("test error")
+
+type LeaderboardRepositorySuite struct {
+	suite.Suite
+	leaderboardRepository *PostgresLeaderboard
+	mock                  sqlxmock.Sqlmock
+}
+
+func (lrs *LeaderboardRepositorySuite) BeforeEach(t provider.T) {
+	db, mock, err := sqlxmock.Newx(sqlxmock.QueryMatcherOption(sqlxmock.QueryMatcherEqual))
+	t.Require().NoError(err)
+	lrs.leaderboardRepository = NewPostgresLeaderboard(db)
+	lrs.mock = mock
+}
+
+func (lrs *LeaderboardRepositorySuite) AfterEach(t provider.T) {
+	t.Require().NoError(lrs.mock.ExpectationsWereMet())
+}
+
+var entryColumns = []string{"rank", "id", "name", "score"}
+
+func (lrs *LeaderboardRepositorySuite) TestGetLeaderboardFunction(t provider.T) {
+	t.Title("GetLeaderboard function of Leaderboard repository")
+	t.NewStep("Init test data")
+	entries := []Entry{
+		{Rank: 1, UserId: 3, Name: "First", Score: 40},
+		{Rank: 2, UserId: 1, Name: "Second", Score: 25},
+		{Rank: 2, UserId: 2, Name: "Third", Score: 25},
+	}
+
+	entryRows := func() *sqlxmock.Rows {
+		rows := sqlxmock.NewRows(entryColumns)
+		for _, entry := range entries {
+			rows.AddRow(entry.Rank, entry.UserId, entry.Name, entry.Score)
+		}
+		return rows
+	}
+
+	t.WithNewStep("Correct earned in week execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(fmt.Sprintf(getEarnedLeaderboard, periodStarts[types.LeaderboardWeek])).
+			WithArgs(string(types.LeaderboardWeek), uint64(3)).
+			WillReturnRows(entryRows())
+
+		t.NewStep("Check result")
+		res, err := lrs.leaderboardRepository.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardWeek,
+			Metric: types.LeaderboardEarned,
+			Limit:  3,
+		})
+		t.Require().NoError(err)
+		t.Require().EqualValues(entries, res)
+	})
+
+	t.WithNewStep("Correct balance execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(getBalanceLeaderboard).
+			WithArgs(uint64(3)).
+			WillReturnRows(entryRows())
+
+		t.NewStep("Check result")
+		res, err := lrs.leaderboardRepository.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardAllTime,
+			Metric: types.LeaderboardBalance,
+			Limit:  3,
+		})
+		t.Require().NoError(err)
+		t.Require().EqualValues(entries, res)
+	})
+
+	t.WithNewStep("Correct empty execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(fmt.Sprintf(getEarnedLeaderboard, periodStarts[types.LeaderboardAllTime])).
+			WithArgs(string(types.LeaderboardAllTime), uint64(3)).
+			WillReturnRows(sqlxmock.NewRows(entryColumns))
+
+		t.NewStep("Check result")
+		res, err := lrs.leaderboardRepository.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardAllTime,
+			Metric: types.LeaderboardEarned,
+			Limit:  3,
+		})
+		t.Require().NoError(err)
+		t.Require().Empty(res)
+	})
+
+	t.WithNewStep("Error balance in month execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		_, err := lrs.leaderboardRepository.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardMonth,
+			Metric: types.LeaderboardBalance,
+			Limit:  3,
+		})
+		t.Require().ErrorIs(err, ErrorUnsupportedWindow)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(fmt.Sprintf(getEarnedLeaderboard, periodStarts[types.LeaderboardMonth])).
+			WithArgs(string(types.LeaderboardMonth), uint64(3)).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := lrs.leaderboardRepository.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardMonth,
+			Metric: types.LeaderboardEarned,
+			Limit:  3,
+		})
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Scan error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(getBalanceLeaderboard).
+			WithArgs(uint64(3)).
+			WillReturnRows(sqlxmock.NewRows(entryColumns).AddRow("first", 1, "First", 40))
+
+		t.NewStep("Check result")
+		_, err := lrs.leaderboardRepository.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardAllTime,
+			Metric: types.LeaderboardBalance,
+			Limit:  3,
+		})
+		t.Require().Error(err)
+	})
+}
+
+func (lrs *LeaderboardRepositorySuite) TestGetRankFunction(t provider.T) {
+	t.Title("GetRank function of Leaderboard repository")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	entry := &Entry{Rank: 2, UserId: userId, Name: "Second", Score: 25}
+
+	t.WithNewStep("Correct earned in month execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(fmt.Sprintf(getEarnedRank, periodStarts[types.LeaderboardMonth])).
+			WithArgs(userId, string(types.LeaderboardMonth)).
+			WillReturnRows(sqlxmock.NewRows(entryColumns).AddRow(entry.Rank, entry.UserId, entry.Name, entry.Score))
+
+		t.NewStep("Check result")
+		res, err := lrs.leaderboardRepository.GetRank(context.Background(), userId,
+			types.LeaderboardMonth, types.LeaderboardEarned)
+		t.Require().NoError(err)
+		t.Require().EqualValues(entry, res)
+	})
+
+	t.WithNewStep("Correct balance execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(getBalanceRank).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(entryColumns).AddRow(entry.Rank, entry.UserId, entry.Name, entry.Score))
+
+		t.NewStep("Check result")
+		res, err := lrs.leaderboardRepository.GetRank(context.Background(), userId,
+			types.LeaderboardAllTime, types.LeaderboardBalance)
+		t.Require().NoError(err)
+		t.Require().EqualValues(entry, res)
+	})
+
+	t.WithNewStep("Error user not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(getBalanceRank).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(entryColumns))
+
+		t.NewStep("Check result")
+		_, err := lrs.leaderboardRepository.GetRank(context.Background(), userId,
+			types.LeaderboardAllTime, types.LeaderboardBalance)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Error balance in week execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		_, err := lrs.leaderboardRepository.GetRank(context.Background(), userId,
+			types.LeaderboardWeek, types.LeaderboardBalance)
+		t.Require().ErrorIs(err, ErrorUnsupportedWindow)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectQuery(fmt.Sprintf(getEarnedRank, periodStarts[types.LeaderboardAllTime])).
+			WithArgs(userId, string(types.LeaderboardAllTime)).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := lrs.leaderboardRepository.GetRank(context.Background(), userId,
+			types.LeaderboardAllTime, types.LeaderboardEarned)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (lrs *LeaderboardRepositorySuite) TestSubtractScoreFunction(t provider.T) {
+	t.Title("SubtractScore function of Leaderboard repository")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	completed := stdtime.Date(2024, 1, 1, 0, 0, 0, 0, stdtime.UTC)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectExec(SubtractScoreQuery).
+			WithArgs(userId, types.Cost(15), completed).
+			WillReturnResult(sqlxmock.NewResult(0, 3))
+		lrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		tx, err := lrs.leaderboardRepository.db.BeginTxx(context.Background(), nil)
+		t.Require().NoError(err)
+		t.Require().NoError(SubtractScore(context.Background(), tx, userId, 15, completed))
+		t.Require().NoError(tx.Commit())
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectExec(SubtractScoreQuery).
+			WithArgs(userId, types.Cost(15), completed).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		tx, err := lrs.leaderboardRepository.db.BeginTxx(context.Background(), nil)
+		t.Require().NoError(err)
+		t.Require().ErrorIs(SubtractScore(context.Background(), tx, userId, 15, completed), testError)
+		t.Require().NoError(tx.Rollback())
+	})
+}
+
+func TestRunLeaderboardRepositorySuite(t *testing.T) {
+	suite.RunSuite(t, new(LeaderboardRepositorySuite))
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/repository/leaderboard (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=LeaderboardRepository . Repository
+//
+
+// Package mr is a generated GoMock package.
+package mr
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	leaderboard "vk_quests/internal/repository/leaderboard"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// LeaderboardRepository is a mock of Repository interface.
+type LeaderboardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *LeaderboardRepositoryMockRecorder
+}
+
+// LeaderboardRepositoryMockRecorder is the mock recorder for LeaderboardRepository.
+type LeaderboardRepositoryMockRecorder struct {
+	mock *LeaderboardRepository
+}
+
+// NewLeaderboardRepository creates a new mock instance.
+func NewLeaderboardRepository(ctrl *gomock.Controller) *LeaderboardRepository {
+	mock := &LeaderboardRepository{ctrl: ctrl}
+	mock.recorder = &LeaderboardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *LeaderboardRepository) EXPECT() *LeaderboardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLeaderboard mocks base method.
+func (m *LeaderboardRepository) GetLeaderboard(arg0 context.Context, arg1 *leaderboard.LeaderboardQuery) ([]leaderboard.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", arg0, arg1)
+	ret0, _ := ret[0].([]leaderboard.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *LeaderboardRepositoryMockRecorder) GetLeaderboard(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*LeaderboardRepository)(nil).GetLeaderboard), arg0, arg1)
+}
+
+// GetRank mocks base method.
+func (m *LeaderboardRepository) GetRank(arg0 context.Context, arg1 types.Id, arg2 types.LeaderboardWindow, arg3 types.LeaderboardMetric) (*leaderboard.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRank", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*leaderboard.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRank indicates an expected call of GetRank.
+func (mr *LeaderboardRepositoryMockRecorder) GetRank(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRank", reflect.TypeOf((*LeaderboardRepository)(nil).GetRank), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,20 @@
+package leaderboard
+
+import (
+	"vk_quests/internal/pkg/types"
+)
+
+type LeaderboardQuery struct {
+	Window types.LeaderboardWindow
+	Metric types.LeaderboardMetric
+	Limit  uint64
+}
+
+// Entry is position of user in leaderboard.
+// Users with equal score share rank, next rank is skipped for each of them.
+type Entry struct {
+	Rank   uint64
+	UserId types.Id
+	Name   string
+	Score  int64 // sum of awards earned in window or current balance
+}
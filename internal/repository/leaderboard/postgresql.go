@@ -0,0 +1,190 @@
+package leaderboard
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	stdtime "time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+// Queries of AddScore and SubtractScore are exported, so repositories calling them inside their transactions
+// can expect them in tests.
+// Scores are grouped by start of window in time zone of database, the same one balance_history.created is stored in.
+const (
+	AddScoreQuery = `
+		INSERT INTO leaderboard_scores (period, period_start, user_id, score) VALUES
+			('all', 'epoch', $1, $2),
+			('week', date_trunc('week', localtimestamp), $1, $2),
+			('month', date_trunc('month', localtimestamp), $1, $2)
+		ON CONFLICT (period, period_start, user_id) DO UPDATE SET score = leaderboard_scores.score + EXCLUDED.score
+	`
+
+	SubtractScoreQuery = `
+		UPDATE leaderboard_scores SET score = score - $2
+		WHERE user_id = $1 AND (period = 'all'
+			OR period = 'week' AND period_start = date_trunc('week', $3::timestamp)
+			OR period = 'month' AND period_start = date_trunc('month', $3::timestamp))
+	`
+)
+
+// Earned queries take start of window from periodStarts, rank is computed over materialised scores,
+// so top is read from leaderboard_scores_rank_idx and rank of user counts only users above it.
+const (
+	getEarnedLeaderboard = `
+		SELECT rank() OVER (ORDER BY score DESC), users.id, users.name, score
+		FROM leaderboard_scores JOIN users ON (leaderboard_scores.user_id = users.id)
+		WHERE period = $1 AND period_start = %s AND users.deleted_at IS NULL
+		ORDER BY score DESC, leaderboard_scores.user_id
+		LIMIT $2
+	`
+
+	getEarnedRank = `
+		SELECT 1 + (
+			SELECT count(*) FROM leaderboard_scores JOIN users AS other ON (leaderboard_scores.user_id = other.id)
+			WHERE period = $2 AND period_start = %[1]s AND other.deleted_at IS NULL
+				AND score > COALESCE(own.score, 0)
+		), users.id, users.name, COALESCE(own.score, 0)
+		FROM users LEFT JOIN leaderboard_scores AS own
+			ON (own.user_id = users.id AND own.period = $2 AND own.period_start = %[1]s)
+		WHERE users.id = $1 AND users.deleted_at IS NULL
+	`
+
+	getBalanceLeaderboard = `
+		SELECT rank() OVER (ORDER BY balance DESC), id, name, balance
+		FROM users WHERE deleted_at IS NULL
+		ORDER BY balance DESC, id
+		LIMIT $1
+	`
+
+	getBalanceRank = `
+		SELECT 1 + (
+			SELECT count(*) FROM users AS other WHERE other.deleted_at IS NULL AND other.balance > users.balance
+		), id, name, balance
+		FROM users WHERE id = $1 AND deleted_at IS NULL
+	`
+)
+
+var periodStarts = map[types.LeaderboardWindow]string{
+	types.LeaderboardAllTime: `'epoch'::timestamp`,
+	types.LeaderboardWeek:    `date_trunc('week', localtimestamp)`,
+	types.LeaderboardMonth:   `date_trunc('month', localtimestamp)`,
+}
+
+type PostgresLeaderboard struct {
+	db *sqlx.DB
+}
+
+func NewPostgresLeaderboard(db *sqlx.DB) *PostgresLeaderboard {
+	return &PostgresLeaderboard{
+		db: db,
+	}
+}
+
+var _ = Repository(&PostgresLeaderboard{})
+
+// AddScore adds award for quest completion to scores of user in all windows.
+// It must be called inside database transaction storing completion in balance_history, so scores match it.
+// Returns Error:
+//   - SQLError
+func AddScore(ctx context.Context, tx *sqlx.Tx, userId types.Id, award types.Cost) error {
+	if _, err := tx.ExecContext(ctx, AddScoreQuery, userId, award); err != nil {
+		return errors.Wrapf(err, "can't add score of user with id %d", userId)
+	}
+
+	return nil
+}
+
+// SubtractScore removes award of revoked completion from scores of user in windows containing time of completion.
+// It must be called inside database transaction deleting completion from balance_history, so scores match it.
+// Returns Error:
+//   - SQLError
+func SubtractScore(ctx context.Context, tx *sqlx.Tx, userId types.Id, award types.Cost,
+	completed stdtime.Time) error {
+	if _, err := tx.ExecContext(ctx, SubtractScoreQuery, userId, award, completed); err != nil {
+		return errors.Wrapf(err, "can't subtract score of user with id %d", userId)
+	}
+
+	return nil
+}
+
+// buildQuery chooses query of metric and fills start of window in it.
+func buildQuery(window types.LeaderboardWindow, metric types.LeaderboardMetric,
+	earnedQuery, balanceQuery string) (string, error) {
+	switch metric {
+	case types.LeaderboardEarned:
+		periodStart, ok := periodStarts[window]
+		if !ok {
+			return "", errors.Errorf("unknown leaderboard window %q", window)
+		}
+		return fmt.Sprintf(earnedQuery, periodStart), nil
+	case types.LeaderboardBalance:
+		// Balance is current, so it can't be split by windows
+		if window != types.LeaderboardAllTime {
+			return "", ErrorUnsupportedWindow
+		}
+		return balanceQuery, nil
+	default:
+		return "", errors.Errorf("unknown leaderboard metric %q", metric)
+	}
+}
+
+func (pl *PostgresLeaderboard) GetLeaderboard(ctx context.Context, query *LeaderboardQuery) ([]Entry, error) {
+	sqlQuery, err := buildQuery(query.Window, query.Metric, getEarnedLeaderboard, getBalanceLeaderboard)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []any{query.Limit}
+	if query.Metric == types.LeaderboardEarned {
+		args = []any{string(query.Window), query.Limit}
+	}
+
+	rows, err := pl.db.QueryxContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't get %s leaderboard of window %s", query.Metric, query.Window)
+	}
+	defer rows.Close()
+
+	entries := make([]Entry, 0)
+	for rows.Next() {
+		entry := Entry{}
+		if err := rows.Scan(&entry.Rank, &entry.UserId, &entry.Name, &entry.Score); err != nil {
+			return nil, errors.Wrapf(err, "can't scan leaderboard entry")
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "can't get %s leaderboard of window %s", query.Metric, query.Window)
+	}
+
+	return entries, nil
+}
+
+func (pl *PostgresLeaderboard) GetRank(ctx context.Context, userId types.Id, window types.LeaderboardWindow,
+	metric types.LeaderboardMetric) (*Entry, error) {
+	sqlQuery, err := buildQuery(window, metric, getEarnedRank, getBalanceRank)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []any{userId}
+	if metric == types.LeaderboardEarned {
+		args = []any{userId, string(window)}
+	}
+
+	entry := &Entry{}
+	if err := pl.db.QueryRowxContext(ctx, sqlQuery, args...).
+		Scan(&entry.Rank, &entry.UserId, &entry.Name, &entry.Score); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrorUserNotFound
+		}
+		return nil, errors.Wrapf(err, "can't get %s rank of user with id %d in window %s", metric, userId, window)
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,47 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+var (
+	ErrorUserNotFound      = errors.New("user with id not found")
+	ErrorInsufficientFunds = errors.New("insufficient funds")
+
+	ErrorRecipientNotFound     = errors.New("recipient with id not found")
+	ErrorTransferLimitExceeded = errors.New("daily transfer limit exceeded")
+)
+
+//go:generate mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=LedgerRepository . Repository
+
+type Repository interface {
+	// Debit
+	// Withdraws amount from user balance and stores debit transaction.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	//   - ErrorInsufficientFunds
+	Debit(ctx context.Context, userId types.Id, amount uint64, reason string) (*Transaction, error)
+
+	// Transfer
+	// Withdraws amount from sender balance, adds it to recipient balance and stores both transactions
+	// and both sides in balance history.
+	// Sum of transfers of sender during last day including this one can't exceed dailyLimit, 0 means no limit.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	//   - ErrorRecipientNotFound
+	//   - ErrorInsufficientFunds
+	//   - ErrorTransferLimitExceeded
+	Transfer(ctx context.Context, from, to types.Id, amount, dailyLimit uint64) (*Transfer, error)
+
+	// Reconcile
+	// Compares user balance with sum of ledger transactions and replaces balance with the sum if they differ.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	Reconcile(ctx context.Context, userId types.Id) (*Reconciliation, error)
+}
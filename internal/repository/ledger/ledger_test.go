@@ -0,0 +1,729 @@
+package ledger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	pkgtime "vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+)
+
+var testError = errors.New("test error")
+
+type LedgerRepositorySuite struct {
+	suite.Suite
+	ledgerRepository *PostgresLedger
+	mock             sqlxmock.Sqlmock
+}
+
+func (lrs *LedgerRepositorySuite) BeforeEach(t provider.T) {
+	db, mock, err := sqlxmock.Newx(sqlxmock.QueryMatcherOption(sqlxmock.QueryMatcherEqual))
+	t.Require().NoError(err)
+	lrs.ledgerRepository = NewPostgresLedger(db)
+	lrs.mock = mock
+}
+
+func (lrs *LedgerRepositorySuite) AfterEach(t provider.T) {
+	t.Require().NoError(lrs.mock.ExpectationsWereMet())
+}
+
+func (lrs *LedgerRepositorySuite) TestDebitFunction(t provider.T) {
+	t.Title("Debit function of Ledger repository")
+	t.NewStep("Init test data")
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	transaction := &Transaction{
+		ID:      3,
+		UserId:  1,
+		Kind:    Debit,
+		Amount:  15,
+		Reason:  "shop",
+		Balance: 5,
+		Created: pkgtime.FormattedTime{Time: created},
+	}
+
+	balanceColumns := []string{
+		"balance",
+	}
+
+	transactionColumns := []string{
+		"id", "created",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(transaction.UserId, transaction.Amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(transaction.Balance))
+		lrs.mock.ExpectQuery(CreateTransactionQuery).
+			WithArgs(transaction.UserId, Debit, transaction.Amount, transaction.Reason, nil, nil, transaction.Balance).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(transaction.ID, created))
+		lrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		res, err := lrs.ledgerRepository.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().NoError(err)
+		t.Require().EqualValues(transaction, res)
+	})
+
+	t.WithNewStep("Insufficient funds execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(transaction.UserId, transaction.Amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectQuery(GetBalanceQuery).
+			WithArgs(transaction.UserId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(10))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().ErrorIs(err, ErrorInsufficientFunds)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(transaction.UserId, transaction.Amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectQuery(GetBalanceQuery).
+			WithArgs(transaction.UserId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Postgres error on get balance query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(transaction.UserId, transaction.Amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectQuery(GetBalanceQuery).
+			WithArgs(transaction.UserId).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on debit query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(transaction.UserId, transaction.Amount).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on create transaction query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(transaction.UserId, transaction.Amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(transaction.Balance))
+		lrs.mock.ExpectQuery(CreateTransactionQuery).
+			WithArgs(transaction.UserId, Debit, transaction.Amount, transaction.Reason, nil, nil, transaction.Balance).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Begin error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Commit error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(transaction.UserId, transaction.Amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(transaction.Balance))
+		lrs.mock.ExpectQuery(CreateTransactionQuery).
+			WithArgs(transaction.UserId, Debit, transaction.Amount, transaction.Reason, nil, nil, transaction.Balance).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(transaction.ID, created))
+		lrs.mock.ExpectCommit().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (lrs *LedgerRepositorySuite) TestTransferFunction(t provider.T) {
+	t.Title("Transfer function of Ledger repository")
+	t.NewStep("Init test data")
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	from, to := types.Id(2), types.Id(1)
+	amount, dailyLimit := uint64(15), uint64(100)
+	transfer := &Transfer{
+		Debit: Transaction{
+			ID:             3,
+			UserId:         from,
+			Kind:           Debit,
+			Amount:         amount,
+			Reason:         TransferReason,
+			CounterpartyId: &to,
+			Balance:        5,
+			Created:        pkgtime.FormattedTime{Time: created},
+		},
+		Credit: Transaction{
+			ID:             4,
+			UserId:         to,
+			Kind:           Credit,
+			Amount:         amount,
+			Reason:         TransferReason,
+			CounterpartyId: &from,
+			Balance:        40,
+			Created:        pkgtime.FormattedTime{Time: created},
+		},
+	}
+
+	balanceColumns := []string{
+		"balance",
+	}
+
+	sumColumns := []string{
+		"coalesce",
+	}
+
+	transactionColumns := []string{
+		"id", "created",
+	}
+
+	expectLock := func() {
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(to).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(25))
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(from).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(20))
+	}
+
+	expectApply := func() {
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(from, amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(transfer.Debit.Balance))
+		lrs.mock.ExpectQuery(CreateTransactionQuery).
+			WithArgs(from, Debit, amount, TransferReason, nil, to, transfer.Debit.Balance).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(transfer.Debit.ID, created))
+		lrs.mock.ExpectExec(createTransferHistory).
+			WithArgs(from, amount, types.SourceTransferOut, to, transfer.Debit.Balance).
+			WillReturnResult(sqlxmock.NewResult(1, 1))
+		lrs.mock.ExpectQuery(CreditQuery).
+			WithArgs(to, amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(transfer.Credit.Balance))
+		lrs.mock.ExpectQuery(CreateTransactionQuery).
+			WithArgs(to, Credit, amount, TransferReason, nil, from, transfer.Credit.Balance).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(transfer.Credit.ID, created))
+		lrs.mock.ExpectExec(createTransferHistory).
+			WithArgs(to, amount, types.SourceTransferIn, from, transfer.Credit.Balance).
+			WillReturnResult(sqlxmock.NewResult(1, 1))
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		expectLock()
+		lrs.mock.ExpectQuery(getTransferred).
+			WithArgs(from, TransferReason).
+			WillReturnRows(sqlxmock.NewRows(sumColumns).AddRow(85))
+		expectApply()
+		lrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		res, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, dailyLimit)
+		t.Require().NoError(err)
+		t.Require().EqualValues(transfer, res)
+	})
+
+	t.WithNewStep("Correct execute without limit", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		expectLock()
+		expectApply()
+		lrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		res, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, 0)
+		t.Require().NoError(err)
+		t.Require().EqualValues(transfer, res)
+	})
+
+	t.WithNewStep("Daily limit exceeded execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		expectLock()
+		lrs.mock.ExpectQuery(getTransferred).
+			WithArgs(from, TransferReason).
+			WillReturnRows(sqlxmock.NewRows(sumColumns).AddRow(86))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, dailyLimit)
+		t.Require().ErrorIs(err, ErrorTransferLimitExceeded)
+	})
+
+	t.WithNewStep("Sender not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(to).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(25))
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(from).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, dailyLimit)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Recipient not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(to).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, dailyLimit)
+		t.Require().ErrorIs(err, ErrorRecipientNotFound)
+	})
+
+	t.WithNewStep("Insufficient funds execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		expectLock()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(from, amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectQuery(GetBalanceQuery).
+			WithArgs(from).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(10))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, 0)
+		t.Require().ErrorIs(err, ErrorInsufficientFunds)
+	})
+
+	t.WithNewStep("Postgres error on lock query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(to).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, dailyLimit)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on transferred query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		expectLock()
+		lrs.mock.ExpectQuery(getTransferred).
+			WithArgs(from, TransferReason).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, dailyLimit)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on history query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		expectLock()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(from, amount).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(transfer.Debit.Balance))
+		lrs.mock.ExpectQuery(CreateTransactionQuery).
+			WithArgs(from, Debit, amount, TransferReason, nil, to, transfer.Debit.Balance).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(transfer.Debit.ID, created))
+		lrs.mock.ExpectExec(createTransferHistory).
+			WithArgs(from, amount, types.SourceTransferOut, to, transfer.Debit.Balance).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, 0)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Begin error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, dailyLimit)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Commit error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		expectLock()
+		expectApply()
+		lrs.mock.ExpectCommit().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Transfer(context.Background(), from, to, amount, 0)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (lrs *LedgerRepositorySuite) TestRevokeFunction(t provider.T) {
+	t.Title("Revoke function of Ledger")
+	t.NewStep("Init test data")
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	questId := types.Id(2)
+
+	reward := func(amount uint64) *Transaction {
+		return &Transaction{
+			UserId:  1,
+			Amount:  amount,
+			Reason:  QuestRevoke,
+			QuestId: &questId,
+		}
+	}
+
+	balanceColumns := []string{
+		"balance",
+	}
+
+	transactionColumns := []string{
+		"id", "created",
+	}
+
+	revoke := func(transaction *Transaction, policy RevokePolicy) error {
+		tx, err := lrs.ledgerRepository.db.BeginTxx(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		return Revoke(context.Background(), tx, transaction, policy)
+	}
+
+	expectTransaction := func(amount uint64, balance int64) {
+		lrs.mock.ExpectQuery(CreateTransactionQuery).
+			WithArgs(types.Id(1), Debit, amount, QuestRevoke, questId, nil, balance).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(3, created))
+	}
+
+	t.WithNewStep("Correct reject policy execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(types.Id(1), uint64(15)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(5))
+		expectTransaction(15, 5)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		transaction := reward(15)
+		t.Require().NoError(revoke(transaction, RevokeReject))
+		t.Require().EqualValues(Debit, transaction.Kind)
+		t.Require().EqualValues(15, transaction.Amount)
+		t.Require().EqualValues(5, transaction.Balance)
+		t.Require().EqualValues(3, transaction.ID)
+	})
+
+	t.WithNewStep("Insufficient funds with reject policy execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(DebitQuery).
+			WithArgs(types.Id(1), uint64(15)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectQuery(GetBalanceQuery).
+			WithArgs(types.Id(1)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(10))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(revoke(reward(15), RevokeReject), ErrorInsufficientFunds)
+	})
+
+	t.WithNewStep("Correct partial policy execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(GetBalanceQuery).
+			WithArgs(types.Id(1)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(10))
+		lrs.mock.ExpectQuery(RevokeQuery).
+			WithArgs(types.Id(1), uint64(10)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(0))
+		expectTransaction(10, 0)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		transaction := reward(15)
+		t.Require().NoError(revoke(transaction, RevokePartial))
+		t.Require().EqualValues(10, transaction.Amount)
+		t.Require().EqualValues(0, transaction.Balance)
+	})
+
+	t.WithNewStep("Partial policy with negative balance execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(GetBalanceQuery).
+			WithArgs(types.Id(1)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(-5))
+		lrs.mock.ExpectQuery(RevokeQuery).
+			WithArgs(types.Id(1), uint64(0)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(-5))
+		expectTransaction(0, -5)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		transaction := reward(15)
+		t.Require().NoError(revoke(transaction, RevokePartial))
+		t.Require().EqualValues(0, transaction.Amount)
+	})
+
+	t.WithNewStep("Correct negative policy execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(RevokeQuery).
+			WithArgs(types.Id(1), uint64(15)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(-5))
+		expectTransaction(15, -5)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		transaction := reward(15)
+		t.Require().NoError(revoke(transaction, RevokeNegative))
+		t.Require().EqualValues(15, transaction.Amount)
+		t.Require().EqualValues(-5, transaction.Balance)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(RevokeQuery).
+			WithArgs(types.Id(1), uint64(15)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(revoke(reward(15), RevokeNegative), ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Postgres error on get balance query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(GetBalanceQuery).
+			WithArgs(types.Id(1)).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(revoke(reward(15), RevokePartial), testError)
+	})
+
+	t.WithNewStep("Postgres error on revoke query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(RevokeQuery).
+			WithArgs(types.Id(1), uint64(15)).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(revoke(reward(15), RevokeNegative), testError)
+	})
+
+	t.WithNewStep("Unknown policy execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		t.Require().Error(revoke(reward(15), RevokePolicy("unknown")))
+	})
+}
+
+func (lrs *LedgerRepositorySuite) TestParseRevokePolicyFunction(t provider.T) {
+	t.Title("ParseRevokePolicy function of Ledger")
+
+	t.WithNewStep("Known policies execute", func(t provider.StepCtx) {
+		for _, policy := range []RevokePolicy{RevokeReject, RevokePartial, RevokeNegative} {
+			res, err := ParseRevokePolicy(string(policy))
+			t.Require().NoError(err)
+			t.Require().Equal(policy, res)
+		}
+	})
+
+	t.WithNewStep("Unknown policy execute", func(t provider.StepCtx) {
+		_, err := ParseRevokePolicy("unknown")
+		t.Require().Error(err)
+	})
+}
+
+func (lrs *LedgerRepositorySuite) TestReconcileFunction(t provider.T) {
+	t.Title("Reconcile function of Ledger repository")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+
+	balanceColumns := []string{
+		"balance",
+	}
+
+	sumColumns := []string{
+		"coalesce",
+	}
+
+	t.WithNewStep("Balance matches ledger execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(20))
+		lrs.mock.ExpectQuery(getLedgerBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(sumColumns).AddRow(20))
+		lrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		res, err := lrs.ledgerRepository.Reconcile(context.Background(), userId)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Reconciliation{UserId: userId, Cached: 20, Ledger: 20}, res)
+	})
+
+	t.WithNewStep("Balance differs from ledger execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(25))
+		lrs.mock.ExpectQuery(getLedgerBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(sumColumns).AddRow(20))
+		lrs.mock.ExpectExec(setBalance).
+			WithArgs(userId, int64(20)).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		lrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		res, err := lrs.ledgerRepository.Reconcile(context.Background(), userId)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Reconciliation{UserId: userId, Cached: 25, Ledger: 20}, res)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Reconcile(context.Background(), userId)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Negative ledger balance execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(25))
+		lrs.mock.ExpectQuery(getLedgerBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(sumColumns).AddRow(-5))
+		lrs.mock.ExpectExec(setBalance).
+			WithArgs(userId, int64(-5)).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		lrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		res, err := lrs.ledgerRepository.Reconcile(context.Background(), userId)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Reconciliation{UserId: userId, Cached: 25, Ledger: -5}, res)
+	})
+
+	t.WithNewStep("Postgres error on lock query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(userId).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Reconcile(context.Background(), userId)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on ledger balance query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(25))
+		lrs.mock.ExpectQuery(getLedgerBalance).
+			WithArgs(userId).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Reconcile(context.Background(), userId)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on set balance query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lrs.mock.ExpectBegin()
+		lrs.mock.ExpectQuery(lockBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(25))
+		lrs.mock.ExpectQuery(getLedgerBalance).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(sumColumns).AddRow(20))
+		lrs.mock.ExpectExec(setBalance).
+			WithArgs(userId, int64(20)).
+			WillReturnError(testError)
+		lrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := lrs.ledgerRepository.Reconcile(context.Background(), userId)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func TestRunLedgerRepositorySuite(t *testing.T) {
+	suite.RunSuite(t, new(LedgerRepositorySuite))
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/repository/ledger (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=LedgerRepository . Repository
+//
+
+// Package mr is a generated GoMock package.
+package mr
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	ledger "vk_quests/internal/repository/ledger"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// LedgerRepository is a mock of Repository interface.
+type LedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *LedgerRepositoryMockRecorder
+}
+
+// LedgerRepositoryMockRecorder is the mock recorder for LedgerRepository.
+type LedgerRepositoryMockRecorder struct {
+	mock *LedgerRepository
+}
+
+// NewLedgerRepository creates a new mock instance.
+func NewLedgerRepository(ctrl *gomock.Controller) *LedgerRepository {
+	mock := &LedgerRepository{ctrl: ctrl}
+	mock.recorder = &LedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *LedgerRepository) EXPECT() *LedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Debit mocks base method.
+func (m *LedgerRepository) Debit(arg0 context.Context, arg1 types.Id, arg2 uint64, arg3 string) (*ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*ledger.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Debit indicates an expected call of Debit.
+func (mr *LedgerRepositoryMockRecorder) Debit(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debit", reflect.TypeOf((*LedgerRepository)(nil).Debit), arg0, arg1, arg2, arg3)
+}
+
+// Reconcile mocks base method.
+func (m *LedgerRepository) Reconcile(arg0 context.Context, arg1 types.Id) (*ledger.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1)
+	ret0, _ := ret[0].(*ledger.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *LedgerRepositoryMockRecorder) Reconcile(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*LedgerRepository)(nil).Reconcile), arg0, arg1)
+}
+
+// Transfer mocks base method.
+func (m *LedgerRepository) Transfer(arg0 context.Context, arg1, arg2 types.Id, arg3, arg4 uint64) (*ledger.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*ledger.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *LedgerRepositoryMockRecorder) Transfer(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*LedgerRepository)(nil).Transfer), arg0, arg1, arg2, arg3, arg4)
+}
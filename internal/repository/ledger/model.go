@@ -0,0 +1,72 @@
+package ledger
+
+import (
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+)
+
+type Kind string
+
+const (
+	Credit Kind = "credit"
+	Debit  Kind = "debit"
+)
+
+const (
+	// QuestReward is reason of credits paid for quest completion.
+	QuestReward = "quest_reward"
+	// ReferralBonus is reason of credits paid to invited user and its referrer for completion of referral quest.
+	ReferralBonus = "referral_bonus"
+	// TransferReason is reason of both transactions of transfer between users.
+	TransferReason = "transfer"
+	// QuestRevoke is reason of debits clawing back reward of revoked quest completion.
+	QuestRevoke = "quest_revoke"
+	// ReferralRevoke is reason of debits clawing back referral bonuses, when referral quest completion is revoked.
+	ReferralRevoke = "referral_revoke"
+	// OpeningBalance is reason of credits backfilled by init.sql for balances accumulated before the ledger.
+	OpeningBalance = "opening_balance"
+)
+
+// RevokePolicy decides how reward of revoked completion is clawed back, if user has already spent it.
+type RevokePolicy string
+
+const (
+	RevokeReject   RevokePolicy = "reject"   // revocation fails with ErrorInsufficientFunds
+	RevokePartial  RevokePolicy = "partial"  // only current balance is clawed back
+	RevokeNegative RevokePolicy = "negative" // whole reward is clawed back, balance may become negative
+)
+
+func ParseRevokePolicy(policy string) (RevokePolicy, error) {
+	switch p := RevokePolicy(policy); p {
+	case RevokeReject, RevokePartial, RevokeNegative:
+		return p, nil
+	default:
+		return "", errors.Errorf("unknown revoke policy %q", policy)
+	}
+}
+
+type Transaction struct {
+	ID             types.Id
+	UserId         types.Id
+	Kind           Kind
+	Amount         uint64
+	Reason         string
+	QuestId        *types.Id // set for quest rewards
+	CounterpartyId *types.Id // set for transfers: recipient for debit and sender for credit
+	Balance        int64     // balance of user after transaction
+	Created        time.FormattedTime
+}
+
+// Transfer is pair of transactions moving amount from sender to recipient.
+type Transfer struct {
+	Debit  Transaction // transaction of sender
+	Credit Transaction // transaction of recipient
+}
+
+// Reconciliation is result of comparison of cached user balance with sum of ledger transactions.
+type Reconciliation struct {
+	UserId types.Id
+	Cached int64 // balance stored in users before reconciliation
+	Ledger int64 // sum of transactions, stored in users after reconciliation
+}
@@ -0,0 +1,338 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+// Queries of Apply are exported, so repositories calling it inside their transactions can expect them in tests.
+// Balance of deleted users is frozen, so they are not found by queries changing it.
+const (
+	CreditQuery = `
+		UPDATE users SET balance = balance + $2 WHERE id = $1 AND deleted_at IS NULL RETURNING balance
+	`
+
+	DebitQuery = `
+		UPDATE users SET balance = balance - $2 WHERE id = $1 AND deleted_at IS NULL AND balance >= $2 RETURNING balance
+	`
+
+	// RevokeQuery withdraws amount without check of balance, it is used by Revoke. Overdraft is raised
+	// to the debt of user, so check of balance on users table allows it.
+	RevokeQuery = `
+		UPDATE users SET balance = balance - $2, overdraft = GREATEST(overdraft, $2 - balance) WHERE id = $1 RETURNING balance
+	`
+
+	GetBalanceQuery = `
+		SELECT balance FROM users WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	CreateTransactionQuery = `
+		INSERT INTO transactions (user_id, kind, amount, reason, quest_id, counterparty_id, balance)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created
+	`
+)
+
+const (
+	lockBalance = `
+		SELECT balance FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+	`
+
+	getLedgerBalance = `
+		SELECT COALESCE(sum(CASE WHEN kind = 'credit' THEN amount ELSE -amount END), 0)
+		FROM transactions WHERE user_id = $1
+	`
+
+	setBalance = `
+		UPDATE users SET balance = $2 WHERE id = $1
+	`
+
+	// createTransferHistory stores side of transfer in history of user, transfers have no quest snapshot
+	createTransferHistory = `
+		INSERT INTO balance_history (user_id, award, base_award, source, counterparty_id, balance)
+		VALUES ($1, $2, $2, $3, $4, $5)
+	`
+
+	getTransferred = `
+		SELECT COALESCE(sum(amount), 0) FROM transactions
+		WHERE user_id = $1 AND kind = 'debit' AND reason = $2 AND created > now() - interval '1 day'
+	`
+)
+
+type PostgresLedger struct {
+	db *sqlx.DB
+}
+
+func NewPostgresLedger(db *sqlx.DB) *PostgresLedger {
+	return &PostgresLedger{
+		db: db,
+	}
+}
+
+var _ = Repository(&PostgresLedger{})
+
+// Apply changes cached balance of user by transaction and stores transaction in ledger.
+// It must be called inside database transaction, so balance and ledger are changed together.
+// ID, Balance and Created of transaction are filled.
+// Returns Error:
+//   - SQLError
+//   - ErrorUserNotFound
+//   - ErrorInsufficientFunds
+func Apply(ctx context.Context, tx *sqlx.Tx, transaction *Transaction) error {
+	query := CreditQuery
+	if transaction.Kind == Debit {
+		query = DebitQuery
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, transaction.UserId, transaction.Amount).
+		Scan(&transaction.Balance); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrapf(err, "can't apply %s to balance of user with id %d", transaction.Kind, transaction.UserId)
+		}
+
+		if transaction.Kind == Debit {
+			return checkDebit(ctx, tx, transaction.UserId)
+		}
+		return ErrorUserNotFound
+	}
+
+	return storeTransaction(ctx, tx, transaction)
+}
+
+// Revoke claws back reward of revoked quest completion with debit transaction according to policy.
+// It must be called inside database transaction with locked user row, so balance and ledger are changed together.
+// For RevokePartial amount of transaction is reduced to the part of user balance, which can be clawed back.
+// ID, Balance and Created of transaction are filled.
+// Returns Error:
+//   - SQLError
+//   - ErrorUserNotFound
+//   - ErrorInsufficientFunds
+func Revoke(ctx context.Context, tx *sqlx.Tx, transaction *Transaction, policy RevokePolicy) error {
+	transaction.Kind = Debit
+
+	switch policy {
+	case RevokeReject:
+		return Apply(ctx, tx, transaction)
+	case RevokePartial:
+		balance := int64(0)
+		if err := tx.QueryRowxContext(ctx, GetBalanceQuery, transaction.UserId).Scan(&balance); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrorUserNotFound
+			}
+			return errors.Wrapf(err, "can't get balance of user with id %d", transaction.UserId)
+		}
+		transaction.Amount = min(transaction.Amount, uint64(max(balance, 0)))
+	case RevokeNegative:
+	default:
+		return errors.Errorf("unknown revoke policy %q", policy)
+	}
+
+	if err := tx.QueryRowxContext(ctx, RevokeQuery, transaction.UserId, transaction.Amount).
+		Scan(&transaction.Balance); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorUserNotFound
+		}
+		return errors.Wrapf(err, "can't revoke reward from balance of user with id %d", transaction.UserId)
+	}
+
+	return storeTransaction(ctx, tx, transaction)
+}
+
+func storeTransaction(ctx context.Context, tx *sqlx.Tx, transaction *Transaction) error {
+	if err := tx.QueryRowxContext(ctx, CreateTransactionQuery,
+		transaction.UserId,
+		transaction.Kind,
+		transaction.Amount,
+		transaction.Reason,
+		transaction.QuestId,
+		transaction.CounterpartyId,
+		transaction.Balance,
+	).Scan(&transaction.ID, &transaction.Created); err != nil {
+		return errors.Wrapf(err, "can't store %s transaction of user with id %d", transaction.Kind, transaction.UserId)
+	}
+
+	return nil
+}
+
+// checkDebit finds out why debit has not changed balance.
+func checkDebit(ctx context.Context, tx *sqlx.Tx, userId types.Id) error {
+	balance := int64(0)
+	if err := tx.QueryRowxContext(ctx, GetBalanceQuery, userId).Scan(&balance); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorUserNotFound
+		}
+		return errors.Wrapf(err, "can't get balance of user with id %d", userId)
+	}
+
+	return ErrorInsufficientFunds
+}
+
+func (pl *PostgresLedger) Debit(ctx context.Context, userId types.Id, amount uint64, reason string) (*Transaction, error) {
+	tx, err := pl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't begin transaction for debit of user with id %d", userId)
+	}
+
+	transaction := &Transaction{
+		UserId: userId,
+		Kind:   Debit,
+		Amount: amount,
+		Reason: reason,
+	}
+
+	if err := Apply(ctx, tx, transaction); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "can't commit debit of user with id %d", userId)
+	}
+
+	return transaction, nil
+}
+
+func (pl *PostgresLedger) Transfer(ctx context.Context, from, to types.Id, amount, dailyLimit uint64) (*Transfer, error) {
+	tx, err := pl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't begin transaction for transfer from user with id %d", from)
+	}
+
+	res, err := transfer(ctx, tx, from, to, amount, dailyLimit)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "can't commit transfer from user with id %d to user with id %d", from, to)
+	}
+
+	return res, nil
+}
+
+func transfer(ctx context.Context, tx *sqlx.Tx, from, to types.Id, amount, dailyLimit uint64) (*Transfer, error) {
+	// Users are locked in order of ids, so concurrent opposite transfers can't deadlock.
+	// Lock of sender also serializes checks of its daily limit.
+	first, second := from, to
+	if first > second {
+		first, second = second, first
+	}
+	for _, userId := range []types.Id{first, second} {
+		balance := int64(0)
+		if err := tx.QueryRowxContext(ctx, lockBalance, userId).Scan(&balance); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.Wrapf(err, "can't lock balance of user with id %d", userId)
+			}
+			if userId == from {
+				return nil, ErrorUserNotFound
+			}
+			return nil, ErrorRecipientNotFound
+		}
+	}
+
+	if dailyLimit != 0 {
+		transferred := uint64(0)
+		if err := tx.QueryRowxContext(ctx, getTransferred, from, TransferReason).Scan(&transferred); err != nil {
+			return nil, errors.Wrapf(err, "can't get transferred amount of user with id %d", from)
+		}
+		if transferred >= dailyLimit || amount > dailyLimit-transferred {
+			return nil, ErrorTransferLimitExceeded
+		}
+	}
+
+	res := &Transfer{
+		Debit: Transaction{
+			UserId:         from,
+			Kind:           Debit,
+			Amount:         amount,
+			Reason:         TransferReason,
+			CounterpartyId: &to,
+		},
+		Credit: Transaction{
+			UserId:         to,
+			Kind:           Credit,
+			Amount:         amount,
+			Reason:         TransferReason,
+			CounterpartyId: &from,
+		},
+	}
+
+	if err := Apply(ctx, tx, &res.Debit); err != nil {
+		return nil, err
+	}
+
+	if err := storeTransferHistory(ctx, tx, &res.Debit, types.SourceTransferOut); err != nil {
+		return nil, err
+	}
+
+	if err := Apply(ctx, tx, &res.Credit); err != nil {
+		return nil, err
+	}
+
+	if err := storeTransferHistory(ctx, tx, &res.Credit, types.SourceTransferIn); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// storeTransferHistory stores applied transaction of transfer in balance history of its user.
+func storeTransferHistory(ctx context.Context, tx *sqlx.Tx, transaction *Transaction,
+	source types.HistorySource) error {
+	if _, err := tx.ExecContext(ctx, createTransferHistory, transaction.UserId, transaction.Amount, source,
+		transaction.CounterpartyId, transaction.Balance); err != nil {
+		return errors.Wrapf(err, "can't store %s history of user with id %d", source, transaction.UserId)
+	}
+
+	return nil
+}
+
+func (pl *PostgresLedger) Reconcile(ctx context.Context, userId types.Id) (*Reconciliation, error) {
+	tx, err := pl.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't begin transaction for reconciliation of user with id %d", userId)
+	}
+
+	reconciliation, err := reconcile(ctx, tx, userId)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "can't commit reconciliation of user with id %d", userId)
+	}
+
+	return reconciliation, nil
+}
+
+func reconcile(ctx context.Context, tx *sqlx.Tx, userId types.Id) (*Reconciliation, error) {
+	reconciliation := &Reconciliation{UserId: userId}
+
+	// Lock of user row waits for transactions applied concurrently
+	if err := tx.QueryRowxContext(ctx, lockBalance, userId).Scan(&reconciliation.Cached); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrorUserNotFound
+		}
+		return nil, errors.Wrapf(err, "can't lock balance of user with id %d", userId)
+	}
+
+	if err := tx.QueryRowxContext(ctx, getLedgerBalance, userId).Scan(&reconciliation.Ledger); err != nil {
+		return nil, errors.Wrapf(err, "can't get ledger balance of user with id %d", userId)
+	}
+
+	if reconciliation.Ledger == reconciliation.Cached {
+		return reconciliation, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, setBalance, userId, reconciliation.Ledger); err != nil {
+		return nil, errors.Wrapf(err, "can't set balance of user with id %d", userId)
+	}
+
+	return reconciliation, nil
+}
@@ -1,13 +1,24 @@
 package quest
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"vk_quests/internal/pkg/types"
 )
 
 var (
-	ErrorQuestNotFound          = errors.New("quest with id not found")
-	ErrorQuestNameAlreadyExists = errors.New("quest with name already exists")
+	ErrorQuestNotFound           = errors.New("quest with id not found")
+	ErrorQuestNameAlreadyExists  = errors.New("quest with name already exists")
+	ErrorStagedQuestNoSteps      = errors.New("staged quest must have at least one step")
+	ErrorCounterQuestNoTarget    = errors.New("counter quest must have positive target")
+	ErrorStreakQuestNoMilestones = errors.New("streak quest must have at least one milestone")
+	ErrorTeamQuestNoTarget       = errors.New("team quest must have positive target")
+	ErrorPrerequisiteNotFound    = errors.New("prerequisite quest not found")
+	ErrorPrerequisiteCycle       = errors.New("prerequisites of quest make a cycle")
+	ErrorInvalidWindow           = errors.New("quest must start before it ends")
+	ErrorCategoryNotFound        = errors.New("category of quest not found")
+	ErrorQuestNotArchived        = errors.New("quest is not archived")
 )
 
 //go:generate mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=QuestRepository . Repository
@@ -17,27 +28,62 @@ type Repository interface {
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorQuestNameAlreadyExists
-	CreateQuest(quest *Quest) (*Quest, error)
+	//   - ErrorStagedQuestNoSteps
+	//   - ErrorCounterQuestNoTarget
+	//   - ErrorStreakQuestNoMilestones
+	//   - ErrorTeamQuestNoTarget
+	//   - ErrorPrerequisiteNotFound
+	//   - ErrorCategoryNotFound
+	//   - ErrorInvalidWindow
+	CreateQuest(ctx context.Context, quest *Quest) (*Quest, error)
 
 	// UpdateQuest
+	// Prerequisites are checked to exist and not to lead back to the quest.
+	// Categories and tags are replaced if they are set.
+	// Quest changing type to streak without max completions gets unlimited completions.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorQuestNotFound
+	//   - ErrorStagedQuestNoSteps
+	//   - ErrorCounterQuestNoTarget
+	//   - ErrorStreakQuestNoMilestones
+	//   - ErrorTeamQuestNoTarget
+	//   - ErrorPrerequisiteNotFound
+	//   - ErrorPrerequisiteCycle
+	//   - ErrorCategoryNotFound
+	//   - ErrorInvalidWindow
+	UpdateQuest(ctx context.Context, quest *UpdateQuest) (*Quest, error)
+
+	// ArchiveQuest
+	// Archived quest is hidden from listings and can't be completed, its history is kept.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorQuestNotFound
+	ArchiveQuest(ctx context.Context, id types.Id) error
+
+	// RestoreQuest
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorQuestNotFound
-	UpdateQuest(quest *UpdateQuest) (*Quest, error)
+	RestoreQuest(ctx context.Context, id types.Id) (*Quest, error)
 
-	// DeleteQuest
+	// PurgeQuest
+	// Deletes archived quest, it is also removed from prerequisites of other quests.
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorQuestNotFound
-	DeleteQuest(id types.Id) error
+	//   - ErrorQuestNotArchived
+	PurgeQuest(ctx context.Context, id types.Id) error
 
 	// GetQuests
+	// Returns page of quests matching query, archived quests are returned only if they are queried.
 	// Returns Error:
 	//   - SQLError
-	GetQuests() ([]Quest, error)
+	GetQuests(ctx context.Context, query *QuestsQuery) ([]Quest, error)
 
 	// GetQuest
+	// Returns quest even if it is archived.
 	// Returns Error:
 	//   - ErrorQuestNotFound
-	GetQuest(id types.Id) (*Quest, error)
+	GetQuest(ctx context.Context, id types.Id) (*Quest, error)
 }
@@ -10,6 +10,7 @@
 package mr
 
 import (
+	context "context"
 	reflect "reflect"
 	types "vk_quests/internal/pkg/types"
 	quest "vk_quests/internal/repository/quest"
@@ -40,76 +41,105 @@ func (m *QuestRepository) EXPECT() *QuestRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CreateQuest mocks base method.
-func (m *QuestRepository) CreateQuest(arg0 *quest.Quest) (*quest.Quest, error) {
+// ArchiveQuest mocks base method.
+func (m *QuestRepository) ArchiveQuest(arg0 context.Context, arg1 types.Id) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateQuest", arg0)
-	ret0, _ := ret[0].(*quest.Quest)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ArchiveQuest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateQuest indicates an expected call of CreateQuest.
-func (mr *QuestRepositoryMockRecorder) CreateQuest(arg0 any) *gomock.Call {
+// ArchiveQuest indicates an expected call of ArchiveQuest.
+func (mr *QuestRepositoryMockRecorder) ArchiveQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuest", reflect.TypeOf((*QuestRepository)(nil).CreateQuest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveQuest", reflect.TypeOf((*QuestRepository)(nil).ArchiveQuest), arg0, arg1)
 }
 
-// DeleteQuest mocks base method.
-func (m *QuestRepository) DeleteQuest(arg0 types.Id) error {
+// CreateQuest mocks base method.
+func (m *QuestRepository) CreateQuest(arg0 context.Context, arg1 *quest.Quest) (*quest.Quest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteQuest", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateQuest", arg0, arg1)
+	ret0, _ := ret[0].(*quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteQuest indicates an expected call of DeleteQuest.
-func (mr *QuestRepositoryMockRecorder) DeleteQuest(arg0 any) *gomock.Call {
+// CreateQuest indicates an expected call of CreateQuest.
+func (mr *QuestRepositoryMockRecorder) CreateQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuest", reflect.TypeOf((*QuestRepository)(nil).DeleteQuest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuest", reflect.TypeOf((*QuestRepository)(nil).CreateQuest), arg0, arg1)
 }
 
 // GetQuest mocks base method.
-func (m *QuestRepository) GetQuest(arg0 types.Id) (*quest.Quest, error) {
+func (m *QuestRepository) GetQuest(arg0 context.Context, arg1 types.Id) (*quest.Quest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQuest", arg0)
+	ret := m.ctrl.Call(m, "GetQuest", arg0, arg1)
 	ret0, _ := ret[0].(*quest.Quest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetQuest indicates an expected call of GetQuest.
-func (mr *QuestRepositoryMockRecorder) GetQuest(arg0 any) *gomock.Call {
+func (mr *QuestRepositoryMockRecorder) GetQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuest", reflect.TypeOf((*QuestRepository)(nil).GetQuest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuest", reflect.TypeOf((*QuestRepository)(nil).GetQuest), arg0, arg1)
 }
 
 // GetQuests mocks base method.
-func (m *QuestRepository) GetQuests() ([]quest.Quest, error) {
+func (m *QuestRepository) GetQuests(arg0 context.Context, arg1 *quest.QuestsQuery) ([]quest.Quest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQuests")
+	ret := m.ctrl.Call(m, "GetQuests", arg0, arg1)
 	ret0, _ := ret[0].([]quest.Quest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetQuests indicates an expected call of GetQuests.
-func (mr *QuestRepositoryMockRecorder) GetQuests() *gomock.Call {
+func (mr *QuestRepositoryMockRecorder) GetQuests(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuests", reflect.TypeOf((*QuestRepository)(nil).GetQuests), arg0, arg1)
+}
+
+// PurgeQuest mocks base method.
+func (m *QuestRepository) PurgeQuest(arg0 context.Context, arg1 types.Id) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeQuest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeQuest indicates an expected call of PurgeQuest.
+func (mr *QuestRepositoryMockRecorder) PurgeQuest(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeQuest", reflect.TypeOf((*QuestRepository)(nil).PurgeQuest), arg0, arg1)
+}
+
+// RestoreQuest mocks base method.
+func (m *QuestRepository) RestoreQuest(arg0 context.Context, arg1 types.Id) (*quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreQuest", arg0, arg1)
+	ret0, _ := ret[0].(*quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreQuest indicates an expected call of RestoreQuest.
+func (mr *QuestRepositoryMockRecorder) RestoreQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuests", reflect.TypeOf((*QuestRepository)(nil).GetQuests))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreQuest", reflect.TypeOf((*QuestRepository)(nil).RestoreQuest), arg0, arg1)
 }
 
 // UpdateQuest mocks base method.
-func (m *QuestRepository) UpdateQuest(arg0 *quest.UpdateQuest) (*quest.Quest, error) {
+func (m *QuestRepository) UpdateQuest(arg0 context.Context, arg1 *quest.UpdateQuest) (*quest.Quest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateQuest", arg0)
+	ret := m.ctrl.Call(m, "UpdateQuest", arg0, arg1)
 	ret0, _ := ret[0].(*quest.Quest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateQuest indicates an expected call of UpdateQuest.
-func (mr *QuestRepositoryMockRecorder) UpdateQuest(arg0 any) *gomock.Call {
+func (mr *QuestRepositoryMockRecorder) UpdateQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuest", reflect.TypeOf((*QuestRepository)(nil).UpdateQuest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuest", reflect.TypeOf((*QuestRepository)(nil).UpdateQuest), arg0, arg1)
 }
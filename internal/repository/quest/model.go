@@ -1,20 +1,82 @@
 package quest
 
 import (
+	"time"
+
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
 )
 
 type Quest struct {
-	ID          types.Id
-	Name        string
-	Description string
-	Cost        types.Cost
-	Type        types.QuestType
+	ID             types.Id
+	Name           string
+	Description    string
+	Cost           types.Cost
+	Type           types.QuestType
+	Steps          []string
+	MaxCompletions uint32                 // zero means unlimited completions
+	Cooldown       time.Duration          // minimal duration between two completions by one user
+	Prerequisites  []types.Id             // quests which user must complete before this one
+	StartsAt       *pkgtime.FormattedTime // nil means quest is active from creation
+	EndsAt         *pkgtime.FormattedTime // nil means quest never ends
+	Probability    float64                // chance of random quest to be completed by one attempt
+	Pity           uint32                 // random quest is completed after this number of failed attempts in a row, zero means never
+	Target         uint32                 // amount which user must accumulate to complete counter quest or number of contributors to team quest
+	Milestones     []Milestone            // streak lengths at which streak quest pays rewards, sorted by days
+	RewardSplit    types.RewardSplit      // distribution of team quest reward between contributors
+	ArchivedAt     *pkgtime.FormattedTime // nil means quest is not archived
+	Categories     []types.Id             // sorted ids of quest categories
+	Tags           []string               // sorted free-form tags of quest
+}
+
+// Milestone is reward paid when streak of user reaches given number of consecutive days.
+type Milestone struct {
+	Days   uint32
+	Reward types.Cost
+}
+
+// MilestoneReward returns reward of streak quest for streak of given length.
+func (q *Quest) MilestoneReward(days uint32) (types.Cost, bool) {
+	for _, milestone := range q.Milestones {
+		if milestone.Days == days {
+			return milestone.Reward, true
+		}
+	}
+	return 0, false
 }
 
 type UpdateQuest struct {
-	ID          types.Id
-	Description *string
-	Cost        *types.Cost
-	Type        *types.QuestType
+	ID             types.Id
+	Description    *string
+	Cost           *types.Cost
+	Type           *types.QuestType
+	Steps          []string
+	MaxCompletions *uint32
+	Cooldown       *time.Duration
+	Prerequisites  []types.Id // nil means prerequisites are not changed
+	StartsAt       *pkgtime.FormattedTime
+	EndsAt         *pkgtime.FormattedTime
+	Probability    *float64
+	Pity           *uint32
+	Target         *uint32
+	Milestones     []Milestone // nil means milestones are not changed
+	RewardSplit    *types.RewardSplit
+	Categories     []types.Id // nil means categories are not changed
+	Tags           []string   // nil means tags are not changed
+}
+
+type QuestsQuery struct {
+	Type       *types.QuestType
+	MinCost    *types.Cost
+	MaxCost    *types.Cost
+	NamePrefix string
+	CategoryId *types.Id
+	Tag        string     // empty means quests are not filtered by tag
+	ActiveAt   *time.Time // nil means quests are not filtered by availability window
+	Archived   bool       // only archived quests instead of not archived ones
+	Sort       types.QuestsSort
+	Order      page.Order
+	After      *page.Cursor // nil means first page
+	Limit      uint64
 }
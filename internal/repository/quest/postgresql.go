@@ -1,58 +1,158 @@
 package quest
 
 import (
+	"cmp"
+	"context"
 	"database/sql"
+	"slices"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
 )
 
+// LabelsColumns selects categories and tags of quest from quests table after its other columns.
+const LabelsColumns = `ARRAY(SELECT category_id FROM quest_categories WHERE quest_id = quests.id ORDER BY category_id) AS categories,
+	ARRAY(SELECT tag FROM quest_tags WHERE quest_id = quests.id ORDER BY tag) AS tags`
+
 const (
 	createQuery = `
 		WITH sel AS (
-				SELECT id, name, description, cost, type
+				SELECT id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at,
+					` + LabelsColumns + `
 				FROM quests
 				WHERE name = $1 LIMIT 1
 		), ins as (
-			INSERT INTO quests (name, description, cost, type)
-				SELECT $1, $2, $3, $4
+			INSERT INTO quests (name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split)
+				SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
 			    WHERE not exists (select 1 from sel)
-			RETURNING id, name, description, cost, type
+			RETURNING id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at
+		), ins_categories AS (
+			INSERT INTO quest_categories (quest_id, category_id) SELECT ins.id, unnest($17::bigint[]) FROM ins
+		), ins_tags AS (
+			INSERT INTO quest_tags (quest_id, tag) SELECT ins.id, unnest($18::text[]) FROM ins
 		)
-		SELECT id, name, description, cost, type, 0
+		SELECT id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at, $17::bigint[], $18::text[], 0
 		FROM ins
 		UNION ALL
-		SELECT id, name, description, cost, type, 1
+		SELECT id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at, categories, tags, 1
 		FROM sel
 	`
 
-	deleteQuest = `
-		DELETE FROM quests WHERE id = $1
+	// archiveQuest keeps time of the first archiving, so repeated archiving changes nothing
+	archiveQuest = `
+		UPDATE quests SET archived_at = COALESCE(archived_at, now()) WHERE id = $1
+	`
+
+	restoreQuest = `
+		UPDATE quests SET archived_at = NULL WHERE id = $1
+		RETURNING id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at,
+			` + LabelsColumns + `
+	`
+
+	// purgeQuest deletes only archived quest and returns number of deleted and found quests
+	purgeQuest = `
+		WITH deleted AS (
+			DELETE FROM quests WHERE id = $1 AND archived_at IS NOT NULL RETURNING id
+		), detached AS (
+			UPDATE quests SET prerequisites = array_remove(prerequisites, $1)
+			WHERE prerequisites @> ARRAY[$1]::bigint[] AND EXISTS (SELECT 1 FROM deleted)
+		)
+		SELECT (SELECT count(*) FROM deleted), (SELECT count(*) FROM quests WHERE id = $1)
 	`
 
+	// updateQuest replaces categories and tags of quest if they are not null.
+	// Quest becoming streak without new max_completions gets unlimited completions like on creation,
+	// so every milestone of streak can be paid
 	updateQuest = `
+		WITH upd AS (
 		UPDATE quests SET description = upd_quest.upd_description, 
-		                 cost = upd_quest.upd_cost, type = upd_quest.upd_type
+		                 cost = upd_quest.upd_cost, type = upd_quest.upd_type,
+		                 steps = upd_quest.upd_steps, max_completions = upd_quest.upd_max_completions,
+		                 cooldown = upd_quest.upd_cooldown, prerequisites = upd_quest.upd_prerequisites,
+		                 starts_at = upd_quest.upd_starts_at, ends_at = upd_quest.upd_ends_at,
+		                 probability = upd_quest.upd_probability, pity = upd_quest.upd_pity,
+		                 target = upd_quest.upd_target, milestone_days = upd_quest.upd_milestone_days,
+		                 milestone_rewards = upd_quest.upd_milestone_rewards,
+		                 reward_split = upd_quest.upd_reward_split
 			FROM (
 				SELECT COALESCE($2, quests.description) as upd_description, 
 					   COALESCE($3, quests.cost) as upd_cost,
-					   COALESCE($4, quests.type) as upd_type 
+					   COALESCE($4, quests.type) as upd_type,
+					   COALESCE($5, quests.steps) as upd_steps,
+					   COALESCE($6, CASE WHEN $4 = 'streak' AND quests.type != 'streak' THEN 0
+					       ELSE quests.max_completions END) as upd_max_completions,
+					   COALESCE($7, quests.cooldown) as upd_cooldown,
+					   COALESCE($8, quests.prerequisites) as upd_prerequisites,
+					   COALESCE($9, quests.starts_at) as upd_starts_at,
+					   COALESCE($10, quests.ends_at) as upd_ends_at,
+					   COALESCE($11, quests.probability) as upd_probability,
+					   COALESCE($12, quests.pity) as upd_pity,
+					   COALESCE($13, quests.target) as upd_target,
+					   COALESCE($14, quests.milestone_days) as upd_milestone_days,
+					   COALESCE($15, quests.milestone_rewards) as upd_milestone_rewards,
+					   COALESCE($16, quests.reward_split) as upd_reward_split
 				FROM quests WHERE id = $1
 			) as upd_quest
 			WHERE id = $1
-			RETURNING id, name, description, cost, type
+			RETURNING id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at
+		), del_categories AS (
+			DELETE FROM quest_categories
+			WHERE $17::bigint[] IS NOT NULL AND quest_id IN (SELECT id FROM upd) AND category_id != ALL($17::bigint[])
+		), ins_categories AS (
+			INSERT INTO quest_categories (quest_id, category_id) SELECT upd.id, unnest($17::bigint[]) FROM upd
+			ON CONFLICT DO NOTHING
+		), del_tags AS (
+			DELETE FROM quest_tags
+			WHERE $18::text[] IS NOT NULL AND quest_id IN (SELECT id FROM upd) AND tag != ALL($18::text[])
+		), ins_tags AS (
+			INSERT INTO quest_tags (quest_id, tag) SELECT upd.id, unnest($18::text[]) FROM upd
+			ON CONFLICT DO NOTHING
+		)
+		SELECT id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at,
+			COALESCE($17::bigint[], ARRAY(SELECT category_id FROM quest_categories WHERE quest_id = upd.id ORDER BY category_id)),
+			COALESCE($18::text[], ARRAY(SELECT tag FROM quest_tags WHERE quest_id = upd.id ORDER BY tag))
+		FROM upd
 	`
 
 	getQuests = `
-		SELECT id, name, description, cost, type FROM quests
+		SELECT id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at,
+			` + LabelsColumns + ` FROM quests
 	`
 
 	getQuest = `
-		SELECT id, name, description, cost, type FROM quests WHERE id = $1
+		SELECT id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target, milestone_days, milestone_rewards, reward_split, archived_at,
+			` + LabelsColumns + `
+		FROM quests WHERE id = $1
+	`
+
+	// Prerequisites are locked, so they can't be deleted before quest referencing them is stored
+	lockPrerequisites = `
+		SELECT count(*) FROM (SELECT id FROM quests WHERE id = ANY($1) FOR SHARE) AS locked
+	`
+
+	// Changes of prerequisites are serialized, so concurrent updates can't make a cycle together
+	lockPrerequisitesGraph = `
+		SELECT pg_advisory_xact_lock($1)
+	`
+
+	hasPrerequisiteCycle = `
+		WITH RECURSIVE required(id) AS (
+			SELECT unnest($2::bigint[])
+			UNION
+			SELECT unnest(quests.prerequisites) FROM quests JOIN required ON (quests.id = required.id)
+		)
+		SELECT EXISTS (SELECT 1 FROM required WHERE id = $1)
 	`
 )
 
+// prerequisitesLockKey is key of advisory lock taken by updates of prerequisites.
+const prerequisitesLockKey = 7_001
+
 type PostgresQuest struct {
 	db *sqlx.DB
 }
@@ -65,19 +165,135 @@ func NewPostgresQuest(db *sqlx.DB) *PostgresQuest {
 
 var _ = Repository(&PostgresQuest{})
 
-func (pt *PostgresQuest) CreateQuest(quest *Quest) (*Quest, error) {
+type Scanner interface {
+	Scan(dest ...any) error
+}
+
+// ScanQuest reads all quest columns in the order of select queries and extra columns after them.
+func ScanQuest(row Scanner, quest *Quest, extra ...any) error {
+	cooldown := int64(0)
+	prerequisites := pq.Int64Array{}
+	startsAt, endsAt := sql.Null[time.Time]{}, sql.Null[time.Time]{}
+	milestoneDays, milestoneRewards := pq.Int64Array{}, pq.Int64Array{}
+	archivedAt := sql.Null[time.Time]{}
+	categories, tags := pq.Int64Array{}, pq.StringArray{}
+	dest := append([]any{
+		&quest.ID,
+		&quest.Name,
+		&quest.Description,
+		&quest.Cost,
+		&quest.Type,
+		pq.Array(&quest.Steps),
+		&quest.MaxCompletions,
+		&cooldown,
+		&prerequisites,
+		&startsAt,
+		&endsAt,
+		&quest.Probability,
+		&quest.Pity,
+		&quest.Target,
+		&milestoneDays,
+		&milestoneRewards,
+		&quest.RewardSplit,
+		&archivedAt,
+		&categories,
+		&tags,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+
+	quest.Cooldown = time.Duration(cooldown) * time.Second
+
+	quest.Prerequisites = nil
+	for _, id := range prerequisites {
+		quest.Prerequisites = append(quest.Prerequisites, types.Id(id))
+	}
+
+	quest.StartsAt = getFormattedTime(startsAt)
+	quest.EndsAt = getFormattedTime(endsAt)
+
+	quest.Milestones = nil
+	for i := range min(len(milestoneDays), len(milestoneRewards)) {
+		quest.Milestones = append(quest.Milestones, Milestone{
+			Days:   uint32(milestoneDays[i]),
+			Reward: types.Cost(milestoneRewards[i]),
+		})
+	}
+
+	quest.ArchivedAt = getFormattedTime(archivedAt)
+
+	quest.Categories = nil
+	for _, id := range categories {
+		quest.Categories = append(quest.Categories, types.Id(id))
+	}
+
+	quest.Tags = nil
+	if len(tags) > 0 {
+		quest.Tags = tags
+	}
+
+	return nil
+}
+
+func getFormattedTime(value sql.Null[time.Time]) *pkgtime.FormattedTime {
+	if !value.Valid {
+		return nil
+	}
+	return &pkgtime.FormattedTime{Time: value.V.UTC()}
+}
+
+func getNullTime(value *pkgtime.FormattedTime) sql.Null[time.Time] {
+	if value == nil {
+		return sql.Null[time.Time]{Valid: false}
+	}
+	return sql.Null[time.Time]{Valid: true, V: value.Time}
+}
+
+func (pt *PostgresQuest) CreateQuest(ctx context.Context, quest *Quest) (*Quest, error) {
+	if len(quest.Prerequisites) == 0 {
+		return createQuest(ctx, pt.db, quest)
+	}
+
+	tx, err := pt.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't begin transaction for create quest")
+	}
+
+	// New quest can't be required by other quests yet, so its prerequisites can't make a cycle
+	if err := checkPrerequisitesExist(ctx, tx, quest.Prerequisites); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	newQuest, err := createQuest(ctx, tx, quest)
+	if err != nil {
+		_ = tx.Rollback()
+		return newQuest, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "can't commit transaction for create quest")
+	}
+
+	return newQuest, nil
+}
+
+func createQuest(ctx context.Context, db sqlx.QueryerContext, quest *Quest) (*Quest, error) {
 	newQuest := &Quest{}
 	exists := 0
-	if err := pt.db.QueryRowx(createQuery, quest.Name, quest.Description, quest.Cost, quest.Type).
-		Scan(
-			&newQuest.ID,
-			&newQuest.Name,
-			&newQuest.Description,
-			&newQuest.Cost,
-			&newQuest.Type,
-			&exists,
-		); err != nil {
-		return nil, errors.Wrap(err, "can't create quest")
+	milestoneDays, milestoneRewards := getMilestones(quest.Milestones)
+	if err := ScanQuest(
+		db.QueryRowxContext(ctx, createQuery, quest.Name, quest.Description, quest.Cost, quest.Type,
+			pq.Array(getSteps(quest.Steps)), quest.MaxCompletions, int64(quest.Cooldown/time.Second),
+			pq.Array(getIds(quest.Prerequisites)), getNullTime(quest.StartsAt), getNullTime(quest.EndsAt),
+			quest.Probability, quest.Pity, quest.Target, pq.Array(milestoneDays), pq.Array(milestoneRewards),
+			getRewardSplit(quest.RewardSplit), pq.Array(getIds(quest.Categories)), pq.Array(getTags(getSteps(quest.Tags)))),
+		newQuest,
+		&exists,
+	); err != nil {
+		return nil, errors.Wrap(checkConflictError(err), "can't create quest")
 	}
 
 	if exists == 1 {
@@ -94,7 +310,127 @@ func getNullString(value *string) sql.NullString {
 	return sql.NullString{Valid: true, String: *value}
 }
 
-func (pt *PostgresQuest) UpdateQuest(quest *UpdateQuest) (*Quest, error) {
+func getSteps(steps []string) []string {
+	if steps == nil {
+		return []string{}
+	}
+	return steps
+}
+
+// getRewardSplit returns equal split for quests created without split.
+func getRewardSplit(split types.RewardSplit) types.RewardSplit {
+	if split == "" {
+		return types.SplitEqual
+	}
+	return split
+}
+
+// getIds returns sorted ids without duplicates.
+func getIds(ids []types.Id) []int64 {
+	res := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, int64(id))
+	}
+	slices.Sort(res)
+
+	return slices.Compact(res)
+}
+
+// getTags returns sorted tags without duplicates, nil tags stay nil.
+func getTags(tags []string) []string {
+	res := slices.Clone(tags)
+	slices.Sort(res)
+
+	return slices.Compact(res)
+}
+
+// getMilestones returns days and rewards of milestones sorted by days without duplicates.
+func getMilestones(milestones []Milestone) ([]int64, []int64) {
+	sorted := slices.Clone(milestones)
+	slices.SortStableFunc(sorted, func(a, b Milestone) int { return cmp.Compare(a.Days, b.Days) })
+	sorted = slices.CompactFunc(sorted, func(a, b Milestone) bool { return a.Days == b.Days })
+
+	days, rewards := make([]int64, 0, len(sorted)), make([]int64, 0, len(sorted))
+	for _, milestone := range sorted {
+		days = append(days, int64(milestone.Days))
+		rewards = append(rewards, int64(milestone.Reward))
+	}
+
+	return days, rewards
+}
+
+// checkPrerequisitesExist locks prerequisites and checks that all of them exist.
+func checkPrerequisitesExist(ctx context.Context, tx *sqlx.Tx, prerequisites []types.Id) error {
+	ids := getIds(prerequisites)
+
+	found := 0
+	if err := tx.QueryRowxContext(ctx, lockPrerequisites, pq.Array(ids)).Scan(&found); err != nil {
+		return errors.Wrap(err, "can't lock prerequisites of quest")
+	}
+
+	if found != len(ids) {
+		return ErrorPrerequisiteNotFound
+	}
+
+	return nil
+}
+
+// checkPrerequisites checks that prerequisites exist and quest is not required by any of them.
+func checkPrerequisites(ctx context.Context, tx *sqlx.Tx, id types.Id, prerequisites []types.Id) error {
+	if slices.Contains(prerequisites, id) {
+		return ErrorPrerequisiteCycle
+	}
+
+	if _, err := tx.ExecContext(ctx, lockPrerequisitesGraph, prerequisitesLockKey); err != nil {
+		return errors.Wrap(err, "can't lock prerequisites graph")
+	}
+
+	if err := checkPrerequisitesExist(ctx, tx, prerequisites); err != nil {
+		return err
+	}
+
+	cycle := false
+	if err := tx.QueryRowxContext(ctx, hasPrerequisiteCycle, id, pq.Array(getIds(prerequisites))).
+		Scan(&cycle); err != nil {
+		return errors.Wrapf(err, "can't check prerequisites of quest with id %d", id)
+	}
+
+	if cycle {
+		return ErrorPrerequisiteCycle
+	}
+
+	return nil
+}
+
+func (pt *PostgresQuest) UpdateQuest(ctx context.Context, quest *UpdateQuest) (*Quest, error) {
+	if len(quest.Prerequisites) == 0 {
+		return updateQuestFields(ctx, pt.db, quest)
+	}
+
+	tx, err := pt.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't begin transaction for update quest with id %d", quest.ID)
+	}
+
+	if err := checkPrerequisites(ctx, tx, quest.ID, quest.Prerequisites); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	updatedQuest, err := updateQuestFields(ctx, tx, quest)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "can't commit transaction for update quest with id %d", quest.ID)
+	}
+
+	return updatedQuest, nil
+}
+
+func updateQuestFields(ctx context.Context, db sqlx.QueryerContext, quest *UpdateQuest) (*Quest, error) {
 	description := getNullString(quest.Description)
 	tp := getNullString((*string)(quest.Type))
 
@@ -103,45 +439,160 @@ func (pt *PostgresQuest) UpdateQuest(quest *UpdateQuest) (*Quest, error) {
 		cost = sql.NullInt64{Valid: true, Int64: int64(*quest.Cost)}
 	}
 
+	maxCompletions := sql.NullInt64{Valid: false}
+	if quest.MaxCompletions != nil {
+		maxCompletions = sql.NullInt64{Valid: true, Int64: int64(*quest.MaxCompletions)}
+	}
+
+	cooldown := sql.NullInt64{Valid: false}
+	if quest.Cooldown != nil {
+		cooldown = sql.NullInt64{Valid: true, Int64: int64(*quest.Cooldown / time.Second)}
+	}
+
+	probability := sql.NullFloat64{Valid: false}
+	if quest.Probability != nil {
+		probability = sql.NullFloat64{Valid: true, Float64: *quest.Probability}
+	}
+
+	pity := sql.NullInt64{Valid: false}
+	if quest.Pity != nil {
+		pity = sql.NullInt64{Valid: true, Int64: int64(*quest.Pity)}
+	}
+
+	target := sql.NullInt64{Valid: false}
+	if quest.Target != nil {
+		target = sql.NullInt64{Valid: true, Int64: int64(*quest.Target)}
+	}
+
+	var prerequisites []int64
+	if quest.Prerequisites != nil {
+		prerequisites = getIds(quest.Prerequisites)
+	}
+
+	var milestoneDays, milestoneRewards []int64
+	if quest.Milestones != nil {
+		milestoneDays, milestoneRewards = getMilestones(quest.Milestones)
+	}
+
+	var categories []int64
+	if quest.Categories != nil {
+		categories = getIds(quest.Categories)
+	}
+
 	updatedQuest := &Quest{}
-	if err := pt.db.QueryRowx(updateQuest, quest.ID, description, cost, tp).
-		Scan(
-			&updatedQuest.ID,
-			&updatedQuest.Name,
-			&updatedQuest.Description,
-			&updatedQuest.Cost,
-			&updatedQuest.Type,
-		); err != nil {
+	if err := ScanQuest(
+		db.QueryRowxContext(ctx, updateQuest, quest.ID, description, cost, tp, pq.Array(quest.Steps),
+			maxCompletions, cooldown, pq.Array(prerequisites), getNullTime(quest.StartsAt), getNullTime(quest.EndsAt),
+			probability, pity, target, pq.Array(milestoneDays), pq.Array(milestoneRewards),
+			getNullString((*string)(quest.RewardSplit)), pq.Array(categories), pq.Array(getTags(quest.Tags))),
+		updatedQuest,
+	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrorQuestNotFound
 		}
 
-		return nil, errors.Wrapf(err, "can't update quest with id %d", quest.ID)
+		return nil, errors.Wrapf(checkConflictError(err), "can't update quest with id %d", quest.ID)
 	}
 
 	return updatedQuest, nil
 }
 
-func (pt *PostgresQuest) DeleteQuest(id types.Id) error {
-	res, err := pt.db.Exec(deleteQuest, id)
+func (pt *PostgresQuest) ArchiveQuest(ctx context.Context, id types.Id) error {
+	res, err := pt.db.ExecContext(ctx, archiveQuest, id)
 	if err != nil {
-		return errors.Wrapf(err, "can't execute deleting query for quest %d", id)
+		return errors.Wrapf(err, "can't execute archiving query for quest %d", id)
 	}
 
-	n, err := res.RowsAffected()
+	affected, err := res.RowsAffected()
 	if err != nil {
-		return errors.Wrapf(err, "can't get number affected rows of deleting query for quest %d", id)
+		return errors.Wrapf(err, "can't get affected rows for archiving quest %d", id)
 	}
 
-	if n != 1 {
+	if affected == 0 {
 		return errors.Wrapf(ErrorQuestNotFound, "with id %d", id)
 	}
 
 	return nil
 }
 
-func (pt *PostgresQuest) GetQuests() ([]Quest, error) {
-	rows, err := pt.db.Queryx(getQuests)
+func (pt *PostgresQuest) RestoreQuest(ctx context.Context, id types.Id) (*Quest, error) {
+	quest := &Quest{}
+	if err := ScanQuest(pt.db.QueryRowxContext(ctx, restoreQuest, id), quest); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorQuestNotFound, "with id %d", id)
+		}
+
+		return nil, errors.Wrapf(err, "can't restore quest with id %d", id)
+	}
+
+	return quest, nil
+}
+
+func (pt *PostgresQuest) PurgeQuest(ctx context.Context, id types.Id) error {
+	deleted, found := 0, 0
+	if err := pt.db.QueryRowxContext(ctx, purgeQuest, id).Scan(&deleted, &found); err != nil {
+		return errors.Wrapf(err, "can't execute purging query for quest %d", id)
+	}
+
+	if found == 0 {
+		return errors.Wrapf(ErrorQuestNotFound, "with id %d", id)
+	}
+
+	if deleted == 0 {
+		return errors.Wrapf(ErrorQuestNotArchived, "with id %d", id)
+	}
+
+	return nil
+}
+
+var questsSortColumns = map[types.QuestsSort]string{
+	types.QuestsSortID:   "id",
+	types.QuestsSortName: "name",
+	types.QuestsSortCost: "cost",
+}
+
+func buildGetQuests(query *QuestsQuery) (string, []any, error) {
+	sort, found := questsSortColumns[query.Sort]
+	if !found {
+		return "", nil, errors.Errorf("unknown quests sort %q", query.Sort)
+	}
+
+	q := page.Query{}
+	q.Where("(archived_at IS NOT NULL) = %s", query.Archived)
+	if query.Type != nil {
+		q.Where("type = %s", *query.Type)
+	}
+	if query.MinCost != nil {
+		q.Where("cost >= %s", *query.MinCost)
+	}
+	if query.MaxCost != nil {
+		q.Where("cost <= %s", *query.MaxCost)
+	}
+	if query.NamePrefix != "" {
+		q.Where("name LIKE %s", page.LikePrefix(query.NamePrefix))
+	}
+	if query.CategoryId != nil {
+		q.Where("EXISTS (SELECT 1 FROM quest_categories WHERE quest_id = quests.id AND category_id = %s)", *query.CategoryId)
+	}
+	if query.Tag != "" {
+		q.Where("EXISTS (SELECT 1 FROM quest_tags WHERE quest_id = quests.id AND tag = %s)", query.Tag)
+	}
+	if query.ActiveAt != nil {
+		q.Where("(starts_at IS NULL OR starts_at <= %[1]s) AND (ends_at IS NULL OR ends_at > %[1]s)", *query.ActiveAt)
+	}
+
+	sqlQuery, args := q.Build(getQuests, sort, query.Order, query.After, query.Limit)
+
+	return sqlQuery, args, nil
+}
+
+func (pt *PostgresQuest) GetQuests(ctx context.Context, query *QuestsQuery) ([]Quest, error) {
+	sqlQuery, args, err := buildGetQuests(query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pt.db.QueryxContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "can't execute get quests query")
 	}
@@ -151,14 +602,7 @@ func (pt *PostgresQuest) GetQuests() ([]Quest, error) {
 	for rows.Next() {
 		var quest Quest
 
-		err := rows.Scan(
-			&quest.ID,
-			&quest.Name,
-			&quest.Description,
-			&quest.Cost,
-			&quest.Type,
-		)
-		if err != nil {
+		if err := ScanQuest(rows, &quest); err != nil {
 			return nil, errors.Wrap(err, "can't scan get quests query result")
 		}
 
@@ -172,16 +616,9 @@ func (pt *PostgresQuest) GetQuests() ([]Quest, error) {
 	return quests, nil
 }
 
-func (pt *PostgresQuest) GetQuest(id types.Id) (*Quest, error) {
+func (pt *PostgresQuest) GetQuest(ctx context.Context, id types.Id) (*Quest, error) {
 	quest := &Quest{}
-	if err := pt.db.QueryRowx(getQuest, id).
-		Scan(
-			&quest.ID,
-			&quest.Name,
-			&quest.Description,
-			&quest.Cost,
-			&quest.Type,
-		); err != nil {
+	if err := ScanQuest(pt.db.QueryRowxContext(ctx, getQuest, id), quest); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrorQuestNotFound
 		}
@@ -191,3 +628,47 @@ func (pt *PostgresQuest) GetQuest(id types.Id) (*Quest, error) {
 
 	return quest, nil
 }
+
+const (
+	checkConflictCode              = "23514"
+	stagedStepsConstraintName      = "staged_steps_check"
+	counterTargetConstraintName    = "counter_target_check"
+	streakMilestonesConstraintName = "streak_milestones_check"
+	teamTargetConstraintName       = "team_target_check"
+	windowConstraintName           = "quests_window_check"
+	foreignKeyConflictCode         = "23503"
+	categoryIdConstraintName       = "quest_categories_category_id_fkey"
+)
+
+func checkConflictError(err error) error {
+	var e *pq.Error
+
+	switch {
+	case errors.As(err, &e):
+		return checkPgError(e)
+	default:
+		return err
+	}
+}
+
+func checkPgError(err *pq.Error) error {
+	if err.Code == checkConflictCode && err.Constraint == stagedStepsConstraintName {
+		return ErrorStagedQuestNoSteps
+	}
+	if err.Code == checkConflictCode && err.Constraint == counterTargetConstraintName {
+		return ErrorCounterQuestNoTarget
+	}
+	if err.Code == checkConflictCode && err.Constraint == streakMilestonesConstraintName {
+		return ErrorStreakQuestNoMilestones
+	}
+	if err.Code == checkConflictCode && err.Constraint == teamTargetConstraintName {
+		return ErrorTeamQuestNoTarget
+	}
+	if err.Code == checkConflictCode && err.Constraint == windowConstraintName {
+		return ErrorInvalidWindow
+	}
+	if err.Code == foreignKeyConflictCode && err.Constraint == categoryIdConstraintName {
+		return ErrorCategoryNotFound
+	}
+	return err
+}
@@ -1,14 +1,19 @@
 package quest
 
 import (
+	"context"
 	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"github.com/pkg/errors"
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
 
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
 )
 
@@ -35,27 +40,36 @@ func (qrs *QuestRepositorySuite) TestCreateFunction(t provider.T) {
 	t.Title("CreateQuest function of Quest repository")
 	t.NewStep("Init test data")
 	quest := &Quest{
-		ID:          1,
-		Name:        "Quest",
-		Description: "good Quest",
-		Cost:        10,
-		Type:        types.USUAL,
+		ID:             1,
+		Name:           "Quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+		Cooldown:       time.Hour,
+		Probability:    0.5,
+		RewardSplit:    types.SplitEqual,
 	}
 
 	questColumns := []string{
-		"id", "name", "description", "cost", "type", "exists",
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards", "reward_split", "archived_at", "categories", "tags", "exists",
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		qrs.mock.ExpectQuery(createQuery).
-			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
 			WillReturnRows(sqlxmock.NewRows(questColumns).
-				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, 0),
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}", 0),
 			)
 
 		t.NewStep("Check result")
-		qst, err := qrs.QuestRepository.CreateQuest(quest)
+		qst, err := qrs.QuestRepository.CreateQuest(context.Background(), quest)
 		t.Require().NoError(err)
 		t.Require().EqualValues(quest, qst)
 	})
@@ -63,81 +77,341 @@ func (qrs *QuestRepositorySuite) TestCreateFunction(t provider.T) {
 	t.WithNewStep("Conflict name exists execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		qrs.mock.ExpectQuery(createQuery).
-			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
 			WillReturnRows(sqlxmock.NewRows(questColumns).
-				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, 1),
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}", 1),
 			)
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.CreateQuest(quest)
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), quest)
 		t.Require().ErrorIs(err, ErrorQuestNameAlreadyExists)
 	})
 
 	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		qrs.mock.ExpectQuery(createQuery).
-			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
 			WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.CreateQuest(quest)
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), quest)
 		t.Require().ErrorIs(err, testError)
 	})
+
+	t.WithNewStep("Staged quest without steps execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
+			WillReturnError(&pq.Error{Code: checkConflictCode, Constraint: stagedStepsConstraintName})
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), quest)
+		t.Require().ErrorIs(err, ErrorStagedQuestNoSteps)
+	})
+
+	t.WithNewStep("Counter quest without target execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
+			WillReturnError(&pq.Error{Code: checkConflictCode, Constraint: counterTargetConstraintName})
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), quest)
+		t.Require().ErrorIs(err, ErrorCounterQuestNoTarget)
+	})
+
+	streakQuest := *quest
+	streakQuest.Type = types.STREAK
+	streakQuest.MaxCompletions = 0
+	streakQuest.Milestones = []Milestone{{Days: 7, Reward: 30}, {Days: 3, Reward: 10}, {Days: 7, Reward: 50}}
+
+	t.WithNewStep("Correct streak quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, streakQuest.Type, pq.Array(quest.Steps), streakQuest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{3, 7}), pq.Array([]int64{10, 30}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
+			WillReturnRows(sqlxmock.NewRows(questColumns).
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, streakQuest.Type, "{}", streakQuest.MaxCompletions, 3600, "{}",
+					nil, nil, 0.5, 0, 0, "{3,7}", "{10,30}", "equal", nil, "{}", "{}", 0),
+			)
+
+		t.NewStep("Check result")
+		newQuest, err := qrs.QuestRepository.CreateQuest(context.Background(), &streakQuest)
+		t.Require().NoError(err)
+		t.Require().EqualValues([]Milestone{{Days: 3, Reward: 10}, {Days: 7, Reward: 30}}, newQuest.Milestones)
+	})
+
+	t.WithNewStep("Streak quest without milestones execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
+			WillReturnError(&pq.Error{Code: checkConflictCode, Constraint: streakMilestonesConstraintName})
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), quest)
+		t.Require().ErrorIs(err, ErrorStreakQuestNoMilestones)
+	})
+
+	teamQuest := *quest
+	teamQuest.Type = types.TEAM
+	teamQuest.RewardSplit = types.SplitFixed
+
+	t.WithNewStep("Team quest without target execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, teamQuest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitFixed, pq.Array([]int64{}), pq.Array([]string{})).
+			WillReturnError(&pq.Error{Code: checkConflictCode, Constraint: teamTargetConstraintName})
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), &teamQuest)
+		t.Require().ErrorIs(err, ErrorTeamQuestNoTarget)
+	})
+
+	labeledQuest := *quest
+	labeledQuest.Categories = []types.Id{3, 1, 3}
+	labeledQuest.Tags = []string{"social", "daily", "social"}
+
+	t.WithNewStep("Correct categories and tags execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{1, 3}), pq.Array([]string{"daily", "social"})).
+			WillReturnRows(sqlxmock.NewRows(questColumns).
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}",
+					nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{1,3}", "{daily,social}", 0),
+			)
+
+		t.NewStep("Check result")
+		newQuest, err := qrs.QuestRepository.CreateQuest(context.Background(), &labeledQuest)
+		t.Require().NoError(err)
+		t.Require().EqualValues([]types.Id{1, 3}, newQuest.Categories)
+		t.Require().EqualValues([]string{"daily", "social"}, newQuest.Tags)
+	})
+
+	t.WithNewStep("Unknown category execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{1, 3}), pq.Array([]string{"daily", "social"})).
+			WillReturnError(&pq.Error{Code: foreignKeyConflictCode, Constraint: categoryIdConstraintName})
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), &labeledQuest)
+		t.Require().ErrorIs(err, ErrorCategoryNotFound)
+	})
+
+	startsAt := pkgtime.MustParse("01.12.2024 - 00:00:00")
+	endsAt := pkgtime.MustParse("01.01.2025 - 00:00:00")
+	seasonalQuest := *quest
+	seasonalQuest.StartsAt, seasonalQuest.EndsAt = &startsAt, &endsAt
+
+	t.WithNewStep("Correct availability window execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), getNullTime(&startsAt), getNullTime(&endsAt),
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
+			WillReturnRows(sqlxmock.NewRows(questColumns).
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}",
+					startsAt.Time, endsAt.Time, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}", 0),
+			)
+
+		t.NewStep("Check result")
+		newQuest, err := qrs.QuestRepository.CreateQuest(context.Background(), &seasonalQuest)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&seasonalQuest, newQuest)
+	})
+
+	t.WithNewStep("Invalid availability window execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array([]int64{}), getNullTime(&startsAt), getNullTime(&endsAt),
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
+			WillReturnError(&pq.Error{Code: checkConflictCode, Constraint: windowConstraintName})
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), &seasonalQuest)
+		t.Require().ErrorIs(err, ErrorInvalidWindow)
+	})
 }
 
-func (qrs *QuestRepositorySuite) TestDeleteFunction(t provider.T) {
-	t.Title("DeleteQuest function of Quest repository")
+func (qrs *QuestRepositorySuite) TestArchiveFunction(t provider.T) {
+	t.Title("ArchiveQuest function of Quest repository")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectExec(archiveQuest).WithArgs(id).WillReturnResult(sqlxmock.NewResult(0, 1))
+
+		t.NewStep("Check result")
+		err := qrs.QuestRepository.ArchiveQuest(context.Background(), id)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Postgres error for archiveQuest query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectExec(archiveQuest).WithArgs(id).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		err := qrs.QuestRepository.ArchiveQuest(context.Background(), id)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Affected rows error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectExec(archiveQuest).WithArgs(id).WillReturnResult(sqlxmock.NewErrorResult(testError))
+
+		t.NewStep("Check result")
+		err := qrs.QuestRepository.ArchiveQuest(context.Background(), id)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Error not found quest", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectExec(archiveQuest).WithArgs(id).WillReturnResult(sqlxmock.NewResult(0, 0))
+
+		t.NewStep("Check result")
+		err := qrs.QuestRepository.ArchiveQuest(context.Background(), id)
+		t.Require().ErrorIs(err, ErrorQuestNotFound)
+	})
+}
+
+func (qrs *QuestRepositorySuite) TestRestoreFunction(t provider.T) {
+	t.Title("RestoreQuest function of Quest repository")
 	t.NewStep("Init test data")
 	quest := &Quest{
-		ID:          1,
-		Name:        "Quest",
-		Description: "good Quest",
-		Cost:        10,
-		Type:        types.USUAL,
+		ID:             1,
+		Name:           "Quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+		Cooldown:       time.Hour,
+		Probability:    0.5,
+		RewardSplit:    types.SplitEqual,
+	}
+
+	questColumns := []string{
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards", "reward_split", "archived_at", "categories", "tags",
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectExec(deleteQuest).
+		qrs.mock.ExpectQuery(restoreQuest).
 			WithArgs(quest.ID).
-			WillReturnResult(sqlxmock.NewResult(0, 1))
+			WillReturnRows(sqlxmock.NewRows(questColumns).
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}"),
+			)
 
 		t.NewStep("Check result")
-		err := qrs.QuestRepository.DeleteQuest(quest.ID)
+		qst, err := qrs.QuestRepository.RestoreQuest(context.Background(), quest.ID)
 		t.Require().NoError(err)
+		t.Require().EqualValues(quest, qst)
+	})
+
+	t.WithNewStep("Error not found quest", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(restoreQuest).
+			WithArgs(quest.ID).
+			WillReturnRows(sqlxmock.NewRows(questColumns))
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.RestoreQuest(context.Background(), quest.ID)
+		t.Require().ErrorIs(err, ErrorQuestNotFound)
 	})
 
-	t.WithNewStep("Postgres error for deleteQuest query", func(t provider.StepCtx) {
+	t.WithNewStep("Postgres error for restoreQuest query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectExec(deleteQuest).
+		qrs.mock.ExpectQuery(restoreQuest).
 			WithArgs(quest.ID).WillReturnError(testError)
 
 		t.NewStep("Check result")
-		err := qrs.QuestRepository.DeleteQuest(quest.ID)
+		_, err := qrs.QuestRepository.RestoreQuest(context.Background(), quest.ID)
 		t.Require().ErrorIs(err, testError)
 	})
+}
+
+func (qrs *QuestRepositorySuite) TestPurgeFunction(t provider.T) {
+	t.Title("PurgeQuest function of Quest repository")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	countColumns := []string{
+		"deleted", "found",
+	}
 
-	t.WithNewStep("Row affected error of deleteQuest query", func(t provider.StepCtx) {
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectExec(deleteQuest).
-			WithArgs(quest.ID).
-			WillReturnResult(sqlxmock.NewErrorResult(testError))
+		qrs.mock.ExpectQuery(purgeQuest).
+			WithArgs(id).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(1, 1))
+
+		t.NewStep("Check result")
+		err := qrs.QuestRepository.PurgeQuest(context.Background(), id)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Postgres error for purgeQuest query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(purgeQuest).
+			WithArgs(id).WillReturnError(testError)
 
 		t.NewStep("Check result")
-		err := qrs.QuestRepository.DeleteQuest(quest.ID)
+		err := qrs.QuestRepository.PurgeQuest(context.Background(), id)
 		t.Require().ErrorIs(err, testError)
 	})
 
 	t.WithNewStep("Error not found quest", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectExec(deleteQuest).
-			WithArgs(quest.ID).
-			WillReturnResult(sqlxmock.NewResult(2, 0))
+		qrs.mock.ExpectQuery(purgeQuest).
+			WithArgs(id).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(0, 0))
 
 		t.NewStep("Check result")
-		err := qrs.QuestRepository.DeleteQuest(quest.ID)
+		err := qrs.QuestRepository.PurgeQuest(context.Background(), id)
 		t.Require().ErrorIs(err, ErrorQuestNotFound)
 	})
+
+	t.WithNewStep("Error not archived quest", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(purgeQuest).
+			WithArgs(id).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(0, 1))
+
+		t.NewStep("Check result")
+		err := qrs.QuestRepository.PurgeQuest(context.Background(), id)
+		t.Require().ErrorIs(err, ErrorQuestNotArchived)
+	})
 }
 
 func (qrs *QuestRepositorySuite) TestGetQuestFunction(t provider.T) {
@@ -145,15 +419,21 @@ func (qrs *QuestRepositorySuite) TestGetQuestFunction(t provider.T) {
 	t.NewStep("Init test data")
 
 	quest := &Quest{
-		ID:          1,
-		Name:        "Quest",
-		Description: "good Quest",
-		Cost:        10,
-		Type:        types.USUAL,
+		ID:             1,
+		Name:           "Quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+		Cooldown:       time.Hour,
+		Probability:    0.5,
+		RewardSplit:    types.SplitEqual,
 	}
 
 	questColumns := []string{
-		"id", "name", "description", "cost", "type",
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards", "reward_split", "archived_at", "categories", "tags",
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
@@ -161,11 +441,11 @@ func (qrs *QuestRepositorySuite) TestGetQuestFunction(t provider.T) {
 		qrs.mock.ExpectQuery(getQuest).
 			WithArgs(quest.ID).
 			WillReturnRows(sqlxmock.NewRows(questColumns).
-				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type),
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}"),
 			)
 
 		t.NewStep("Check result")
-		qst, err := qrs.QuestRepository.GetQuest(quest.ID)
+		qst, err := qrs.QuestRepository.GetQuest(context.Background(), quest.ID)
 		t.Require().NoError(err)
 		t.Require().EqualValues(quest, qst)
 	})
@@ -177,7 +457,7 @@ func (qrs *QuestRepositorySuite) TestGetQuestFunction(t provider.T) {
 			WillReturnRows(sqlxmock.NewRows(questColumns))
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.GetQuest(quest.ID)
+		_, err := qrs.QuestRepository.GetQuest(context.Background(), quest.ID)
 		t.Require().ErrorIs(err, ErrorQuestNotFound)
 	})
 
@@ -188,7 +468,7 @@ func (qrs *QuestRepositorySuite) TestGetQuestFunction(t provider.T) {
 			WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.GetQuest(quest.ID)
+		_, err := qrs.QuestRepository.GetQuest(context.Background(), quest.ID)
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -197,15 +477,21 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 	t.Title("UpdateQuest function of Quest repository")
 	t.NewStep("Init test data")
 	quest := &Quest{
-		ID:          1,
-		Name:        "Quest",
-		Description: "good Quest",
-		Cost:        10,
-		Type:        types.USUAL,
+		ID:             1,
+		Name:           "Quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+		Cooldown:       time.Hour,
+		Probability:    0.5,
+		RewardSplit:    types.SplitEqual,
 	}
 
 	questColumns := []string{
-		"id", "name", "description", "cost", "type",
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards", "reward_split", "archived_at", "categories", "tags",
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
@@ -215,13 +501,25 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 				getNullString(&quest.Description),
 				sql.NullInt64{Valid: true, Int64: int64(quest.Cost)},
 				getNullString((*string)(&quest.Type)),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
 			).
 			WillReturnRows(sqlxmock.NewRows(questColumns).AddRow(
-				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type,
+				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}",
 			))
 
 		t.NewStep("Check result")
-		updatedQuest, err := qrs.QuestRepository.UpdateQuest(&UpdateQuest{
+		updatedQuest, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
 			ID:          quest.ID,
 			Description: &quest.Description,
 			Cost:        &quest.Cost,
@@ -238,13 +536,25 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 				getNullString(&quest.Description),
 				sql.NullInt64{Valid: false},
 				getNullString(nil),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
 			).
 			WillReturnRows(sqlxmock.NewRows(questColumns).AddRow(
-				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type,
+				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}",
 			))
 
 		t.NewStep("Check result")
-		updatedQuest, err := qrs.QuestRepository.UpdateQuest(&UpdateQuest{
+		updatedQuest, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
 			ID:          quest.ID,
 			Description: &quest.Description,
 			Cost:        nil,
@@ -261,13 +571,25 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 				getNullString(nil),
 				sql.NullInt64{Valid: true, Int64: int64(quest.Cost)},
 				getNullString(nil),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
 			).
 			WillReturnRows(sqlxmock.NewRows(questColumns).AddRow(
-				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type,
+				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}",
 			))
 
 		t.NewStep("Check result")
-		updatedQuest, err := qrs.QuestRepository.UpdateQuest(&UpdateQuest{
+		updatedQuest, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
 			ID:          quest.ID,
 			Description: nil,
 			Cost:        &quest.Cost,
@@ -284,13 +606,25 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 				getNullString(nil),
 				sql.NullInt64{Valid: false},
 				getNullString((*string)(&quest.Type)),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
 			).
 			WillReturnRows(sqlxmock.NewRows(questColumns).AddRow(
-				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type,
+				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}",
 			))
 
 		t.NewStep("Check result")
-		updatedQuest, err := qrs.QuestRepository.UpdateQuest(&UpdateQuest{
+		updatedQuest, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
 			ID:          quest.ID,
 			Description: nil,
 			Cost:        nil,
@@ -300,6 +634,151 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 		t.Require().EqualValues(quest, updatedQuest)
 	})
 
+	t.WithNewStep("Correct only steps execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		steps := []string{"first", "second"}
+		qrs.mock.ExpectQuery(updateQuest).
+			WithArgs(quest.ID,
+				getNullString(nil),
+				sql.NullInt64{Valid: false},
+				getNullString(nil),
+				pq.Array(steps),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
+			).
+			WillReturnRows(sqlxmock.NewRows(questColumns).AddRow(
+				quest.ID, quest.Name, quest.Description, quest.Cost, types.STAGED, "{first,second}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}",
+			))
+
+		t.NewStep("Check result")
+		updatedQuest, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:    quest.ID,
+			Steps: steps,
+		})
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Quest{
+			ID:             quest.ID,
+			Name:           quest.Name,
+			Description:    quest.Description,
+			Cost:           quest.Cost,
+			Type:           types.STAGED,
+			Steps:          steps,
+			MaxCompletions: quest.MaxCompletions,
+			Cooldown:       quest.Cooldown,
+			Probability:    quest.Probability,
+			RewardSplit:    types.SplitEqual,
+		}, updatedQuest)
+	})
+
+	t.WithNewStep("Correct only completions limit and cooldown execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(updateQuest).
+			WithArgs(quest.ID,
+				getNullString(nil),
+				sql.NullInt64{Valid: false},
+				getNullString(nil),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: true, Int64: int64(quest.MaxCompletions)},
+				sql.NullInt64{Valid: true, Int64: 3600},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
+			).
+			WillReturnRows(sqlxmock.NewRows(questColumns).AddRow(
+				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}",
+			))
+
+		t.NewStep("Check result")
+		updatedQuest, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:             quest.ID,
+			MaxCompletions: &quest.MaxCompletions,
+			Cooldown:       &quest.Cooldown,
+		})
+		t.Require().NoError(err)
+		t.Require().EqualValues(quest, updatedQuest)
+	})
+
+	t.WithNewStep("Correct only categories and tags execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectQuery(updateQuest).
+			WithArgs(quest.ID,
+				getNullString(nil),
+				sql.NullInt64{Valid: false},
+				getNullString(nil),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64{}), pq.Array([]string{"daily"}),
+			).
+			WillReturnRows(sqlxmock.NewRows(questColumns).AddRow(
+				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{daily}",
+			))
+
+		t.NewStep("Check result")
+		updatedQuest, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:         quest.ID,
+			Categories: []types.Id{},
+			Tags:       []string{"daily", "daily"},
+		})
+		t.Require().NoError(err)
+		t.Require().Nil(updatedQuest.Categories)
+		t.Require().EqualValues([]string{"daily"}, updatedQuest.Tags)
+	})
+
+	t.WithNewStep("Staged quest without steps execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		staged := types.STAGED
+		qrs.mock.ExpectQuery(updateQuest).
+			WithArgs(quest.ID,
+				getNullString(nil),
+				sql.NullInt64{Valid: false},
+				getNullString((*string)(&staged)),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
+			).
+			WillReturnError(&pq.Error{Code: checkConflictCode, Constraint: stagedStepsConstraintName})
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:   quest.ID,
+			Type: &staged,
+		})
+		t.Require().ErrorIs(err, ErrorStagedQuestNoSteps)
+	})
+
 	t.WithNewStep("Error quest not found execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		qrs.mock.ExpectQuery(updateQuest).
@@ -307,11 +786,23 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 				getNullString(&quest.Description),
 				sql.NullInt64{Valid: true, Int64: int64(quest.Cost)},
 				getNullString((*string)(&quest.Type)),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
 			).
 			WillReturnRows(sqlxmock.NewRows(questColumns))
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.UpdateQuest(&UpdateQuest{
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
 			ID:          quest.ID,
 			Description: &quest.Description,
 			Cost:        &quest.Cost,
@@ -327,10 +818,22 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 				getNullString(&quest.Description),
 				sql.NullInt64{Valid: true, Int64: int64(quest.Cost)},
 				getNullString((*string)(&quest.Type)),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
 			).WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.UpdateQuest(&UpdateQuest{
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
 			ID:          quest.ID,
 			Description: &quest.Description,
 			Cost:        &quest.Cost,
@@ -340,35 +843,290 @@ func (qrs *QuestRepositorySuite) TestUpdateFunction(t provider.T) {
 	})
 }
 
+func (qrs *QuestRepositorySuite) TestPrerequisitesFunction(t provider.T) {
+	t.Title("Prerequisites of quests in CreateQuest and UpdateQuest functions of Quest repository")
+	t.NewStep("Init test data")
+	quest := &Quest{
+		ID:             3,
+		Name:           "Quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+		Cooldown:       time.Hour,
+		Probability:    0.5,
+		RewardSplit:    types.SplitEqual,
+		Prerequisites:  []types.Id{1, 2},
+	}
+	// Duplicates are removed and ids are sorted before storing
+	requested := []types.Id{2, 1, 2}
+	stored := []int64{1, 2}
+
+	questColumns := []string{
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards", "reward_split", "archived_at", "categories", "tags", "exists",
+	}
+
+	countColumns := []string{
+		"count",
+	}
+
+	cycleColumns := []string{
+		"exists",
+	}
+
+	expectUpdate := func() *sqlxmock.ExpectedQuery {
+		return qrs.mock.ExpectQuery(updateQuest).
+			WithArgs(quest.ID,
+				getNullString(nil),
+				sql.NullInt64{Valid: false},
+				getNullString(nil),
+				pq.Array([]string(nil)),
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array(stored),
+				sql.Null[time.Time]{},
+				sql.Null[time.Time]{},
+				sql.NullFloat64{Valid: false},
+				sql.NullInt64{Valid: false},
+				sql.NullInt64{Valid: false},
+				pq.Array([]int64(nil)), pq.Array([]int64(nil)),
+				sql.NullString{},
+				pq.Array([]int64(nil)), pq.Array([]string(nil)),
+			)
+	}
+
+	t.WithNewStep("Correct create execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin()
+		qrs.mock.ExpectQuery(lockPrerequisites).
+			WithArgs(pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(2))
+		qrs.mock.ExpectQuery(createQuery).
+			WithArgs(quest.Name, quest.Description, quest.Cost, quest.Type, pq.Array(quest.Steps), quest.MaxCompletions, int64(3600),
+				pq.Array(stored), sql.Null[time.Time]{}, sql.Null[time.Time]{},
+				quest.Probability, quest.Pity, quest.Target, pq.Array([]int64{}), pq.Array([]int64{}),
+				types.SplitEqual, pq.Array([]int64{}), pq.Array([]string{})).
+			WillReturnRows(sqlxmock.NewRows(questColumns).
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{1,2}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}", 0),
+			)
+		qrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		createdQuest, err := qrs.QuestRepository.CreateQuest(context.Background(), &Quest{
+			Name:           quest.Name,
+			Description:    quest.Description,
+			Cost:           quest.Cost,
+			Type:           quest.Type,
+			Steps:          quest.Steps,
+			MaxCompletions: quest.MaxCompletions,
+			Cooldown:       quest.Cooldown,
+			Probability:    quest.Probability,
+			Prerequisites:  requested,
+		})
+		t.Require().NoError(err)
+		t.Require().EqualValues(quest, createdQuest)
+	})
+
+	t.WithNewStep("Create with unknown prerequisite execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin()
+		qrs.mock.ExpectQuery(lockPrerequisites).
+			WithArgs(pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(1))
+		qrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.CreateQuest(context.Background(), &Quest{
+			Name:          quest.Name,
+			Type:          quest.Type,
+			Prerequisites: requested,
+		})
+		t.Require().ErrorIs(err, ErrorPrerequisiteNotFound)
+	})
+
+	t.WithNewStep("Correct update execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin()
+		qrs.mock.ExpectExec(lockPrerequisitesGraph).
+			WithArgs(prerequisitesLockKey).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		qrs.mock.ExpectQuery(lockPrerequisites).
+			WithArgs(pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(2))
+		qrs.mock.ExpectQuery(hasPrerequisiteCycle).
+			WithArgs(quest.ID, pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(cycleColumns).AddRow(false))
+		expectUpdate().
+			WillReturnRows(sqlxmock.NewRows(questColumns[:20]).AddRow(
+				quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{1,2}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}",
+			))
+		qrs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		updatedQuest, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:            quest.ID,
+			Prerequisites: requested,
+		})
+		t.Require().NoError(err)
+		t.Require().EqualValues(quest, updatedQuest)
+	})
+
+	t.WithNewStep("Update with cycle execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin()
+		qrs.mock.ExpectExec(lockPrerequisitesGraph).
+			WithArgs(prerequisitesLockKey).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		qrs.mock.ExpectQuery(lockPrerequisites).
+			WithArgs(pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(2))
+		qrs.mock.ExpectQuery(hasPrerequisiteCycle).
+			WithArgs(quest.ID, pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(cycleColumns).AddRow(true))
+		qrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:            quest.ID,
+			Prerequisites: requested,
+		})
+		t.Require().ErrorIs(err, ErrorPrerequisiteCycle)
+	})
+
+	t.WithNewStep("Update with itself as prerequisite execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin()
+		qrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:            quest.ID,
+			Prerequisites: []types.Id{1, quest.ID},
+		})
+		t.Require().ErrorIs(err, ErrorPrerequisiteCycle)
+	})
+
+	t.WithNewStep("Update with unknown prerequisite execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin()
+		qrs.mock.ExpectExec(lockPrerequisitesGraph).
+			WithArgs(prerequisitesLockKey).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		qrs.mock.ExpectQuery(lockPrerequisites).
+			WithArgs(pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(1))
+		qrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:            quest.ID,
+			Prerequisites: requested,
+		})
+		t.Require().ErrorIs(err, ErrorPrerequisiteNotFound)
+	})
+
+	t.WithNewStep("Update of unknown quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin()
+		qrs.mock.ExpectExec(lockPrerequisitesGraph).
+			WithArgs(prerequisitesLockKey).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		qrs.mock.ExpectQuery(lockPrerequisites).
+			WithArgs(pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(2))
+		qrs.mock.ExpectQuery(hasPrerequisiteCycle).
+			WithArgs(quest.ID, pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(cycleColumns).AddRow(false))
+		expectUpdate().WillReturnRows(sqlxmock.NewRows(questColumns[:20]))
+		qrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:            quest.ID,
+			Prerequisites: requested,
+		})
+		t.Require().ErrorIs(err, ErrorQuestNotFound)
+	})
+
+	t.WithNewStep("Postgres error on cycle query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin()
+		qrs.mock.ExpectExec(lockPrerequisitesGraph).
+			WithArgs(prerequisitesLockKey).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		qrs.mock.ExpectQuery(lockPrerequisites).
+			WithArgs(pq.Array(stored)).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(2))
+		qrs.mock.ExpectQuery(hasPrerequisiteCycle).
+			WithArgs(quest.ID, pq.Array(stored)).
+			WillReturnError(testError)
+		qrs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:            quest.ID,
+			Prerequisites: requested,
+		})
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Begin error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qrs.mock.ExpectBegin().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.UpdateQuest(context.Background(), &UpdateQuest{
+			ID:            quest.ID,
+			Prerequisites: requested,
+		})
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
 func (qrs *QuestRepositorySuite) TestGetQuestsFunction(t provider.T) {
 	t.Title("GetQuests function of Quest repository")
 	t.NewStep("Init test data")
 
 	quest := &Quest{
-		ID:          1,
-		Name:        "Quest",
-		Description: "good Quest",
-		Cost:        10,
-		Type:        types.USUAL,
+		ID:             1,
+		Name:           "Quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+		Cooldown:       time.Hour,
+		Probability:    0.5,
+		RewardSplit:    types.SplitEqual,
 	}
 
 	questColumns := []string{
-		"id", "name", "description", "cost", "type",
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards", "reward_split", "archived_at", "categories", "tags",
+	}
+
+	query := &QuestsQuery{
+		Sort:  types.QuestsSortID,
+		Order: page.Asc,
+		Limit: 10,
 	}
+	sqlQuery := getQuests + " WHERE (archived_at IS NOT NULL) = $1 ORDER BY id ASC LIMIT $2"
 
 	questRows := func() *sqlxmock.Rows {
 		return sqlxmock.NewRows(questColumns).
-			AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type).
-			AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type).
-			AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type)
+			AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}").
+			AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}").
+			AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}")
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectQuery(getQuests).WillReturnRows(questRows())
+		qrs.mock.ExpectQuery(sqlQuery).WithArgs(false, query.Limit).WillReturnRows(questRows())
 
 		t.NewStep("Check result")
-		qsts, err := qrs.QuestRepository.GetQuests()
+		qsts, err := qrs.QuestRepository.GetQuests(context.Background(), query)
 		t.Require().NoError(err)
 		t.Require().EqualValues([]Quest{
 			*quest, *quest, *quest,
@@ -377,39 +1135,141 @@ func (qrs *QuestRepositorySuite) TestGetQuestsFunction(t provider.T) {
 
 	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectQuery(getQuests).WillReturnError(testError)
+		qrs.mock.ExpectQuery(sqlQuery).WithArgs(false, query.Limit).WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.GetQuests()
+		_, err := qrs.QuestRepository.GetQuests(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 
 	t.WithNewStep("Row error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectQuery(getQuests).WillReturnRows(questRows().RowError(1, testError))
+		qrs.mock.ExpectQuery(sqlQuery).WithArgs(false, query.Limit).WillReturnRows(questRows().RowError(1, testError))
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.GetQuests()
+		_, err := qrs.QuestRepository.GetQuests(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 
 	t.WithNewStep("Scan error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectQuery(getQuests).WillReturnRows(questRows().AddRow(1, 1, 1, 1, 1))
+		qrs.mock.ExpectQuery(sqlQuery).WithArgs(false, query.Limit).WillReturnRows(questRows().AddRow(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1))
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.GetQuests()
+		_, err := qrs.QuestRepository.GetQuests(context.Background(), query)
 		t.Require().Error(err)
 	})
 
 	t.WithNewStep("Close row error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qrs.mock.ExpectQuery(getQuests).WillReturnRows(questRows().CloseError(testError))
+		qrs.mock.ExpectQuery(sqlQuery).WithArgs(false, query.Limit).WillReturnRows(questRows().CloseError(testError))
 
 		t.NewStep("Check result")
-		_, err := qrs.QuestRepository.GetQuests()
+		_, err := qrs.QuestRepository.GetQuests(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
+
+	t.WithNewStep("Filters and cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		questType := types.RANDOM
+		minCost, maxCost := types.Cost(5), types.Cost(50)
+		filteredQuery := &QuestsQuery{
+			Type:       &questType,
+			MinCost:    &minCost,
+			MaxCost:    &maxCost,
+			NamePrefix: "Qu",
+			Sort:       types.QuestsSortName,
+			Order:      page.Asc,
+			After:      &page.Cursor{Value: "Quest", ID: 3},
+			Limit:      10,
+		}
+		qrs.mock.ExpectQuery(getQuests+
+			" WHERE (archived_at IS NOT NULL) = $1 AND type = $2 AND cost >= $3 AND cost <= $4 AND name LIKE $5"+
+			" AND (name, id) > ($6, $7) ORDER BY name ASC, id ASC LIMIT $8").
+			WithArgs(false, questType, minCost, maxCost, "Qu%", "Quest", types.Id(3), filteredQuery.Limit).
+			WillReturnRows(questRows())
+
+		t.NewStep("Check result")
+		qsts, err := qrs.QuestRepository.GetQuests(context.Background(), filteredQuery)
+		t.Require().NoError(err)
+		t.Require().EqualValues([]Quest{
+			*quest, *quest, *quest,
+		}, qsts)
+	})
+
+	t.WithNewStep("Category and tag filter execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		categoryId := types.Id(2)
+		labelQuery := &QuestsQuery{
+			CategoryId: &categoryId,
+			Tag:        "daily",
+			Sort:       types.QuestsSortID,
+			Order:      page.Asc,
+			Limit:      10,
+		}
+		qrs.mock.ExpectQuery(getQuests+
+			" WHERE (archived_at IS NOT NULL) = $1"+
+			" AND EXISTS (SELECT 1 FROM quest_categories WHERE quest_id = quests.id AND category_id = $2)"+
+			" AND EXISTS (SELECT 1 FROM quest_tags WHERE quest_id = quests.id AND tag = $3)"+
+			" ORDER BY id ASC LIMIT $4").
+			WithArgs(false, categoryId, "daily", labelQuery.Limit).
+			WillReturnRows(questRows())
+
+		t.NewStep("Check result")
+		qsts, err := qrs.QuestRepository.GetQuests(context.Background(), labelQuery)
+		t.Require().NoError(err)
+		t.Require().Len(qsts, 3)
+	})
+
+	t.WithNewStep("Active filter execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		now := time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC)
+		activeQuery := &QuestsQuery{
+			ActiveAt: &now,
+			Sort:     types.QuestsSortID,
+			Order:    page.Asc,
+			Limit:    10,
+		}
+		qrs.mock.ExpectQuery(getQuests+
+			" WHERE (archived_at IS NOT NULL) = $1 AND (starts_at IS NULL OR starts_at <= $2) AND (ends_at IS NULL OR ends_at > $2)"+
+			" ORDER BY id ASC LIMIT $3").
+			WithArgs(false, now, activeQuery.Limit).
+			WillReturnRows(questRows())
+
+		t.NewStep("Check result")
+		qsts, err := qrs.QuestRepository.GetQuests(context.Background(), activeQuery)
+		t.Require().NoError(err)
+		t.Require().Len(qsts, 3)
+	})
+
+	t.WithNewStep("Archived filter execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		archivedAt := pkgtime.MustParse("01.02.2025 - 00:00:00")
+		archivedQuery := &QuestsQuery{
+			Archived: true,
+			Sort:     types.QuestsSortID,
+			Order:    page.Asc,
+			Limit:    10,
+		}
+		qrs.mock.ExpectQuery(sqlQuery).
+			WithArgs(true, archivedQuery.Limit).
+			WillReturnRows(sqlxmock.NewRows(questColumns).
+				AddRow(quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type, "{}", quest.MaxCompletions, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", archivedAt.Time, "{}", "{}"),
+			)
+
+		t.NewStep("Check result")
+		archivedQuest := *quest
+		archivedQuest.ArchivedAt = &archivedAt
+		qsts, err := qrs.QuestRepository.GetQuests(context.Background(), archivedQuery)
+		t.Require().NoError(err)
+		t.Require().EqualValues([]Quest{archivedQuest}, qsts)
+	})
+
+	t.WithNewStep("Unknown sort execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		_, err := qrs.QuestRepository.GetQuests(context.Background(), &QuestsQuery{Sort: "created", Limit: 10})
+		t.Require().Error(err)
+	})
 }
 
 func TestRunQuestRepositorySuite(t *testing.T) {
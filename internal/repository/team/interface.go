@@ -0,0 +1,58 @@
+package team
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/types"
+)
+
+var (
+	ErrorTeamNotFound          = errors.New("team with id not found")
+	ErrorTeamNameAlreadyExists = errors.New("team with name already exists")
+	ErrorUserNotFound          = errors.New("user with id not found")
+	ErrorUserAlreadyInTeam     = errors.New("user is already member of team")
+	ErrorMemberNotFound        = errors.New("user is not member of team")
+
+	ErrorUserNotInTeam      = errors.New("user is not member of any team")
+	ErrorAlreadyContributed = errors.New("user already contributed to team quest")
+)
+
+//go:generate mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=TeamRepository . Repository
+
+type Repository interface {
+	// CreateTeam
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorTeamNameAlreadyExists
+	CreateTeam(ctx context.Context, name string) (*Team, error)
+
+	// GetTeams
+	// Returns all teams sorted by id without members.
+	// Returns Error:
+	//   - SQLError
+	GetTeams(ctx context.Context) ([]Team, error)
+
+	// GetTeam
+	// Returns team with its active members.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorTeamNotFound
+	GetTeam(ctx context.Context, id types.Id) (*Team, error)
+
+	// AddMember
+	// User can be member of only one team.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorTeamNotFound
+	//   - ErrorUserNotFound
+	//   - ErrorUserAlreadyInTeam
+	AddMember(ctx context.Context, teamId, userId types.Id) (*Member, error)
+
+	// RemoveMember
+	// Contributions of user to unfinished team quests are removed too.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorMemberNotFound
+	RemoveMember(ctx context.Context, teamId, userId types.Id) error
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/repository/team (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=TeamRepository . Repository
+//
+
+// Package mr is a generated GoMock package.
+package mr
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	team "vk_quests/internal/repository/team"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// TeamRepository is a mock of Repository interface.
+type TeamRepository struct {
+	ctrl     *gomock.Controller
+	recorder *TeamRepositoryMockRecorder
+}
+
+// TeamRepositoryMockRecorder is the mock recorder for TeamRepository.
+type TeamRepositoryMockRecorder struct {
+	mock *TeamRepository
+}
+
+// NewTeamRepository creates a new mock instance.
+func NewTeamRepository(ctrl *gomock.Controller) *TeamRepository {
+	mock := &TeamRepository{ctrl: ctrl}
+	mock.recorder = &TeamRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *TeamRepository) EXPECT() *TeamRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *TeamRepository) AddMember(arg0 context.Context, arg1, arg2 types.Id) (*team.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*team.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *TeamRepositoryMockRecorder) AddMember(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*TeamRepository)(nil).AddMember), arg0, arg1, arg2)
+}
+
+// CreateTeam mocks base method.
+func (m *TeamRepository) CreateTeam(arg0 context.Context, arg1 string) (*team.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", arg0, arg1)
+	ret0, _ := ret[0].(*team.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *TeamRepositoryMockRecorder) CreateTeam(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*TeamRepository)(nil).CreateTeam), arg0, arg1)
+}
+
+// GetTeam mocks base method.
+func (m *TeamRepository) GetTeam(arg0 context.Context, arg1 types.Id) (*team.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", arg0, arg1)
+	ret0, _ := ret[0].(*team.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *TeamRepositoryMockRecorder) GetTeam(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*TeamRepository)(nil).GetTeam), arg0, arg1)
+}
+
+// GetTeams mocks base method.
+func (m *TeamRepository) GetTeams(arg0 context.Context) ([]team.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeams", arg0)
+	ret0, _ := ret[0].([]team.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeams indicates an expected call of GetTeams.
+func (mr *TeamRepositoryMockRecorder) GetTeams(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeams", reflect.TypeOf((*TeamRepository)(nil).GetTeams), arg0)
+}
+
+// RemoveMember mocks base method.
+func (m *TeamRepository) RemoveMember(arg0 context.Context, arg1, arg2 types.Id) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *TeamRepositoryMockRecorder) RemoveMember(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*TeamRepository)(nil).RemoveMember), arg0, arg1, arg2)
+}
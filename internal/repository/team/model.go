@@ -0,0 +1,25 @@
+package team
+
+import (
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+)
+
+type Team struct {
+	ID      types.Id
+	Name    string
+	Created time.FormattedTime
+	Members []Member // active members sorted by time of joining, filled only by GetTeam
+}
+
+type Member struct {
+	UserId types.Id
+	Name   string
+	Joined time.FormattedTime
+}
+
+// Contribution is contribution of member to current completion of team quest.
+type Contribution struct {
+	Created      time.FormattedTime
+	Contributors uint32 // number of active members contributed to current completion including this one
+}
@@ -0,0 +1,289 @@
+package team
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+)
+
+// Queries of LockTeam, Contribute and TakeContributors are exported, so repositories calling them
+// inside their transactions can expect them in tests.
+// Contributions of removed or deleted members are ignored, so they are neither counted nor paid.
+const (
+	LockTeamQuery = `
+		SELECT teams.id FROM team_members JOIN teams ON (team_members.team_id = teams.id)
+		WHERE team_members.user_id = $1
+		FOR UPDATE OF teams
+	`
+
+	// ContributeQuery returns nothing if user already contributed to current completion of quest
+	ContributeQuery = `
+		WITH contribution AS (
+			INSERT INTO team_contributions (team_id, quest_id, user_id) VALUES ($1, $2, $3)
+			ON CONFLICT (team_id, quest_id, user_id) DO NOTHING
+			RETURNING created
+		)
+		SELECT created, 1 + (
+			SELECT count(*) FROM team_contributions
+				JOIN team_members ON (team_members.user_id = team_contributions.user_id AND team_members.team_id = $1)
+				JOIN users ON (team_contributions.user_id = users.id)
+			WHERE team_contributions.team_id = $1 AND quest_id = $2 AND users.deleted_at IS NULL
+		) FROM contribution
+	`
+
+	TakeContributorsQuery = `
+		WITH taken AS (
+			DELETE FROM team_contributions WHERE team_id = $1 AND quest_id = $2
+			RETURNING user_id, created
+		)
+		SELECT taken.user_id FROM taken
+			JOIN team_members ON (team_members.user_id = taken.user_id AND team_members.team_id = $1)
+			JOIN users ON (taken.user_id = users.id)
+		WHERE users.deleted_at IS NULL
+		ORDER BY taken.created, taken.user_id
+	`
+)
+
+const (
+	createTeam = `
+		INSERT INTO teams (name) VALUES ($1)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING id, name, created
+	`
+
+	getTeams = `
+		SELECT id, name, created FROM teams ORDER BY id
+	`
+
+	getTeam = `
+		SELECT id, name, created FROM teams WHERE id = $1
+	`
+
+	getMembers = `
+		SELECT users.id, users.name, joined FROM team_members JOIN users ON (team_members.user_id = users.id)
+		WHERE team_id = $1 AND users.deleted_at IS NULL
+		ORDER BY joined, users.id
+	`
+
+	// addMember returns nothing if user is not found and null time of joining if user is already in some team
+	addMember = `
+		WITH member AS (
+			SELECT id, name FROM users WHERE id = $2 AND deleted_at IS NULL
+		), joined AS (
+			INSERT INTO team_members (user_id, team_id)
+			SELECT member.id, teams.id FROM member, teams WHERE teams.id = $1
+			ON CONFLICT (user_id) DO NOTHING
+			RETURNING joined
+		)
+		SELECT EXISTS (SELECT 1 FROM teams WHERE id = $1), member.id, member.name, joined.joined
+		FROM member LEFT JOIN joined ON true
+	`
+
+	// removeMember locks team like LockTeamQuery, so member doesn't leave during completion of team quest
+	removeMember = `
+		WITH team AS (
+			SELECT id FROM teams WHERE id = $1 FOR UPDATE
+		), member AS (
+			DELETE FROM team_members USING team WHERE team_members.team_id = team.id AND user_id = $2
+			RETURNING user_id
+		), contributions AS (
+			DELETE FROM team_contributions WHERE team_id = $1 AND user_id IN (SELECT user_id FROM member)
+		)
+		SELECT count(*) FROM member
+	`
+)
+
+type PostgresTeam struct {
+	db *sqlx.DB
+}
+
+func NewPostgresTeam(db *sqlx.DB) *PostgresTeam {
+	return &PostgresTeam{
+		db: db,
+	}
+}
+
+var _ = Repository(&PostgresTeam{})
+
+// LockTeam locks team of user until the end of transaction and returns its id,
+// so contributions of members to team quests are serialized.
+// Returns Error:
+//   - SQLError
+//   - ErrorUserNotInTeam
+func LockTeam(ctx context.Context, tx *sqlx.Tx, userId types.Id) (types.Id, error) {
+	var teamId types.Id
+	if err := tx.QueryRowxContext(ctx, LockTeamQuery, userId).Scan(&teamId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errors.Wrapf(ErrorUserNotInTeam, "user with id %d", userId)
+		}
+		return 0, errors.Wrapf(err, "can't lock team of user with id %d", userId)
+	}
+
+	return teamId, nil
+}
+
+// Contribute stores contribution of member to current completion of team quest.
+// It must be called inside transaction holding lock of LockTeam.
+// Returns Error:
+//   - SQLError
+//   - ErrorAlreadyContributed
+func Contribute(ctx context.Context, tx *sqlx.Tx, teamId, questId, userId types.Id) (*Contribution, error) {
+	contribution := &Contribution{}
+	if err := tx.QueryRowxContext(ctx, ContributeQuery, teamId, questId, userId).
+		Scan(&contribution.Created, &contribution.Contributors); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorAlreadyContributed, "user with id %d to quest with id %d", userId, questId)
+		}
+		return nil, errors.Wrapf(err, "can't store contribution of user with id %d to quest with id %d",
+			userId, questId)
+	}
+
+	return contribution, nil
+}
+
+// TakeContributors removes contributions to finished completion of team quest
+// and returns active members contributed to it in order of contribution.
+// It must be called inside transaction holding lock of LockTeam.
+// Returns Error:
+//   - SQLError
+func TakeContributors(ctx context.Context, tx *sqlx.Tx, teamId, questId types.Id) ([]types.Id, error) {
+	rows, err := tx.QueryxContext(ctx, TakeContributorsQuery, teamId, questId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't take contributors of team with id %d to quest with id %d",
+			teamId, questId)
+	}
+
+	contributors := make([]types.Id, 0)
+
+	for rows.Next() {
+		var userId types.Id
+
+		if err := rows.Scan(&userId); err != nil {
+			return nil, errors.Wrapf(err, "can't scan contributors of team with id %d", teamId)
+		}
+
+		contributors = append(contributors, userId)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "can't end scan contributors of team with id %d", teamId)
+	}
+
+	return contributors, nil
+}
+
+func (pt *PostgresTeam) CreateTeam(ctx context.Context, name string) (*Team, error) {
+	team := &Team{}
+	if err := pt.db.QueryRowxContext(ctx, createTeam, name).Scan(&team.ID, &team.Name, &team.Created); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorTeamNameAlreadyExists, "with name %q", name)
+		}
+
+		return nil, errors.Wrap(err, "can't create team")
+	}
+
+	return team, nil
+}
+
+func (pt *PostgresTeam) GetTeams(ctx context.Context) ([]Team, error) {
+	rows, err := pt.db.QueryxContext(ctx, getTeams)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't execute get teams query")
+	}
+
+	teams := make([]Team, 0)
+
+	for rows.Next() {
+		var team Team
+
+		if err := rows.Scan(&team.ID, &team.Name, &team.Created); err != nil {
+			return nil, errors.Wrap(err, "can't scan get teams query result")
+		}
+
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "can't end scan get teams query result")
+	}
+
+	return teams, nil
+}
+
+func (pt *PostgresTeam) GetTeam(ctx context.Context, id types.Id) (*Team, error) {
+	team := &Team{}
+	if err := pt.db.QueryRowxContext(ctx, getTeam, id).Scan(&team.ID, &team.Name, &team.Created); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorTeamNotFound, "with id %d", id)
+		}
+
+		return nil, errors.Wrapf(err, "can't get team with id %d", id)
+	}
+
+	rows, err := pt.db.QueryxContext(ctx, getMembers, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't execute get members query for team with id %d", id)
+	}
+
+	team.Members = make([]Member, 0)
+
+	for rows.Next() {
+		var member Member
+
+		if err := rows.Scan(&member.UserId, &member.Name, &member.Joined); err != nil {
+			return nil, errors.Wrapf(err, "can't scan get members query result for team with id %d", id)
+		}
+
+		team.Members = append(team.Members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "can't end scan get members query result for team with id %d", id)
+	}
+
+	return team, nil
+}
+
+func (pt *PostgresTeam) AddMember(ctx context.Context, teamId, userId types.Id) (*Member, error) {
+	var (
+		found  bool
+		joined *time.FormattedTime
+	)
+	member := &Member{}
+	if err := pt.db.QueryRowxContext(ctx, addMember, teamId, userId).
+		Scan(&found, &member.UserId, &member.Name, &joined); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrapf(ErrorUserNotFound, "with id %d", userId)
+		}
+
+		return nil, errors.Wrapf(err, "can't add user with id %d to team with id %d", userId, teamId)
+	}
+
+	switch {
+	case !found:
+		return nil, errors.Wrapf(ErrorTeamNotFound, "with id %d", teamId)
+	case joined == nil:
+		return nil, errors.Wrapf(ErrorUserAlreadyInTeam, "user with id %d", userId)
+	}
+
+	member.Joined = *joined
+
+	return member, nil
+}
+
+func (pt *PostgresTeam) RemoveMember(ctx context.Context, teamId, userId types.Id) error {
+	var removed uint64
+	if err := pt.db.QueryRowxContext(ctx, removeMember, teamId, userId).Scan(&removed); err != nil {
+		return errors.Wrapf(err, "can't remove user with id %d from team with id %d", userId, teamId)
+	}
+
+	if removed == 0 {
+		return errors.Wrapf(ErrorMemberNotFound, "user with id %d in team with id %d", userId, teamId)
+	}
+
+	return nil
+}
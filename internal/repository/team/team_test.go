@@ -0,0 +1,307 @@
+package team
+
+import (
+	"context"
+	"testing"
+	stdtime "time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+)
+
+var testError = errors.New("test error")
+
+type TeamRepositorySuite struct {
+	suite.Suite
+	teamRepository *PostgresTeam
+	mock           sqlxmock.Sqlmock
+}
+
+func (trs *TeamRepositorySuite) BeforeEach(t provider.T) {
+	db, mock, err := sqlxmock.Newx(sqlxmock.QueryMatcherOption(sqlxmock.QueryMatcherEqual))
+	t.Require().NoError(err)
+	trs.teamRepository = NewPostgresTeam(db)
+	trs.mock = mock
+}
+
+func (trs *TeamRepositorySuite) AfterEach(t provider.T) {
+	t.Require().NoError(trs.mock.ExpectationsWereMet())
+}
+
+var (
+	teamColumns   = []string{"id", "name", "created"}
+	memberColumns = []string{"id", "name", "joined"}
+	created       = stdtime.Date(2024, 3, 1, 12, 0, 0, 0, stdtime.UTC)
+)
+
+func (trs *TeamRepositorySuite) TestCreateTeamFunction(t provider.T) {
+	t.Title("CreateTeam function of Team repository")
+	t.NewStep("Init test data")
+	team := &Team{ID: 1, Name: "Wolves", Created: time.FormattedTime{Time: created}}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(createTeam).WithArgs(team.Name).
+			WillReturnRows(sqlxmock.NewRows(teamColumns).AddRow(team.ID, team.Name, created))
+
+		t.NewStep("Check result")
+		newTeam, err := trs.teamRepository.CreateTeam(context.Background(), team.Name)
+		t.Require().NoError(err)
+		t.Require().EqualValues(team, newTeam)
+	})
+
+	t.WithNewStep("Conflict name exists execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(createTeam).WithArgs(team.Name).WillReturnRows(sqlxmock.NewRows(teamColumns))
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.CreateTeam(context.Background(), team.Name)
+		t.Require().ErrorIs(err, ErrorTeamNameAlreadyExists)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(createTeam).WithArgs(team.Name).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.CreateTeam(context.Background(), team.Name)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (trs *TeamRepositorySuite) TestGetTeamsFunction(t provider.T) {
+	t.Title("GetTeams function of Team repository")
+	t.NewStep("Init test data")
+	teams := []Team{
+		{ID: 1, Name: "Wolves", Created: time.FormattedTime{Time: created}},
+		{ID: 2, Name: "Foxes", Created: time.FormattedTime{Time: created}},
+	}
+
+	teamRows := func() *sqlxmock.Rows {
+		return sqlxmock.NewRows(teamColumns).
+			AddRow(teams[0].ID, teams[0].Name, created).
+			AddRow(teams[1].ID, teams[1].Name, created)
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(getTeams).WillReturnRows(teamRows())
+
+		t.NewStep("Check result")
+		res, err := trs.teamRepository.GetTeams(context.Background())
+		t.Require().NoError(err)
+		t.Require().EqualValues(teams, res)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(getTeams).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.GetTeams(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Row error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(getTeams).WillReturnRows(teamRows().RowError(1, testError))
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.GetTeams(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Scan error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(getTeams).WillReturnRows(teamRows().AddRow("id", 1, 1))
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.GetTeams(context.Background())
+		t.Require().Error(err)
+	})
+}
+
+func (trs *TeamRepositorySuite) TestGetTeamFunction(t provider.T) {
+	t.Title("GetTeam function of Team repository")
+	t.NewStep("Init test data")
+	team := &Team{
+		ID:      1,
+		Name:    "Wolves",
+		Created: time.FormattedTime{Time: created},
+		Members: []Member{
+			{UserId: 3, Name: "Alice", Joined: time.FormattedTime{Time: created}},
+			{UserId: 2, Name: "Bob", Joined: time.FormattedTime{Time: created}},
+		},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(getTeam).WithArgs(team.ID).
+			WillReturnRows(sqlxmock.NewRows(teamColumns).AddRow(team.ID, team.Name, created))
+		trs.mock.ExpectQuery(getMembers).WithArgs(team.ID).
+			WillReturnRows(sqlxmock.NewRows(memberColumns).
+				AddRow(team.Members[0].UserId, team.Members[0].Name, created).
+				AddRow(team.Members[1].UserId, team.Members[1].Name, created))
+
+		t.NewStep("Check result")
+		res, err := trs.teamRepository.GetTeam(context.Background(), team.ID)
+		t.Require().NoError(err)
+		t.Require().EqualValues(team, res)
+	})
+
+	t.WithNewStep("Error team not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(getTeam).WithArgs(team.ID).WillReturnRows(sqlxmock.NewRows(teamColumns))
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.GetTeam(context.Background(), team.ID)
+		t.Require().ErrorIs(err, ErrorTeamNotFound)
+	})
+
+	t.WithNewStep("Postgres error on getMembers query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(getTeam).WithArgs(team.ID).
+			WillReturnRows(sqlxmock.NewRows(teamColumns).AddRow(team.ID, team.Name, created))
+		trs.mock.ExpectQuery(getMembers).WithArgs(team.ID).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.GetTeam(context.Background(), team.ID)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (trs *TeamRepositorySuite) TestAddMemberFunction(t provider.T) {
+	t.Title("AddMember function of Team repository")
+	t.NewStep("Init test data")
+	teamId := types.Id(1)
+	member := &Member{UserId: 2, Name: "Bob", Joined: time.FormattedTime{Time: created}}
+	addColumns := []string{"exists", "id", "name", "joined"}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(addMember).WithArgs(teamId, member.UserId).
+			WillReturnRows(sqlxmock.NewRows(addColumns).AddRow(true, member.UserId, member.Name, created))
+
+		t.NewStep("Check result")
+		res, err := trs.teamRepository.AddMember(context.Background(), teamId, member.UserId)
+		t.Require().NoError(err)
+		t.Require().EqualValues(member, res)
+	})
+
+	t.WithNewStep("Error user not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(addMember).WithArgs(teamId, member.UserId).WillReturnRows(sqlxmock.NewRows(addColumns))
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.AddMember(context.Background(), teamId, member.UserId)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Error team not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(addMember).WithArgs(teamId, member.UserId).
+			WillReturnRows(sqlxmock.NewRows(addColumns).AddRow(false, member.UserId, member.Name, nil))
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.AddMember(context.Background(), teamId, member.UserId)
+		t.Require().ErrorIs(err, ErrorTeamNotFound)
+	})
+
+	t.WithNewStep("Error user already in team execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(addMember).WithArgs(teamId, member.UserId).
+			WillReturnRows(sqlxmock.NewRows(addColumns).AddRow(true, member.UserId, member.Name, nil))
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.AddMember(context.Background(), teamId, member.UserId)
+		t.Require().ErrorIs(err, ErrorUserAlreadyInTeam)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(addMember).WithArgs(teamId, member.UserId).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := trs.teamRepository.AddMember(context.Background(), teamId, member.UserId)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (trs *TeamRepositorySuite) TestRemoveMemberFunction(t provider.T) {
+	t.Title("RemoveMember function of Team repository")
+	t.NewStep("Init test data")
+	teamId, userId := types.Id(1), types.Id(2)
+	countColumns := []string{"count"}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(removeMember).WithArgs(teamId, userId).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(1))
+
+		t.NewStep("Check result")
+		t.Require().NoError(trs.teamRepository.RemoveMember(context.Background(), teamId, userId))
+	})
+
+	t.WithNewStep("Error member not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(removeMember).WithArgs(teamId, userId).
+			WillReturnRows(sqlxmock.NewRows(countColumns).AddRow(0))
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(trs.teamRepository.RemoveMember(context.Background(), teamId, userId), ErrorMemberNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectQuery(removeMember).WithArgs(teamId, userId).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(trs.teamRepository.RemoveMember(context.Background(), teamId, userId), testError)
+	})
+}
+
+func (trs *TeamRepositorySuite) TestTakeContributorsFunction(t provider.T) {
+	t.Title("TakeContributors function of Team repository")
+	t.NewStep("Init test data")
+	teamId, questId := types.Id(1), types.Id(5)
+	contributors := []types.Id{3, 2}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectBegin()
+		trs.mock.ExpectQuery(TakeContributorsQuery).WithArgs(teamId, questId).
+			WillReturnRows(sqlxmock.NewRows([]string{"user_id"}).AddRow(contributors[0]).AddRow(contributors[1]))
+		trs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		tx, err := trs.teamRepository.db.BeginTxx(context.Background(), nil)
+		t.Require().NoError(err)
+		res, err := TakeContributors(context.Background(), tx, teamId, questId)
+		t.Require().NoError(err)
+		t.Require().EqualValues(contributors, res)
+		t.Require().NoError(tx.Commit())
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		trs.mock.ExpectBegin()
+		trs.mock.ExpectQuery(TakeContributorsQuery).WithArgs(teamId, questId).WillReturnError(testError)
+		trs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		tx, err := trs.teamRepository.db.BeginTxx(context.Background(), nil)
+		t.Require().NoError(err)
+		_, err = TakeContributors(context.Background(), tx, teamId, questId)
+		t.Require().ErrorIs(err, testError)
+		t.Require().NoError(tx.Rollback())
+	})
+}
+
+func TestRunTeamRepositorySuite(t *testing.T) {
+	suite.RunSuite(t, new(TeamRepositorySuite))
+}
@@ -0,0 +1,294 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+
+	"vk_quests/internal/pkg/page"
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/ledger"
+	qr "vk_quests/internal/repository/quest"
+	"vk_quests/internal/repository/team"
+)
+
+// PostgresURLEnv is url of postgres with applied script/init.sql for concurrency tests.
+// Tests are skipped if it is not set.
+const PostgresURLEnv = "QUESTS_TEST_POSTGRES_URL"
+
+const concurrentCompletions = 50
+
+const concurrentPayouts = 20
+
+type UserConcurrencySuite struct {
+	suite.Suite
+	db               *sqlx.DB
+	userRepository   *PostgresUser
+	questRepository  *qr.PostgresQuest
+	ledgerRepository *ledger.PostgresLedger
+	teamRepository   *team.PostgresTeam
+}
+
+func (ucs *UserConcurrencySuite) BeforeAll(t provider.T) {
+	db, err := sqlx.Open("postgres", os.Getenv(PostgresURLEnv))
+	t.Require().NoError(err)
+	t.Require().NoError(db.Ping())
+
+	ucs.db = db
+	ucs.userRepository = NewPostgresUser(db)
+	ucs.questRepository = qr.NewPostgresQuest(db)
+	ucs.ledgerRepository = ledger.NewPostgresLedger(db)
+	ucs.teamRepository = team.NewPostgresTeam(db)
+}
+
+func (ucs *UserConcurrencySuite) AfterAll(t provider.T) {
+	if ucs.db != nil {
+		t.Require().NoError(ucs.db.Close())
+	}
+}
+
+// limitCheck rejects completion like use-case does when completions limit is reached.
+func limitCheck(quest *qr.Quest, completions *Completions) (*Attempt, error) {
+	if quest.MaxCompletions != 0 && completions.Count >= uint64(quest.MaxCompletions) {
+		return nil, ErrorUserAlreadyCompleteQuest
+	}
+
+	return nil, nil
+}
+
+func (ucs *UserConcurrencySuite) createPair(t provider.T, quest *qr.Quest) (*User, *qr.Quest) {
+	suffix := fmt.Sprintf("%s %d", t.Name(), time.Now().UnixNano())
+
+	usr, err := ucs.userRepository.CreateUser(context.Background(), &User{Name: "user " + suffix}, "")
+	t.Require().NoError(err)
+
+	quest.Name = "quest " + suffix
+	qst, err := ucs.questRepository.CreateQuest(context.Background(), quest)
+	t.Require().NoError(err)
+
+	t.Cleanup(func() {
+		_, _ = ucs.userRepository.DeleteUser(context.Background(), usr.ID, DeleteHard)
+		_ = ucs.questRepository.ArchiveQuest(context.Background(), qst.ID)
+		_ = ucs.questRepository.PurgeQuest(context.Background(), qst.ID)
+	})
+
+	return usr, qst
+}
+
+// completeConcurrently completes quest by user from many goroutines at once
+// and returns number of successful completions and unexpected errors.
+func (ucs *UserConcurrencySuite) completeConcurrently(usr *User, qst *qr.Quest) (int, []error) {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		errs      []error
+	)
+
+	start := make(chan struct{})
+	for range concurrentCompletions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			_, err := ucs.userRepository.CompleteQuest(context.Background(), usr.ID, qst.ID, 1, time.Now(), Referral{}, limitCheck)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, ErrorUserAlreadyCompleteQuest):
+				errs = append(errs, err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	return succeeded, errs
+}
+
+func (ucs *UserConcurrencySuite) checkState(t provider.T, usr *User, qst *qr.Quest, balance types.Cost, records int) {
+	users, err := ucs.userRepository.GetUsers(context.Background(), &UsersQuery{
+		NamePrefix: usr.Name,
+		Sort:       types.UsersSortID,
+		Order:      page.Asc,
+		Limit:      page.DefaultLimit,
+	})
+	t.Require().NoError(err)
+	t.Require().Len(users, 1)
+	t.Require().EqualValues(balance, users[0].Balance)
+
+	reconciliation, err := ucs.ledgerRepository.Reconcile(context.Background(), usr.ID)
+	t.Require().NoError(err)
+	t.Require().EqualValues(balance, reconciliation.Ledger)
+	t.Require().Equal(reconciliation.Cached, reconciliation.Ledger)
+
+	tx, err := ucs.db.Beginx()
+	t.Require().NoError(err)
+	defer func() { _ = tx.Rollback() }()
+
+	completions, err := getQuestCompletions(context.Background(), tx, usr, qst)
+	t.Require().NoError(err)
+	t.Require().EqualValues(records, completions.Count)
+}
+
+func (ucs *UserConcurrencySuite) TestSingleCompletion(t provider.T) {
+	t.Title("Concurrent completions of single completion quest")
+	t.NewStep("Init test data")
+	usr, qst := ucs.createPair(t, &qr.Quest{
+		Description:    "usual quest",
+		Cost:           100,
+		Type:           types.USUAL,
+		MaxCompletions: 1,
+	})
+
+	t.NewStep("Complete concurrently")
+	succeeded, errs := ucs.completeConcurrently(usr, qst)
+
+	t.NewStep("Check result")
+	t.Require().Empty(errs)
+	t.Require().Equal(1, succeeded)
+	ucs.checkState(t, usr, qst, qst.Cost, 1)
+}
+
+func (ucs *UserConcurrencySuite) TestLimitedCompletions(t provider.T) {
+	t.Title("Concurrent completions of repeatable quest with completions limit")
+	t.NewStep("Init test data")
+	usr, qst := ucs.createPair(t, &qr.Quest{
+		Description:    "repeatable quest",
+		Cost:           100,
+		Type:           types.USUAL,
+		MaxCompletions: 3,
+	})
+
+	t.NewStep("Complete concurrently")
+	succeeded, errs := ucs.completeConcurrently(usr, qst)
+
+	t.NewStep("Check result")
+	t.Require().Empty(errs)
+	t.Require().Equal(3, succeeded)
+	ucs.checkState(t, usr, qst, 3*qst.Cost, 3)
+}
+
+func (ucs *UserConcurrencySuite) TestStagedCompletion(t provider.T) {
+	t.Title("Concurrent completions of staged quest")
+	t.NewStep("Init test data")
+	usr, qst := ucs.createPair(t, &qr.Quest{
+		Description:    "staged quest",
+		Cost:           100,
+		Type:           types.STAGED,
+		Steps:          []string{"first", "second", "third"},
+		MaxCompletions: 1,
+	})
+
+	t.NewStep("Complete concurrently")
+	succeeded, errs := ucs.completeConcurrently(usr, qst)
+
+	t.NewStep("Check result")
+	t.Require().Empty(errs)
+	t.Require().Equal(len(qst.Steps), succeeded)
+	ucs.checkState(t, usr, qst, qst.Cost, 1)
+
+	progress, err := ucs.userRepository.GetProgress(context.Background(), usr.ID)
+	t.Require().NoError(err)
+	t.Require().Len(progress, 1)
+	t.Require().EqualValues(len(qst.Steps), progress[0].Step)
+}
+
+func (ucs *UserConcurrencySuite) TestTeamPayoutWithTransfers(t provider.T) {
+	t.Title("Team quest payouts concurrent with transfers between contributors")
+	t.NewStep("Init test data")
+	// First member has lower id, so payout to the second one must lock it in order of ids like transfer
+	first, qst := ucs.createPair(t, &qr.Quest{
+		Description: "team quest",
+		Cost:        10,
+		Type:        types.TEAM,
+		Target:      2,
+		RewardSplit: types.SplitFixed,
+	})
+	second, err := ucs.userRepository.CreateUser(context.Background(), &User{Name: "second " + first.Name}, "")
+	t.Require().NoError(err)
+	t.Cleanup(func() {
+		_, _ = ucs.userRepository.DeleteUser(context.Background(), second.ID, DeleteHard)
+	})
+
+	tm, err := ucs.teamRepository.CreateTeam(context.Background(), "team "+first.Name)
+	t.Require().NoError(err)
+	for _, usr := range []*User{first, second} {
+		_, err := ucs.teamRepository.AddMember(context.Background(), tm.ID, usr.ID)
+		t.Require().NoError(err)
+	}
+
+	complete := func(usr *User) error {
+		_, err := ucs.userRepository.CompleteQuest(context.Background(), usr.ID, qst.ID, 1, time.Now(), Referral{}, limitCheck)
+		return err
+	}
+
+	// Both members get the first reward, so they can transfer to each other
+	t.Require().NoError(complete(first))
+	t.Require().NoError(complete(second))
+
+	t.NewStep("Pay team concurrently with transfers")
+	var errs []error
+	for range concurrentPayouts {
+		t.Require().NoError(complete(first))
+
+		var (
+			wg sync.WaitGroup
+			mu sync.Mutex
+		)
+		start := make(chan struct{})
+		run := func(f func() error) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+
+				if err := f(); err != nil {
+					mu.Lock()
+					defer mu.Unlock()
+					errs = append(errs, err)
+				}
+			}()
+		}
+
+		run(func() error { return complete(second) })
+		run(func() error {
+			_, err := ucs.ledgerRepository.Transfer(context.Background(), first.ID, second.ID, 1, 0)
+			return err
+		})
+		run(func() error {
+			_, err := ucs.ledgerRepository.Transfer(context.Background(), second.ID, first.ID, 1, 0)
+			return err
+		})
+
+		close(start)
+		wg.Wait()
+	}
+
+	t.NewStep("Check result")
+	t.Require().Empty(errs)
+	balance := types.Cost(concurrentPayouts+1) * qst.Cost
+	ucs.checkState(t, first, qst, balance, concurrentPayouts+1)
+	ucs.checkState(t, second, qst, balance, concurrentPayouts+1)
+}
+
+func TestRunUserConcurrencySuite(t *testing.T) {
+	if os.Getenv(PostgresURLEnv) == "" {
+		t.Skip(PostgresURLEnv + " is not set")
+	}
+
+	suite.RunSuite(t, new(UserConcurrencySuite))
+}
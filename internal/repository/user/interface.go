@@ -1,65 +1,158 @@
 package user
 
 import (
+	"context"
+	"time"
+
 	"github.com/pkg/errors"
 	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/ledger"
 	"vk_quests/internal/repository/quest"
 )
 
 var (
 	ErrorUserNotFound             = errors.New("user with id not found")
 	ErrorUserAlreadyCompleteQuest = errors.New("user already complete quest")
+	ErrorCompletionNotFound       = errors.New("user has not completed quest")
+	// ErrorAttemptFailed is returned by CompleteQuest when roll of random quest failed
+	ErrorAttemptFailed = errors.New("attempt to complete quest failed")
+	// ErrorStreakAlreadyExtended is returned by CompleteQuest when streak quest is already completed on the day of event
+	ErrorStreakAlreadyExtended = errors.New("streak is already extended today")
+	// ErrorUserNotInTeam is returned by CompleteQuest when user completing team quest is not member of any team
+	ErrorUserNotInTeam = errors.New("user is not member of any team")
+	// ErrorAlreadyContributed is returned by CompleteQuest when user already contributed to current completion of team quest
+	ErrorAlreadyContributed = errors.New("user already contributed to team quest")
+	// ErrorReferralCodeNotFound is returned by CreateUser when no active user has referral code
+	ErrorReferralCodeNotFound = errors.New("user with referral code not found")
 )
 
+// CompletionCheck decides if quest can be completed by user with given completions.
+// It returns attempt for random quests and nil attempt for others.
+type CompletionCheck func(quest *quest.Quest, completions *Completions) (*Attempt, error)
+
 //go:generate mockgen -destination=mocks/repository.go -package=mr -mock_names=Repository=UserRepository . Repository
 
 type Repository interface {
 	// CreateUser
+	// Creates user invited by active owner of referralCode, empty referralCode means user was not invited.
+	// Referrer always exists before invited user, so users can't invite themselves or each other in a loop.
 	// Returns Error:
 	//   - SQLError
-	CreateUser(user *User) (*User, error)
+	//   - ErrorReferralCodeNotFound
+	CreateUser(ctx context.Context, user *User, referralCode string) (*User, error)
 
 	// UpdateUser
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorUserNotFound
-	UpdateUser(user *User) (*User, error)
+	UpdateUser(ctx context.Context, user *User) (*User, error)
 
 	// DeleteUser
+	// Deletes user according to policy, deleted user is not found by other methods except ExportUser.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	DeleteUser(ctx context.Context, id types.Id, policy DeletePolicy) (*User, error)
+
+	// AnonymizeUser
+	// Scrubs name of user and deletes it, history and ledger of user are kept.
+	// Already deleted user is anonymized too.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	AnonymizeUser(ctx context.Context, id types.Id) (*User, error)
+
+	// ExportUser
+	// Returns all data stored about user including deleted one, data is read from one snapshot.
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorUserNotFound
-	DeleteUser(id types.Id) (*User, error)
+	ExportUser(ctx context.Context, id types.Id) (*Export, error)
 
 	// GetUsers
+	// Returns page of users matching query.
 	// Returns Error:
 	//   - SQLError
-	GetUsers() ([]User, error)
+	GetUsers(ctx context.Context, query *UsersQuery) ([]User, error)
+
+	// GetUser
+	// Returns user with aggregates of its completion history.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	GetUser(ctx context.Context, id types.Id) (*UserStats, error)
 
 	// HasUser
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorUserNotFound
-	HasUser(userId types.Id) error
+	HasUser(ctx context.Context, userId types.Id) error
 
 	// GetHistory
+	// Returns page of user history ordered by completion time.
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorUserNotFound
-	GetHistory(id types.Id) ([]HistoryRecord, error)
+	GetHistory(ctx context.Context, query *HistoryQuery) ([]HistoryRecord, error)
 
-	// ApplyCost
+	// CompleteQuest
+	// Completes quest for user in a single transaction. User and quest rows are locked,
+	// so concurrent completions of the same pair are serialized and see each other's results.
+	// The check is called inside the transaction with the locked quest and its completions
+	// by user; returned error aborts completion. Attempt returned by check is stored,
+	// failed attempt is committed and reported with ErrorAttemptFailed.
+	// Staged quests are advanced by one step, counter quests are advanced by amount up to their target,
+	// cost is applied when the quest is finished. Amount is ignored by quests of other types.
+	// Streak quests are extended if day of event follows the previous one and started again otherwise,
+	// reward of milestone reached by streak is applied instead of cost. Only calendar date of day is used.
+	// Team quests store contribution of user to its team, when target number of members contributed
+	// cost is paid to all of them according to reward split of quest.
+	// When invited user is rewarded for referral quest for the first time, referral bonus is paid
+	// to it and to its referrer, if the referrer is not deleted. Bonuses are stored in history with referral source.
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorUserNotFound
 	//   - quest.ErrorQuestNotFound
-	//   - ErrorUserAlreadyCompleteQuest
-	ApplyCost(user *User, quest *quest.Quest) error
+	//   - ErrorAttemptFailed
+	//   - ErrorStreakAlreadyExtended
+	//   - ErrorUserNotInTeam
+	//   - ErrorAlreadyContributed
+	//   - error of check
+	CompleteQuest(ctx context.Context, userId, questId types.Id, amount uint32, day time.Time,
+		referral Referral, check CompletionCheck) (*Progress, error)
 
-	// IsCompletedQuest
+	// RevokeQuest
+	// Revokes the last completion of quest by user in a single transaction: history record is marked as revoked,
+	// so quest can be completed again, its reward is clawed back according to policy
+	// and compensating history record with reason is stored. Revocation of the last completion of referral quest
+	// claws back referral bonuses paid to user and its referrer too.
 	// Returns Error:
 	//   - SQLError
 	//   - ErrorUserNotFound
-	//   - quest.ErrorQuestNotFound
-	IsCompletedQuest(user *User, quest *quest.Quest) error
+	//   - ErrorCompletionNotFound
+	//   - ledger.ErrorInsufficientFunds
+	RevokeQuest(ctx context.Context, userId, questId types.Id, reason string, policy ledger.RevokePolicy,
+		referral Referral) (*Revocation, error)
+
+	// GetAttempts
+	// Returns page of user attempts of random quests ordered by id.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	GetAttempts(ctx context.Context, query *AttemptsQuery) ([]AttemptRecord, error)
+
+	// GetAvailableQuests
+	// Returns page of quests ordered by id, which user can complete now: all their prerequisites
+	// are completed, completions limit is not reached, cooldown is over
+	// and quest availability window contains current moment.
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	GetAvailableQuests(ctx context.Context, query *AvailableQuestsQuery) ([]quest.Quest, error)
+
+	// GetProgress
+	// Returns Error:
+	//   - SQLError
+	//   - ErrorUserNotFound
+	GetProgress(ctx context.Context, id types.Id) ([]Progress, error)
 }
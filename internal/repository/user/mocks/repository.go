@@ -10,8 +10,11 @@
 package mr
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 	types "vk_quests/internal/pkg/types"
+	ledger "vk_quests/internal/repository/ledger"
 	quest "vk_quests/internal/repository/quest"
 	user "vk_quests/internal/repository/user"
 
@@ -41,119 +44,211 @@ func (m *UserRepository) EXPECT() *UserRepositoryMockRecorder {
 	return m.recorder
 }
 
-// ApplyCost mocks base method.
-func (m *UserRepository) ApplyCost(arg0 *user.User, arg1 *quest.Quest) error {
+// AnonymizeUser mocks base method.
+func (m *UserRepository) AnonymizeUser(arg0 context.Context, arg1 types.Id) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplyCost", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AnonymizeUser", arg0, arg1)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ApplyCost indicates an expected call of ApplyCost.
-func (mr *UserRepositoryMockRecorder) ApplyCost(arg0, arg1 any) *gomock.Call {
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *UserRepositoryMockRecorder) AnonymizeUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCost", reflect.TypeOf((*UserRepository)(nil).ApplyCost), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*UserRepository)(nil).AnonymizeUser), arg0, arg1)
+}
+
+// CompleteQuest mocks base method.
+func (m *UserRepository) CompleteQuest(arg0 context.Context, arg1, arg2 types.Id, arg3 uint32, arg4 time.Time, arg5 user.Referral, arg6 user.CompletionCheck) (*user.Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteQuest", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*user.Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteQuest indicates an expected call of CompleteQuest.
+func (mr *UserRepositoryMockRecorder) CompleteQuest(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteQuest", reflect.TypeOf((*UserRepository)(nil).CompleteQuest), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
 // CreateUser mocks base method.
-func (m *UserRepository) CreateUser(arg0 *user.User) (*user.User, error) {
+func (m *UserRepository) CreateUser(arg0 context.Context, arg1 *user.User, arg2 string) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0)
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *UserRepositoryMockRecorder) CreateUser(arg0 any) *gomock.Call {
+func (mr *UserRepositoryMockRecorder) CreateUser(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*UserRepository)(nil).CreateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*UserRepository)(nil).CreateUser), arg0, arg1, arg2)
 }
 
 // DeleteUser mocks base method.
-func (m *UserRepository) DeleteUser(arg0 types.Id) (*user.User, error) {
+func (m *UserRepository) DeleteUser(arg0 context.Context, arg1 types.Id, arg2 user.DeletePolicy) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0)
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *UserRepositoryMockRecorder) DeleteUser(arg0 any) *gomock.Call {
+func (mr *UserRepositoryMockRecorder) DeleteUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*UserRepository)(nil).DeleteUser), arg0, arg1, arg2)
+}
+
+// ExportUser mocks base method.
+func (m *UserRepository) ExportUser(arg0 context.Context, arg1 types.Id) (*user.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUser", arg0, arg1)
+	ret0, _ := ret[0].(*user.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUser indicates an expected call of ExportUser.
+func (mr *UserRepositoryMockRecorder) ExportUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUser", reflect.TypeOf((*UserRepository)(nil).ExportUser), arg0, arg1)
+}
+
+// GetAttempts mocks base method.
+func (m *UserRepository) GetAttempts(arg0 context.Context, arg1 *user.AttemptsQuery) ([]user.AttemptRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttempts", arg0, arg1)
+	ret0, _ := ret[0].([]user.AttemptRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttempts indicates an expected call of GetAttempts.
+func (mr *UserRepositoryMockRecorder) GetAttempts(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*UserRepository)(nil).DeleteUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttempts", reflect.TypeOf((*UserRepository)(nil).GetAttempts), arg0, arg1)
+}
+
+// GetAvailableQuests mocks base method.
+func (m *UserRepository) GetAvailableQuests(arg0 context.Context, arg1 *user.AvailableQuestsQuery) ([]quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableQuests", arg0, arg1)
+	ret0, _ := ret[0].([]quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableQuests indicates an expected call of GetAvailableQuests.
+func (mr *UserRepositoryMockRecorder) GetAvailableQuests(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableQuests", reflect.TypeOf((*UserRepository)(nil).GetAvailableQuests), arg0, arg1)
 }
 
 // GetHistory mocks base method.
-func (m *UserRepository) GetHistory(arg0 types.Id) ([]user.HistoryRecord, error) {
+func (m *UserRepository) GetHistory(arg0 context.Context, arg1 *user.HistoryQuery) ([]user.HistoryRecord, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHistory", arg0)
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1)
 	ret0, _ := ret[0].([]user.HistoryRecord)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetHistory indicates an expected call of GetHistory.
-func (mr *UserRepositoryMockRecorder) GetHistory(arg0 any) *gomock.Call {
+func (mr *UserRepositoryMockRecorder) GetHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*UserRepository)(nil).GetHistory), arg0, arg1)
+}
+
+// GetProgress mocks base method.
+func (m *UserRepository) GetProgress(arg0 context.Context, arg1 types.Id) ([]user.Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgress", arg0, arg1)
+	ret0, _ := ret[0].([]user.Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgress indicates an expected call of GetProgress.
+func (mr *UserRepositoryMockRecorder) GetProgress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgress", reflect.TypeOf((*UserRepository)(nil).GetProgress), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *UserRepository) GetUser(arg0 context.Context, arg1 types.Id) (*user.UserStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(*user.UserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *UserRepositoryMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*UserRepository)(nil).GetHistory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*UserRepository)(nil).GetUser), arg0, arg1)
 }
 
 // GetUsers mocks base method.
-func (m *UserRepository) GetUsers() ([]user.User, error) {
+func (m *UserRepository) GetUsers(arg0 context.Context, arg1 *user.UsersQuery) ([]user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsers")
+	ret := m.ctrl.Call(m, "GetUsers", arg0, arg1)
 	ret0, _ := ret[0].([]user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUsers indicates an expected call of GetUsers.
-func (mr *UserRepositoryMockRecorder) GetUsers() *gomock.Call {
+func (mr *UserRepositoryMockRecorder) GetUsers(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*UserRepository)(nil).GetUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*UserRepository)(nil).GetUsers), arg0, arg1)
 }
 
 // HasUser mocks base method.
-func (m *UserRepository) HasUser(arg0 types.Id) error {
+func (m *UserRepository) HasUser(arg0 context.Context, arg1 types.Id) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasUser", arg0)
+	ret := m.ctrl.Call(m, "HasUser", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HasUser indicates an expected call of HasUser.
-func (mr *UserRepositoryMockRecorder) HasUser(arg0 any) *gomock.Call {
+func (mr *UserRepositoryMockRecorder) HasUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUser", reflect.TypeOf((*UserRepository)(nil).HasUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUser", reflect.TypeOf((*UserRepository)(nil).HasUser), arg0, arg1)
 }
 
-// IsCompletedQuest mocks base method.
-func (m *UserRepository) IsCompletedQuest(arg0 *user.User, arg1 *quest.Quest) error {
+// RevokeQuest mocks base method.
+func (m *UserRepository) RevokeQuest(arg0 context.Context, arg1, arg2 types.Id, arg3 string, arg4 ledger.RevokePolicy, arg5 user.Referral) (*user.Revocation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsCompletedQuest", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RevokeQuest", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*user.Revocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// IsCompletedQuest indicates an expected call of IsCompletedQuest.
-func (mr *UserRepositoryMockRecorder) IsCompletedQuest(arg0, arg1 any) *gomock.Call {
+// RevokeQuest indicates an expected call of RevokeQuest.
+func (mr *UserRepositoryMockRecorder) RevokeQuest(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCompletedQuest", reflect.TypeOf((*UserRepository)(nil).IsCompletedQuest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeQuest", reflect.TypeOf((*UserRepository)(nil).RevokeQuest), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // UpdateUser mocks base method.
-func (m *UserRepository) UpdateUser(arg0 *user.User) (*user.User, error) {
+func (m *UserRepository) UpdateUser(arg0 context.Context, arg1 *user.User) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", arg0)
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *UserRepositoryMockRecorder) UpdateUser(arg0 any) *gomock.Call {
+func (mr *UserRepositoryMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*UserRepository)(nil).UpdateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*UserRepository)(nil).UpdateUser), arg0, arg1)
 }
@@ -1,19 +1,162 @@
 package user
 
 import (
+	stdtime "time"
+
+	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/page"
 	"vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/ledger"
 	"vk_quests/internal/repository/quest"
 )
 
 type User struct {
-	ID      types.Id
-	Name    string
-	Balance uint64
+	ID           types.Id
+	Name         string
+	Balance      int64
+	ReferralCode string    // code passed by users invited by this one, filled only by CreateUser, GetUser and ExportUser
+	ReferrerId   *types.Id // user who invited this one, nil if user was not invited
+}
+
+// Referral is bonus paid once to invited user and its referrer, when invited user completes referral quest.
+type Referral struct {
+	QuestId types.Id // 0 - referral bonus is disabled
+	Bonus   types.Cost
+}
+
+// DeletePolicy decides what deletion of user does with its data.
+type DeletePolicy string
+
+const (
+	DeleteSoft      DeletePolicy = "soft"      // user is hidden, its data is kept
+	DeleteAnonymize DeletePolicy = "anonymize" // user is hidden and its name is scrubbed, history and ledger are kept
+	DeleteHard      DeletePolicy = "hard"      // user is removed together with its history and ledger
+)
+
+func ParseDeletePolicy(policy string) (DeletePolicy, error) {
+	switch p := DeletePolicy(policy); p {
+	case DeleteSoft, DeleteAnonymize, DeleteHard:
+		return p, nil
+	default:
+		return "", errors.Errorf("unknown delete policy %q", policy)
+	}
+}
+
+// TypeStats is aggregate of user completions of quests with one type.
+type TypeStats struct {
+	Completed uint64
+	Earned    uint64
+}
+
+// UserStats is user with aggregates of completion history.
+type UserStats struct {
+	User           User
+	Completed      uint64
+	Earned         uint64
+	LastCompletion *time.FormattedTime // nil if user has not completed any quest
+	ByType         map[types.QuestType]TypeStats
+}
+
+type UsersQuery struct {
+	NamePrefix string
+	MinBalance *int64
+	MaxBalance *int64
+	Sort       types.UsersSort
+	Order      page.Order
+	After      *page.Cursor // nil means first page
+	Limit      uint64
+}
+
+type HistoryQuery struct {
+	UserId    types.Id
+	From      *stdtime.Time // inclusive
+	To        *stdtime.Time // exclusive
+	QuestType *types.QuestType
+	Order     page.Order
+	After     *page.Cursor // nil means first page
+	Limit     uint64
+}
+
+type AttemptsQuery struct {
+	UserId  types.Id
+	QuestId *types.Id
+	Order   page.Order
+	After   *page.Cursor // nil means first page
+	Limit   uint64
+}
+
+type AvailableQuestsQuery struct {
+	UserId types.Id
+	After  *page.Cursor // nil means first page
+	Limit  uint64
+}
+
+// QuestSnapshot is state of quest at the moment of its completion.
+type QuestSnapshot struct {
+	Name        string
+	Description string
+	Type        types.QuestType
 }
 
 type HistoryRecord struct {
+	ID             types.Id
+	Snapshot       *QuestSnapshot // nil for transfers
+	Award          types.Cost     // transferred amount for transfers
+	BaseAward      types.Cost     // award before boost multiplier was applied
+	Multiplier     float64        // multiplier of boost applied to award, 1 if no boost was active
+	Source         types.HistorySource
+	CounterpartyId *types.Id    // set for transfers: recipient for sent and sender for received points
+	Reason         string       // reason of revocation, set for compensating records of revocations
+	Quest          *quest.Quest // current state of quest, nil if quest was deleted
+	Created        time.FormattedTime
+	Balance        int64
+}
+
+type Progress struct {
 	Quest   *quest.Quest
+	Step    uint32
+	Updated time.FormattedTime
+}
+
+type Completions struct {
+	Count                uint64
+	Elapsed              stdtime.Duration // time passed since last completion
+	MissingPrerequisites []types.Id       // prerequisites of quest not completed by user yet
+	Failures             uint32           // failed attempts of random quest in a row since last completion
+	DailyAttempts        uint32           // attempts of random quest during last day
+}
+
+// Attempt is roll of random quest made by CompletionCheck.
+type Attempt struct {
+	Success bool
+	Roll    *float64 // nil if success is guaranteed by pity
+}
+
+type AttemptRecord struct {
+	ID      types.Id
+	QuestId *types.Id // nil if quest was deleted
+	Attempt Attempt
 	Created time.FormattedTime
-	Balance uint64
+}
+
+// Revocation is revoked quest completion, its reward is clawed back by ledger transaction
+// and compensating history record.
+type Revocation struct {
+	ID          types.Id // id of compensating history record
+	QuestId     types.Id
+	Snapshot    QuestSnapshot // state of quest at the moment of revoked completion
+	Award       types.Cost
+	Completed   time.FormattedTime // time of revoked completion
+	Reason      string
+	Transaction ledger.Transaction // its amount is less than award, if reward is clawed back partially
+	Created     time.FormattedTime
+}
+
+// Export is bundle of all data stored about user, it is returned for deleted users too.
+type Export struct {
+	User      User
+	DeletedAt *time.FormattedTime // nil if user is not deleted
+	History   []HistoryRecord     // ordered by completion time
+	Attempts  []AttemptRecord     // ordered by id
 }
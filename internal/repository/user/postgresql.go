@@ -1,27 +1,58 @@
 package user
 
 import (
+	"context"
 	"database/sql"
+	"time"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/boost"
+	"vk_quests/internal/repository/leaderboard"
+	"vk_quests/internal/repository/ledger"
 	qr "vk_quests/internal/repository/quest"
+	"vk_quests/internal/repository/team"
 )
 
 const (
 	createQuery = `
 		INSERT INTO users (name)
 		VALUES ($1)
-		RETURNING id, name, balance
+		RETURNING id, name, balance, referral_code, referrer_id
+	`
+
+	// createReferredQuery creates user invited by active owner of referral code,
+	// nothing is inserted if there is no such user
+	createReferredQuery = `
+		INSERT INTO users (name, referrer_id)
+		SELECT $1, id FROM users WHERE referral_code = $2 AND deleted_at IS NULL
+		RETURNING id, name, balance, referral_code, referrer_id
 	`
 
+	// deleteUser removes user with cascade deletion of its history and ledger
 	deleteUser = `
 		DELETE FROM users WHERE id = $1 RETURNING id, name, balance
 	`
 
+	// softDeleteUser hides user and scrubs its name if $2 is true
+	softDeleteUser = `
+		UPDATE users SET deleted_at = now(), name = CASE WHEN $2 THEN '' ELSE name END
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, name, balance
+	`
+
+	// anonymizeUser keeps time of the first deletion of user
+	anonymizeUser = `
+		UPDATE users SET name = '', deleted_at = COALESCE(deleted_at, now()) WHERE id = $1
+		RETURNING id, name, balance
+	`
+
 	updateUser = `
-		UPDATE users SET name = $2 WHERE id = $1
+		UPDATE users SET name = $2 WHERE id = $1 AND deleted_at IS NULL
 			RETURNING id, name, balance
 	`
 
@@ -29,27 +60,210 @@ const (
 		SELECT id, name, balance FROM users
 	`
 
-	applyCost = `
-		UPDATE users SET balance = balance + $2 WHERE id = $1 RETURNING id
-	`
-
 	createHistory = `
-		INSERT INTO balance_history (user_id, quest_id, balance) 
-		SELECT $1, $2, users.balance FROM users WHERE id = $1
+		INSERT INTO balance_history (user_id, quest_id, award, base_award, multiplier, quest_name, quest_description, quest_type, source,
+			counterparty_id, balance) 
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, users.balance FROM users WHERE id = $1
 	`
 
 	getHistory = `
-		SELECT quests.id, quests.name, quests.description, quests.cost, quests.type, created, balance 
+		SELECT balance_history.id, award, base_award, multiplier, quest_name, quest_description, quest_type, balance_history.source,
+			counterparty_id, reason, quests.id, quests.name, quests.description, quests.cost, quests.type, created, balance 
 		FROM balance_history LEFT JOIN quests ON (balance_history.quest_id = quests.id)
-		WHERE user_id = $1
 	`
 
-	getCompleteQuest = `
-		SELECT quest_id FROM balance_history WHERE user_id = $1 and quest_id = $2
+	getCompletions = `
+		SELECT count(*), COALESCE(EXTRACT(EPOCH FROM now() - max(created)), 0)::float8
+		FROM balance_history WHERE user_id = $1 and quest_id = $2 AND source = 'quest' AND revoked_at IS NULL
+	`
+
+	getMissingPrerequisites = `
+		SELECT COALESCE(array_agg(id ORDER BY id), '{}') FROM quests
+		WHERE id = ANY($2) AND archived_at IS NULL AND NOT EXISTS (
+			SELECT 1 FROM balance_history WHERE user_id = $1 AND quest_id = quests.id AND source = 'quest' AND revoked_at IS NULL
+		)
+	`
+
+	getAvailableQuests = `
+		SELECT id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target,
+			milestone_days, milestone_rewards, reward_split, archived_at, ` + qr.LabelsColumns + ` FROM quests
+	`
+
+	// availableQuest is condition of getAvailableQuests with user id placeholder,
+	// archived prerequisites can't be completed, so they are not required
+	availableQuest = `quests.archived_at IS NULL AND NOT EXISTS (
+			SELECT 1 FROM quests AS required
+			WHERE required.id = ANY(quests.prerequisites) AND required.archived_at IS NULL AND NOT EXISTS (
+				SELECT 1 FROM balance_history
+				WHERE user_id = %[1]s AND quest_id = required.id AND source = 'quest' AND revoked_at IS NULL
+			)
+		) AND NOT EXISTS (
+			SELECT 1 FROM balance_history WHERE user_id = %[1]s AND quest_id = quests.id AND source = 'quest' AND revoked_at IS NULL
+			HAVING (quests.max_completions != 0 AND count(*) >= quests.max_completions)
+				OR max(created) + quests.cooldown * interval '1 second' > now()
+		) AND (starts_at IS NULL OR starts_at <= now()) AND (ends_at IS NULL OR ends_at > now())`
+
+	// getUser aggregates only not revoked quest completions, referral bonuses are not completions
+	getUser = `
+		SELECT users.id, users.name, users.balance, users.referral_code, users.referrer_id, balance_history.quest_type,
+			count(balance_history.id), COALESCE(sum(balance_history.award), 0), max(balance_history.created)
+		FROM users LEFT JOIN balance_history ON (
+			balance_history.user_id = users.id AND balance_history.source = 'quest' AND balance_history.revoked_at IS NULL
+		)
+		WHERE users.id = $1 AND users.deleted_at IS NULL
+		GROUP BY users.id, balance_history.quest_type
 	`
 
 	hasUser = `
-		SELECT id FROM users WHERE id = $1
+		SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	lockUser = `
+		SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+	`
+
+	// checkUser finds active user without lock, users paid for team quest are locked only before payout
+	checkUser = `
+		SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	// lockPayees locks users paid for completion of team quest in order of ids
+	lockPayees = `
+		SELECT id FROM users WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE
+	`
+
+	// lockReferralPayees locks users paid for completion of team referral quest and their referrers
+	// waiting for referral bonus in order of ids
+	lockReferralPayees = `
+		SELECT id FROM users
+		WHERE deleted_at IS NULL AND (id = ANY($1) OR id IN (
+			SELECT referrer_id FROM users WHERE id = ANY($1) AND referral_rewarded_at IS NULL
+		))
+		ORDER BY id FOR UPDATE
+	`
+
+	// lockReferral locks user and its referrer waiting for referral bonus in order of ids
+	lockReferral = `
+		SELECT id FROM users
+		WHERE deleted_at IS NULL AND (id = $1 OR id = (
+			SELECT referrer_id FROM users WHERE id = $1 AND referral_rewarded_at IS NULL
+		))
+		ORDER BY id FOR UPDATE
+	`
+
+	// lockReferrer locks user and its referrer in order of ids
+	lockReferrer = `
+		SELECT id FROM users
+		WHERE deleted_at IS NULL AND (id = $1 OR id = (SELECT referrer_id FROM users WHERE id = $1))
+		ORDER BY id FOR UPDATE
+	`
+
+	// claimReferral marks referral bonus of invited user as paid and returns its referrer
+	claimReferral = `
+		UPDATE users SET referral_rewarded_at = now()
+		WHERE id = $1 AND referrer_id IS NOT NULL AND referral_rewarded_at IS NULL
+		RETURNING referrer_id
+	`
+
+	getExportedUser = `
+		SELECT id, name, balance, referral_code, referrer_id, deleted_at FROM users WHERE id = $1
+	`
+
+	lockQuest = `
+		SELECT id, name, description, cost, type, steps, max_completions, cooldown, prerequisites, starts_at, ends_at, probability, pity, target,
+			milestone_days, milestone_rewards, reward_split, archived_at, ` + qr.LabelsColumns + `
+		FROM quests WHERE id = $1 FOR SHARE
+	`
+
+	applyStep = `
+		INSERT INTO quest_progress (user_id, quest_id, step) VALUES ($1, $2, 1)
+		ON CONFLICT (user_id, quest_id) DO UPDATE SET updated = now(),
+			step = CASE WHEN quest_progress.step >= $3 THEN 1 ELSE quest_progress.step + 1 END
+		RETURNING step, updated
+	`
+
+	// applyCounter adds amount to progress of counter quest, progress of finished quest starts again
+	applyCounter = `
+		INSERT INTO quest_progress (user_id, quest_id, step) VALUES ($1, $2, LEAST($3, $4))
+		ON CONFLICT (user_id, quest_id) DO UPDATE SET updated = now(),
+			step = CASE WHEN quest_progress.step >= $4 THEN LEAST($3, $4) ELSE LEAST(quest_progress.step + $3, $4) END
+		RETURNING step, updated
+	`
+
+	// applyStreak extends streak of user if event happens on the next calendar day after the last one,
+	// otherwise streak starts again, as well as streak which reached the last milestone.
+	// Nothing is returned if streak is already extended on the day of event.
+	applyStreak = `
+		INSERT INTO quest_progress (user_id, quest_id, step, day) VALUES ($1, $2, 1, $3)
+		ON CONFLICT (user_id, quest_id) DO UPDATE SET updated = now(), day = $3,
+			step = CASE WHEN quest_progress.day = $3::date - 1 AND quest_progress.step < $4
+				THEN quest_progress.step + 1 ELSE 1 END
+		WHERE quest_progress.day IS DISTINCT FROM $3::date
+		RETURNING step, updated
+	`
+
+	// getAttemptStats returns failed attempts after the last successful one and attempts during last day
+	getAttemptStats = `
+		SELECT count(*) FILTER (WHERE NOT success AND quest_attempts.id > COALESCE(last_success.id, 0)),
+			count(*) FILTER (WHERE created > now() - interval '1 day')
+		FROM quest_attempts, (
+			SELECT max(id) AS id FROM quest_attempts WHERE user_id = $1 AND quest_id = $2 AND success
+		) AS last_success
+		WHERE user_id = $1 AND quest_id = $2
+	`
+
+	createAttempt = `
+		INSERT INTO quest_attempts (user_id, quest_id, success, roll) VALUES ($1, $2, $3, $4)
+	`
+
+	getAttempts = `
+		SELECT id, quest_id, success, roll, created FROM quest_attempts
+	`
+
+	exportHistory = getHistory + `WHERE balance_history.user_id = $1 ORDER BY balance_history.created, balance_history.id`
+
+	exportAttempts = getAttempts + `WHERE user_id = $1 ORDER BY id`
+
+	// revokeLastCompletion marks the last not revoked history record of quest completion by user as revoked,
+	// the record stays in history
+	revokeLastCompletion = `
+		UPDATE balance_history SET revoked_at = now() WHERE id = (
+			SELECT id FROM balance_history WHERE user_id = $1 AND quest_id = $2 AND source = 'quest' AND revoked_at IS NULL
+			ORDER BY created DESC, id DESC LIMIT 1
+		)
+		RETURNING award, quest_name, quest_description, quest_type, created
+	`
+
+	// revokeReferralBonuses marks referral bonuses paid to invited user and to its referrer for completion
+	// of referral quest as revoked, bonuses of deleted users are kept, because their balance is frozen
+	revokeReferralBonuses = `
+		UPDATE balance_history SET revoked_at = now()
+		FROM users
+		WHERE users.id = $1 AND balance_history.source = 'referral' AND balance_history.quest_id = $2
+			AND balance_history.revoked_at IS NULL
+			AND ((balance_history.user_id = users.id AND balance_history.counterparty_id = users.referrer_id)
+				OR (balance_history.user_id = users.referrer_id AND balance_history.counterparty_id = users.id))
+			AND EXISTS (SELECT 1 FROM users AS owner WHERE owner.id = balance_history.user_id AND owner.deleted_at IS NULL)
+		RETURNING balance_history.user_id, balance_history.award, balance_history.quest_name,
+			balance_history.quest_description, balance_history.quest_type
+	`
+
+	// createRevokeHistory stores compensating history record of clawed back reward with reason of revocation
+	createRevokeHistory = `
+		INSERT INTO balance_history (user_id, quest_id, award, base_award, quest_name, quest_description, quest_type,
+			source, reason, balance)
+		VALUES ($1, $2, $3, $3, $4, $5, $6, 'revoke', $7, $8)
+		RETURNING id, created
+	`
+
+	getProgress = `
+		SELECT quests.id, quests.name, quests.description, quests.cost, quests.type, quests.steps,
+			quests.max_completions, quests.cooldown, quests.prerequisites, quests.starts_at, quests.ends_at,
+			quests.probability, quests.pity, quests.target, quests.milestone_days, quests.milestone_rewards,
+			quests.reward_split, quests.archived_at, ` + qr.LabelsColumns + `, step, updated
+		FROM quest_progress JOIN quests ON (quest_progress.quest_id = quests.id)
+		WHERE user_id = $1
+		ORDER BY updated DESC
 	`
 )
 
@@ -63,23 +277,37 @@ func NewPostgresUser(db *sqlx.DB) *PostgresUser {
 	}
 }
 
-func (pu *PostgresUser) CreateUser(user *User) (*User, error) {
+func (pu *PostgresUser) CreateUser(ctx context.Context, user *User, referralCode string) (*User, error) {
+	query, args := createQuery, []any{user.Name}
+	if referralCode != "" {
+		query, args = createReferredQuery, []any{user.Name, referralCode}
+	}
+
 	newUser := &User{}
-	if err := pu.db.QueryRowx(createQuery, user.Name).
-		Scan(
-			&newUser.ID,
-			&newUser.Name,
-			&newUser.Balance,
-		); err != nil {
+	referrerId := sql.Null[types.Id]{}
+	if err := pu.db.QueryRowxContext(ctx, query, args...).Scan(
+		&newUser.ID,
+		&newUser.Name,
+		&newUser.Balance,
+		&newUser.ReferralCode,
+		&referrerId,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrorReferralCodeNotFound
+		}
 		return nil, errors.Wrap(err, "can't create user")
 	}
 
+	if referrerId.Valid {
+		newUser.ReferrerId = &referrerId.V
+	}
+
 	return newUser, nil
 }
 
-func (pu *PostgresUser) UpdateUser(user *User) (*User, error) {
+func (pu *PostgresUser) UpdateUser(ctx context.Context, user *User) (*User, error) {
 	updatedUser := &User{}
-	if err := pu.db.QueryRowx(updateUser, user.ID, user.Name).
+	if err := pu.db.QueryRowxContext(ctx, updateUser, user.ID, user.Name).
 		Scan(
 			&updatedUser.ID,
 			&updatedUser.Name,
@@ -94,14 +322,23 @@ func (pu *PostgresUser) UpdateUser(user *User) (*User, error) {
 	return updatedUser, nil
 }
 
-func (pu *PostgresUser) DeleteUser(id types.Id) (*User, error) {
+func (pu *PostgresUser) DeleteUser(ctx context.Context, id types.Id, policy DeletePolicy) (*User, error) {
+	var row *sqlx.Row
+	switch policy {
+	case DeleteSoft, DeleteAnonymize:
+		row = pu.db.QueryRowxContext(ctx, softDeleteUser, id, policy == DeleteAnonymize)
+	case DeleteHard:
+		row = pu.db.QueryRowxContext(ctx, deleteUser, id)
+	default:
+		return nil, errors.Errorf("unknown delete policy %q", policy)
+	}
+
 	deletedUser := &User{}
-	if err := pu.db.QueryRowx(deleteUser, id).
-		Scan(
-			&deletedUser.ID,
-			&deletedUser.Name,
-			&deletedUser.Balance,
-		); err != nil {
+	if err := row.Scan(
+		&deletedUser.ID,
+		&deletedUser.Name,
+		&deletedUser.Balance,
+	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrorUserNotFound
 		}
@@ -111,8 +348,59 @@ func (pu *PostgresUser) DeleteUser(id types.Id) (*User, error) {
 	return deletedUser, nil
 }
 
-func (pu *PostgresUser) GetUsers() ([]User, error) {
-	rows, err := pu.db.Queryx(getUsers)
+func (pu *PostgresUser) AnonymizeUser(ctx context.Context, id types.Id) (*User, error) {
+	anonymizedUser := &User{}
+	if err := pu.db.QueryRowxContext(ctx, anonymizeUser, id).
+		Scan(
+			&anonymizedUser.ID,
+			&anonymizedUser.Name,
+			&anonymizedUser.Balance,
+		); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrorUserNotFound
+		}
+		return nil, errors.Wrapf(err, "can't anonymize user with id %d", id)
+	}
+
+	return anonymizedUser, nil
+}
+
+var usersSortColumns = map[types.UsersSort]string{
+	types.UsersSortID:      "id",
+	types.UsersSortName:    "name",
+	types.UsersSortBalance: "balance",
+}
+
+func buildGetUsers(query *UsersQuery) (string, []any, error) {
+	sort, found := usersSortColumns[query.Sort]
+	if !found {
+		return "", nil, errors.Errorf("unknown users sort %q", query.Sort)
+	}
+
+	q := page.Query{}
+	q.Filter("deleted_at IS NULL")
+	if query.NamePrefix != "" {
+		q.Where("name LIKE %s", page.LikePrefix(query.NamePrefix))
+	}
+	if query.MinBalance != nil {
+		q.Where("balance >= %s", *query.MinBalance)
+	}
+	if query.MaxBalance != nil {
+		q.Where("balance <= %s", *query.MaxBalance)
+	}
+
+	sqlQuery, args := q.Build(getUsers, sort, query.Order, query.After, query.Limit)
+
+	return sqlQuery, args, nil
+}
+
+func (pu *PostgresUser) GetUsers(ctx context.Context, query *UsersQuery) ([]User, error) {
+	sqlQuery, args, err := buildGetUsers(query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pu.db.QueryxContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "can't execute get users query")
 	}
@@ -141,9 +429,70 @@ func (pu *PostgresUser) GetUsers() ([]User, error) {
 	return users, nil
 }
 
-func (pu *PostgresUser) HasUser(userId types.Id) error {
+func (pu *PostgresUser) GetUser(ctx context.Context, id types.Id) (*UserStats, error) {
+	rows, err := pu.db.QueryxContext(ctx, getUser, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't execute get user query for user with id %d", id)
+	}
+
+	var stats *UserStats
+
+	// Query returns row for each type of completed quests or single row with null type without completions.
+	for rows.Next() {
+		var user User
+		referrerId := sql.Null[types.Id]{}
+		tp := sql.NullString{}
+		typeStats := TypeStats{}
+		last := sql.Null[time.Time]{}
+
+		err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Balance,
+			&user.ReferralCode,
+			&referrerId,
+			&tp,
+			&typeStats.Completed,
+			&typeStats.Earned,
+			&last,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't scan get user query result for user with id %d", id)
+		}
+
+		if stats == nil {
+			if referrerId.Valid {
+				user.ReferrerId = &referrerId.V
+			}
+			stats = &UserStats{User: user, ByType: make(map[types.QuestType]TypeStats)}
+		}
+
+		if !tp.Valid {
+			continue
+		}
+
+		stats.ByType[types.QuestType(tp.String)] = typeStats
+		stats.Completed += typeStats.Completed
+		stats.Earned += typeStats.Earned
+		if stats.LastCompletion == nil || last.V.After(stats.LastCompletion.Time) {
+			stats.LastCompletion = &pkgtime.FormattedTime{Time: last.V}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "can't end scan get user query result for user with id %d", id)
+	}
+
+	if stats == nil {
+		return nil, ErrorUserNotFound
+	}
+
+	return stats, nil
+}
+
+func (pu *PostgresUser) HasUser(ctx context.Context, userId types.Id) error {
 	id := types.Id(0)
-	if err := pu.db.QueryRowx(hasUser, userId).Scan(&id); err != nil {
+	if err := pu.db.QueryRowxContext(ctx, hasUser, userId).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrorUserNotFound
 		}
@@ -153,8 +502,48 @@ func (pu *PostgresUser) HasUser(userId types.Id) error {
 	return nil
 }
 
-func (pu *PostgresUser) GetHistory(id types.Id) ([]HistoryRecord, error) {
-	rows, err := pu.db.Queryx(getHistory, id)
+// historyCreatedColumn is sort column of history, history is always ordered by completion time.
+const historyCreatedColumn = "balance_history.created"
+
+func buildGetHistory(query *HistoryQuery) (string, []any) {
+	q := page.Query{IDColumn: "balance_history.id"}
+	q.Where("balance_history.user_id = %s", query.UserId)
+	if query.From != nil {
+		q.Where(historyCreatedColumn+" >= %s", *query.From)
+	}
+	if query.To != nil {
+		q.Where(historyCreatedColumn+" < %s", *query.To)
+	}
+	if query.QuestType != nil {
+		q.Where("balance_history.quest_type = %s", *query.QuestType)
+	}
+
+	return q.Build(getHistory, historyCreatedColumn, query.Order, query.After, query.Limit)
+}
+
+func (pu *PostgresUser) GetHistory(ctx context.Context, query *HistoryQuery) ([]HistoryRecord, error) {
+	id := query.UserId
+
+	sqlQuery, args := buildGetHistory(query)
+	history, err := queryHistory(ctx, pu.db, id, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty history of unknown user is reported as error
+	if len(history) == 0 {
+		if err := pu.HasUser(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
+
+// queryHistory returns history records of user with id selected by sqlQuery from getHistory.
+func queryHistory(ctx context.Context, db sqlx.QueryerContext, id types.Id, sqlQuery string,
+	args ...any) ([]HistoryRecord, error) {
+	rows, err := db.QueryxContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "can't execute get history query for user with id %d", id)
 	}
@@ -164,6 +553,11 @@ func (pu *PostgresUser) GetHistory(id types.Id) ([]HistoryRecord, error) {
 	for rows.Next() {
 		var record HistoryRecord
 
+		snapshotName := sql.NullString{}
+		snapshotDescription := sql.NullString{}
+		snapshotType := sql.NullString{}
+		counterpartyId := sql.Null[types.Id]{}
+		reason := sql.NullString{}
 		questId := sql.Null[types.Id]{}
 		name := sql.NullString{}
 		description := sql.NullString{}
@@ -171,6 +565,16 @@ func (pu *PostgresUser) GetHistory(id types.Id) ([]HistoryRecord, error) {
 		tp := sql.NullString{}
 
 		err := rows.Scan(
+			&record.ID,
+			&record.Award,
+			&record.BaseAward,
+			&record.Multiplier,
+			&snapshotName,
+			&snapshotDescription,
+			&snapshotType,
+			&record.Source,
+			&counterpartyId,
+			&reason,
 			&questId,
 			&name,
 			&description,
@@ -183,6 +587,22 @@ func (pu *PostgresUser) GetHistory(id types.Id) ([]HistoryRecord, error) {
 			return nil, errors.Wrapf(err, "can't scan get history query result for user with id %d", id)
 		}
 
+		record.Snapshot = nil
+		if snapshotName.Valid && snapshotDescription.Valid && snapshotType.Valid {
+			record.Snapshot = &QuestSnapshot{
+				Name:        snapshotName.String,
+				Description: snapshotDescription.String,
+				Type:        types.QuestType(snapshotType.String),
+			}
+		}
+
+		record.CounterpartyId = nil
+		if counterpartyId.Valid {
+			record.CounterpartyId = &counterpartyId.V
+		}
+
+		record.Reason = reason.String
+
 		record.Quest = nil
 		if questId.Valid && name.Valid && description.Valid && cost.Valid && tp.Valid {
 			record.Quest = &qr.Quest{
@@ -204,57 +624,794 @@ func (pu *PostgresUser) GetHistory(id types.Id) ([]HistoryRecord, error) {
 	return history, nil
 }
 
-func (pu *PostgresUser) IsCompletedQuest(user *User, quest *qr.Quest) error {
-	questId := types.Id(0)
-	if err := pu.db.QueryRowx(getCompleteQuest, user.ID, quest.ID).Scan(&questId); err != nil {
+func buildGetAttempts(query *AttemptsQuery) (string, []any) {
+	q := page.Query{}
+	q.Where("user_id = %s", query.UserId)
+	if query.QuestId != nil {
+		q.Where("quest_id = %s", *query.QuestId)
+	}
+
+	return q.Build(getAttempts, "id", query.Order, query.After, query.Limit)
+}
+
+func (pu *PostgresUser) GetAttempts(ctx context.Context, query *AttemptsQuery) ([]AttemptRecord, error) {
+	id := query.UserId
+
+	sqlQuery, args := buildGetAttempts(query)
+	attempts, err := queryAttempts(ctx, pu.db, id, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty attempts of unknown user is reported as error
+	if len(attempts) == 0 {
+		if err := pu.HasUser(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return attempts, nil
+}
+
+// queryAttempts returns attempts of user with id selected by sqlQuery from getAttempts.
+func queryAttempts(ctx context.Context, db sqlx.QueryerContext, id types.Id, sqlQuery string,
+	args ...any) ([]AttemptRecord, error) {
+	rows, err := db.QueryxContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't execute get attempts query for user with id %d", id)
+	}
+
+	attempts := make([]AttemptRecord, 0)
+
+	for rows.Next() {
+		var record AttemptRecord
+
+		questId := sql.Null[types.Id]{}
+		roll := sql.NullFloat64{}
+
+		err := rows.Scan(
+			&record.ID,
+			&questId,
+			&record.Attempt.Success,
+			&roll,
+			&record.Created,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't scan get attempts query result for user with id %d", id)
+		}
+
+		if questId.Valid {
+			record.QuestId = &questId.V
+		}
+		if roll.Valid {
+			record.Attempt.Roll = &roll.Float64
+		}
+
+		attempts = append(attempts, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "can't end scan get attempts query result for user with id %d", id)
+	}
+
+	return attempts, nil
+}
+
+func (pu *PostgresUser) ExportUser(ctx context.Context, id types.Id) (*Export, error) {
+	// User and its records are read from one snapshot
+	tx, err := pu.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't begin transaction for export of user with id %d", id)
+	}
+
+	export, err := exportUser(ctx, tx, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "can't commit transaction for export of user with id %d", id)
+	}
+
+	return export, nil
+}
+
+func exportUser(ctx context.Context, tx *sqlx.Tx, id types.Id) (*Export, error) {
+	export := &Export{}
+	referrerId := sql.Null[types.Id]{}
+	deletedAt := sql.Null[time.Time]{}
+	if err := tx.QueryRowxContext(ctx, getExportedUser, id).Scan(
+		&export.User.ID,
+		&export.User.Name,
+		&export.User.Balance,
+		&export.User.ReferralCode,
+		&referrerId,
+		&deletedAt,
+	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil
+			return nil, ErrorUserNotFound
 		}
-		return errors.Wrapf(err, "can't get quest for user with id %d", user.ID)
+		return nil, errors.Wrapf(err, "can't get user with id %d for export", id)
+	}
+
+	if referrerId.Valid {
+		export.User.ReferrerId = &referrerId.V
+	}
+	if deletedAt.Valid {
+		export.DeletedAt = &pkgtime.FormattedTime{Time: deletedAt.V}
+	}
+
+	var err error
+	if export.History, err = queryHistory(ctx, tx, id, exportHistory, id); err != nil {
+		return nil, err
 	}
 
-	return ErrorUserAlreadyCompleteQuest
+	if export.Attempts, err = queryAttempts(ctx, tx, id, exportAttempts, id); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+func buildGetAvailableQuests(query *AvailableQuestsQuery) (string, []any) {
+	q := page.Query{}
+	q.Where(availableQuest, query.UserId)
+
+	return q.Build(getAvailableQuests, "id", page.Asc, query.After, query.Limit)
 }
 
-func (pu *PostgresUser) ApplyCost(user *User, quest *qr.Quest) error {
-	tx, err := pu.db.Beginx()
+func (pu *PostgresUser) GetAvailableQuests(ctx context.Context, query *AvailableQuestsQuery) ([]qr.Quest, error) {
+	id := query.UserId
+
+	sqlQuery, args := buildGetAvailableQuests(query)
+	rows, err := pu.db.QueryxContext(ctx, sqlQuery, args...)
 	if err != nil {
-		return errors.Wrapf(err,
-			"can't begin transaction for apply cost to user with id %d and quest id %d", user.ID, quest.ID)
+		return nil, errors.Wrapf(err, "can't execute get available quests query for user with id %d", id)
 	}
 
-	id := types.Id(0)
-	if err := tx.QueryRowx(applyCost, user.ID, quest.Cost).Scan(&id); err != nil {
+	quests := make([]qr.Quest, 0)
+
+	for rows.Next() {
+		var quest qr.Quest
+
+		if err := qr.ScanQuest(rows, &quest); err != nil {
+			return nil, errors.Wrapf(err, "can't scan get available quests query result for user with id %d", id)
+		}
+
+		quests = append(quests, quest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "can't end scan get available quests query result for user with id %d", id)
+	}
+
+	// Empty list of unknown user is reported as error
+	if len(quests) == 0 {
+		if err := pu.HasUser(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return quests, nil
+}
+
+func (pu *PostgresUser) CompleteQuest(ctx context.Context, userId, questId types.Id, amount uint32,
+	day time.Time, referral Referral, check CompletionCheck,
+) (*Progress, error) {
+	tx, err := pu.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"can't begin transaction for complete quest with id %d by user with id %d", questId, userId)
+	}
+
+	progress, err := completeQuest(ctx, tx, userId, questId, amount, day, referral, check)
+	// Failed attempt is stored, so transaction is committed in spite of error
+	if err != nil && !errors.Is(err, ErrorAttemptFailed) {
 		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err,
+			"can't commit transaction for complete quest with id %d by user with id %d", questId, userId)
+	}
+
+	return progress, err
+}
+
+func completeQuest(ctx context.Context, tx *sqlx.Tx, userId, questId types.Id, amount uint32,
+	day time.Time, referral Referral, check CompletionCheck,
+) (*Progress, error) {
+	quest := &qr.Quest{}
+	if err := qr.ScanQuest(tx.QueryRowxContext(ctx, lockQuest, questId), quest); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, qr.ErrorQuestNotFound
+		}
+		return nil, errors.Wrapf(err, "can't lock quest with id %d", questId)
+	}
+
+	// Team lock serializes completions of team quest, so user isn't locked until payout: completion pays
+	// other members, and they are locked all at once in order of ids like in transfer, so they can't deadlock
+	var teamId types.Id
+	if quest.Type == types.TEAM {
+		id, err := team.LockTeam(ctx, tx, userId)
+		if err != nil {
+			if errors.Is(err, team.ErrorUserNotInTeam) {
+				return nil, ErrorUserNotInTeam
+			}
+			return nil, err
+		}
+		teamId = id
+
+		if err := tx.QueryRowxContext(ctx, checkUser, userId).Scan(&userId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrorUserNotFound
+			}
+			return nil, errors.Wrapf(err, "can't check user with id %d", userId)
+		}
+	} else if err := lockCompletingUser(ctx, tx, userId, quest, referral); err != nil {
+		return nil, err
+	}
+
+	user := &User{ID: userId}
+
+	completions, err := getQuestCompletions(ctx, tx, user, quest)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt, err := check(quest, completions)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt != nil {
+		if _, err := tx.ExecContext(ctx, createAttempt, user.ID, quest.ID, attempt.Success, attempt.Roll); err != nil {
+			return nil, errors.Wrapf(err, "can't store attempt of quest with id %d by user with id %d",
+				quest.ID, user.ID)
+		}
+
+		if !attempt.Success {
+			return nil, ErrorAttemptFailed
+		}
+	}
+
+	award := quest.Cost
+	progress := &Progress{Quest: quest}
+	switch quest.Type {
+	case types.STAGED:
+		if err := applyQuestStep(ctx, tx, user, progress); err != nil {
+			return nil, err
+		}
+
+		if int(progress.Step) < len(quest.Steps) {
+			return progress, nil
+		}
+	case types.COUNTER:
+		if err := applyQuestCounter(ctx, tx, user, amount, progress); err != nil {
+			return nil, err
+		}
+
+		if progress.Step < quest.Target {
+			return progress, nil
+		}
+	case types.STREAK:
+		if err := applyQuestStreak(ctx, tx, user, day, progress); err != nil {
+			return nil, err
+		}
+
+		reward, reached := quest.MilestoneReward(progress.Step)
+		if !reached {
+			return progress, nil
+		}
+		award = reward
+	case types.TEAM:
+		if err := applyQuestContribution(ctx, tx, user, teamId, progress); err != nil {
+			return nil, err
+		}
+
+		if progress.Step < quest.Target {
+			return progress, nil
+		}
+	}
+
+	multiplier, err := boost.GetMultiplier(ctx, tx, quest)
+	if err != nil {
+		return nil, err
+	}
+
+	if quest.Type == types.TEAM {
+		return progress, payTeam(ctx, tx, user.ID, teamId, quest, multiplier, referral)
+	}
+
+	if err := applyQuestCost(ctx, tx, user, quest, award, multiplier); err != nil {
+		return nil, err
+	}
+
+	return progress, payReferral(ctx, tx, user.ID, quest, referral)
+}
+
+// lockCompletingUser locks user completing quest. Completion of referral quest locks referrer
+// waiting for bonus too.
+func lockCompletingUser(ctx context.Context, tx *sqlx.Tx, userId types.Id, quest *qr.Quest, referral Referral) error {
+	return lockWithReferrer(ctx, tx, userId, quest.ID, referral, lockReferral)
+}
+
+// lockRevokingUser locks user, which completion of quest is revoked. Revocation of referral quest
+// locks referrer too, because its referral bonus may be clawed back.
+func lockRevokingUser(ctx context.Context, tx *sqlx.Tx, userId, questId types.Id, referral Referral) error {
+	return lockWithReferrer(ctx, tx, userId, questId, referral, lockReferrer)
+}
+
+// lockWithReferrer locks user and, for referral quest, its referrer selected by query.
+// Users are locked in order of ids like in transfer, so they can't deadlock.
+func lockWithReferrer(ctx context.Context, tx *sqlx.Tx, userId, questId types.Id, referral Referral,
+	query string) error {
+	if referral.QuestId == 0 || referral.QuestId != questId {
+		if err := tx.QueryRowxContext(ctx, lockUser, userId).Scan(&userId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrorUserNotFound
+			}
+			return errors.Wrapf(err, "can't lock user with id %d", userId)
+		}
+
+		return nil
+	}
+
+	rows, err := tx.QueryxContext(ctx, query, userId)
+	if err != nil {
+		return errors.Wrapf(err, "can't lock user with id %d and its referrer", userId)
+	}
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		id := types.Id(0)
+		if err := rows.Scan(&id); err != nil {
+			return errors.Wrapf(err, "can't scan lock of user with id %d and its referrer", userId)
+		}
+		found = found || id == userId
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.Wrapf(err, "can't end scan lock of user with id %d and its referrer", userId)
+	}
+
+	if !found {
+		return ErrorUserNotFound
+	}
+
+	return nil
+}
+
+func getQuestCompletions(ctx context.Context, tx *sqlx.Tx, user *User, quest *qr.Quest) (*Completions, error) {
+	completions := &Completions{}
+	elapsed := float64(0)
+	if err := tx.QueryRowxContext(ctx, getCompletions, user.ID, quest.ID).Scan(&completions.Count, &elapsed); err != nil {
+		return nil, errors.Wrapf(err, "can't get completions of quest with id %d for user with id %d",
+			quest.ID, user.ID)
+	}
+
+	completions.Elapsed = time.Duration(elapsed * float64(time.Second))
+
+	if len(quest.Prerequisites) > 0 {
+		missing := pq.Int64Array{}
+		if err := tx.QueryRowxContext(ctx, getMissingPrerequisites, user.ID, pq.Array(quest.Prerequisites)).
+			Scan(&missing); err != nil {
+			return nil, errors.Wrapf(err, "can't get missing prerequisites of quest with id %d for user with id %d",
+				quest.ID, user.ID)
+		}
+
+		for _, id := range missing {
+			completions.MissingPrerequisites = append(completions.MissingPrerequisites, types.Id(id))
+		}
+	}
+
+	if quest.Type == types.RANDOM {
+		if err := tx.QueryRowxContext(ctx, getAttemptStats, user.ID, quest.ID).
+			Scan(&completions.Failures, &completions.DailyAttempts); err != nil {
+			return nil, errors.Wrapf(err, "can't get attempts of quest with id %d for user with id %d",
+				quest.ID, user.ID)
+		}
+	}
+
+	return completions, nil
+}
+
+func applyQuestStep(ctx context.Context, tx *sqlx.Tx, user *User, progress *Progress) error {
+	quest := progress.Quest
+	if err := tx.QueryRowxContext(ctx, applyStep, user.ID, quest.ID, len(quest.Steps)).Scan(&progress.Step, &progress.Updated); err != nil {
+		return errors.Wrapf(
+			checkConflictError(err),
+			"can't apply step to user with id %d and quest id %d", user.ID, quest.ID,
+		)
+	}
+
+	return nil
+}
+
+func applyQuestCounter(ctx context.Context, tx *sqlx.Tx, user *User, amount uint32, progress *Progress) error {
+	quest := progress.Quest
+	if err := tx.QueryRowxContext(ctx, applyCounter, user.ID, quest.ID, amount, quest.Target).
+		Scan(&progress.Step, &progress.Updated); err != nil {
+		return errors.Wrapf(
+			checkConflictError(err),
+			"can't apply amount %d to user with id %d and quest id %d", amount, user.ID, quest.ID,
+		)
+	}
+
+	return nil
+}
+
+func applyQuestStreak(ctx context.Context, tx *sqlx.Tx, user *User, day time.Time, progress *Progress) error {
+	quest := progress.Quest
+	lastMilestone := quest.Milestones[len(quest.Milestones)-1].Days
+	if err := tx.QueryRowxContext(ctx, applyStreak, user.ID, quest.ID, day.Format(time.DateOnly), lastMilestone).
+		Scan(&progress.Step, &progress.Updated); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorStreakAlreadyExtended
+		}
+		return errors.Wrapf(
+			checkConflictError(err),
+			"can't extend streak of user with id %d and quest id %d", user.ID, quest.ID,
+		)
+	}
+
+	return nil
+}
+
+func applyQuestContribution(ctx context.Context, tx *sqlx.Tx, user *User, teamId types.Id, progress *Progress) error {
+	quest := progress.Quest
+	contribution, err := team.Contribute(ctx, tx, teamId, quest.ID, user.ID)
+	if err != nil {
+		if errors.Is(err, team.ErrorAlreadyContributed) {
+			return ErrorAlreadyContributed
+		}
+		return err
+	}
+
+	progress.Step = contribution.Contributors
+	progress.Updated = contribution.Created
+
+	return nil
+}
+
+// payTeam distributes cost of completed team quest between contributors according to its reward split.
+// Boost multiplier is applied to share of every contributor.
+// Completing user, contributors and their referrers are locked before any credit.
+func payTeam(ctx context.Context, tx *sqlx.Tx, userId, teamId types.Id, quest *qr.Quest, multiplier float64,
+	referral Referral) error {
+	contributors, err := team.TakeContributors(ctx, tx, teamId, quest.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := lockTeamPayees(ctx, tx, append([]types.Id{userId}, contributors...), quest.ID, referral); err != nil {
+		return err
+	}
+
+	for i, id := range contributors {
+		award := quest.Cost
+		if quest.RewardSplit == types.SplitEqual {
+			// Remainder of division goes to the earliest contributors
+			award = quest.Cost / types.Cost(len(contributors))
+			if i < int(quest.Cost%types.Cost(len(contributors))) {
+				award++
+			}
+		}
+
+		if err := applyQuestCost(ctx, tx, &User{ID: id}, quest, award, multiplier); err != nil {
+			return err
+		}
+
+		if err := payReferral(ctx, tx, id, quest, referral); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockTeamPayees locks users paid for completion of team quest by single query, so they are locked in order of ids.
+// Completion of referral quest locks their referrers waiting for bonus too.
+func lockTeamPayees(ctx context.Context, tx *sqlx.Tx, ids []types.Id, questId types.Id, referral Referral) error {
+	query := lockPayees
+	if referral.QuestId != 0 && referral.QuestId == questId {
+		query = lockReferralPayees
+	}
+
+	rows, err := tx.QueryxContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return errors.Wrapf(err, "can't lock users paid for quest with id %d", questId)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		id := types.Id(0)
+		if err := rows.Scan(&id); err != nil {
+			return errors.Wrapf(err, "can't scan lock of users paid for quest with id %d", questId)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.Wrapf(err, "can't end scan lock of users paid for quest with id %d", questId)
+	}
+
+	return nil
+}
+
+// applyQuestCost credits award for completion of quest multiplied by boost multiplier to user
+// and stores it in history and leaderboard scores.
+func applyQuestCost(ctx context.Context, tx *sqlx.Tx, user *User, quest *qr.Quest, base types.Cost,
+	multiplier float64) error {
+	if err := creditAward(ctx, tx, user.ID, quest, base, multiplier, types.SourceQuest, nil); err != nil {
+		return err
+	}
+
+	return leaderboard.AddScore(ctx, tx, user.ID, boost.Multiply(base, multiplier))
+}
+
+// payReferral pays referral bonus to invited user rewarded for referral quest and to its referrer,
+// if the bonus is not paid yet. Referrer deleted since invitation doesn't get the bonus.
+// Referral bonuses are not quest rewards, so they are not boosted and don't change leaderboard scores.
+func payReferral(ctx context.Context, tx *sqlx.Tx, userId types.Id, quest *qr.Quest, referral Referral) error {
+	if referral.QuestId == 0 || referral.QuestId != quest.ID {
+		return nil
+	}
+
+	referrerId := types.Id(0)
+	if err := tx.QueryRowxContext(ctx, claimReferral, userId).Scan(&referrerId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.Wrapf(err, "can't claim referral bonus of user with id %d", userId)
+	}
+
+	if err := creditAward(ctx, tx, userId, quest, referral.Bonus, 1, types.SourceReferral, &referrerId); err != nil {
+		return err
+	}
+
+	if err := creditAward(ctx, tx, referrerId, quest, referral.Bonus, 1, types.SourceReferral, &userId); err != nil &&
+		!errors.Is(err, ErrorUserNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// creditAward credits base award from source multiplied by multiplier to user
+// and stores it in history with snapshot of quest. Referral bonuses store the other user of referral as counterparty,
+// so bonuses can be found on revocation.
+func creditAward(ctx context.Context, tx *sqlx.Tx, userId types.Id, quest *qr.Quest, base types.Cost,
+	multiplier float64, source types.HistorySource, counterpartyId *types.Id) error {
+	reason := ledger.QuestReward
+	if source == types.SourceReferral {
+		reason = ledger.ReferralBonus
+	}
+
+	award := boost.Multiply(base, multiplier)
+	questId := quest.ID
+	reward := &ledger.Transaction{
+		UserId:  userId,
+		Kind:    ledger.Credit,
+		Amount:  uint64(award),
+		Reason:  reason,
+		QuestId: &questId,
+	}
+	if err := ledger.Apply(ctx, tx, reward); err != nil {
+		if errors.Is(err, ledger.ErrorUserNotFound) {
 			return ErrorUserNotFound
 		}
-		return errors.Wrapf(err, "can't apply cost to user with id %d and quest id %d", user.ID, quest.ID)
+		return errors.Wrapf(err, "can't apply %s to user with id %d and quest id %d", source, userId, quest.ID)
 	}
 
-	if _, err := tx.Exec(createHistory, user.ID, quest.ID); err != nil {
-		_ = tx.Rollback()
+	_, err := tx.ExecContext(ctx, createHistory,
+		userId, quest.ID, award, base, multiplier, quest.Name, quest.Description, quest.Type, source, counterpartyId)
+	if err != nil {
 		return errors.Wrapf(
 			checkConflictError(err),
-			"can't store history for user with id %d and quest id %d", user.ID, quest.ID,
+			"can't store history for user with id %d and quest id %d", userId, quest.ID,
 		)
 	}
 
+	return nil
+}
+
+func (pu *PostgresUser) RevokeQuest(ctx context.Context, userId, questId types.Id, reason string,
+	policy ledger.RevokePolicy, referral Referral) (*Revocation, error) {
+	tx, err := pu.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"can't begin transaction for revoke quest with id %d from user with id %d", questId, userId)
+	}
+
+	revocation, err := revokeQuest(ctx, tx, userId, questId, reason, policy, referral)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
-		return errors.Wrapf(err,
-			"can't commit transaction for apply cost to user with id %d and quest id %d", user.ID, quest.ID)
+		return nil, errors.Wrapf(err,
+			"can't commit transaction for revoke quest with id %d from user with id %d", questId, userId)
+	}
+
+	return revocation, nil
+}
+
+func revokeQuest(ctx context.Context, tx *sqlx.Tx, userId, questId types.Id, reason string,
+	policy ledger.RevokePolicy, referral Referral) (*Revocation, error) {
+	// Lock of user serializes revocation with concurrent completions and balance changes
+	if err := lockRevokingUser(ctx, tx, userId, questId, referral); err != nil {
+		return nil, err
+	}
+
+	revocation := &Revocation{
+		QuestId: questId,
+		Reason:  reason,
+	}
+	if err := tx.QueryRowxContext(ctx, revokeLastCompletion, userId, questId).Scan(
+		&revocation.Award,
+		&revocation.Snapshot.Name,
+		&revocation.Snapshot.Description,
+		&revocation.Snapshot.Type,
+		&revocation.Completed,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrorCompletionNotFound
+		}
+		return nil, errors.Wrapf(err, "can't revoke completion of quest with id %d by user with id %d",
+			questId, userId)
+	}
+
+	if err := clawBack(ctx, tx, userId, revocation, ledger.QuestRevoke, policy); err != nil {
+		return nil, err
+	}
+
+	// Scores lose the whole award like revoked completion is excluded from history,
+	// policy decides only how much of it is clawed back from balance
+	if err := leaderboard.SubtractScore(ctx, tx, userId, revocation.Award, revocation.Completed.Time); err != nil {
+		return nil, err
+	}
+
+	if err := revokeReferral(ctx, tx, userId, questId, reason, policy, referral); err != nil {
+		return nil, err
+	}
+
+	return revocation, nil
+}
+
+// referralBonus is referral bonus clawed back from its owner on revocation of referral quest.
+type referralBonus struct {
+	userId     types.Id
+	revocation Revocation
+}
+
+// revokeReferral claws back referral bonuses paid to user and its referrer for completion of referral quest,
+// if revoked completion was the last one of user. Bonus is paid once, so next completion doesn't pay it again.
+func revokeReferral(ctx context.Context, tx *sqlx.Tx, userId, questId types.Id, reason string,
+	policy ledger.RevokePolicy, referral Referral) error {
+	if referral.QuestId == 0 || referral.QuestId != questId {
+		return nil
+	}
+
+	completions, err := getQuestCompletions(ctx, tx, &User{ID: userId}, &qr.Quest{ID: questId})
+	if err != nil {
+		return err
+	}
+	if completions.Count > 0 {
+		return nil
+	}
+
+	rows, err := tx.QueryxContext(ctx, revokeReferralBonuses, userId, questId)
+	if err != nil {
+		return errors.Wrapf(err, "can't revoke referral bonuses of user with id %d", userId)
+	}
+	defer rows.Close()
+
+	bonuses := make([]referralBonus, 0, 2)
+	for rows.Next() {
+		bonus := referralBonus{revocation: Revocation{QuestId: questId, Reason: reason}}
+		if err := rows.Scan(
+			&bonus.userId,
+			&bonus.revocation.Award,
+			&bonus.revocation.Snapshot.Name,
+			&bonus.revocation.Snapshot.Description,
+			&bonus.revocation.Snapshot.Type,
+		); err != nil {
+			return errors.Wrapf(err, "can't scan revoked referral bonuses of user with id %d", userId)
+		}
+		bonuses = append(bonuses, bonus)
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.Wrapf(err, "can't end scan revoked referral bonuses of user with id %d", userId)
+	}
+
+	for i := range bonuses {
+		if err := clawBack(ctx, tx, bonuses[i].userId, &bonuses[i].revocation, ledger.ReferralRevoke, policy); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-const (
-	uniqueConflictCode   = "23505"
-	uniqueConstraintName = "quest_unique"
+// clawBack claws back award of revocation from user according to policy by transaction with transactionReason
+// and stores compensating history record with reason of revocation.
+// Transaction, ID and Created of revocation are filled.
+func clawBack(ctx context.Context, tx *sqlx.Tx, userId types.Id, revocation *Revocation, transactionReason string,
+	policy ledger.RevokePolicy) error {
+	questId := revocation.QuestId
+	revocation.Transaction = ledger.Transaction{
+		UserId:  userId,
+		Amount:  uint64(revocation.Award),
+		Reason:  transactionReason,
+		QuestId: &questId,
+	}
+	if err := ledger.Revoke(ctx, tx, &revocation.Transaction, policy); err != nil {
+		if errors.Is(err, ledger.ErrorUserNotFound) {
+			return ErrorUserNotFound
+		}
+		return err
+	}
+
+	if err := tx.QueryRowxContext(ctx, createRevokeHistory,
+		userId,
+		questId,
+		revocation.Transaction.Amount,
+		revocation.Snapshot.Name,
+		revocation.Snapshot.Description,
+		revocation.Snapshot.Type,
+		revocation.Reason,
+		revocation.Transaction.Balance,
+	).Scan(&revocation.ID, &revocation.Created); err != nil {
+		return errors.Wrapf(err, "can't store revocation of quest with id %d from user with id %d",
+			questId, userId)
+	}
 
-	foreignKeyConflictCode = "23503"
-	userIdConstraintName   = "balance_history_user_id_fkey"
-	questIdConstraintName  = "balance_history_quest_id_fkey"
+	return nil
+}
+
+func (pu *PostgresUser) GetProgress(ctx context.Context, id types.Id) ([]Progress, error) {
+	rows, err := pu.db.QueryxContext(ctx, getProgress, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't execute get progress query for user with id %d", id)
+	}
+
+	progress := make([]Progress, 0)
+
+	for rows.Next() {
+		record := Progress{Quest: &qr.Quest{}}
+
+		if err := qr.ScanQuest(rows, record.Quest, &record.Step, &record.Updated); err != nil {
+			return nil, errors.Wrapf(err, "can't scan get progress query result for user with id %d", id)
+		}
+
+		progress = append(progress, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "can't end scan get progress query result for user with id %d", id)
+	}
+
+	// Empty progress of unknown user is reported as error
+	if len(progress) == 0 {
+		if err := pu.HasUser(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return progress, nil
+}
+
+const (
+	foreignKeyConflictCode        = "23503"
+	userIdConstraintName          = "balance_history_user_id_fkey"
+	questIdConstraintName         = "balance_history_quest_id_fkey"
+	progressUserIdConstraintName  = "quest_progress_user_id_fkey"
+	progressQuestIdConstraintName = "quest_progress_quest_id_fkey"
 )
 
 func checkConflictError(err error) error {
@@ -271,15 +1428,12 @@ func checkConflictError(err error) error {
 func checkPgError(err *pq.Error) error {
 	switch err.Code {
 	case foreignKeyConflictCode:
-		if err.Constraint == questIdConstraintName {
+		switch err.Constraint {
+		case questIdConstraintName, progressQuestIdConstraintName:
 			return qr.ErrorQuestNotFound
-		} else if err.Constraint == userIdConstraintName {
+		case userIdConstraintName, progressUserIdConstraintName:
 			return ErrorUserNotFound
 		}
-	case uniqueConflictCode:
-		if err.Constraint == uniqueConstraintName {
-			return ErrorUserAlreadyCompleteQuest
-		}
 	}
 	return err
 }
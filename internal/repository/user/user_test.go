@@ -1,7 +1,10 @@
 package user
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
@@ -9,8 +12,14 @@ import (
 	"github.com/pkg/errors"
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
 
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/boost"
+	"vk_quests/internal/repository/leaderboard"
+	"vk_quests/internal/repository/ledger"
 	qr "vk_quests/internal/repository/quest"
+	"vk_quests/internal/repository/team"
 )
 
 var testError = errors.New("test error")
@@ -35,14 +44,16 @@ func (urs *UserRepositorySuite) AfterEach(t provider.T) {
 func (urs *UserRepositorySuite) TestCreateFunction(t provider.T) {
 	t.Title("CreateUser function of User repository")
 	t.NewStep("Init test data")
+	referrerId := types.Id(2)
 	user := &User{
-		ID:      1,
-		Name:    "user",
-		Balance: 200,
+		ID:           1,
+		Name:         "user",
+		Balance:      200,
+		ReferralCode: "3f9a1c07be",
 	}
 
 	userColumns := []string{
-		"id", "name", "balance",
+		"id", "name", "balance", "referral_code", "referrer_id",
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
@@ -50,15 +61,52 @@ func (urs *UserRepositorySuite) TestCreateFunction(t provider.T) {
 		urs.mock.ExpectQuery(createQuery).
 			WithArgs(user.Name).
 			WillReturnRows(sqlxmock.NewRows(userColumns).
-				AddRow(user.ID, user.Name, user.Balance),
+				AddRow(user.ID, user.Name, user.Balance, user.ReferralCode, nil),
 			)
 
 		t.NewStep("Check result")
-		usr, err := urs.userRepository.CreateUser(user)
+		usr, err := urs.userRepository.CreateUser(context.Background(), user, "")
 		t.Require().NoError(err)
 		t.Require().EqualValues(user, usr)
 	})
 
+	t.WithNewStep("Referred user execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		// Unexpected query only returns error to its caller, so executed queries are recorded to check
+		// that user isn't created without referrer before referred one
+		executed := make([]string, 0, 1)
+		db, mock, err := sqlxmock.Newx(sqlxmock.QueryMatcherOption(sqlxmock.QueryMatcherFunc(
+			func(expectedSQL, actualSQL string) error {
+				executed = append(executed, actualSQL)
+				return sqlxmock.QueryMatcherEqual.Match(expectedSQL, actualSQL)
+			},
+		)))
+		t.Require().NoError(err)
+		mock.ExpectQuery(createReferredQuery).
+			WithArgs(user.Name, "a1b2c3d4e5").
+			WillReturnRows(sqlxmock.NewRows(userColumns).
+				AddRow(user.ID, user.Name, user.Balance, user.ReferralCode, referrerId),
+			)
+
+		t.NewStep("Check result")
+		usr, err := NewPostgresUser(db).CreateUser(context.Background(), user, "a1b2c3d4e5")
+		t.Require().NoError(err)
+		t.Require().Equal(&referrerId, usr.ReferrerId)
+		t.Require().Equal([]string{createReferredQuery}, executed)
+		t.Require().NoError(mock.ExpectationsWereMet())
+	})
+
+	t.WithNewStep("Referral code not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(createReferredQuery).
+			WithArgs(user.Name, "unknown").
+			WillReturnRows(sqlxmock.NewRows(userColumns))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CreateUser(context.Background(), user, "unknown")
+		t.Require().ErrorIs(err, ErrorReferralCodeNotFound)
+	})
+
 	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		urs.mock.ExpectQuery(createQuery).
@@ -66,7 +114,7 @@ func (urs *UserRepositorySuite) TestCreateFunction(t provider.T) {
 			WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.CreateUser(user)
+		_, err := urs.userRepository.CreateUser(context.Background(), user, "")
 		t.Require().ErrorIs(err, testError)
 	})
 
@@ -77,7 +125,7 @@ func (urs *UserRepositorySuite) TestCreateFunction(t provider.T) {
 			WillReturnRows(sqlxmock.NewRows(userColumns))
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.CreateUser(user)
+		_, err := urs.userRepository.CreateUser(context.Background(), user, "")
 		t.Require().Error(err)
 	})
 }
@@ -95,7 +143,35 @@ func (urs *UserRepositorySuite) TestDeleteFunction(t provider.T) {
 		"id", "name", "balance",
 	}
 
-	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+	t.WithNewStep("Correct soft execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(softDeleteUser).
+			WithArgs(user.ID, false).
+			WillReturnRows(sqlxmock.NewRows(userColumns).
+				AddRow(user.ID, user.Name, user.Balance),
+			)
+
+		t.NewStep("Check result")
+		usr, err := urs.userRepository.DeleteUser(context.Background(), user.ID, DeleteSoft)
+		t.Require().NoError(err)
+		t.Require().EqualValues(user, usr)
+	})
+
+	t.WithNewStep("Correct anonymize execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(softDeleteUser).
+			WithArgs(user.ID, true).
+			WillReturnRows(sqlxmock.NewRows(userColumns).
+				AddRow(user.ID, "", user.Balance),
+			)
+
+		t.NewStep("Check result")
+		usr, err := urs.userRepository.DeleteUser(context.Background(), user.ID, DeleteAnonymize)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&User{ID: user.ID, Balance: user.Balance}, usr)
+	})
+
+	t.WithNewStep("Correct hard execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		urs.mock.ExpectQuery(deleteUser).
 			WithArgs(user.ID).
@@ -104,7 +180,7 @@ func (urs *UserRepositorySuite) TestDeleteFunction(t provider.T) {
 			)
 
 		t.NewStep("Check result")
-		usr, err := urs.userRepository.DeleteUser(user.ID)
+		usr, err := urs.userRepository.DeleteUser(context.Background(), user.ID, DeleteHard)
 		t.Require().NoError(err)
 		t.Require().EqualValues(user, usr)
 	})
@@ -116,18 +192,73 @@ func (urs *UserRepositorySuite) TestDeleteFunction(t provider.T) {
 			WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.DeleteUser(user.ID)
+		_, err := urs.userRepository.DeleteUser(context.Background(), user.ID, DeleteHard)
 		t.Require().ErrorIs(err, testError)
 	})
 
 	t.WithNewStep("Empty result of execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(deleteUser).
+		urs.mock.ExpectQuery(softDeleteUser).
+			WithArgs(user.ID, false).
+			WillReturnRows(sqlxmock.NewRows(userColumns))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.DeleteUser(context.Background(), user.ID, DeleteSoft)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Unknown policy execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		_, err := urs.userRepository.DeleteUser(context.Background(), user.ID, "archive")
+		t.Require().Error(err)
+	})
+}
+
+func (urs *UserRepositorySuite) TestAnonymizeFunction(t provider.T) {
+	t.Title("AnonymizeUser function of User repository")
+	t.NewStep("Init test data")
+	user := &User{
+		ID:      1,
+		Balance: 10,
+	}
+
+	userColumns := []string{
+		"id", "name", "balance",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(anonymizeUser).
+			WithArgs(user.ID).
+			WillReturnRows(sqlxmock.NewRows(userColumns).
+				AddRow(user.ID, user.Name, user.Balance),
+			)
+
+		t.NewStep("Check result")
+		usr, err := urs.userRepository.AnonymizeUser(context.Background(), user.ID)
+		t.Require().NoError(err)
+		t.Require().EqualValues(user, usr)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(anonymizeUser).
+			WithArgs(user.ID).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.AnonymizeUser(context.Background(), user.ID)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Empty result of execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(anonymizeUser).
 			WithArgs(user.ID).
 			WillReturnRows(sqlxmock.NewRows(userColumns))
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.DeleteUser(user.ID)
+		_, err := urs.userRepository.AnonymizeUser(context.Background(), user.ID)
 		t.Require().ErrorIs(err, ErrorUserNotFound)
 	})
 }
@@ -145,6 +276,13 @@ func (urs *UserRepositorySuite) TestGetFunction(t provider.T) {
 		"id", "name", "balance",
 	}
 
+	query := &UsersQuery{
+		Sort:  types.UsersSortID,
+		Order: page.Asc,
+		Limit: 10,
+	}
+	sqlQuery := getUsers + " WHERE deleted_at IS NULL ORDER BY id ASC LIMIT $1"
+
 	usersRows := func() *sqlxmock.Rows {
 		return sqlxmock.NewRows(userColumns).
 			AddRow(user.ID, user.Name, user.Balance).
@@ -154,47 +292,188 @@ func (urs *UserRepositorySuite) TestGetFunction(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getUsers).WillReturnRows(usersRows())
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(query.Limit).WillReturnRows(usersRows())
 
 		t.NewStep("Check result")
-		users, err := urs.userRepository.GetUsers()
+		users, err := urs.userRepository.GetUsers(context.Background(), query)
 		t.Require().NoError(err)
 		t.Require().EqualValues([]User{*user, *user, *user}, users)
 	})
 
 	t.WithNewStep("Postgres error query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getUsers).WillReturnError(testError)
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(query.Limit).WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.GetUsers()
+		_, err := urs.userRepository.GetUsers(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 
 	t.WithNewStep("Rows error query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getUsers).WillReturnRows(usersRows().RowError(1, testError))
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(query.Limit).WillReturnRows(usersRows().RowError(1, testError))
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.GetUsers()
+		_, err := urs.userRepository.GetUsers(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 
 	t.WithNewStep("Incorrect field in row of getUsers query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getUsers).WillReturnRows(usersRows().AddRow(1, 1, "top"))
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(query.Limit).WillReturnRows(usersRows().AddRow(1, 1, "top"))
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.GetUsers()
+		_, err := urs.userRepository.GetUsers(context.Background(), query)
 		t.Require().Error(err)
 	})
 
 	t.WithNewStep("Rows close error on getUsers query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getUsers).WillReturnRows(usersRows().CloseError(testError))
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(query.Limit).WillReturnRows(usersRows().CloseError(testError))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetUsers(context.Background(), query)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Filters and cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		minBalance, maxBalance := int64(10), int64(30)
+		filteredQuery := &UsersQuery{
+			NamePrefix: "us_r%",
+			MinBalance: &minBalance,
+			MaxBalance: &maxBalance,
+			Sort:       types.UsersSortBalance,
+			Order:      page.Desc,
+			After:      &page.Cursor{Value: "25", ID: 7},
+			Limit:      10,
+		}
+		urs.mock.ExpectQuery(getUsers+
+			" WHERE deleted_at IS NULL AND name LIKE $1 AND balance >= $2 AND balance <= $3 AND (balance, id) < ($4, $5)"+
+			" ORDER BY balance DESC, id DESC LIMIT $6").
+			WithArgs(`us\_r\%%`, minBalance, maxBalance, "25", types.Id(7), filteredQuery.Limit).
+			WillReturnRows(usersRows())
+
+		t.NewStep("Check result")
+		users, err := urs.userRepository.GetUsers(context.Background(), filteredQuery)
+		t.Require().NoError(err)
+		t.Require().EqualValues([]User{*user, *user, *user}, users)
+	})
+
+	t.WithNewStep("Unknown sort execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetUsers(context.Background(), &UsersQuery{Sort: "created", Limit: 10})
+		t.Require().Error(err)
+	})
+}
+
+func (urs *UserRepositorySuite) TestGetUserFunction(t provider.T) {
+	t.Title("GetUser function of User repository")
+	t.NewStep("Init test data")
+	referrerId := types.Id(2)
+	user := User{
+		ID:           1,
+		Name:         "user",
+		Balance:      40,
+		ReferralCode: "3f9a1c07be",
+		ReferrerId:   &referrerId,
+	}
+
+	userColumns := []string{
+		"id", "name", "balance", "referral_code", "referrer_id", "quest_type", "count", "sum", "max",
+	}
+
+	first := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getUser).
+			WithArgs(user.ID).
+			WillReturnRows(sqlxmock.NewRows(userColumns).
+				AddRow(user.ID, user.Name, user.Balance, user.ReferralCode, referrerId, types.USUAL, 2, 30, last).
+				AddRow(user.ID, user.Name, user.Balance, user.ReferralCode, referrerId, types.STAGED, 1, 10, first),
+			)
+
+		t.NewStep("Check result")
+		stats, err := urs.userRepository.GetUser(context.Background(), user.ID)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&UserStats{
+			User:           user,
+			Completed:      3,
+			Earned:         40,
+			LastCompletion: &pkgtime.FormattedTime{Time: last},
+			ByType: map[types.QuestType]TypeStats{
+				types.USUAL:  {Completed: 2, Earned: 30},
+				types.STAGED: {Completed: 1, Earned: 10},
+			},
+		}, stats)
+	})
+
+	t.WithNewStep("User without completions execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getUser).
+			WithArgs(user.ID).
+			WillReturnRows(sqlxmock.NewRows(userColumns).
+				AddRow(user.ID, user.Name, user.Balance, user.ReferralCode, referrerId, nil, 0, 0, nil),
+			)
+
+		t.NewStep("Check result")
+		stats, err := urs.userRepository.GetUser(context.Background(), user.ID)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&UserStats{
+			User:   user,
+			ByType: map[types.QuestType]TypeStats{},
+		}, stats)
+	})
+
+	t.WithNewStep("No user found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getUser).
+			WithArgs(user.ID).
+			WillReturnRows(sqlxmock.NewRows(userColumns))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetUser(context.Background(), user.ID)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getUser).
+			WithArgs(user.ID).
+			WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetUser(context.Background(), user.ID)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Incorrect field in row execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getUser).
+			WithArgs(user.ID).
+			WillReturnRows(sqlxmock.NewRows(userColumns).
+				AddRow(user.ID, user.Name, "top", user.ReferralCode, nil, nil, 0, 0, nil),
+			)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetUser(context.Background(), user.ID)
+		t.Require().Error(err)
+	})
+
+	t.WithNewStep("Rows error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getUser).
+			WithArgs(user.ID).
+			WillReturnRows(sqlxmock.NewRows(userColumns).
+				AddRow(user.ID, user.Name, user.Balance, user.ReferralCode, referrerId, types.USUAL, 2, 30, last).
+				AddRow(user.ID, user.Name, user.Balance, user.ReferralCode, referrerId, types.STAGED, 1, 10, first).
+				RowError(1, testError),
+			)
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.GetUsers()
+		_, err := urs.userRepository.GetUser(context.Background(), user.ID)
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -217,7 +496,7 @@ func (urs *UserRepositorySuite) TestHasUserFunction(t provider.T) {
 			)
 
 		t.NewStep("Check result")
-		err := urs.userRepository.HasUser(userId)
+		err := urs.userRepository.HasUser(context.Background(), userId)
 		t.Require().NoError(err)
 	})
 
@@ -228,7 +507,7 @@ func (urs *UserRepositorySuite) TestHasUserFunction(t provider.T) {
 			WillReturnError(testError)
 
 		t.NewStep("Check result")
-		err := urs.userRepository.HasUser(userId)
+		err := urs.userRepository.HasUser(context.Background(), userId)
 		t.Require().ErrorIs(err, testError)
 	})
 
@@ -239,7 +518,7 @@ func (urs *UserRepositorySuite) TestHasUserFunction(t provider.T) {
 			WillReturnRows(sqlxmock.NewRows(userColumns))
 
 		t.NewStep("Check result")
-		err := urs.userRepository.HasUser(userId)
+		err := urs.userRepository.HasUser(context.Background(), userId)
 		t.Require().ErrorIs(err, ErrorUserNotFound)
 	})
 }
@@ -266,7 +545,7 @@ func (urs *UserRepositorySuite) TestUpdateFunction(t provider.T) {
 			)
 
 		t.NewStep("Check result")
-		usr, err := urs.userRepository.UpdateUser(user)
+		usr, err := urs.userRepository.UpdateUser(context.Background(), user)
 		t.Require().NoError(err)
 		t.Require().EqualValues(user, usr)
 	})
@@ -278,7 +557,7 @@ func (urs *UserRepositorySuite) TestUpdateFunction(t provider.T) {
 			WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.UpdateUser(user)
+		_, err := urs.userRepository.UpdateUser(context.Background(), user)
 		t.Require().ErrorIs(err, testError)
 	})
 
@@ -289,7 +568,7 @@ func (urs *UserRepositorySuite) TestUpdateFunction(t provider.T) {
 			WillReturnRows(sqlxmock.NewRows(userColumns))
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.UpdateUser(user)
+		_, err := urs.userRepository.UpdateUser(context.Background(), user)
 		t.Require().ErrorIs(err, ErrorUserNotFound)
 	})
 }
@@ -299,284 +578,1987 @@ func (urs *UserRepositorySuite) TestGetHistoryFunction(t provider.T) {
 	t.NewStep("Init test data")
 	userId := types.Id(1)
 
+	query := &HistoryQuery{
+		UserId: userId,
+		Order:  page.Desc,
+		Limit:  10,
+	}
+	sqlQuery := getHistory + " WHERE balance_history.user_id = $1" +
+		" ORDER BY balance_history.created DESC, balance_history.id DESC LIMIT $2"
+
 	historyColumns := []string{
-		"id", "name", "description", "cost", "type", "created", "balance",
+		"id", "award", "base_award", "multiplier", "quest_name", "quest_description", "quest_type", "source",
+		"counterparty_id", "reason", "id", "name", "description", "cost", "type", "created", "balance",
+	}
+
+	snapshot := &QuestSnapshot{
+		Name:        "Old name",
+		Description: "old description",
+		Type:        types.USUAL,
 	}
+	counterpartyId := types.Id(2)
 
 	resHistory := []HistoryRecord{
 		{
+			ID:             4,
+			Award:          7,
+			BaseAward:      7,
+			Multiplier:     1,
+			Source:         types.SourceTransferOut,
+			CounterpartyId: &counterpartyId,
+			Balance:        23,
+		},
+		{
+			ID:         3,
+			Snapshot:   snapshot,
+			Award:      10,
+			BaseAward:  5,
+			Multiplier: 2,
+			Source:     types.SourceQuest,
 			Quest: &qr.Quest{
 				ID:   1,
 				Name: "Not null",
+				Cost: 10,
+				Type: types.USUAL,
 			},
 			Balance: 30,
 		},
 		{
-			Quest:   nil,
-			Balance: 25,
+			ID:         2,
+			Snapshot:   snapshot,
+			Award:      5,
+			BaseAward:  5,
+			Multiplier: 1,
+			Source:     types.SourceReferral,
+			Quest:      nil,
+			Balance:    25,
 		},
 		{
-			Quest:   nil,
-			Balance: 26,
+			ID:         1,
+			Snapshot:   snapshot,
+			Award:      5,
+			BaseAward:  5,
+			Multiplier: 1,
+			Source:     types.SourceRevoke,
+			Reason:     "fraud",
+			Quest:      nil,
+			Balance:    26,
 		},
 	}
 
 	historyRows := func() *sqlxmock.Rows {
 		return sqlxmock.NewRows(historyColumns).
-			AddRow(resHistory[0].Quest.ID, resHistory[0].Quest.Name, resHistory[0].Quest.Description,
-				resHistory[0].Quest.Cost, resHistory[0].Quest.Type, resHistory[0].Created.Time, resHistory[0].Balance).
-			AddRow(nil, nil, nil, nil, nil, resHistory[1].Created.Time, resHistory[1].Balance).
-			AddRow(resHistory[0].Quest.ID, nil, nil,
-				resHistory[0].Quest.Cost, nil, resHistory[2].Created.Time, resHistory[2].Balance)
+			AddRow(resHistory[0].ID, resHistory[0].Award, resHistory[0].BaseAward, resHistory[0].Multiplier, nil, nil, nil, resHistory[0].Source,
+				counterpartyId, nil, nil, nil, nil, nil, nil, resHistory[0].Created.Time, resHistory[0].Balance).
+			AddRow(resHistory[1].ID, resHistory[1].Award, resHistory[1].BaseAward, resHistory[1].Multiplier, snapshot.Name, snapshot.Description, snapshot.Type, resHistory[1].Source,
+				nil, nil, resHistory[1].Quest.ID, resHistory[1].Quest.Name, resHistory[1].Quest.Description,
+				resHistory[1].Quest.Cost, resHistory[1].Quest.Type, resHistory[1].Created.Time, resHistory[1].Balance).
+			AddRow(resHistory[2].ID, resHistory[2].Award, resHistory[2].BaseAward, resHistory[2].Multiplier, snapshot.Name, snapshot.Description, snapshot.Type, resHistory[2].Source,
+				nil, nil, nil, nil, nil, nil, nil, resHistory[2].Created.Time, resHistory[2].Balance).
+			AddRow(resHistory[3].ID, resHistory[3].Award, resHistory[3].BaseAward, resHistory[3].Multiplier, snapshot.Name, snapshot.Description, snapshot.Type, resHistory[3].Source,
+				nil, resHistory[3].Reason, resHistory[1].Quest.ID, nil, nil, resHistory[1].Quest.Cost, nil, resHistory[3].Created.Time, resHistory[3].Balance)
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getHistory).WillReturnRows(historyRows())
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(historyRows())
 
 		t.NewStep("Check result")
-		hist, err := urs.userRepository.GetHistory(userId)
+		hist, err := urs.userRepository.GetHistory(context.Background(), query)
 		t.Require().NoError(err)
 		t.Require().EqualValues(resHistory, hist)
 	})
 
+	t.WithNewStep("Unknown user execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(sqlxmock.NewRows(historyColumns))
+		urs.mock.ExpectQuery(hasUser).WithArgs(userId).WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetHistory(context.Background(), query)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
 	t.WithNewStep("Postgres error query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getHistory).WillReturnError(testError)
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnError(testError)
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.GetHistory(userId)
+		_, err := urs.userRepository.GetHistory(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 
 	t.WithNewStep("Rows error query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getHistory).WillReturnRows(historyRows().RowError(1, testError))
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(historyRows().RowError(1, testError))
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.GetHistory(userId)
+		_, err := urs.userRepository.GetHistory(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 
 	t.WithNewStep("Incorrect field in row of getUsers query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getHistory).WillReturnRows(historyRows().AddRow(1, 1, 1, 1, 1, 1, 1))
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(historyRows().AddRow(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1))
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.GetHistory(userId)
+		_, err := urs.userRepository.GetHistory(context.Background(), query)
 		t.Require().Error(err)
 	})
 
 	t.WithNewStep("Rows close error on getUsers query", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getHistory).WillReturnRows(historyRows().CloseError(testError))
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(historyRows().CloseError(testError))
 
 		t.NewStep("Check result")
-		_, err := urs.userRepository.GetHistory(userId)
+		_, err := urs.userRepository.GetHistory(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
+
+	t.WithNewStep("Filters and cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		questType := types.STAGED
+		filteredQuery := &HistoryQuery{
+			UserId:    userId,
+			From:      &from,
+			To:        &to,
+			QuestType: &questType,
+			Order:     page.Asc,
+			After:     &page.Cursor{Value: "2024-01-10T00:00:00Z", ID: 5},
+			Limit:     10,
+		}
+		urs.mock.ExpectQuery(getHistory+
+			" WHERE balance_history.user_id = $1 AND balance_history.created >= $2 AND balance_history.created < $3"+
+			" AND balance_history.quest_type = $4 AND (balance_history.created, balance_history.id) > ($5, $6)"+
+			" ORDER BY balance_history.created ASC, balance_history.id ASC LIMIT $7").
+			WithArgs(userId, from, to, questType, "2024-01-10T00:00:00Z", types.Id(5), filteredQuery.Limit).
+			WillReturnRows(historyRows())
+
+		t.NewStep("Check result")
+		hist, err := urs.userRepository.GetHistory(context.Background(), filteredQuery)
+		t.Require().NoError(err)
+		t.Require().EqualValues(resHistory, hist)
+	})
 }
 
-func (urs *UserRepositorySuite) TestIsCompletedQuestFunction(t provider.T) {
-	t.Title("IsCompletedQuest function of User repository")
+func (urs *UserRepositorySuite) TestGetAttemptsFunction(t provider.T) {
+	t.Title("GetAttempts function of User repository")
 	t.NewStep("Init test data")
+	userId := types.Id(1)
+	questId := types.Id(4)
+	roll := 0.3
 
-	user := &User{
-		ID:      1,
-		Name:    "user",
-		Balance: 10,
+	query := &AttemptsQuery{
+		UserId: userId,
+		Order:  page.Desc,
+		Limit:  10,
 	}
+	sqlQuery := getAttempts + " WHERE user_id = $1 ORDER BY id DESC LIMIT $2"
 
-	quest := &qr.Quest{
-		ID:   2,
-		Name: "Quest",
+	attemptsColumns := []string{
+		"id", "quest_id", "success", "roll", "created",
 	}
 
-	userColumns := []string{
-		"id",
+	resAttempts := []AttemptRecord{
+		{
+			ID:      3,
+			QuestId: &questId,
+			Attempt: Attempt{Success: true},
+		},
+		{
+			ID:      2,
+			QuestId: &questId,
+			Attempt: Attempt{Success: false, Roll: &roll},
+		},
+		{
+			ID:      1,
+			QuestId: nil,
+			Attempt: Attempt{Success: false, Roll: &roll},
+		},
+	}
+
+	attemptsRows := func() *sqlxmock.Rows {
+		return sqlxmock.NewRows(attemptsColumns).
+			AddRow(resAttempts[0].ID, questId, true, nil, resAttempts[0].Created.Time).
+			AddRow(resAttempts[1].ID, questId, false, roll, resAttempts[1].Created.Time).
+			AddRow(resAttempts[2].ID, nil, false, roll, resAttempts[2].Created.Time)
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getCompleteQuest).
-			WithArgs(user.ID, quest.ID).
-			WillReturnRows(sqlxmock.NewRows(userColumns))
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(attemptsRows())
 
 		t.NewStep("Check result")
-		err := urs.userRepository.IsCompletedQuest(user, quest)
+		attempts, err := urs.userRepository.GetAttempts(context.Background(), query)
 		t.Require().NoError(err)
+		t.Require().EqualValues(resAttempts, attempts)
 	})
 
-	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+	t.WithNewStep("Unknown user execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getCompleteQuest).
-			WithArgs(user.ID, quest.ID).
-			WillReturnError(testError)
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(sqlxmock.NewRows(attemptsColumns))
+		urs.mock.ExpectQuery(hasUser).WithArgs(userId).WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetAttempts(context.Background(), query)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Postgres error query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetAttempts(context.Background(), query)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Rows error query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(attemptsRows().RowError(1, testError))
 
 		t.NewStep("Check result")
-		err := urs.userRepository.IsCompletedQuest(user, quest)
+		_, err := urs.userRepository.GetAttempts(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 
-	t.WithNewStep("Quest already completed for user error execute", func(t provider.StepCtx) {
+	t.WithNewStep("Incorrect field in row of getAttempts query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(attemptsRows().AddRow(1, 1, 1, 1, 1))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetAttempts(context.Background(), query)
+		t.Require().Error(err)
+	})
+
+	t.WithNewStep("Quest filter and cursor execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectQuery(getCompleteQuest).
-			WithArgs(user.ID, quest.ID).
-			WillReturnRows(sqlxmock.NewRows(userColumns).AddRow(quest.ID))
+		filteredQuery := &AttemptsQuery{
+			UserId:  userId,
+			QuestId: &questId,
+			Order:   page.Asc,
+			After:   &page.Cursor{ID: 5},
+			Limit:   10,
+		}
+		urs.mock.ExpectQuery(getAttempts+
+			" WHERE user_id = $1 AND quest_id = $2 AND id > $3 ORDER BY id ASC LIMIT $4").
+			WithArgs(userId, questId, types.Id(5), filteredQuery.Limit).
+			WillReturnRows(attemptsRows())
 
 		t.NewStep("Check result")
-		err := urs.userRepository.IsCompletedQuest(user, quest)
-		t.Require().ErrorIs(err, ErrorUserAlreadyCompleteQuest)
+		attempts, err := urs.userRepository.GetAttempts(context.Background(), filteredQuery)
+		t.Require().NoError(err)
+		t.Require().EqualValues(resAttempts, attempts)
 	})
 }
 
-func (urs *UserRepositorySuite) TestApplyCostFunction(t provider.T) {
-	t.Title("ApplyCost function of User repository")
+func (urs *UserRepositorySuite) TestExportFunction(t provider.T) {
+	t.Title("ExportUser function of User repository")
 	t.NewStep("Init test data")
+	userId := types.Id(1)
+	questId := types.Id(4)
+	roll := 0.3
+	referrerId := types.Id(2)
+	deletedAt := pkgtime.MustParse("01.02.2025 - 00:00:00")
+	created := pkgtime.MustParse("15.01.2025 - 10:00:00")
 
-	user := &User{
-		ID:      1,
-		Name:    "user",
-		Balance: 10,
+	userColumns := []string{
+		"id", "name", "balance", "referral_code", "referrer_id", "deleted_at",
 	}
 
-	quest := &qr.Quest{
-		ID:   2,
-		Name: "Quest",
+	historyColumns := []string{
+		"id", "award", "base_award", "multiplier", "quest_name", "quest_description", "quest_type", "source",
+		"counterparty_id", "reason", "id", "name", "description", "cost", "type", "created", "balance",
 	}
 
-	userColumns := []string{
-		"id",
+	attemptsColumns := []string{
+		"id", "quest_id", "success", "roll", "created",
+	}
+
+	resExport := &Export{
+		User:      User{ID: userId, Balance: 5, ReferralCode: "3f9a1c07be", ReferrerId: &referrerId},
+		DeletedAt: &deletedAt,
+		History: []HistoryRecord{
+			{
+				ID:         2,
+				Snapshot:   &QuestSnapshot{Name: "Quest", Description: "good Quest", Type: types.USUAL},
+				Award:      5,
+				BaseAward:  5,
+				Multiplier: 1,
+				Source:     types.SourceReferral,
+				Created:    created,
+				Balance:    5,
+			},
+		},
+		Attempts: []AttemptRecord{
+			{ID: 3, QuestId: &questId, Attempt: Attempt{Roll: &roll}, Created: created},
+		},
+	}
+
+	expectUser := func() *sqlxmock.ExpectedQuery {
+		urs.mock.ExpectBegin()
+		return urs.mock.ExpectQuery(getExportedUser).WithArgs(userId)
+	}
+
+	userRows := func() *sqlxmock.Rows {
+		return sqlxmock.NewRows(userColumns).AddRow(userId, "", 5, "3f9a1c07be", referrerId, deletedAt.Time)
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectBegin()
-		urs.mock.ExpectQuery(applyCost).
-			WithArgs(user.ID, quest.Cost).
-			WillReturnRows(sqlxmock.NewRows(userColumns).AddRow(user.ID))
-		urs.mock.ExpectExec(createHistory).
-			WithArgs(user.ID, quest.ID).
-			WillReturnResult(sqlxmock.NewResult(0, 1))
+		expectUser().WillReturnRows(userRows())
+		urs.mock.ExpectQuery(exportHistory).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(historyColumns).
+				AddRow(2, 5, 5, 1.0, "Quest", "good Quest", types.USUAL, types.SourceReferral, nil, nil, nil, nil, nil, nil, nil, created.Time, 5))
+		urs.mock.ExpectQuery(exportAttempts).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(attemptsColumns).AddRow(3, questId, false, roll, created.Time))
 		urs.mock.ExpectCommit()
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
+		export, err := urs.userRepository.ExportUser(context.Background(), userId)
 		t.Require().NoError(err)
+		t.Require().EqualValues(resExport, export)
 	})
 
-	t.WithNewStep("Postgres error create transaction execute", func(t provider.StepCtx) {
+	t.WithNewStep("Not deleted user without records execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectBegin().WillReturnError(testError)
+		expectUser().WillReturnRows(sqlxmock.NewRows(userColumns).AddRow(userId, "User", 0, "a1b2c3d4e5", nil, nil))
+		urs.mock.ExpectQuery(exportHistory).WithArgs(userId).WillReturnRows(sqlxmock.NewRows(historyColumns))
+		urs.mock.ExpectQuery(exportAttempts).WithArgs(userId).WillReturnRows(sqlxmock.NewRows(attemptsColumns))
+		urs.mock.ExpectCommit()
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
-		t.Require().ErrorIs(err, testError)
+		export, err := urs.userRepository.ExportUser(context.Background(), userId)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Export{
+			User:     User{ID: userId, Name: "User", ReferralCode: "a1b2c3d4e5"},
+			History:  []HistoryRecord{},
+			Attempts: []AttemptRecord{},
+		}, export)
 	})
 
-	t.WithNewStep("Postgres error on applyCost query execute", func(t provider.StepCtx) {
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectBegin()
-		urs.mock.ExpectQuery(applyCost).
-			WithArgs(user.ID, quest.Cost).
-			WillReturnError(testError)
+		expectUser().WillReturnRows(sqlxmock.NewRows(userColumns))
 		urs.mock.ExpectRollback()
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
-		t.Require().ErrorIs(err, testError)
+		_, err := urs.userRepository.ExportUser(context.Background(), userId)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
 	})
 
-	t.WithNewStep("No user found on applyCost query execute", func(t provider.StepCtx) {
+	t.WithNewStep("Postgres error for history execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		urs.mock.ExpectBegin()
-		urs.mock.ExpectQuery(applyCost).
-			WithArgs(user.ID, quest.Cost).
-			WillReturnRows(sqlxmock.NewRows(userColumns))
+		expectUser().WillReturnRows(userRows())
+		urs.mock.ExpectQuery(exportHistory).WithArgs(userId).WillReturnError(testError)
 		urs.mock.ExpectRollback()
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
-		t.Require().ErrorIs(err, ErrorUserNotFound)
+		_, err := urs.userRepository.ExportUser(context.Background(), userId)
+		t.Require().ErrorIs(err, testError)
 	})
 
-	t.WithNewStep("Postgres error on createHistory query execute", func(t provider.StepCtx) {
+	t.WithNewStep("Postgres error for attempts execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
+		expectUser().WillReturnRows(userRows())
+		urs.mock.ExpectQuery(exportHistory).WithArgs(userId).WillReturnRows(sqlxmock.NewRows(historyColumns))
+		urs.mock.ExpectQuery(exportAttempts).WithArgs(userId).WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.ExportUser(context.Background(), userId)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (urs *UserRepositorySuite) TestCompleteQuestFunction(t provider.T) {
+	t.Title("CompleteQuest function of User repository")
+	t.NewStep("Init test data")
+
+	userId := types.Id(1)
+
+	quest := &qr.Quest{
+		ID:             2,
+		Name:           "Quest",
+		Description:    "usual quest",
+		Cost:           15,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+		Cooldown:       time.Hour,
+	}
+
+	stagedQuest := &qr.Quest{
+		ID:             3,
+		Name:           "Staged",
+		Description:    "staged quest",
+		Cost:           20,
+		Type:           types.STAGED,
+		Steps:          []string{"first", "second"},
+		MaxCompletions: 1,
+	}
+
+	chainedQuest := &qr.Quest{
+		ID:             4,
+		Name:           "Chained",
+		Description:    "quest with prerequisites",
+		Cost:           10,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+		Prerequisites:  []types.Id{2, 3},
+	}
+
+	randomQuest := &qr.Quest{
+		ID:             5,
+		Name:           "Random",
+		Description:    "random quest",
+		Cost:           5,
+		Type:           types.RANDOM,
+		Steps:          []string{},
+		MaxCompletions: 0,
+		Probability:    0.25,
+		Pity:           3,
+	}
+
+	counterQuest := &qr.Quest{
+		ID:             6,
+		Name:           "Counter",
+		Description:    "counter quest",
+		Cost:           20,
+		Type:           types.COUNTER,
+		Steps:          []string{},
+		MaxCompletions: 0,
+		Target:         10,
+	}
+
+	streakQuest := &qr.Quest{
+		ID:             7,
+		Name:           "Streak",
+		Description:    "streak quest",
+		Type:           types.STREAK,
+		Steps:          []string{},
+		MaxCompletions: 0,
+		Milestones: []qr.Milestone{
+			{Days: 3, Reward: 15},
+			{Days: 7, Reward: 50},
+		},
+	}
+
+	teamQuest := &qr.Quest{
+		ID:          8,
+		Name:        "Team",
+		Description: "team quest",
+		Cost:        10,
+		Type:        types.TEAM,
+		Steps:       []string{},
+		Target:      3,
+		RewardSplit: types.SplitEqual,
+	}
+
+	fixedTeamQuest := *teamQuest
+	fixedTeamQuest.RewardSplit = types.SplitFixed
+
+	referralQuest := &qr.Quest{
+		ID:             10,
+		Name:           "Referral",
+		Description:    "quest of invited users",
+		Cost:           5,
+		Type:           types.USUAL,
+		Steps:          []string{},
+		MaxCompletions: 1,
+	}
+
+	referral := Referral{QuestId: referralQuest.ID, Bonus: 4}
+	referrerId := types.Id(11)
+
+	teamId := types.Id(9)
+	members := []types.Id{2, userId, 3}
+
+	// day of event in the evening, only its calendar date is used
+	day := time.Date(2024, 3, 2, 21, 30, 0, 0, time.UTC)
+
+	questColumns := []string{
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards",
+		"reward_split", "archived_at", "categories", "tags",
+	}
+
+	questRows := func(quest *qr.Quest) *sqlxmock.Rows {
+		prerequisites := pq.Int64Array{}
+		for _, id := range quest.Prerequisites {
+			prerequisites = append(prerequisites, int64(id))
+		}
+
+		milestoneDays, milestoneRewards := pq.Int64Array{}, pq.Int64Array{}
+		for _, milestone := range quest.Milestones {
+			milestoneDays = append(milestoneDays, int64(milestone.Days))
+			milestoneRewards = append(milestoneRewards, int64(milestone.Reward))
+		}
+
+		return sqlxmock.NewRows(questColumns).AddRow(
+			quest.ID, quest.Name, quest.Description, quest.Cost, quest.Type,
+			pq.StringArray(quest.Steps), quest.MaxCompletions, int64(quest.Cooldown/time.Second), prerequisites,
+			nil, nil, quest.Probability, quest.Pity, quest.Target, milestoneDays, milestoneRewards,
+			quest.RewardSplit, nil, pq.Int64Array{}, pq.StringArray{},
+		)
+	}
+
+	missingColumns := []string{
+		"missing",
+	}
+
+	attemptStatsColumns := []string{
+		"failures", "daily",
+	}
+
+	completionsColumns := []string{
+		"count", "elapsed",
+	}
+
+	progressColumns := []string{
+		"step", "updated",
+	}
+
+	idColumns := []string{
+		"id",
+	}
+
+	balanceColumns := []string{
+		"balance",
+	}
+
+	transactionColumns := []string{
+		"id", "created",
+	}
+
+	completions := &Completions{Count: 2, Elapsed: 1500 * time.Millisecond}
+
+	noCheck := func(*qr.Quest, *Completions) (*Attempt, error) { return nil, nil }
+
+	expectLocks := func(quest *qr.Quest) {
 		urs.mock.ExpectBegin()
-		urs.mock.ExpectQuery(applyCost).
-			WithArgs(user.ID, quest.Cost).
-			WillReturnRows(sqlxmock.NewRows(userColumns).AddRow(user.ID))
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(quest.ID).
+			WillReturnRows(questRows(quest))
+		if quest.Type == types.TEAM {
+			urs.mock.ExpectQuery(team.LockTeamQuery).
+				WithArgs(userId).
+				WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(teamId))
+			urs.mock.ExpectQuery(checkUser).
+				WithArgs(userId).
+				WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId))
+		} else if quest.ID == referral.QuestId {
+			urs.mock.ExpectQuery(lockReferral).
+				WithArgs(userId).
+				WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId).AddRow(referrerId))
+		} else {
+			urs.mock.ExpectQuery(lockUser).
+				WithArgs(userId).
+				WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId))
+		}
+		urs.mock.ExpectQuery(getCompletions).
+			WithArgs(userId, quest.ID).
+			WillReturnRows(sqlxmock.NewRows(completionsColumns).AddRow(completions.Count, 1.5))
+	}
+
+	multiplierColumns := []string{
+		"multiplier",
+	}
+
+	expectMultiplier := func(quest *qr.Quest, multiplier float64) {
+		urs.mock.ExpectQuery(boost.MultiplierQuery).
+			WithArgs(quest.ID, quest.Type, pq.Array([]int64{})).
+			WillReturnRows(sqlxmock.NewRows(multiplierColumns).AddRow(multiplier))
+	}
+
+	expectMemberAward := func(memberId types.Id, quest *qr.Quest, base types.Cost, multiplier float64) {
+		award := boost.Multiply(base, multiplier)
+		urs.mock.ExpectQuery(ledger.CreditQuery).
+			WithArgs(memberId, uint64(award)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(award))
+		urs.mock.ExpectQuery(ledger.CreateTransactionQuery).
+			WithArgs(memberId, ledger.Credit, uint64(award), ledger.QuestReward, quest.ID, nil, uint64(award)).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(1, time.Time{}))
+		urs.mock.ExpectExec(createHistory).
+			WithArgs(memberId, quest.ID, award, base, multiplier, quest.Name, quest.Description, quest.Type,
+				types.SourceQuest, nil).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		urs.mock.ExpectExec(leaderboard.AddScoreQuery).
+			WithArgs(memberId, award).
+			WillReturnResult(sqlxmock.NewResult(0, 3))
+	}
+
+	expectAward := func(quest *qr.Quest, award types.Cost) {
+		expectMultiplier(quest, 1)
+		expectMemberAward(userId, quest, award, 1)
+	}
+
+	expectBonus := func(id, counterpartyId types.Id) {
+		urs.mock.ExpectQuery(ledger.CreditQuery).
+			WithArgs(id, uint64(referral.Bonus)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(referral.Bonus))
+		urs.mock.ExpectQuery(ledger.CreateTransactionQuery).
+			WithArgs(id, ledger.Credit, uint64(referral.Bonus), ledger.ReferralBonus, referralQuest.ID, nil,
+				uint64(referral.Bonus)).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(1, time.Time{}))
 		urs.mock.ExpectExec(createHistory).
-			WithArgs(user.ID, quest.ID).
+			WithArgs(id, referralQuest.ID, referral.Bonus, referral.Bonus, 1.0, referralQuest.Name,
+				referralQuest.Description, referralQuest.Type, types.SourceReferral, &counterpartyId).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+	}
+
+	expectContribution := func(quest *qr.Quest, contributors uint32) {
+		urs.mock.ExpectQuery(team.ContributeQuery).
+			WithArgs(teamId, quest.ID, userId).
+			WillReturnRows(sqlxmock.NewRows(progressColumns).AddRow(time.Time{}, contributors))
+	}
+
+	contributorRows := func() *sqlxmock.Rows {
+		rows := sqlxmock.NewRows(idColumns)
+		for _, id := range members {
+			rows.AddRow(id)
+		}
+		return rows
+	}
+
+	expectPayees := func(quest *qr.Quest) {
+		urs.mock.ExpectQuery(team.TakeContributorsQuery).
+			WithArgs(teamId, quest.ID).
+			WillReturnRows(contributorRows())
+		urs.mock.ExpectQuery(lockPayees).
+			WithArgs(pq.Array(append([]types.Id{userId}, members...))).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId).AddRow(members[0]).AddRow(members[2]))
+	}
+
+	expectCost := func(quest *qr.Quest) {
+		expectAward(quest, quest.Cost)
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		expectCost(quest)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		var checkedQuest *qr.Quest
+		var checkedCompletions *Completions
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral,
+			func(quest *qr.Quest, completions *Completions) (*Attempt, error) {
+				checkedQuest, checkedCompletions = quest, completions
+				return nil, nil
+			},
+		)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: quest}, progress)
+		t.Require().EqualValues(quest, checkedQuest)
+		t.Require().EqualValues(completions, checkedCompletions)
+	})
+
+	t.WithNewStep("Correct quest with prerequisites execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(chainedQuest)
+		urs.mock.ExpectQuery(getMissingPrerequisites).
+			WithArgs(userId, pq.Array(chainedQuest.Prerequisites)).
+			WillReturnRows(sqlxmock.NewRows(missingColumns).AddRow("{3}"))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		var checkedCompletions *Completions
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, chainedQuest.ID, 1, day, referral,
+			func(_ *qr.Quest, completions *Completions) (*Attempt, error) {
+				checkedCompletions = completions
+				return nil, testError
+			},
+		)
+		t.Require().ErrorIs(err, testError)
+		t.Require().EqualValues(&Completions{
+			Count:                completions.Count,
+			Elapsed:              completions.Elapsed,
+			MissingPrerequisites: []types.Id{3},
+		}, checkedCompletions)
+	})
+
+	t.WithNewStep("Postgres error on getMissingPrerequisites query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(chainedQuest)
+		urs.mock.ExpectQuery(getMissingPrerequisites).
+			WithArgs(userId, pq.Array(chainedQuest.Prerequisites)).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, chainedQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Random quest failed attempt execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		roll := 0.75
+		expectLocks(randomQuest)
+		urs.mock.ExpectQuery(getAttemptStats).
+			WithArgs(userId, randomQuest.ID).
+			WillReturnRows(sqlxmock.NewRows(attemptStatsColumns).AddRow(2, 4))
+		urs.mock.ExpectExec(createAttempt).
+			WithArgs(userId, randomQuest.ID, false, &roll).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		var checkedCompletions *Completions
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, randomQuest.ID, 1, day, referral,
+			func(_ *qr.Quest, completions *Completions) (*Attempt, error) {
+				checkedCompletions = completions
+				return &Attempt{Success: false, Roll: &roll}, nil
+			},
+		)
+		t.Require().ErrorIs(err, ErrorAttemptFailed)
+		t.Require().EqualValues(&Completions{
+			Count:         completions.Count,
+			Elapsed:       completions.Elapsed,
+			Failures:      2,
+			DailyAttempts: 4,
+		}, checkedCompletions)
+	})
+
+	t.WithNewStep("Postgres error on createAttempt query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		roll := 0.75
+		expectLocks(randomQuest)
+		urs.mock.ExpectQuery(getAttemptStats).
+			WithArgs(userId, randomQuest.ID).
+			WillReturnRows(sqlxmock.NewRows(attemptStatsColumns).AddRow(0, 0))
+		urs.mock.ExpectExec(createAttempt).
+			WithArgs(userId, randomQuest.ID, false, &roll).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, randomQuest.ID, 1, day, referral,
+			func(*qr.Quest, *Completions) (*Attempt, error) { return &Attempt{Success: false, Roll: &roll}, nil },
+		)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Correct random quest guaranteed by pity execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(randomQuest)
+		urs.mock.ExpectQuery(getAttemptStats).
+			WithArgs(userId, randomQuest.ID).
+			WillReturnRows(sqlxmock.NewRows(attemptStatsColumns).AddRow(3, 3))
+		urs.mock.ExpectExec(createAttempt).
+			WithArgs(userId, randomQuest.ID, true, nil).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		expectCost(randomQuest)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, randomQuest.ID, 1, day, referral,
+			func(*qr.Quest, *Completions) (*Attempt, error) { return &Attempt{Success: true}, nil },
+		)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: randomQuest}, progress)
+	})
+
+	t.WithNewStep("Postgres error on getAttemptStats query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(randomQuest)
+		urs.mock.ExpectQuery(getAttemptStats).
+			WithArgs(userId, randomQuest.ID).
 			WillReturnError(testError)
 		urs.mock.ExpectRollback()
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, randomQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Check error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral,
+			func(*qr.Quest, *Completions) (*Attempt, error) { return nil, testError },
+		)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Correct staged quest intermediate step execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(stagedQuest)
+		urs.mock.ExpectQuery(applyStep).
+			WithArgs(userId, stagedQuest.ID, len(stagedQuest.Steps)).
+			WillReturnRows(sqlxmock.NewRows(progressColumns).AddRow(1, time.Time{}))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, stagedQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: stagedQuest, Step: 1}, progress)
+	})
+
+	t.WithNewStep("Correct staged quest last step execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(stagedQuest)
+		urs.mock.ExpectQuery(applyStep).
+			WithArgs(userId, stagedQuest.ID, len(stagedQuest.Steps)).
+			WillReturnRows(sqlxmock.NewRows(progressColumns).AddRow(2, time.Time{}))
+		expectCost(stagedQuest)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, stagedQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: stagedQuest, Step: 2}, progress)
+	})
+
+	t.WithNewStep("Postgres error create transaction execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
 		t.Require().ErrorIs(err, testError)
 	})
 
-	t.WithNewStep("No user found on createHistory query execute", func(t provider.StepCtx) {
+	t.WithNewStep("No user found on lockUser query execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		urs.mock.ExpectBegin()
-		urs.mock.ExpectQuery(applyCost).
-			WithArgs(user.ID, quest.Cost).
-			WillReturnRows(sqlxmock.NewRows(userColumns).AddRow(user.ID))
-		urs.mock.ExpectExec(createHistory).
-			WithArgs(user.ID, quest.ID).
-			WillReturnError(&pq.Error{Code: foreignKeyConflictCode, Constraint: userIdConstraintName})
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(quest.ID).
+			WillReturnRows(questRows(quest))
+		urs.mock.ExpectQuery(lockUser).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns))
 		urs.mock.ExpectRollback()
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
 		t.Require().ErrorIs(err, ErrorUserNotFound)
 	})
 
-	t.WithNewStep("No quest found on createHistory query execute", func(t provider.StepCtx) {
+	t.WithNewStep("Postgres error on lockUser query execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		urs.mock.ExpectBegin()
-		urs.mock.ExpectQuery(applyCost).
-			WithArgs(user.ID, quest.Cost).
-			WillReturnRows(sqlxmock.NewRows(userColumns).AddRow(user.ID))
-		urs.mock.ExpectExec(createHistory).
-			WithArgs(user.ID, quest.ID).
-			WillReturnError(&pq.Error{Code: foreignKeyConflictCode, Constraint: questIdConstraintName})
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(quest.ID).
+			WillReturnRows(questRows(quest))
+		urs.mock.ExpectQuery(lockUser).
+			WithArgs(userId).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("No quest found on lockQuest query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(quest.ID).
+			WillReturnRows(sqlxmock.NewRows(questColumns))
 		urs.mock.ExpectRollback()
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
 		t.Require().ErrorIs(err, qr.ErrorQuestNotFound)
 	})
 
-	t.WithNewStep("Quest already completed for user on createHistory query execute", func(t provider.StepCtx) {
+	t.WithNewStep("Postgres error on lockQuest query execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		urs.mock.ExpectBegin()
-		urs.mock.ExpectQuery(applyCost).
-			WithArgs(user.ID, quest.Cost).
-			WillReturnRows(sqlxmock.NewRows(userColumns).AddRow(user.ID))
-		urs.mock.ExpectExec(createHistory).
-			WithArgs(user.ID, quest.ID).
-			WillReturnError(&pq.Error{Code: uniqueConflictCode, Constraint: uniqueConstraintName})
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(quest.ID).
+			WillReturnError(testError)
 		urs.mock.ExpectRollback()
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
-		t.Require().ErrorIs(err, ErrorUserAlreadyCompleteQuest)
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
 	})
 
-	t.WithNewStep("Postgres error commit transaction execute", func(t provider.StepCtx) {
+	t.WithNewStep("Postgres error on getCompletions query execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
 		urs.mock.ExpectBegin()
-		urs.mock.ExpectQuery(applyCost).
-			WithArgs(user.ID, quest.Cost).
-			WillReturnRows(sqlxmock.NewRows(userColumns).AddRow(user.ID))
-		urs.mock.ExpectExec(createHistory).
-			WithArgs(user.ID, quest.ID).
-			WillReturnResult(sqlxmock.NewResult(0, 1))
-		urs.mock.ExpectCommit().WillReturnError(testError)
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(quest.ID).
+			WillReturnRows(questRows(quest))
+		urs.mock.ExpectQuery(lockUser).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId))
+		urs.mock.ExpectQuery(getCompletions).
+			WithArgs(userId, quest.ID).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Correct counter quest intermediate amount execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(counterQuest)
+		urs.mock.ExpectQuery(applyCounter).
+			WithArgs(userId, counterQuest.ID, uint32(4), counterQuest.Target).
+			WillReturnRows(sqlxmock.NewRows(progressColumns).AddRow(4, time.Time{}))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, counterQuest.ID, 4, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: counterQuest, Step: 4}, progress)
+	})
+
+	t.WithNewStep("Correct counter quest target reached execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(counterQuest)
+		urs.mock.ExpectQuery(applyCounter).
+			WithArgs(userId, counterQuest.ID, uint32(7), counterQuest.Target).
+			WillReturnRows(sqlxmock.NewRows(progressColumns).AddRow(10, time.Time{}))
+		expectCost(counterQuest)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, counterQuest.ID, 7, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: counterQuest, Step: 10}, progress)
+	})
+
+	t.WithNewStep("Correct team quest contribution execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(teamQuest)
+		expectContribution(teamQuest, 2)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, teamQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: teamQuest, Step: 2}, progress)
+	})
+
+	t.WithNewStep("Correct team quest equal split execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(teamQuest)
+		expectContribution(teamQuest, 3)
+		expectMultiplier(teamQuest, 1)
+		expectPayees(teamQuest)
+		// remainder of 10 between 3 contributors goes to the earliest one
+		expectMemberAward(members[0], teamQuest, 4, 1)
+		expectMemberAward(members[1], teamQuest, 3, 1)
+		expectMemberAward(members[2], teamQuest, 3, 1)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, teamQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: teamQuest, Step: 3}, progress)
+	})
+
+	t.WithNewStep("Correct boosted team quest equal split execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(teamQuest)
+		expectContribution(teamQuest, 3)
+		expectMultiplier(teamQuest, 1.5)
+		expectPayees(teamQuest)
+		// multiplier is applied to share of every contributor
+		expectMemberAward(members[0], teamQuest, 4, 1.5)
+		expectMemberAward(members[1], teamQuest, 3, 1.5)
+		expectMemberAward(members[2], teamQuest, 3, 1.5)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, teamQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: teamQuest, Step: 3}, progress)
+	})
+
+	t.WithNewStep("Correct team quest fixed split execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(&fixedTeamQuest)
+		expectContribution(&fixedTeamQuest, 3)
+		expectMultiplier(&fixedTeamQuest, 1)
+		expectPayees(&fixedTeamQuest)
+		for _, id := range members {
+			expectMemberAward(id, &fixedTeamQuest, fixedTeamQuest.Cost, 1)
+		}
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, fixedTeamQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: &fixedTeamQuest, Step: 3}, progress)
+	})
+
+	t.WithNewStep("Correct referral quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(referralQuest)
+		expectCost(referralQuest)
+		urs.mock.ExpectQuery(claimReferral).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(referrerId))
+		expectBonus(userId, referrerId)
+		expectBonus(referrerId, userId)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, referralQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: referralQuest}, progress)
+	})
+
+	t.WithNewStep("Referral bonus already paid execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(referralQuest)
+		expectCost(referralQuest)
+		urs.mock.ExpectQuery(claimReferral).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, referralQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Deleted referrer on referral quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(referralQuest)
+		expectCost(referralQuest)
+		urs.mock.ExpectQuery(claimReferral).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(referrerId))
+		expectBonus(userId, referrerId)
+		urs.mock.ExpectQuery(ledger.CreditQuery).
+			WithArgs(referrerId, uint64(referral.Bonus)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, referralQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Postgres error on claimReferral query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(referralQuest)
+		expectCost(referralQuest)
+		urs.mock.ExpectQuery(claimReferral).
+			WithArgs(userId).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, referralQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("No user found on lockReferral query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(referralQuest.ID).
+			WillReturnRows(questRows(referralQuest))
+		urs.mock.ExpectQuery(lockReferral).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(referrerId))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, referralQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("User not in team on team quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(teamQuest.ID).
+			WillReturnRows(questRows(teamQuest))
+		urs.mock.ExpectQuery(team.LockTeamQuery).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, teamQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, ErrorUserNotInTeam)
+	})
+
+	t.WithNewStep("User not found on team quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockQuest).
+			WithArgs(teamQuest.ID).
+			WillReturnRows(questRows(teamQuest))
+		urs.mock.ExpectQuery(team.LockTeamQuery).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(teamId))
+		urs.mock.ExpectQuery(checkUser).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, teamQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("User already contributed to team quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(teamQuest)
+		urs.mock.ExpectQuery(team.ContributeQuery).
+			WithArgs(teamId, teamQuest.ID, userId).
+			WillReturnRows(sqlxmock.NewRows(progressColumns))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, teamQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, ErrorAlreadyContributed)
+	})
+
+	t.WithNewStep("Postgres error on TakeContributors query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(teamQuest)
+		expectContribution(teamQuest, 3)
+		expectMultiplier(teamQuest, 1)
+		urs.mock.ExpectQuery(team.TakeContributorsQuery).
+			WithArgs(teamId, teamQuest.ID).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, teamQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on lock payees query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(teamQuest)
+		expectContribution(teamQuest, 3)
+		expectMultiplier(teamQuest, 1)
+		urs.mock.ExpectQuery(team.TakeContributorsQuery).
+			WithArgs(teamId, teamQuest.ID).
+			WillReturnRows(contributorRows())
+		urs.mock.ExpectQuery(lockPayees).
+			WithArgs(pq.Array(append([]types.Id{userId}, members...))).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, teamQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Correct streak quest without milestone execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(streakQuest)
+		urs.mock.ExpectQuery(applyStreak).
+			WithArgs(userId, streakQuest.ID, "2024-03-02", uint32(7)).
+			WillReturnRows(sqlxmock.NewRows(progressColumns).AddRow(2, time.Time{}))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, streakQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: streakQuest, Step: 2}, progress)
+	})
+
+	t.WithNewStep("Correct streak quest milestone reached execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(streakQuest)
+		urs.mock.ExpectQuery(applyStreak).
+			WithArgs(userId, streakQuest.ID, "2024-03-02", uint32(7)).
+			WillReturnRows(sqlxmock.NewRows(progressColumns).AddRow(3, time.Time{}))
+		expectAward(streakQuest, 15)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, streakQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: streakQuest, Step: 3}, progress)
+	})
+
+	t.WithNewStep("Streak already extended today execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(streakQuest)
+		urs.mock.ExpectQuery(applyStreak).
+			WithArgs(userId, streakQuest.ID, "2024-03-02", uint32(7)).
+			WillReturnRows(sqlxmock.NewRows(progressColumns))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, streakQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, ErrorStreakAlreadyExtended)
+	})
+
+	t.WithNewStep("Postgres error on applyStreak query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(streakQuest)
+		urs.mock.ExpectQuery(applyStreak).
+			WithArgs(userId, streakQuest.ID, "2024-03-02", uint32(7)).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, streakQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on applyCounter query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(counterQuest)
+		urs.mock.ExpectQuery(applyCounter).
+			WithArgs(userId, counterQuest.ID, uint32(1), counterQuest.Target).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, counterQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on applyStep query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(stagedQuest)
+		urs.mock.ExpectQuery(applyStep).
+			WithArgs(userId, stagedQuest.ID, len(stagedQuest.Steps)).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, stagedQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("No quest found on applyStep query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(stagedQuest)
+		urs.mock.ExpectQuery(applyStep).
+			WithArgs(userId, stagedQuest.ID, len(stagedQuest.Steps)).
+			WillReturnError(&pq.Error{Code: foreignKeyConflictCode, Constraint: progressQuestIdConstraintName})
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, stagedQuest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, qr.ErrorQuestNotFound)
+	})
+
+	t.WithNewStep("Correct boosted quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		expectMultiplier(quest, 2)
+		expectMemberAward(userId, quest, quest.Cost, 2)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&Progress{Quest: quest}, progress)
+	})
+
+	t.WithNewStep("Correct boosted referral quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(referralQuest)
+		expectMultiplier(referralQuest, 2)
+		expectMemberAward(userId, referralQuest, referralQuest.Cost, 2)
+		urs.mock.ExpectQuery(claimReferral).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(referrerId))
+		// referral bonuses are not boosted
+		expectBonus(userId, referrerId)
+		expectBonus(referrerId, userId)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, referralQuest.ID, 1, day, referral, noCheck)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Postgres error on multiplier query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		urs.mock.ExpectQuery(boost.MultiplierQuery).
+			WithArgs(quest.ID, quest.Type, pq.Array([]int64{})).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on credit query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		expectMultiplier(quest, 1)
+		urs.mock.ExpectQuery(ledger.CreditQuery).
+			WithArgs(userId, uint64(quest.Cost)).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("No user found on credit query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		expectMultiplier(quest, 1)
+		urs.mock.ExpectQuery(ledger.CreditQuery).
+			WithArgs(userId, uint64(quest.Cost)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("No quest found on createHistory query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		expectMultiplier(quest, 1)
+		urs.mock.ExpectQuery(ledger.CreditQuery).
+			WithArgs(userId, uint64(quest.Cost)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(quest.Cost))
+		urs.mock.ExpectQuery(ledger.CreateTransactionQuery).
+			WithArgs(userId, ledger.Credit, uint64(quest.Cost), ledger.QuestReward, quest.ID, nil, uint64(quest.Cost)).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(1, time.Time{}))
+		urs.mock.ExpectExec(createHistory).
+			WithArgs(userId, quest.ID, quest.Cost, quest.Cost, 1.0, quest.Name, quest.Description, quest.Type,
+				types.SourceQuest, nil).
+			WillReturnError(&pq.Error{Code: foreignKeyConflictCode, Constraint: questIdConstraintName})
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, qr.ErrorQuestNotFound)
+	})
+
+	t.WithNewStep("Postgres error on add score query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		expectMultiplier(quest, 1)
+		urs.mock.ExpectQuery(ledger.CreditQuery).
+			WithArgs(userId, uint64(quest.Cost)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(quest.Cost))
+		urs.mock.ExpectQuery(ledger.CreateTransactionQuery).
+			WithArgs(userId, ledger.Credit, uint64(quest.Cost), ledger.QuestReward, quest.ID, nil, uint64(quest.Cost)).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(1, time.Time{}))
+		urs.mock.ExpectExec(createHistory).
+			WithArgs(userId, quest.ID, quest.Cost, quest.Cost, 1.0, quest.Name, quest.Description, quest.Type,
+				types.SourceQuest, nil).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		urs.mock.ExpectExec(leaderboard.AddScoreQuery).
+			WithArgs(userId, quest.Cost).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error commit transaction execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectLocks(quest)
+		expectCost(quest)
+		urs.mock.ExpectCommit().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.CompleteQuest(context.Background(), userId, quest.ID, 1, day, referral, noCheck)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (urs *UserRepositorySuite) TestGetAvailableQuestsFunction(t provider.T) {
+	t.Title("GetAvailableQuests function of User repository")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	quests := []qr.Quest{
+		{
+			ID:             2,
+			Name:           "Quest",
+			Description:    "usual quest",
+			Cost:           15,
+			Type:           types.USUAL,
+			Steps:          []string{},
+			MaxCompletions: 1,
+			Cooldown:       time.Hour,
+			Probability:    0.5,
+			RewardSplit:    types.SplitEqual,
+		},
+		{
+			ID:             4,
+			Name:           "Chained",
+			Description:    "quest with prerequisites",
+			Cost:           10,
+			Type:           types.USUAL,
+			Steps:          []string{},
+			MaxCompletions: 0,
+			Prerequisites:  []types.Id{2},
+			Probability:    0.5,
+			RewardSplit:    types.SplitEqual,
+		},
+	}
+
+	questColumns := []string{
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards",
+		"reward_split", "archived_at", "categories", "tags",
+	}
+
+	questRows := func() *sqlxmock.Rows {
+		return sqlxmock.NewRows(questColumns).
+			AddRow(2, "Quest", "usual quest", 15, types.USUAL, "{}", 1, 3600, "{}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}").
+			AddRow(4, "Chained", "quest with prerequisites", 10, types.USUAL, "{}", 0, 0, "{2}", nil, nil, 0.5, 0, 0, "{}", "{}", "equal", nil, "{}", "{}")
+	}
+
+	condition := strings.ReplaceAll(availableQuest, "%[1]s", "$1")
+
+	query := &AvailableQuestsQuery{
+		UserId: userId,
+		Limit:  page.DefaultLimit,
+	}
+	sqlQuery := getAvailableQuests + " WHERE " + condition + " ORDER BY id ASC LIMIT $2"
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(questRows())
+
+		t.NewStep("Check result")
+		res, err := urs.userRepository.GetAvailableQuests(context.Background(), query)
+		t.Require().NoError(err)
+		t.Require().EqualValues(quests, res)
+	})
+
+	t.WithNewStep("Correct next page execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		nextQuery := &AvailableQuestsQuery{
+			UserId: userId,
+			After:  &page.Cursor{Sort: "id", Order: page.Asc, ID: 1},
+			Limit:  page.DefaultLimit,
+		}
+		urs.mock.ExpectQuery(getAvailableQuests+" WHERE "+condition+" AND id > $2 ORDER BY id ASC LIMIT $3").
+			WithArgs(userId, types.Id(1), nextQuery.Limit).
+			WillReturnRows(questRows())
+
+		t.NewStep("Check result")
+		res, err := urs.userRepository.GetAvailableQuests(context.Background(), nextQuery)
+		t.Require().NoError(err)
+		t.Require().EqualValues(quests, res)
+	})
+
+	t.WithNewStep("Unknown user execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnRows(sqlxmock.NewRows(questColumns))
+		urs.mock.ExpectQuery(hasUser).WithArgs(userId).WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetAvailableQuests(context.Background(), query)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Postgres error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetAvailableQuests(context.Background(), query)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Scan error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(sqlQuery).WithArgs(userId, query.Limit).
+			WillReturnRows(questRows().AddRow(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetAvailableQuests(context.Background(), query)
+		t.Require().Error(err)
+	})
+}
+
+func (urs *UserRepositorySuite) TestRevokeQuestFunction(t provider.T) {
+	t.Title("RevokeQuest function of User repository")
+	t.NewStep("Init test data")
+
+	userId := types.Id(1)
+	questId := types.Id(2)
+	referrerId := types.Id(3)
+	reason := "fraud"
+	referral := Referral{QuestId: 7, Bonus: 5}
+	referralOfQuest := Referral{QuestId: questId, Bonus: 5}
+	completed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	resRevocation := &Revocation{
+		ID:      4,
+		QuestId: questId,
+		Snapshot: QuestSnapshot{
+			Name:        "Quest",
+			Description: "usual quest",
+			Type:        types.USUAL,
+		},
+		Award:     15,
+		Completed: pkgtime.FormattedTime{Time: completed},
+		Reason:    reason,
+		Transaction: ledger.Transaction{
+			ID:      3,
+			UserId:  userId,
+			Kind:    ledger.Debit,
+			Amount:  15,
+			Reason:  ledger.QuestRevoke,
+			QuestId: &questId,
+			Balance: 5,
+			Created: pkgtime.FormattedTime{Time: created},
+		},
+		Created: pkgtime.FormattedTime{Time: created},
+	}
+
+	idColumns := []string{
+		"id",
+	}
+
+	completionColumns := []string{
+		"award", "quest_name", "quest_description", "quest_type", "created",
+	}
+
+	balanceColumns := []string{
+		"balance",
+	}
+
+	transactionColumns := []string{
+		"id", "created",
+	}
+
+	completionsColumns := []string{
+		"count", "elapsed",
+	}
+
+	bonusColumns := []string{
+		"user_id", "award", "quest_name", "quest_description", "quest_type",
+	}
+
+	expectClawback := func(amount uint64, balance int64) {
+		urs.mock.ExpectQuery(ledger.CreateTransactionQuery).
+			WithArgs(userId, ledger.Debit, amount, ledger.QuestRevoke, questId, nil, balance).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(resRevocation.Transaction.ID, created))
+	}
+
+	expectRevocation := func(amount uint64, balance int64) *sqlxmock.ExpectedQuery {
+		return urs.mock.ExpectQuery(createRevokeHistory).
+			WithArgs(userId, questId, amount, resRevocation.Snapshot.Name, resRevocation.Snapshot.Description,
+				resRevocation.Snapshot.Type, reason, balance)
+	}
+
+	expectCompletion := func() {
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockUser).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId))
+		urs.mock.ExpectQuery(revokeLastCompletion).
+			WithArgs(userId, questId).
+			WillReturnRows(sqlxmock.NewRows(completionColumns).AddRow(
+				resRevocation.Award, resRevocation.Snapshot.Name, resRevocation.Snapshot.Description,
+				resRevocation.Snapshot.Type, completed))
+	}
+
+	expectSubtract := func(amount types.Cost) *sqlxmock.ExpectedExec {
+		return urs.mock.ExpectExec(leaderboard.SubtractScoreQuery).
+			WithArgs(userId, amount, completed)
+	}
+
+	expectDebit := func() {
+		urs.mock.ExpectQuery(ledger.DebitQuery).
+			WithArgs(userId, uint64(15)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(5))
+		expectClawback(15, 5)
+		expectRevocation(15, 5).WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(resRevocation.ID, created))
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectCompletion()
+		expectDebit()
+		expectSubtract(15).WillReturnResult(sqlxmock.NewResult(0, 3))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		revocation, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().NoError(err)
+		t.Require().EqualValues(resRevocation, revocation)
+	})
+
+	t.WithNewStep("Correct partial clawback execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectCompletion()
+		urs.mock.ExpectQuery(ledger.GetBalanceQuery).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(10))
+		urs.mock.ExpectQuery(ledger.RevokeQuery).
+			WithArgs(userId, uint64(10)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(0))
+		expectClawback(10, 0)
+		expectRevocation(10, 0).WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(resRevocation.ID, created))
+		expectSubtract(15).WillReturnResult(sqlxmock.NewResult(0, 3))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		revocation, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokePartial,
+			referral)
+		t.Require().NoError(err)
+		t.Require().EqualValues(resRevocation.Award, revocation.Award)
+		t.Require().EqualValues(10, revocation.Transaction.Amount)
+		t.Require().EqualValues(0, revocation.Transaction.Balance)
+	})
+
+	t.WithNewStep("Correct negative balance execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectCompletion()
+		urs.mock.ExpectQuery(ledger.RevokeQuery).
+			WithArgs(userId, uint64(15)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(-5))
+		expectClawback(15, -5)
+		expectRevocation(15, -5).WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(resRevocation.ID, created))
+		expectSubtract(15).WillReturnResult(sqlxmock.NewResult(0, 3))
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		revocation, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeNegative,
+			referral)
+		t.Require().NoError(err)
+		t.Require().EqualValues(-5, revocation.Transaction.Balance)
+	})
+
+	t.WithNewStep("Insufficient funds execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectCompletion()
+		urs.mock.ExpectQuery(ledger.DebitQuery).
+			WithArgs(userId, uint64(15)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns))
+		urs.mock.ExpectQuery(ledger.GetBalanceQuery).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(10))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, ledger.ErrorInsufficientFunds)
+	})
+
+	t.WithNewStep("User not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockUser).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Completion not found execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockUser).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId))
+		urs.mock.ExpectQuery(revokeLastCompletion).
+			WithArgs(userId, questId).
+			WillReturnRows(sqlxmock.NewRows(completionColumns))
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, ErrorCompletionNotFound)
+	})
+
+	t.WithNewStep("Postgres error on lock user query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockUser).
+			WithArgs(userId).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on revoke completion query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockUser).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId))
+		urs.mock.ExpectQuery(revokeLastCompletion).
+			WithArgs(userId, questId).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on subtract score query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectCompletion()
+		expectDebit()
+		expectSubtract(15).WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Postgres error on create revoke history query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectCompletion()
+		urs.mock.ExpectQuery(ledger.DebitQuery).
+			WithArgs(userId, uint64(15)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(5))
+		expectClawback(15, 5)
+		expectRevocation(15, 5).WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	expectReferralCompletion := func(count uint64) {
+		urs.mock.ExpectBegin()
+		urs.mock.ExpectQuery(lockReferrer).
+			WithArgs(userId).
+			WillReturnRows(sqlxmock.NewRows(idColumns).AddRow(userId).AddRow(referrerId))
+		urs.mock.ExpectQuery(revokeLastCompletion).
+			WithArgs(userId, questId).
+			WillReturnRows(sqlxmock.NewRows(completionColumns).AddRow(
+				resRevocation.Award, resRevocation.Snapshot.Name, resRevocation.Snapshot.Description,
+				resRevocation.Snapshot.Type, completed))
+		expectDebit()
+		expectSubtract(15).WillReturnResult(sqlxmock.NewResult(0, 3))
+		urs.mock.ExpectQuery(getCompletions).
+			WithArgs(userId, questId).
+			WillReturnRows(sqlxmock.NewRows(completionsColumns).AddRow(count, 0))
+	}
+
+	expectBonusClawback := func(id types.Id) {
+		urs.mock.ExpectQuery(ledger.DebitQuery).
+			WithArgs(id, uint64(referralOfQuest.Bonus)).
+			WillReturnRows(sqlxmock.NewRows(balanceColumns).AddRow(0))
+		urs.mock.ExpectQuery(ledger.CreateTransactionQuery).
+			WithArgs(id, ledger.Debit, uint64(referralOfQuest.Bonus), ledger.ReferralRevoke, questId, nil, 0).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(5, created))
+		urs.mock.ExpectQuery(createRevokeHistory).
+			WithArgs(id, questId, uint64(referralOfQuest.Bonus), resRevocation.Snapshot.Name,
+				resRevocation.Snapshot.Description, resRevocation.Snapshot.Type, reason, 0).
+			WillReturnRows(sqlxmock.NewRows(transactionColumns).AddRow(6, created))
+	}
+
+	bonusRows := func() *sqlxmock.Rows {
+		rows := sqlxmock.NewRows(bonusColumns)
+		for _, id := range []types.Id{userId, referrerId} {
+			rows.AddRow(id, referralOfQuest.Bonus, resRevocation.Snapshot.Name, resRevocation.Snapshot.Description,
+				resRevocation.Snapshot.Type)
+		}
+		return rows
+	}
+
+	t.WithNewStep("Correct referral quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectReferralCompletion(0)
+		urs.mock.ExpectQuery(revokeReferralBonuses).
+			WithArgs(userId, questId).
+			WillReturnRows(bonusRows())
+		expectBonusClawback(userId)
+		expectBonusClawback(referrerId)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		revocation, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason,
+			ledger.RevokeReject, referralOfQuest)
+		t.Require().NoError(err)
+		t.Require().EqualValues(resRevocation, revocation)
+	})
+
+	t.WithNewStep("Correct referral quest with remaining completion execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectReferralCompletion(1)
+		urs.mock.ExpectCommit()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason,
+			ledger.RevokeReject, referralOfQuest)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Postgres error on revoke referral bonuses query execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectReferralCompletion(0)
+		urs.mock.ExpectQuery(revokeReferralBonuses).
+			WithArgs(userId, questId).
+			WillReturnError(testError)
+		urs.mock.ExpectRollback()
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason,
+			ledger.RevokeReject, referralOfQuest)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Begin error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectBegin().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Commit error execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		expectCompletion()
+		expectDebit()
+		expectSubtract(15).WillReturnResult(sqlxmock.NewResult(0, 3))
+		urs.mock.ExpectCommit().WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.RevokeQuest(context.Background(), userId, questId, reason, ledger.RevokeReject,
+			referral)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (urs *UserRepositorySuite) TestGetProgressFunction(t provider.T) {
+	t.Title("GetProgress function of User repository")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+
+	progressColumns := []string{
+		"id", "name", "description", "cost", "type", "steps", "max_completions", "cooldown", "prerequisites",
+		"starts_at", "ends_at", "probability", "pity", "target", "milestone_days", "milestone_rewards",
+		"reward_split", "archived_at", "categories", "tags",
+		"step", "updated",
+	}
+
+	resProgress := []Progress{
+		{
+			Quest: &qr.Quest{
+				ID:          1,
+				Name:        "Quest",
+				Description: "staged quest",
+				Cost:        10,
+				Type:        types.STAGED,
+				Steps:       []string{"first", "second"},
+				RewardSplit: types.SplitEqual,
+			},
+			Step: 1,
+		},
+	}
+
+	progressRows := func() *sqlxmock.Rows {
+		return sqlxmock.NewRows(progressColumns).
+			AddRow(resProgress[0].Quest.ID, resProgress[0].Quest.Name, resProgress[0].Quest.Description,
+				resProgress[0].Quest.Cost, resProgress[0].Quest.Type, "{first,second}", 0, 0, "{}",
+				nil, nil, 0.0, 0, resProgress[0].Quest.Target, "{}", "{}", "equal", nil, "{}", "{}",
+				resProgress[0].Step, resProgress[0].Updated.Time)
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getProgress).WithArgs(userId).WillReturnRows(progressRows())
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.GetProgress(context.Background(), userId)
+		t.Require().NoError(err)
+		t.Require().EqualValues(resProgress, progress)
+	})
+
+	t.WithNewStep("Empty progress execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getProgress).WithArgs(userId).WillReturnRows(sqlxmock.NewRows(progressColumns))
+		urs.mock.ExpectQuery(hasUser).WithArgs(userId).WillReturnRows(sqlxmock.NewRows([]string{"id"}).AddRow(userId))
+
+		t.NewStep("Check result")
+		progress, err := urs.userRepository.GetProgress(context.Background(), userId)
+		t.Require().NoError(err)
+		t.Require().Empty(progress)
+	})
+
+	t.WithNewStep("Unknown user execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getProgress).WithArgs(userId).WillReturnRows(sqlxmock.NewRows(progressColumns))
+		urs.mock.ExpectQuery(hasUser).WithArgs(userId).WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetProgress(context.Background(), userId)
+		t.Require().ErrorIs(err, ErrorUserNotFound)
+	})
+
+	t.WithNewStep("Postgres error query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getProgress).WithArgs(userId).WillReturnError(testError)
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetProgress(context.Background(), userId)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Rows error query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getProgress).WithArgs(userId).
+			WillReturnRows(progressRows().
+				AddRow(1, "", "", 1, "", "{}", 0, 0, "{}", nil, nil, 0.0, 0, 0, "{}", "{}", "equal", nil, "{}", "{}", 1, time.Time{}).
+				RowError(1, testError))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetProgress(context.Background(), userId)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Incorrect field in row of getProgress query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getProgress).WithArgs(userId).
+			WillReturnRows(progressRows().AddRow(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1))
+
+		t.NewStep("Check result")
+		_, err := urs.userRepository.GetProgress(context.Background(), userId)
+		t.Require().Error(err)
+	})
+
+	t.WithNewStep("Rows close error on getProgress query", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		urs.mock.ExpectQuery(getProgress).WithArgs(userId).WillReturnRows(progressRows().CloseError(testError))
 
 		t.NewStep("Check result")
-		err := urs.userRepository.ApplyCost(user, quest)
+		_, err := urs.userRepository.GetProgress(context.Background(), userId)
 		t.Require().ErrorIs(err, testError)
 	})
 }
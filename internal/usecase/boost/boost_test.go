@@ -0,0 +1,211 @@
+package boost
+
+import (
+	"context"
+	"testing"
+	stdtime "time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	br "vk_quests/internal/repository/boost"
+	mrb "vk_quests/internal/repository/boost/mocks"
+)
+
+var testError = errors.New("test error")
+
+type BoostUsecaseSuite struct {
+	suite.Suite
+	boostUsecase *BoostUsecase
+	mockBoost    *mrb.BoostRepository
+	gmc          *gomock.Controller
+}
+
+func (bus *BoostUsecaseSuite) BeforeEach(t provider.T) {
+	bus.gmc = gomock.NewController(t)
+	bus.mockBoost = mrb.NewBoostRepository(bus.gmc)
+	bus.boostUsecase = NewBoostUsecase(bus.mockBoost)
+}
+
+func (bus *BoostUsecaseSuite) AfterEach(t provider.T) {
+	bus.gmc.Finish()
+}
+
+var (
+	startsAt = time.FormattedTime{Time: stdtime.Date(2024, 3, 2, 0, 0, 0, 0, stdtime.UTC)}
+	endsAt   = time.FormattedTime{Time: stdtime.Date(2024, 3, 4, 0, 0, 0, 0, stdtime.UTC)}
+)
+
+func (bus *BoostUsecaseSuite) TestCreateBoostFunction(t provider.T) {
+	t.Title("CreateBoost function of boost usecase")
+	t.NewStep("Init test data")
+	tp := types.USUAL
+	boost := &Boost{
+		Name:       "Double points weekend",
+		Multiplier: 2,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+		QuestType:  &tp,
+	}
+	repositoryBoost := &br.Boost{
+		Name:       boost.Name,
+		Multiplier: boost.Multiplier,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+		QuestType:  &tp,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		createdBoost := *repositoryBoost
+		createdBoost.ID = 1
+		bus.mockBoost.EXPECT().CreateBoost(context.Background(), repositoryBoost).Return(&createdBoost, nil).Times(1)
+
+		t.NewStep("Check result")
+		bst, err := bus.boostUsecase.CreateBoost(context.Background(), boost)
+		t.Require().NoError(err)
+		expected := *boost
+		expected.ID = 1
+		t.Require().Equal(&expected, bst)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().CreateBoost(context.Background(), repositoryBoost).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := bus.boostUsecase.CreateBoost(context.Background(), boost)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (bus *BoostUsecaseSuite) TestDeleteBoostFunction(t provider.T) {
+	t.Title("DeleteBoost function of boost usecase")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().DeleteBoost(context.Background(), id).Return(nil).Times(1)
+
+		t.NewStep("Check result")
+		t.Require().NoError(bus.boostUsecase.DeleteBoost(context.Background(), id))
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().DeleteBoost(context.Background(), id).Return(testError).Times(1)
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(bus.boostUsecase.DeleteBoost(context.Background(), id), testError)
+	})
+}
+
+func (bus *BoostUsecaseSuite) TestUpdateBoostFunction(t provider.T) {
+	t.Title("UpdateBoost function of boost usecase")
+	t.NewStep("Init test data")
+	multiplier := 3.0
+	boost := &Boost{
+		ID:         1,
+		Name:       "Triple points weekend",
+		Multiplier: multiplier,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+	}
+	updateBoost := &UpdateBoost{Multiplier: &multiplier}
+	repositoryUpdateBoost := &br.UpdateBoost{ID: boost.ID, Multiplier: &multiplier}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().UpdateBoost(context.Background(), repositoryUpdateBoost).
+			Return(&br.Boost{ID: boost.ID, Name: boost.Name, Multiplier: multiplier, StartsAt: startsAt, EndsAt: endsAt}, nil).
+			Times(1)
+
+		t.NewStep("Check result")
+		bst, err := bus.boostUsecase.UpdateBoost(context.Background(), boost.ID, updateBoost)
+		t.Require().NoError(err)
+		t.Require().Equal(boost, bst)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().UpdateBoost(context.Background(), repositoryUpdateBoost).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := bus.boostUsecase.UpdateBoost(context.Background(), boost.ID, updateBoost)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (bus *BoostUsecaseSuite) TestGetBoostsFunction(t provider.T) {
+	t.Title("GetBoosts function of boost usecase")
+	t.NewStep("Init test data")
+	questId := types.Id(5)
+	repositoryBoosts := []br.Boost{
+		{ID: 1, Name: "Double points weekend", Multiplier: 2, StartsAt: startsAt, EndsAt: endsAt},
+		{ID: 2, Name: "Quest of the day", Multiplier: 1.5, StartsAt: startsAt, EndsAt: endsAt, QuestId: &questId},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().GetBoosts(context.Background()).Return(repositoryBoosts, nil).Times(1)
+
+		t.NewStep("Check result")
+		boosts, err := bus.boostUsecase.GetBoosts(context.Background())
+		t.Require().NoError(err)
+		t.Require().Equal([]Boost{
+			{ID: 1, Name: "Double points weekend", Multiplier: 2, StartsAt: startsAt, EndsAt: endsAt},
+			{ID: 2, Name: "Quest of the day", Multiplier: 1.5, StartsAt: startsAt, EndsAt: endsAt, QuestId: &questId},
+		}, boosts)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().GetBoosts(context.Background()).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := bus.boostUsecase.GetBoosts(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (bus *BoostUsecaseSuite) TestGetBoostFunction(t provider.T) {
+	t.Title("GetBoost function of boost usecase")
+	t.NewStep("Init test data")
+	categoryId := types.Id(2)
+	boost := &Boost{
+		ID:         1,
+		Name:       "Daily boost",
+		Multiplier: 1.5,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+		CategoryId: &categoryId,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().GetBoost(context.Background(), boost.ID).Return(boost.ToRepBoost(), nil).Times(1)
+
+		t.NewStep("Check result")
+		bst, err := bus.boostUsecase.GetBoost(context.Background(), boost.ID)
+		t.Require().NoError(err)
+		t.Require().Equal(boost, bst)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		bus.mockBoost.EXPECT().GetBoost(context.Background(), boost.ID).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := bus.boostUsecase.GetBoost(context.Background(), boost.ID)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func TestRunBoostUsecaseSuite(t *testing.T) {
+	suite.RunSuite(t, new(BoostUsecaseSuite))
+}
@@ -0,0 +1,17 @@
+package boost
+
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+)
+
+//go:generate mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=BoostUsecase . Usecase
+
+type Usecase interface {
+	CreateBoost(ctx context.Context, boost *Boost) (*Boost, error)
+	DeleteBoost(ctx context.Context, id types.Id) error
+	UpdateBoost(ctx context.Context, id types.Id, boost *UpdateBoost) (*Boost, error)
+	GetBoosts(ctx context.Context) ([]Boost, error)
+	GetBoost(ctx context.Context, id types.Id) (*Boost, error)
+}
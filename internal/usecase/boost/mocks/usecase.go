@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/usecase/boost (interfaces: Usecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=BoostUsecase . Usecase
+//
+
+// Package mu is a generated GoMock package.
+package mu
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	boost "vk_quests/internal/usecase/boost"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// BoostUsecase is a mock of Usecase interface.
+type BoostUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *BoostUsecaseMockRecorder
+}
+
+// BoostUsecaseMockRecorder is the mock recorder for BoostUsecase.
+type BoostUsecaseMockRecorder struct {
+	mock *BoostUsecase
+}
+
+// NewBoostUsecase creates a new mock instance.
+func NewBoostUsecase(ctrl *gomock.Controller) *BoostUsecase {
+	mock := &BoostUsecase{ctrl: ctrl}
+	mock.recorder = &BoostUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *BoostUsecase) EXPECT() *BoostUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateBoost mocks base method.
+func (m *BoostUsecase) CreateBoost(arg0 context.Context, arg1 *boost.Boost) (*boost.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoost", arg0, arg1)
+	ret0, _ := ret[0].(*boost.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoost indicates an expected call of CreateBoost.
+func (mr *BoostUsecaseMockRecorder) CreateBoost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoost", reflect.TypeOf((*BoostUsecase)(nil).CreateBoost), arg0, arg1)
+}
+
+// DeleteBoost mocks base method.
+func (m *BoostUsecase) DeleteBoost(arg0 context.Context, arg1 types.Id) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoost", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoost indicates an expected call of DeleteBoost.
+func (mr *BoostUsecaseMockRecorder) DeleteBoost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoost", reflect.TypeOf((*BoostUsecase)(nil).DeleteBoost), arg0, arg1)
+}
+
+// GetBoost mocks base method.
+func (m *BoostUsecase) GetBoost(arg0 context.Context, arg1 types.Id) (*boost.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoost", arg0, arg1)
+	ret0, _ := ret[0].(*boost.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoost indicates an expected call of GetBoost.
+func (mr *BoostUsecaseMockRecorder) GetBoost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoost", reflect.TypeOf((*BoostUsecase)(nil).GetBoost), arg0, arg1)
+}
+
+// GetBoosts mocks base method.
+func (m *BoostUsecase) GetBoosts(arg0 context.Context) ([]boost.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoosts", arg0)
+	ret0, _ := ret[0].([]boost.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoosts indicates an expected call of GetBoosts.
+func (mr *BoostUsecaseMockRecorder) GetBoosts(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoosts", reflect.TypeOf((*BoostUsecase)(nil).GetBoosts), arg0)
+}
+
+// UpdateBoost mocks base method.
+func (m *BoostUsecase) UpdateBoost(arg0 context.Context, arg1 types.Id, arg2 *boost.UpdateBoost) (*boost.Boost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*boost.Boost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoost indicates an expected call of UpdateBoost.
+func (mr *BoostUsecaseMockRecorder) UpdateBoost(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoost", reflect.TypeOf((*BoostUsecase)(nil).UpdateBoost), arg0, arg1, arg2)
+}
@@ -0,0 +1,71 @@
+package boost
+
+import (
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/boost"
+)
+
+type Boost struct {
+	ID         types.Id
+	Name       string
+	Multiplier float64
+	StartsAt   time.FormattedTime
+	EndsAt     time.FormattedTime
+	QuestId    *types.Id // nil means boost applies to quests of any id
+	CategoryId *types.Id // nil means boost applies to quests of any category
+	QuestType  *types.QuestType
+}
+
+func FromRepBoost(b *boost.Boost) *Boost {
+	if b == nil {
+		return nil
+	}
+
+	return &Boost{
+		ID:         b.ID,
+		Name:       b.Name,
+		Multiplier: b.Multiplier,
+		StartsAt:   b.StartsAt,
+		EndsAt:     b.EndsAt,
+		QuestId:    b.QuestId,
+		CategoryId: b.CategoryId,
+		QuestType:  b.QuestType,
+	}
+}
+
+func (b *Boost) ToRepBoost() *boost.Boost {
+	return &boost.Boost{
+		ID:         b.ID,
+		Name:       b.Name,
+		Multiplier: b.Multiplier,
+		StartsAt:   b.StartsAt,
+		EndsAt:     b.EndsAt,
+		QuestId:    b.QuestId,
+		CategoryId: b.CategoryId,
+		QuestType:  b.QuestType,
+	}
+}
+
+type UpdateBoost struct {
+	Name       *string
+	Multiplier *float64
+	StartsAt   *time.FormattedTime
+	EndsAt     *time.FormattedTime
+	QuestId    *types.Id        // zero removes restriction by quest
+	CategoryId *types.Id        // zero removes restriction by category
+	QuestType  *types.QuestType // empty type removes restriction by type of quests
+}
+
+func (ub *UpdateBoost) ToRepUpdateBoost(id types.Id) *boost.UpdateBoost {
+	return &boost.UpdateBoost{
+		ID:         id,
+		Name:       ub.Name,
+		Multiplier: ub.Multiplier,
+		StartsAt:   ub.StartsAt,
+		EndsAt:     ub.EndsAt,
+		QuestId:    ub.QuestId,
+		CategoryId: ub.CategoryId,
+		QuestType:  ub.QuestType,
+	}
+}
@@ -0,0 +1,50 @@
+package boost
+
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/boost"
+	"vk_quests/pkg/slices"
+)
+
+type BoostUsecase struct {
+	boosts boost.Repository
+}
+
+func NewBoostUsecase(boosts boost.Repository) *BoostUsecase {
+	return &BoostUsecase{
+		boosts: boosts,
+	}
+}
+
+func (bu *BoostUsecase) CreateBoost(ctx context.Context, bst *Boost) (*Boost, error) {
+	createdBst, err := bu.boosts.CreateBoost(ctx, bst.ToRepBoost())
+
+	return FromRepBoost(createdBst), err
+}
+
+func (bu *BoostUsecase) DeleteBoost(ctx context.Context, id types.Id) error {
+	return bu.boosts.DeleteBoost(ctx, id)
+}
+
+func (bu *BoostUsecase) UpdateBoost(ctx context.Context, id types.Id, bst *UpdateBoost) (*Boost, error) {
+	updatedBst, err := bu.boosts.UpdateBoost(ctx, bst.ToRepUpdateBoost(id))
+
+	return FromRepBoost(updatedBst), err
+}
+
+func (bu *BoostUsecase) GetBoosts(ctx context.Context) ([]Boost, error) {
+	boosts, err := bu.boosts.GetBoosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(boosts, func(b boost.Boost) Boost { return *FromRepBoost(&b) }), nil
+}
+
+func (bu *BoostUsecase) GetBoost(ctx context.Context, id types.Id) (*Boost, error) {
+	bst, err := bu.boosts.GetBoost(ctx, id)
+
+	return FromRepBoost(bst), err
+}
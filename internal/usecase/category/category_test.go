@@ -0,0 +1,187 @@
+package category
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+
+	"vk_quests/internal/pkg/types"
+	cr "vk_quests/internal/repository/category"
+	mrc "vk_quests/internal/repository/category/mocks"
+)
+
+var testError = errors.New("test error")
+
+type CategoryUsecaseSuite struct {
+	suite.Suite
+	categoryUsecase *CategoryUsecase
+	mockCategory    *mrc.CategoryRepository
+	gmc             *gomock.Controller
+}
+
+func (cus *CategoryUsecaseSuite) BeforeEach(t provider.T) {
+	cus.gmc = gomock.NewController(t)
+	cus.mockCategory = mrc.NewCategoryRepository(cus.gmc)
+	cus.categoryUsecase = NewCategoryUsecase(cus.mockCategory)
+}
+
+func (cus *CategoryUsecaseSuite) AfterEach(t provider.T) {
+	cus.gmc.Finish()
+}
+
+func (cus *CategoryUsecaseSuite) TestCreateCategoryFunction(t provider.T) {
+	t.Title("CreateCategory function of category usecase")
+	t.NewStep("Init test data")
+	category := &Category{
+		ID:          1,
+		Name:        "Daily",
+		Description: "daily quests",
+	}
+	repositoryCategory := &cr.Category{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().CreateCategory(context.Background(), repositoryCategory).Return(repositoryCategory, nil).Times(1)
+
+		t.NewStep("Check result")
+		ctg, err := cus.categoryUsecase.CreateCategory(context.Background(), category)
+		t.Require().NoError(err)
+		t.Require().Equal(category, ctg)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().CreateCategory(context.Background(), repositoryCategory).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := cus.categoryUsecase.CreateCategory(context.Background(), category)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (cus *CategoryUsecaseSuite) TestDeleteCategoryFunction(t provider.T) {
+	t.Title("DeleteCategory function of category usecase")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().DeleteCategory(context.Background(), id).Return(nil).Times(1)
+
+		t.NewStep("Check result")
+		t.Require().NoError(cus.categoryUsecase.DeleteCategory(context.Background(), id))
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().DeleteCategory(context.Background(), id).Return(testError).Times(1)
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(cus.categoryUsecase.DeleteCategory(context.Background(), id), testError)
+	})
+}
+
+func (cus *CategoryUsecaseSuite) TestUpdateCategoryFunction(t provider.T) {
+	t.Title("UpdateCategory function of category usecase")
+	t.NewStep("Init test data")
+	name := "Weekly"
+	category := &Category{
+		ID:   1,
+		Name: name,
+	}
+	updateCategory := &UpdateCategory{Name: &name}
+	repositoryUpdateCategory := &cr.UpdateCategory{ID: category.ID, Name: &name}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().UpdateCategory(context.Background(), repositoryUpdateCategory).
+			Return(&cr.Category{ID: category.ID, Name: name}, nil).Times(1)
+
+		t.NewStep("Check result")
+		ctg, err := cus.categoryUsecase.UpdateCategory(context.Background(), category.ID, updateCategory)
+		t.Require().NoError(err)
+		t.Require().Equal(category, ctg)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().UpdateCategory(context.Background(), repositoryUpdateCategory).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := cus.categoryUsecase.UpdateCategory(context.Background(), category.ID, updateCategory)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (cus *CategoryUsecaseSuite) TestGetCategoriesFunction(t provider.T) {
+	t.Title("GetCategories function of category usecase")
+	t.NewStep("Init test data")
+	repositoryCategories := []cr.Category{
+		{ID: 1, Name: "Daily", Description: "daily quests"},
+		{ID: 2, Name: "Social"},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().GetCategories(context.Background()).Return(repositoryCategories, nil).Times(1)
+
+		t.NewStep("Check result")
+		categories, err := cus.categoryUsecase.GetCategories(context.Background())
+		t.Require().NoError(err)
+		t.Require().Equal([]Category{
+			{ID: 1, Name: "Daily", Description: "daily quests"},
+			{ID: 2, Name: "Social"},
+		}, categories)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().GetCategories(context.Background()).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := cus.categoryUsecase.GetCategories(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (cus *CategoryUsecaseSuite) TestGetCategoryFunction(t provider.T) {
+	t.Title("GetCategory function of category usecase")
+	t.NewStep("Init test data")
+	category := &Category{
+		ID:          1,
+		Name:        "Daily",
+		Description: "daily quests",
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().GetCategory(context.Background(), category.ID).
+			Return(&cr.Category{ID: category.ID, Name: category.Name, Description: category.Description}, nil).Times(1)
+
+		t.NewStep("Check result")
+		ctg, err := cus.categoryUsecase.GetCategory(context.Background(), category.ID)
+		t.Require().NoError(err)
+		t.Require().Equal(category, ctg)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cus.mockCategory.EXPECT().GetCategory(context.Background(), category.ID).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := cus.categoryUsecase.GetCategory(context.Background(), category.ID)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func TestRunCategoryUsecaseSuite(t *testing.T) {
+	suite.RunSuite(t, new(CategoryUsecaseSuite))
+}
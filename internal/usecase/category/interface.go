@@ -0,0 +1,17 @@
+package category
+
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+)
+
+//go:generate mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=CategoryUsecase . Usecase
+
+type Usecase interface {
+	CreateCategory(ctx context.Context, category *Category) (*Category, error)
+	DeleteCategory(ctx context.Context, id types.Id) error
+	UpdateCategory(ctx context.Context, id types.Id, category *UpdateCategory) (*Category, error)
+	GetCategories(ctx context.Context) ([]Category, error)
+	GetCategory(ctx context.Context, id types.Id) (*Category, error)
+}
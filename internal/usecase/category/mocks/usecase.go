@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/usecase/category (interfaces: Usecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=CategoryUsecase . Usecase
+//
+
+// Package mu is a generated GoMock package.
+package mu
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	category "vk_quests/internal/usecase/category"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// CategoryUsecase is a mock of Usecase interface.
+type CategoryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *CategoryUsecaseMockRecorder
+}
+
+// CategoryUsecaseMockRecorder is the mock recorder for CategoryUsecase.
+type CategoryUsecaseMockRecorder struct {
+	mock *CategoryUsecase
+}
+
+// NewCategoryUsecase creates a new mock instance.
+func NewCategoryUsecase(ctrl *gomock.Controller) *CategoryUsecase {
+	mock := &CategoryUsecase{ctrl: ctrl}
+	mock.recorder = &CategoryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *CategoryUsecase) EXPECT() *CategoryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *CategoryUsecase) CreateCategory(arg0 context.Context, arg1 *category.Category) (*category.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(*category.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *CategoryUsecaseMockRecorder) CreateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*CategoryUsecase)(nil).CreateCategory), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *CategoryUsecase) DeleteCategory(arg0 context.Context, arg1 types.Id) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *CategoryUsecaseMockRecorder) DeleteCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*CategoryUsecase)(nil).DeleteCategory), arg0, arg1)
+}
+
+// GetCategories mocks base method.
+func (m *CategoryUsecase) GetCategories(arg0 context.Context) ([]category.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", arg0)
+	ret0, _ := ret[0].([]category.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *CategoryUsecaseMockRecorder) GetCategories(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*CategoryUsecase)(nil).GetCategories), arg0)
+}
+
+// GetCategory mocks base method.
+func (m *CategoryUsecase) GetCategory(arg0 context.Context, arg1 types.Id) (*category.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(*category.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *CategoryUsecaseMockRecorder) GetCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*CategoryUsecase)(nil).GetCategory), arg0, arg1)
+}
+
+// UpdateCategory mocks base method.
+func (m *CategoryUsecase) UpdateCategory(arg0 context.Context, arg1 types.Id, arg2 *category.UpdateCategory) (*category.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*category.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *CategoryUsecaseMockRecorder) UpdateCategory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*CategoryUsecase)(nil).UpdateCategory), arg0, arg1, arg2)
+}
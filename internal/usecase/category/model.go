@@ -0,0 +1,37 @@
+package category
+
+import (
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/category"
+)
+
+type Category struct {
+	ID          types.Id
+	Name        string
+	Description string
+}
+
+func FromRepCategory(c *category.Category) *Category {
+	if c == nil {
+		return nil
+	}
+
+	return &Category{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+	}
+}
+
+type UpdateCategory struct {
+	Name        *string
+	Description *string
+}
+
+func (uc *UpdateCategory) ToRepUpdateCategory(id types.Id) *category.UpdateCategory {
+	return &category.UpdateCategory{
+		ID:          id,
+		Name:        uc.Name,
+		Description: uc.Description,
+	}
+}
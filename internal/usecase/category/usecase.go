@@ -0,0 +1,57 @@
+package category
+
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/category"
+	"vk_quests/pkg/slices"
+)
+
+type CategoryUsecase struct {
+	categories category.Repository
+}
+
+func NewCategoryUsecase(categories category.Repository) *CategoryUsecase {
+	return &CategoryUsecase{
+		categories: categories,
+	}
+}
+
+func (cu *CategoryUsecase) CreateCategory(ctx context.Context, ctg *Category) (*Category, error) {
+	createdCtg, err := cu.categories.CreateCategory(
+		ctx,
+		&category.Category{
+			ID:          ctg.ID,
+			Name:        ctg.Name,
+			Description: ctg.Description,
+		},
+	)
+
+	return FromRepCategory(createdCtg), err
+}
+
+func (cu *CategoryUsecase) DeleteCategory(ctx context.Context, id types.Id) error {
+	return cu.categories.DeleteCategory(ctx, id)
+}
+
+func (cu *CategoryUsecase) UpdateCategory(ctx context.Context, id types.Id, ctg *UpdateCategory) (*Category, error) {
+	updatedCtg, err := cu.categories.UpdateCategory(ctx, ctg.ToRepUpdateCategory(id))
+
+	return FromRepCategory(updatedCtg), err
+}
+
+func (cu *CategoryUsecase) GetCategories(ctx context.Context) ([]Category, error) {
+	categories, err := cu.categories.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(categories, func(c category.Category) Category { return *FromRepCategory(&c) }), nil
+}
+
+func (cu *CategoryUsecase) GetCategory(ctx context.Context, id types.Id) (*Category, error) {
+	ctg, err := cu.categories.GetCategory(ctx, id)
+
+	return FromRepCategory(ctg), err
+}
@@ -0,0 +1,15 @@
+package leaderboard
+
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+)
+
+//go:generate mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=LeaderboardUsecase . Usecase
+
+type Usecase interface {
+	GetLeaderboard(ctx context.Context, query *LeaderboardQuery) ([]Entry, error)
+	GetRank(ctx context.Context, userId types.Id, window types.LeaderboardWindow,
+		metric types.LeaderboardMetric) (*Entry, error)
+}
@@ -0,0 +1,131 @@
+package leaderboard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+
+	"vk_quests/internal/pkg/page"
+	"vk_quests/internal/pkg/types"
+	lr "vk_quests/internal/repository/leaderboard"
+	mrl "vk_quests/internal/repository/leaderboard/mocks"
+)
+
+var testError = errors.New("test error")
+
+type LeaderboardUsecaseSuite struct {
+	suite.Suite
+	leaderboardUsecase *LeaderboardUsecase
+	mockLeaderboard    *mrl.LeaderboardRepository
+	gmc                *gomock.Controller
+}
+
+func (lus *LeaderboardUsecaseSuite) BeforeEach(t provider.T) {
+	lus.gmc = gomock.NewController(t)
+	lus.mockLeaderboard = mrl.NewLeaderboardRepository(lus.gmc)
+	lus.leaderboardUsecase = NewLeaderboardUsecase(lus.mockLeaderboard)
+}
+
+func (lus *LeaderboardUsecaseSuite) AfterEach(t provider.T) {
+	lus.gmc.Finish()
+}
+
+func (lus *LeaderboardUsecaseSuite) TestGetLeaderboardFunction(t provider.T) {
+	t.Title("GetLeaderboard function of leaderboard usecase")
+	t.NewStep("Init test data")
+	entries := []Entry{
+		{Rank: 1, UserId: 3, Name: "First", Score: 40},
+		{Rank: 2, UserId: 1, Name: "Second", Score: 25},
+	}
+	repositoryEntries := []lr.Entry{
+		{Rank: 1, UserId: 3, Name: "First", Score: 40},
+		{Rank: 2, UserId: 1, Name: "Second", Score: 25},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lus.mockLeaderboard.EXPECT().GetLeaderboard(context.Background(), &lr.LeaderboardQuery{
+			Window: types.LeaderboardWeek,
+			Metric: types.LeaderboardEarned,
+			Limit:  2,
+		}).Return(repositoryEntries, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := lus.leaderboardUsecase.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardWeek,
+			Metric: types.LeaderboardEarned,
+			Limit:  2,
+		})
+		t.Require().NoError(err)
+		t.Require().Equal(entries, res)
+	})
+
+	t.WithNewStep("Correct default limit execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lus.mockLeaderboard.EXPECT().GetLeaderboard(context.Background(), &lr.LeaderboardQuery{
+			Window: types.LeaderboardAllTime,
+			Metric: types.LeaderboardBalance,
+			Limit:  page.DefaultLimit,
+		}).Return(repositoryEntries, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := lus.leaderboardUsecase.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardAllTime,
+			Metric: types.LeaderboardBalance,
+		})
+		t.Require().NoError(err)
+		t.Require().Equal(entries, res)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lus.mockLeaderboard.EXPECT().GetLeaderboard(context.Background(), gomock.Any()).
+			Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := lus.leaderboardUsecase.GetLeaderboard(context.Background(), &LeaderboardQuery{
+			Window: types.LeaderboardMonth,
+			Metric: types.LeaderboardEarned,
+		})
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (lus *LeaderboardUsecaseSuite) TestGetRankFunction(t provider.T) {
+	t.Title("GetRank function of leaderboard usecase")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	entry := &Entry{Rank: 2, UserId: userId, Name: "Second", Score: 25}
+	repositoryEntry := &lr.Entry{Rank: 2, UserId: userId, Name: "Second", Score: 25}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lus.mockLeaderboard.EXPECT().GetRank(context.Background(), userId, types.LeaderboardWeek, types.LeaderboardEarned).
+			Return(repositoryEntry, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := lus.leaderboardUsecase.GetRank(context.Background(), userId,
+			types.LeaderboardWeek, types.LeaderboardEarned)
+		t.Require().NoError(err)
+		t.Require().Equal(entry, res)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		lus.mockLeaderboard.EXPECT().GetRank(context.Background(), userId, types.LeaderboardWeek, types.LeaderboardEarned).
+			Return(nil, lr.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Check result")
+		_, err := lus.leaderboardUsecase.GetRank(context.Background(), userId,
+			types.LeaderboardWeek, types.LeaderboardEarned)
+		t.Require().ErrorIs(err, lr.ErrorUserNotFound)
+	})
+}
+
+func TestRunLeaderboardUsecaseSuite(t *testing.T) {
+	suite.RunSuite(t, new(LeaderboardUsecaseSuite))
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/usecase/leaderboard (interfaces: Usecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=LeaderboardUsecase . Usecase
+//
+
+// Package mu is a generated GoMock package.
+package mu
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	leaderboard "vk_quests/internal/usecase/leaderboard"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// LeaderboardUsecase is a mock of Usecase interface.
+type LeaderboardUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *LeaderboardUsecaseMockRecorder
+}
+
+// LeaderboardUsecaseMockRecorder is the mock recorder for LeaderboardUsecase.
+type LeaderboardUsecaseMockRecorder struct {
+	mock *LeaderboardUsecase
+}
+
+// NewLeaderboardUsecase creates a new mock instance.
+func NewLeaderboardUsecase(ctrl *gomock.Controller) *LeaderboardUsecase {
+	mock := &LeaderboardUsecase{ctrl: ctrl}
+	mock.recorder = &LeaderboardUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *LeaderboardUsecase) EXPECT() *LeaderboardUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetLeaderboard mocks base method.
+func (m *LeaderboardUsecase) GetLeaderboard(arg0 context.Context, arg1 *leaderboard.LeaderboardQuery) ([]leaderboard.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", arg0, arg1)
+	ret0, _ := ret[0].([]leaderboard.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *LeaderboardUsecaseMockRecorder) GetLeaderboard(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*LeaderboardUsecase)(nil).GetLeaderboard), arg0, arg1)
+}
+
+// GetRank mocks base method.
+func (m *LeaderboardUsecase) GetRank(arg0 context.Context, arg1 types.Id, arg2 types.LeaderboardWindow, arg3 types.LeaderboardMetric) (*leaderboard.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRank", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*leaderboard.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRank indicates an expected call of GetRank.
+func (mr *LeaderboardUsecaseMockRecorder) GetRank(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRank", reflect.TypeOf((*LeaderboardUsecase)(nil).GetRank), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,41 @@
+package leaderboard
+
+import (
+	"vk_quests/internal/pkg/page"
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/leaderboard"
+)
+
+type LeaderboardQuery struct {
+	Window types.LeaderboardWindow
+	Metric types.LeaderboardMetric
+	Limit  uint64 // 0 means default limit
+}
+
+func (lq *LeaderboardQuery) ToRepLeaderboardQuery() *leaderboard.LeaderboardQuery {
+	return &leaderboard.LeaderboardQuery{
+		Window: lq.Window,
+		Metric: lq.Metric,
+		Limit:  page.NormalizeLimit(lq.Limit),
+	}
+}
+
+type Entry struct {
+	Rank   uint64
+	UserId types.Id
+	Name   string
+	Score  int64
+}
+
+func FromRepEntry(e *leaderboard.Entry) *Entry {
+	if e == nil {
+		return nil
+	}
+
+	return &Entry{
+		Rank:   e.Rank,
+		UserId: e.UserId,
+		Name:   e.Name,
+		Score:  e.Score,
+	}
+}
@@ -0,0 +1,35 @@
+package leaderboard
+
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/leaderboard"
+	"vk_quests/pkg/slices"
+)
+
+type LeaderboardUsecase struct {
+	leaderboards leaderboard.Repository
+}
+
+func NewLeaderboardUsecase(leaderboards leaderboard.Repository) *LeaderboardUsecase {
+	return &LeaderboardUsecase{
+		leaderboards: leaderboards,
+	}
+}
+
+func (lu *LeaderboardUsecase) GetLeaderboard(ctx context.Context, query *LeaderboardQuery) ([]Entry, error) {
+	entries, err := lu.leaderboards.GetLeaderboard(ctx, query.ToRepLeaderboardQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(entries, func(e leaderboard.Entry) Entry { return *FromRepEntry(&e) }), nil
+}
+
+func (lu *LeaderboardUsecase) GetRank(ctx context.Context, userId types.Id, window types.LeaderboardWindow,
+	metric types.LeaderboardMetric) (*Entry, error) {
+	entry, err := lu.leaderboards.GetRank(ctx, userId, window, metric)
+
+	return FromRepEntry(entry), err
+}
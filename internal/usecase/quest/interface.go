@@ -1,13 +1,19 @@
 package quest
 
-import "vk_quests/internal/pkg/types"
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+)
 
 //go:generate mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=QuestUsecase . Usecase
 
 type Usecase interface {
-	CreateQuest(quest *Quest) (*Quest, error)
-	DeleteQuest(id types.Id) error
-	UpdateQuest(id types.Id, quest *UpdateQuest) (*Quest, error)
-	GetQuests() ([]Quest, error)
-	GetQuest(id types.Id) (*Quest, error)
+	CreateQuest(ctx context.Context, quest *Quest) (*Quest, error)
+	ArchiveQuest(ctx context.Context, id types.Id) error
+	RestoreQuest(ctx context.Context, id types.Id) (*Quest, error)
+	PurgeQuest(ctx context.Context, id types.Id) error
+	UpdateQuest(ctx context.Context, id types.Id, quest *UpdateQuest) (*Quest, error)
+	GetQuests(ctx context.Context, query *QuestsQuery) (*QuestsPage, error)
+	GetQuest(ctx context.Context, id types.Id) (*Quest, error)
 }
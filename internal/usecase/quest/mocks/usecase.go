@@ -10,6 +10,7 @@
 package mu
 
 import (
+	context "context"
 	reflect "reflect"
 	types "vk_quests/internal/pkg/types"
 	quest "vk_quests/internal/usecase/quest"
@@ -40,76 +41,105 @@ func (m *QuestUsecase) EXPECT() *QuestUsecaseMockRecorder {
 	return m.recorder
 }
 
-// CreateQuest mocks base method.
-func (m *QuestUsecase) CreateQuest(arg0 *quest.Quest) (*quest.Quest, error) {
+// ArchiveQuest mocks base method.
+func (m *QuestUsecase) ArchiveQuest(arg0 context.Context, arg1 types.Id) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateQuest", arg0)
-	ret0, _ := ret[0].(*quest.Quest)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ArchiveQuest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateQuest indicates an expected call of CreateQuest.
-func (mr *QuestUsecaseMockRecorder) CreateQuest(arg0 any) *gomock.Call {
+// ArchiveQuest indicates an expected call of ArchiveQuest.
+func (mr *QuestUsecaseMockRecorder) ArchiveQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuest", reflect.TypeOf((*QuestUsecase)(nil).CreateQuest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveQuest", reflect.TypeOf((*QuestUsecase)(nil).ArchiveQuest), arg0, arg1)
 }
 
-// DeleteQuest mocks base method.
-func (m *QuestUsecase) DeleteQuest(arg0 types.Id) error {
+// CreateQuest mocks base method.
+func (m *QuestUsecase) CreateQuest(arg0 context.Context, arg1 *quest.Quest) (*quest.Quest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteQuest", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateQuest", arg0, arg1)
+	ret0, _ := ret[0].(*quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteQuest indicates an expected call of DeleteQuest.
-func (mr *QuestUsecaseMockRecorder) DeleteQuest(arg0 any) *gomock.Call {
+// CreateQuest indicates an expected call of CreateQuest.
+func (mr *QuestUsecaseMockRecorder) CreateQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuest", reflect.TypeOf((*QuestUsecase)(nil).DeleteQuest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuest", reflect.TypeOf((*QuestUsecase)(nil).CreateQuest), arg0, arg1)
 }
 
 // GetQuest mocks base method.
-func (m *QuestUsecase) GetQuest(arg0 types.Id) (*quest.Quest, error) {
+func (m *QuestUsecase) GetQuest(arg0 context.Context, arg1 types.Id) (*quest.Quest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQuest", arg0)
+	ret := m.ctrl.Call(m, "GetQuest", arg0, arg1)
 	ret0, _ := ret[0].(*quest.Quest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetQuest indicates an expected call of GetQuest.
-func (mr *QuestUsecaseMockRecorder) GetQuest(arg0 any) *gomock.Call {
+func (mr *QuestUsecaseMockRecorder) GetQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuest", reflect.TypeOf((*QuestUsecase)(nil).GetQuest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuest", reflect.TypeOf((*QuestUsecase)(nil).GetQuest), arg0, arg1)
 }
 
 // GetQuests mocks base method.
-func (m *QuestUsecase) GetQuests() ([]quest.Quest, error) {
+func (m *QuestUsecase) GetQuests(arg0 context.Context, arg1 *quest.QuestsQuery) (*quest.QuestsPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQuests")
-	ret0, _ := ret[0].([]quest.Quest)
+	ret := m.ctrl.Call(m, "GetQuests", arg0, arg1)
+	ret0, _ := ret[0].(*quest.QuestsPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetQuests indicates an expected call of GetQuests.
-func (mr *QuestUsecaseMockRecorder) GetQuests() *gomock.Call {
+func (mr *QuestUsecaseMockRecorder) GetQuests(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuests", reflect.TypeOf((*QuestUsecase)(nil).GetQuests), arg0, arg1)
+}
+
+// PurgeQuest mocks base method.
+func (m *QuestUsecase) PurgeQuest(arg0 context.Context, arg1 types.Id) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeQuest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeQuest indicates an expected call of PurgeQuest.
+func (mr *QuestUsecaseMockRecorder) PurgeQuest(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeQuest", reflect.TypeOf((*QuestUsecase)(nil).PurgeQuest), arg0, arg1)
+}
+
+// RestoreQuest mocks base method.
+func (m *QuestUsecase) RestoreQuest(arg0 context.Context, arg1 types.Id) (*quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreQuest", arg0, arg1)
+	ret0, _ := ret[0].(*quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreQuest indicates an expected call of RestoreQuest.
+func (mr *QuestUsecaseMockRecorder) RestoreQuest(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuests", reflect.TypeOf((*QuestUsecase)(nil).GetQuests))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreQuest", reflect.TypeOf((*QuestUsecase)(nil).RestoreQuest), arg0, arg1)
 }
 
 // UpdateQuest mocks base method.
-func (m *QuestUsecase) UpdateQuest(arg0 types.Id, arg1 *quest.UpdateQuest) (*quest.Quest, error) {
+func (m *QuestUsecase) UpdateQuest(arg0 context.Context, arg1 types.Id, arg2 *quest.UpdateQuest) (*quest.Quest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateQuest", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateQuest", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*quest.Quest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateQuest indicates an expected call of UpdateQuest.
-func (mr *QuestUsecaseMockRecorder) UpdateQuest(arg0, arg1 any) *gomock.Call {
+func (mr *QuestUsecaseMockRecorder) UpdateQuest(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuest", reflect.TypeOf((*QuestUsecase)(nil).UpdateQuest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuest", reflect.TypeOf((*QuestUsecase)(nil).UpdateQuest), arg0, arg1, arg2)
 }
@@ -1,16 +1,77 @@
 package quest
 
 import (
+	"strconv"
+	"time"
+
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
 	"vk_quests/internal/repository/quest"
+	"vk_quests/pkg/slices"
 )
 
 type Quest struct {
-	ID          types.Id
-	Name        string
-	Description string
-	Cost        types.Cost
-	Type        types.QuestType
+	ID             types.Id
+	Name           string
+	Description    string
+	Cost           types.Cost
+	Type           types.QuestType
+	Steps          []string
+	MaxCompletions uint32                 // zero means unlimited completions
+	Cooldown       time.Duration          // minimal duration between two completions by one user
+	Prerequisites  []types.Id             // quests which user must complete before this one
+	StartsAt       *pkgtime.FormattedTime // nil means quest is active from creation
+	EndsAt         *pkgtime.FormattedTime // nil means quest never ends
+	Probability    float64                // chance of random quest to be completed by one attempt
+	Pity           uint32                 // random quest is completed after this number of failed attempts in a row, zero means never
+	Target         uint32                 // amount which user must accumulate to complete counter quest or number of contributors to team quest
+	Milestones     []Milestone            // streak lengths at which streak quest pays rewards, sorted by days
+	RewardSplit    types.RewardSplit      // distribution of team quest reward between contributors
+	ArchivedAt     *pkgtime.FormattedTime // nil means quest is not archived
+	Categories     []types.Id             // sorted ids of quest categories
+	Tags           []string               // sorted free-form tags of quest
+}
+
+// Milestone is reward paid when streak of user reaches given number of consecutive days.
+type Milestone struct {
+	Days   uint32
+	Reward types.Cost
+}
+
+// Total returns progress needed to finish quest: number of steps of staged quest,
+// target of counter quest, the last milestone of streak quest or number of contributors to team quest,
+// other quests are finished at once.
+func (q *Quest) Total() uint32 {
+	switch q.Type {
+	case types.STAGED:
+		return uint32(len(q.Steps))
+	case types.COUNTER, types.TEAM:
+		return q.Target
+	case types.STREAK:
+		if len(q.Milestones) == 0 {
+			return 0
+		}
+		return q.Milestones[len(q.Milestones)-1].Days
+	default:
+		return 0
+	}
+}
+
+func fromRepMilestones(milestones []quest.Milestone) []Milestone {
+	if milestones == nil {
+		return nil
+	}
+
+	return slices.Map(milestones, func(m quest.Milestone) Milestone { return Milestone(m) })
+}
+
+func toRepMilestones(milestones []Milestone) []quest.Milestone {
+	if milestones == nil {
+		return nil
+	}
+
+	return slices.Map(milestones, func(m Milestone) quest.Milestone { return quest.Milestone(m) })
 }
 
 func FromRepQuest(q *quest.Quest) *Quest {
@@ -19,25 +80,135 @@ func FromRepQuest(q *quest.Quest) *Quest {
 	}
 
 	return &Quest{
-		ID:          q.ID,
-		Name:        q.Name,
-		Description: q.Description,
-		Cost:        q.Cost,
-		Type:        q.Type,
+		ID:             q.ID,
+		Name:           q.Name,
+		Description:    q.Description,
+		Cost:           q.Cost,
+		Type:           q.Type,
+		Steps:          q.Steps,
+		MaxCompletions: q.MaxCompletions,
+		Cooldown:       q.Cooldown,
+		Prerequisites:  q.Prerequisites,
+		StartsAt:       q.StartsAt,
+		EndsAt:         q.EndsAt,
+		Probability:    q.Probability,
+		Pity:           q.Pity,
+		Target:         q.Target,
+		Milestones:     fromRepMilestones(q.Milestones),
+		RewardSplit:    q.RewardSplit,
+		ArchivedAt:     q.ArchivedAt,
+		Categories:     q.Categories,
+		Tags:           q.Tags,
 	}
 }
 
 type UpdateQuest struct {
-	Description *string
-	Cost        *types.Cost
-	Type        *types.QuestType
+	Description    *string
+	Cost           *types.Cost
+	Type           *types.QuestType
+	Steps          []string
+	MaxCompletions *uint32
+	Cooldown       *time.Duration
+	Prerequisites  []types.Id // nil means prerequisites are not changed
+	StartsAt       *pkgtime.FormattedTime
+	EndsAt         *pkgtime.FormattedTime
+	Probability    *float64
+	Pity           *uint32
+	Target         *uint32
+	Milestones     []Milestone // nil means milestones are not changed
+	RewardSplit    *types.RewardSplit
+	Categories     []types.Id // nil means categories are not changed
+	Tags           []string   // nil means tags are not changed
 }
 
 func (uq *UpdateQuest) ToRepUpdateQuest(id types.Id) *quest.UpdateQuest {
 	return &quest.UpdateQuest{
-		ID:          id,
-		Description: uq.Description,
-		Cost:        uq.Cost,
-		Type:        uq.Type,
+		ID:             id,
+		Description:    uq.Description,
+		Cost:           uq.Cost,
+		Type:           uq.Type,
+		Steps:          uq.Steps,
+		MaxCompletions: uq.MaxCompletions,
+		Cooldown:       uq.Cooldown,
+		Prerequisites:  uq.Prerequisites,
+		StartsAt:       uq.StartsAt,
+		EndsAt:         uq.EndsAt,
+		Probability:    uq.Probability,
+		Pity:           uq.Pity,
+		Target:         uq.Target,
+		Milestones:     toRepMilestones(uq.Milestones),
+		RewardSplit:    uq.RewardSplit,
+		Categories:     uq.Categories,
+		Tags:           uq.Tags,
 	}
 }
+
+type QuestsQuery struct {
+	Type       *types.QuestType
+	MinCost    *types.Cost
+	MaxCost    *types.Cost
+	NamePrefix string
+	CategoryId *types.Id // only quests of category
+	Tag        string    // only quests with tag, empty means any tags
+	Active     bool      // only quests which can be completed now
+	Archived   bool      // only archived quests instead of not archived ones
+	Sort       types.QuestsSort
+	Order      page.Order
+	Cursor     string // empty means first page
+	Limit      uint64
+}
+
+// ToRepQuestsQuery returns query of one more quest than limit to find out if there is next page.
+func (qq *QuestsQuery) ToRepQuestsQuery() (*quest.QuestsQuery, error) {
+	var after *page.Cursor
+	if qq.Cursor != "" {
+		cursor, err := page.DecodeCursor(qq.Cursor, string(qq.Sort), qq.Order)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	var activeAt *time.Time
+	if qq.Active {
+		now := time.Now()
+		activeAt = &now
+	}
+
+	return &quest.QuestsQuery{
+		Type:       qq.Type,
+		MinCost:    qq.MinCost,
+		MaxCost:    qq.MaxCost,
+		NamePrefix: qq.NamePrefix,
+		CategoryId: qq.CategoryId,
+		Tag:        qq.Tag,
+		ActiveAt:   activeAt,
+		Archived:   qq.Archived,
+		Sort:       qq.Sort,
+		Order:      qq.Order,
+		After:      after,
+		Limit:      page.NormalizeLimit(qq.Limit) + 1,
+	}, nil
+}
+
+type QuestsPage struct {
+	Quests     []Quest
+	NextCursor string // empty if there is no next page
+}
+
+func questsCursor(q *quest.Quest, sort types.QuestsSort, order page.Order) *page.Cursor {
+	cursor := &page.Cursor{
+		Sort:  string(sort),
+		Order: order,
+		ID:    q.ID,
+	}
+
+	switch sort {
+	case types.QuestsSortName:
+		cursor.Value = q.Name
+	case types.QuestsSortCost:
+		cursor.Value = strconv.FormatUint(uint64(q.Cost), 10)
+	}
+
+	return cursor
+}
@@ -1,13 +1,16 @@
 package quest
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"github.com/pkg/errors"
 	"go.uber.org/mock/gomock"
 
+	"vk_quests/internal/pkg/page"
 	"vk_quests/internal/pkg/types"
 	qr "vk_quests/internal/repository/quest"
 	mrq "vk_quests/internal/repository/quest/mocks"
@@ -53,26 +56,93 @@ func (qus *QuestUsecaseSuite) TestCreateQuestFunction(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().CreateQuest(repositoryQuest).Return(repositoryQuest, nil).Times(1)
+		qus.mockQuest.EXPECT().CreateQuest(context.Background(), repositoryQuest).Return(repositoryQuest, nil).Times(1)
 
 		t.NewStep("Check result")
-		qst, err := qus.questUsecase.CreateQuest(quest)
+		qst, err := qus.questUsecase.CreateQuest(context.Background(), quest)
 		t.Require().NoError(err)
 		t.Require().Equal(quest, qst)
 	})
 
+	t.WithNewStep("Correct streak quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init test data")
+		streakQuest := &Quest{
+			ID:         2,
+			Name:       "Streak",
+			Type:       types.STREAK,
+			Milestones: []Milestone{{Days: 3, Reward: 10}, {Days: 7, Reward: 30}},
+		}
+		repositoryStreakQuest := &qr.Quest{
+			ID:         streakQuest.ID,
+			Name:       streakQuest.Name,
+			Type:       streakQuest.Type,
+			Milestones: []qr.Milestone{{Days: 3, Reward: 10}, {Days: 7, Reward: 30}},
+		}
+
+		t.NewStep("Init mock")
+		qus.mockQuest.EXPECT().CreateQuest(context.Background(), repositoryStreakQuest).
+			Return(repositoryStreakQuest, nil).Times(1)
+
+		t.NewStep("Check result")
+		qst, err := qus.questUsecase.CreateQuest(context.Background(), streakQuest)
+		t.Require().NoError(err)
+		t.Require().Equal(streakQuest, qst)
+		t.Require().EqualValues(7, qst.Total())
+	})
+
+	t.WithNewStep("Correct categories and tags execute", func(t provider.StepCtx) {
+		t.NewStep("Init test data")
+		labeledQuest := *quest
+		labeledQuest.Categories, labeledQuest.Tags = []types.Id{1, 2}, []string{"daily"}
+		repositoryLabeledQuest := *repositoryQuest
+		repositoryLabeledQuest.Categories, repositoryLabeledQuest.Tags = []types.Id{1, 2}, []string{"daily"}
+
+		t.NewStep("Init mock")
+		qus.mockQuest.EXPECT().CreateQuest(context.Background(), &repositoryLabeledQuest).
+			Return(&repositoryLabeledQuest, nil).Times(1)
+
+		t.NewStep("Check result")
+		qst, err := qus.questUsecase.CreateQuest(context.Background(), &labeledQuest)
+		t.Require().NoError(err)
+		t.Require().Equal(&labeledQuest, qst)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qus.mockQuest.EXPECT().CreateQuest(context.Background(), repositoryQuest).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := qus.questUsecase.CreateQuest(context.Background(), quest)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (qus *QuestUsecaseSuite) TestArchiveQuestFunction(t provider.T) {
+	t.Title("ArchiveQuest function of quest usecase")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qus.mockQuest.EXPECT().ArchiveQuest(context.Background(), id).Return(nil).Times(1)
+
+		t.NewStep("Check result")
+		err := qus.questUsecase.ArchiveQuest(context.Background(), id)
+		t.Require().NoError(err)
+	})
+
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().CreateQuest(repositoryQuest).Return(nil, testError).Times(1)
+		qus.mockQuest.EXPECT().ArchiveQuest(context.Background(), id).Return(testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := qus.questUsecase.CreateQuest(quest)
+		err := qus.questUsecase.ArchiveQuest(context.Background(), id)
 		t.Require().ErrorIs(err, testError)
 	})
 }
 
-func (qus *QuestUsecaseSuite) TestDeleteQuestFunction(t provider.T) {
-	t.Title("DeleteQuest function of quest usecase")
+func (qus *QuestUsecaseSuite) TestRestoreQuestFunction(t provider.T) {
+	t.Title("RestoreQuest function of quest usecase")
 	t.NewStep("Init test data")
 	quest := &Quest{
 		ID:          1,
@@ -82,25 +152,58 @@ func (qus *QuestUsecaseSuite) TestDeleteQuestFunction(t provider.T) {
 		Type:        types.USUAL,
 	}
 
+	repositoryQuest := &qr.Quest{
+		ID:          quest.ID,
+		Name:        quest.Name,
+		Description: quest.Description,
+		Cost:        quest.Cost,
+		Type:        quest.Type,
+	}
+
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().DeleteQuest(quest.ID).Return(nil).Times(1)
+		qus.mockQuest.EXPECT().RestoreQuest(context.Background(), quest.ID).Return(repositoryQuest, nil).Times(1)
 
 		t.NewStep("Check result")
-		err := qus.questUsecase.DeleteQuest(quest.ID)
+		qst, err := qus.questUsecase.RestoreQuest(context.Background(), quest.ID)
 		t.Require().NoError(err)
+		t.Require().Equal(quest, qst)
 	})
 
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().DeleteQuest(quest.ID).Return(testError).Times(1)
+		qus.mockQuest.EXPECT().RestoreQuest(context.Background(), quest.ID).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		err := qus.questUsecase.DeleteQuest(quest.ID)
+		_, err := qus.questUsecase.RestoreQuest(context.Background(), quest.ID)
 		t.Require().ErrorIs(err, testError)
 	})
 }
 
+func (qus *QuestUsecaseSuite) TestPurgeQuestFunction(t provider.T) {
+	t.Title("PurgeQuest function of quest usecase")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qus.mockQuest.EXPECT().PurgeQuest(context.Background(), id).Return(nil).Times(1)
+
+		t.NewStep("Check result")
+		err := qus.questUsecase.PurgeQuest(context.Background(), id)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		qus.mockQuest.EXPECT().PurgeQuest(context.Background(), id).Return(qr.ErrorQuestNotArchived).Times(1)
+
+		t.NewStep("Check result")
+		err := qus.questUsecase.PurgeQuest(context.Background(), id)
+		t.Require().ErrorIs(err, qr.ErrorQuestNotArchived)
+	})
+}
+
 func (qus *QuestUsecaseSuite) TestUpdateQuestFunction(t provider.T) {
 	t.Title("UpdateQuest function of quest usecase")
 	t.NewStep("Init test data")
@@ -135,20 +238,20 @@ func (qus *QuestUsecaseSuite) TestUpdateQuestFunction(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().UpdateQuest(respositoryUpdateQuest).Return(repositoryQuest, nil).Times(1)
+		qus.mockQuest.EXPECT().UpdateQuest(context.Background(), respositoryUpdateQuest).Return(repositoryQuest, nil).Times(1)
 
 		t.NewStep("Check result")
-		qst, err := qus.questUsecase.UpdateQuest(quest.ID, updateQuest)
+		qst, err := qus.questUsecase.UpdateQuest(context.Background(), quest.ID, updateQuest)
 		t.Require().NoError(err)
 		t.Require().Equal(quest, qst)
 	})
 
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().UpdateQuest(respositoryUpdateQuest).Return(nil, testError).Times(1)
+		qus.mockQuest.EXPECT().UpdateQuest(context.Background(), respositoryUpdateQuest).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := qus.questUsecase.UpdateQuest(quest.ID, updateQuest)
+		_, err := qus.questUsecase.UpdateQuest(context.Background(), quest.ID, updateQuest)
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -174,20 +277,20 @@ func (qus *QuestUsecaseSuite) TestGetQuestFunction(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().GetQuest(quest.ID).Return(repositoryQuest, nil).Times(1)
+		qus.mockQuest.EXPECT().GetQuest(context.Background(), quest.ID).Return(repositoryQuest, nil).Times(1)
 
 		t.NewStep("Check result")
-		qst, err := qus.questUsecase.GetQuest(quest.ID)
+		qst, err := qus.questUsecase.GetQuest(context.Background(), quest.ID)
 		t.Require().NoError(err)
 		t.Require().Equal(quest, qst)
 	})
 
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().GetQuest(quest.ID).Return(nil, testError).Times(1)
+		qus.mockQuest.EXPECT().GetQuest(context.Background(), quest.ID).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := qus.questUsecase.GetQuest(quest.ID)
+		_, err := qus.questUsecase.GetQuest(context.Background(), quest.ID)
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -211,24 +314,109 @@ func (qus *QuestUsecaseSuite) TestGetQuestsFunction(t provider.T) {
 			Cost:        quest.Cost,
 			Type:        quest.Type,
 		},
+		{
+			ID:   2,
+			Name: "Next quest",
+			Cost: 20,
+			Type: types.USUAL,
+		},
+	}
+
+	questType := types.USUAL
+	query := &QuestsQuery{
+		Type:  &questType,
+		Sort:  types.QuestsSortCost,
+		Order: page.Desc,
+		Limit: 1,
+	}
+	repositoryQuery := &qr.QuestsQuery{
+		Type:  query.Type,
+		Sort:  query.Sort,
+		Order: query.Order,
+		Limit: 2,
+	}
+	cursor := &page.Cursor{
+		Sort:  string(types.QuestsSortCost),
+		Order: page.Desc,
+		Value: "10",
+		ID:    quest.ID,
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().GetQuests().Return(repositoryQuest, nil).Times(1)
+		qus.mockQuest.EXPECT().GetQuests(context.Background(), repositoryQuery).Return(repositoryQuest, nil).Times(1)
+
+		t.NewStep("Check result")
+		questsPage, err := qus.questUsecase.GetQuests(context.Background(), query)
+		t.Require().NoError(err)
+		t.Require().Equal([]Quest{*quest}, questsPage.Quests)
+		t.Require().Equal(cursor.Encode(), questsPage.NextCursor)
+	})
+
+	t.WithNewStep("Last page execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		nextQuery := *query
+		nextQuery.Cursor = cursor.Encode()
+		nextRepositoryQuery := *repositoryQuery
+		nextRepositoryQuery.After = cursor
+		qus.mockQuest.EXPECT().GetQuests(context.Background(), &nextRepositoryQuery).Return(repositoryQuest[1:], nil).Times(1)
+
+		t.NewStep("Check result")
+		questsPage, err := qus.questUsecase.GetQuests(context.Background(), &nextQuery)
+		t.Require().NoError(err)
+		t.Require().Len(questsPage.Quests, 1)
+		t.Require().Empty(questsPage.NextCursor)
+	})
+
+	t.WithNewStep("Cursor of other sort execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		otherQuery := *query
+		otherQuery.Sort = types.QuestsSortName
+		otherQuery.Cursor = cursor.Encode()
+		_, err := qus.questUsecase.GetQuests(context.Background(), &otherQuery)
+		t.Require().ErrorIs(err, page.ErrorInvalidCursor)
+	})
+
+	t.WithNewStep("Category and tag quests execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		categoryId := types.Id(3)
+		labelQuery := *query
+		labelQuery.CategoryId, labelQuery.Tag = &categoryId, "daily"
+		labelRepositoryQuery := *repositoryQuery
+		labelRepositoryQuery.CategoryId, labelRepositoryQuery.Tag = &categoryId, "daily"
+		qus.mockQuest.EXPECT().GetQuests(context.Background(), &labelRepositoryQuery).Return(repositoryQuest[1:], nil).Times(1)
+
+		t.NewStep("Check result")
+		questsPage, err := qus.questUsecase.GetQuests(context.Background(), &labelQuery)
+		t.Require().NoError(err)
+		t.Require().Len(questsPage.Quests, 1)
+	})
+
+	t.WithNewStep("Active quests execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		activeQuery := *query
+		activeQuery.Active = true
+		before := time.Now()
+		qus.mockQuest.EXPECT().GetQuests(context.Background(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, q *qr.QuestsQuery) ([]qr.Quest, error) {
+				t.Require().NotNil(q.ActiveAt)
+				t.Require().False(q.ActiveAt.Before(before))
+				t.Require().False(q.ActiveAt.After(time.Now()))
+				return repositoryQuest[1:], nil
+			}).Times(1)
 
 		t.NewStep("Check result")
-		qst, err := qus.questUsecase.GetQuests()
+		questsPage, err := qus.questUsecase.GetQuests(context.Background(), &activeQuery)
 		t.Require().NoError(err)
-		t.Require().Equal([]Quest{*quest}, qst)
+		t.Require().Len(questsPage.Quests, 1)
 	})
 
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		qus.mockQuest.EXPECT().GetQuests().Return(nil, testError).Times(1)
+		qus.mockQuest.EXPECT().GetQuests(context.Background(), repositoryQuery).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := qus.questUsecase.GetQuests()
+		_, err := qus.questUsecase.GetQuests(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -1,6 +1,8 @@
 package quest
 
 import (
+	"context"
+
 	"vk_quests/internal/pkg/types"
 	"vk_quests/internal/repository/quest"
 	"vk_quests/pkg/slices"
@@ -16,41 +18,78 @@ func NewQuestUsecase(quests quest.Repository) *QuestUsecase {
 	}
 }
 
-func (qu *QuestUsecase) CreateQuest(qst *Quest) (*Quest, error) {
+func (qu *QuestUsecase) CreateQuest(ctx context.Context, qst *Quest) (*Quest, error) {
 	createdQst, err := qu.quests.CreateQuest(
+		ctx,
 		&quest.Quest{
-			ID:          qst.ID,
-			Name:        qst.Name,
-			Description: qst.Description,
-			Cost:        qst.Cost,
-			Type:        qst.Type,
+			ID:             qst.ID,
+			Name:           qst.Name,
+			Description:    qst.Description,
+			Cost:           qst.Cost,
+			Type:           qst.Type,
+			Steps:          qst.Steps,
+			MaxCompletions: qst.MaxCompletions,
+			Cooldown:       qst.Cooldown,
+			Prerequisites:  qst.Prerequisites,
+			StartsAt:       qst.StartsAt,
+			EndsAt:         qst.EndsAt,
+			Probability:    qst.Probability,
+			Pity:           qst.Pity,
+			Target:         qst.Target,
+			Milestones:     toRepMilestones(qst.Milestones),
+			RewardSplit:    qst.RewardSplit,
+			Categories:     qst.Categories,
+			Tags:           qst.Tags,
 		},
 	)
 
 	return FromRepQuest(createdQst), err
 }
 
-func (qu *QuestUsecase) DeleteQuest(id types.Id) error {
-	return qu.quests.DeleteQuest(id)
+func (qu *QuestUsecase) ArchiveQuest(ctx context.Context, id types.Id) error {
+	return qu.quests.ArchiveQuest(ctx, id)
+}
+
+func (qu *QuestUsecase) RestoreQuest(ctx context.Context, id types.Id) (*Quest, error) {
+	qst, err := qu.quests.RestoreQuest(ctx, id)
+
+	return FromRepQuest(qst), err
+}
+
+func (qu *QuestUsecase) PurgeQuest(ctx context.Context, id types.Id) error {
+	return qu.quests.PurgeQuest(ctx, id)
 }
 
-func (qu *QuestUsecase) UpdateQuest(id types.Id, qst *UpdateQuest) (*Quest, error) {
-	updatedQst, err := qu.quests.UpdateQuest(qst.ToRepUpdateQuest(id))
+func (qu *QuestUsecase) UpdateQuest(ctx context.Context, id types.Id, qst *UpdateQuest) (*Quest, error) {
+	updatedQst, err := qu.quests.UpdateQuest(ctx, qst.ToRepUpdateQuest(id))
 
 	return FromRepQuest(updatedQst), err
 }
 
-func (qu *QuestUsecase) GetQuests() ([]Quest, error) {
-	quests, err := qu.quests.GetQuests()
+func (qu *QuestUsecase) GetQuests(ctx context.Context, query *QuestsQuery) (*QuestsPage, error) {
+	repQuery, err := query.ToRepQuestsQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	return slices.Map(quests, func(q quest.Quest) Quest { return *FromRepQuest(&q) }), nil
+	quests, err := qu.quests.GetQuests(ctx, repQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &QuestsPage{}
+	if limit := int(repQuery.Limit) - 1; len(quests) > limit {
+		quests = quests[:limit]
+		res.NextCursor = questsCursor(&quests[limit-1], query.Sort, query.Order).Encode()
+	}
+
+	res.Quests = slices.Map(quests, func(q quest.Quest) Quest { return *FromRepQuest(&q) })
+
+	return res, nil
 }
 
-func (qu *QuestUsecase) GetQuest(id types.Id) (*Quest, error) {
-	qst, err := qu.quests.GetQuest(id)
+func (qu *QuestUsecase) GetQuest(ctx context.Context, id types.Id) (*Quest, error) {
+	qst, err := qu.quests.GetQuest(ctx, id)
 
 	return FromRepQuest(qst), err
 }
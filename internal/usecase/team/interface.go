@@ -0,0 +1,19 @@
+package team
+
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+)
+
+//go:generate mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=TeamUsecase . Usecase
+
+type Usecase interface {
+	CreateTeam(ctx context.Context, name string) (*Team, error)
+	GetTeams(ctx context.Context) ([]Team, error)
+	// GetTeam returns team with its active members.
+	GetTeam(ctx context.Context, id types.Id) (*Team, error)
+	AddMember(ctx context.Context, teamId, userId types.Id) (*Member, error)
+	// RemoveMember removes user from team together with its contributions to unfinished team quests.
+	RemoveMember(ctx context.Context, teamId, userId types.Id) error
+}
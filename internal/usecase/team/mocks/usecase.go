@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vk_quests/internal/usecase/team (interfaces: Usecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=TeamUsecase . Usecase
+//
+
+// Package mu is a generated GoMock package.
+package mu
+
+import (
+	context "context"
+	reflect "reflect"
+	types "vk_quests/internal/pkg/types"
+	team "vk_quests/internal/usecase/team"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// TeamUsecase is a mock of Usecase interface.
+type TeamUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *TeamUsecaseMockRecorder
+}
+
+// TeamUsecaseMockRecorder is the mock recorder for TeamUsecase.
+type TeamUsecaseMockRecorder struct {
+	mock *TeamUsecase
+}
+
+// NewTeamUsecase creates a new mock instance.
+func NewTeamUsecase(ctrl *gomock.Controller) *TeamUsecase {
+	mock := &TeamUsecase{ctrl: ctrl}
+	mock.recorder = &TeamUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *TeamUsecase) EXPECT() *TeamUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *TeamUsecase) AddMember(arg0 context.Context, arg1, arg2 types.Id) (*team.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*team.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *TeamUsecaseMockRecorder) AddMember(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*TeamUsecase)(nil).AddMember), arg0, arg1, arg2)
+}
+
+// CreateTeam mocks base method.
+func (m *TeamUsecase) CreateTeam(arg0 context.Context, arg1 string) (*team.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", arg0, arg1)
+	ret0, _ := ret[0].(*team.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *TeamUsecaseMockRecorder) CreateTeam(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*TeamUsecase)(nil).CreateTeam), arg0, arg1)
+}
+
+// GetTeam mocks base method.
+func (m *TeamUsecase) GetTeam(arg0 context.Context, arg1 types.Id) (*team.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", arg0, arg1)
+	ret0, _ := ret[0].(*team.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *TeamUsecaseMockRecorder) GetTeam(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*TeamUsecase)(nil).GetTeam), arg0, arg1)
+}
+
+// GetTeams mocks base method.
+func (m *TeamUsecase) GetTeams(arg0 context.Context) ([]team.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeams", arg0)
+	ret0, _ := ret[0].([]team.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeams indicates an expected call of GetTeams.
+func (mr *TeamUsecaseMockRecorder) GetTeams(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeams", reflect.TypeOf((*TeamUsecase)(nil).GetTeams), arg0)
+}
+
+// RemoveMember mocks base method.
+func (m *TeamUsecase) RemoveMember(arg0 context.Context, arg1, arg2 types.Id) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *TeamUsecaseMockRecorder) RemoveMember(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*TeamUsecase)(nil).RemoveMember), arg0, arg1, arg2)
+}
@@ -0,0 +1,50 @@
+package team
+
+import (
+	"vk_quests/internal/pkg/time"
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/team"
+	"vk_quests/pkg/slices"
+)
+
+type Team struct {
+	ID      types.Id
+	Name    string
+	Created time.FormattedTime
+	Members []Member // nil for teams of list
+}
+
+type Member struct {
+	UserId types.Id
+	Name   string
+	Joined time.FormattedTime
+}
+
+func FromRepMember(m *team.Member) *Member {
+	if m == nil {
+		return nil
+	}
+
+	return &Member{
+		UserId: m.UserId,
+		Name:   m.Name,
+		Joined: m.Joined,
+	}
+}
+
+func FromRepTeam(t *team.Team) *Team {
+	if t == nil {
+		return nil
+	}
+
+	res := &Team{
+		ID:      t.ID,
+		Name:    t.Name,
+		Created: t.Created,
+	}
+	if t.Members != nil {
+		res.Members = slices.Map(t.Members, func(m team.Member) Member { return *FromRepMember(&m) })
+	}
+
+	return res
+}
@@ -0,0 +1,161 @@
+package team
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+
+	"vk_quests/internal/pkg/types"
+	tr "vk_quests/internal/repository/team"
+	mrt "vk_quests/internal/repository/team/mocks"
+)
+
+var testError = errors.New("test error")
+
+type TeamUsecaseSuite struct {
+	suite.Suite
+	teamUsecase *TeamUsecase
+	mockTeam    *mrt.TeamRepository
+	gmc         *gomock.Controller
+}
+
+func (tus *TeamUsecaseSuite) BeforeEach(t provider.T) {
+	tus.gmc = gomock.NewController(t)
+	tus.mockTeam = mrt.NewTeamRepository(tus.gmc)
+	tus.teamUsecase = NewTeamUsecase(tus.mockTeam)
+}
+
+func (tus *TeamUsecaseSuite) AfterEach(t provider.T) {
+	tus.gmc.Finish()
+}
+
+func (tus *TeamUsecaseSuite) TestCreateTeamFunction(t provider.T) {
+	t.Title("CreateTeam function of team usecase")
+	t.NewStep("Init test data")
+	name := "Wolves"
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().CreateTeam(context.Background(), name).Return(&tr.Team{ID: 1, Name: name}, nil).Times(1)
+
+		t.NewStep("Check result")
+		team, err := tus.teamUsecase.CreateTeam(context.Background(), name)
+		t.Require().NoError(err)
+		t.Require().Equal(&Team{ID: 1, Name: name}, team)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().CreateTeam(context.Background(), name).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := tus.teamUsecase.CreateTeam(context.Background(), name)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (tus *TeamUsecaseSuite) TestGetTeamsFunction(t provider.T) {
+	t.Title("GetTeams function of team usecase")
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().GetTeams(context.Background()).
+			Return([]tr.Team{{ID: 1, Name: "Wolves"}, {ID: 2, Name: "Foxes"}}, nil).Times(1)
+
+		t.NewStep("Check result")
+		teams, err := tus.teamUsecase.GetTeams(context.Background())
+		t.Require().NoError(err)
+		t.Require().Equal([]Team{{ID: 1, Name: "Wolves"}, {ID: 2, Name: "Foxes"}}, teams)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().GetTeams(context.Background()).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := tus.teamUsecase.GetTeams(context.Background())
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (tus *TeamUsecaseSuite) TestGetTeamFunction(t provider.T) {
+	t.Title("GetTeam function of team usecase")
+	t.NewStep("Init test data")
+	id := types.Id(1)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().GetTeam(context.Background(), id).
+			Return(&tr.Team{ID: id, Name: "Wolves", Members: []tr.Member{{UserId: 2, Name: "Bob"}}}, nil).Times(1)
+
+		t.NewStep("Check result")
+		team, err := tus.teamUsecase.GetTeam(context.Background(), id)
+		t.Require().NoError(err)
+		t.Require().Equal(&Team{ID: id, Name: "Wolves", Members: []Member{{UserId: 2, Name: "Bob"}}}, team)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().GetTeam(context.Background(), id).Return(nil, tr.ErrorTeamNotFound).Times(1)
+
+		t.NewStep("Check result")
+		_, err := tus.teamUsecase.GetTeam(context.Background(), id)
+		t.Require().ErrorIs(err, tr.ErrorTeamNotFound)
+	})
+}
+
+func (tus *TeamUsecaseSuite) TestAddMemberFunction(t provider.T) {
+	t.Title("AddMember function of team usecase")
+	t.NewStep("Init test data")
+	teamId, userId := types.Id(1), types.Id(2)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().AddMember(context.Background(), teamId, userId).
+			Return(&tr.Member{UserId: userId, Name: "Bob"}, nil).Times(1)
+
+		t.NewStep("Check result")
+		member, err := tus.teamUsecase.AddMember(context.Background(), teamId, userId)
+		t.Require().NoError(err)
+		t.Require().Equal(&Member{UserId: userId, Name: "Bob"}, member)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().AddMember(context.Background(), teamId, userId).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := tus.teamUsecase.AddMember(context.Background(), teamId, userId)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (tus *TeamUsecaseSuite) TestRemoveMemberFunction(t provider.T) {
+	t.Title("RemoveMember function of team usecase")
+	t.NewStep("Init test data")
+	teamId, userId := types.Id(1), types.Id(2)
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().RemoveMember(context.Background(), teamId, userId).Return(nil).Times(1)
+
+		t.NewStep("Check result")
+		t.Require().NoError(tus.teamUsecase.RemoveMember(context.Background(), teamId, userId))
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		tus.mockTeam.EXPECT().RemoveMember(context.Background(), teamId, userId).Return(testError).Times(1)
+
+		t.NewStep("Check result")
+		t.Require().ErrorIs(tus.teamUsecase.RemoveMember(context.Background(), teamId, userId), testError)
+	})
+}
+
+func TestRunTeamUsecaseSuite(t *testing.T) {
+	suite.RunSuite(t, new(TeamUsecaseSuite))
+}
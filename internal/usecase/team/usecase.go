@@ -0,0 +1,50 @@
+package team
+
+import (
+	"context"
+
+	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/team"
+	"vk_quests/pkg/slices"
+)
+
+type TeamUsecase struct {
+	teams team.Repository
+}
+
+func NewTeamUsecase(teams team.Repository) *TeamUsecase {
+	return &TeamUsecase{
+		teams: teams,
+	}
+}
+
+func (tu *TeamUsecase) CreateTeam(ctx context.Context, name string) (*Team, error) {
+	createdTeam, err := tu.teams.CreateTeam(ctx, name)
+
+	return FromRepTeam(createdTeam), err
+}
+
+func (tu *TeamUsecase) GetTeams(ctx context.Context) ([]Team, error) {
+	teams, err := tu.teams.GetTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(teams, func(t team.Team) Team { return *FromRepTeam(&t) }), nil
+}
+
+func (tu *TeamUsecase) GetTeam(ctx context.Context, id types.Id) (*Team, error) {
+	tm, err := tu.teams.GetTeam(ctx, id)
+
+	return FromRepTeam(tm), err
+}
+
+func (tu *TeamUsecase) AddMember(ctx context.Context, teamId, userId types.Id) (*Member, error) {
+	member, err := tu.teams.AddMember(ctx, teamId, userId)
+
+	return FromRepMember(member), err
+}
+
+func (tu *TeamUsecase) RemoveMember(ctx context.Context, teamId, userId types.Id) error {
+	return tu.teams.RemoveMember(ctx, teamId, userId)
+}
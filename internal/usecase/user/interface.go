@@ -1,19 +1,90 @@
 package user
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/pkg/errors"
 	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/usecase/quest"
 )
 
+// Random is source of rolls of random quests, it must be safe for concurrent use.
+type Random interface {
+	// Float64 returns number in [0.0, 1.0).
+	Float64() float64
+}
+
 //go:generate mockgen -destination=mocks/usecase.go -package=mu -mock_names=Usecase=UserUsecase . Usecase
 
-var QuestNotApplied = errors.New("quest not applied")
+var (
+	QuestNotApplied  = errors.New("quest not applied")
+	QuestStepApplied = errors.New("quest step applied")
+
+	ErrorQuestCooldownActive = errors.New("quest cooldown active")
+
+	ErrorPrerequisitesNotCompleted = errors.New("prerequisites of quest are not completed")
+
+	ErrorQuestNotActive = errors.New("quest is outside of its availability window")
+	ErrorQuestArchived  = errors.New("quest is archived")
+
+	ErrorAttemptsLimitReached = errors.New("daily limit of quest attempts is reached")
+
+	ErrorIdempotencyKeyInProgress = errors.New("request with idempotency key is in progress")
+	ErrorIdempotencyKeyMismatch   = errors.New("idempotency key is used with other parameters")
+
+	ErrorSelfTransfer = errors.New("transfer to yourself")
+)
+
+// CooldownError is returned when quest is completed again before its cooldown ends.
+// It matches ErrorQuestCooldownActive with errors.Is.
+type CooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (ce *CooldownError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrorQuestCooldownActive, ce.RetryAfter)
+}
+
+func (ce *CooldownError) Is(target error) bool {
+	return target == ErrorQuestCooldownActive
+}
 
 type Usecase interface {
-	CreateUser(name string) (*User, error)
-	DeleteUser(id types.Id) (*User, error)
-	UpdateUser(id types.Id, name string) (*User, error)
-	GetUsers() ([]User, error)
-	GetUserHistory(id types.Id) ([]HistoryRecord, error)
-	ApplyQuests(questId, userId types.Id) error
+	// CreateUser creates user invited by owner of referralCode, empty referralCode means user was not invited.
+	CreateUser(ctx context.Context, name, referralCode string) (*User, error)
+	// DeleteUser deletes user according to configured policy.
+	DeleteUser(ctx context.Context, id types.Id) (*User, error)
+	// AnonymizeUser scrubs name of user and deletes it, history and ledger of user are kept.
+	AnonymizeUser(ctx context.Context, id types.Id) (*User, error)
+	// ExportUser returns all data stored about user, deleted user is exported too.
+	ExportUser(ctx context.Context, id types.Id) (*Export, error)
+	UpdateUser(ctx context.Context, id types.Id, name string) (*User, error)
+	GetUsers(ctx context.Context, query *UsersQuery) (*UsersPage, error)
+	// GetUser returns user with aggregates of its completion history.
+	GetUser(ctx context.Context, id types.Id) (*UserStats, error)
+	GetUserHistory(ctx context.Context, id types.Id, query *HistoryQuery) (*HistoryPage, error)
+	// ApplyQuests applies completion event to quest, amount advances counter quest.
+	// Progress is returned for staged, counter, streak and team quests, together with QuestStepApplied
+	// if quest is not finished yet or streak has not reached milestone.
+	// Streak days follow calendar of usecase timezone.
+	// Completion of configured referral quest by invited user pays referral bonus to it and its referrer once.
+	ApplyQuests(ctx context.Context, questId, userId types.Id, amount uint32) (*QuestProgress, error)
+	// ApplyQuestsIdempotent works as ApplyQuests, but result of first request with key is stored
+	// and returned for repeated requests with the same key instead of applying quest again.
+	ApplyQuestsIdempotent(ctx context.Context, key string, questId, userId types.Id, amount uint32) (*QuestProgress, error)
+	// RevokeQuest revokes the last completion of quest by user and claws back its reward
+	// according to configured policy.
+	RevokeQuest(ctx context.Context, userId, questId types.Id, reason string) (*Revocation, error)
+	GetUserProgress(ctx context.Context, id types.Id) ([]Progress, error)
+	// GetUserAttempts returns page of user attempts of random quests.
+	GetUserAttempts(ctx context.Context, id types.Id, query *AttemptsQuery) (*AttemptsPage, error)
+	// GetAvailableQuests returns page of quests, which user can complete now.
+	GetAvailableQuests(ctx context.Context, id types.Id, query *AvailableQuestsQuery) (*quest.QuestsPage, error)
+	Debit(ctx context.Context, userId types.Id, amount uint64, reason string) (*Transaction, error)
+	// Transfer moves amount from one user to another, both sides are stored in ledger.
+	Transfer(ctx context.Context, from, to types.Id, amount uint64) (*Transfer, error)
+	// ReconcileBalance recalculates cached user balance from ledger transactions.
+	ReconcileBalance(ctx context.Context, userId types.Id) (*Reconciliation, error)
 }
@@ -10,8 +10,10 @@
 package mu
 
 import (
+	context "context"
 	reflect "reflect"
 	types "vk_quests/internal/pkg/types"
+	quest "vk_quests/internal/usecase/quest"
 	user "vk_quests/internal/usecase/user"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,91 +42,257 @@ func (m *UserUsecase) EXPECT() *UserUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AnonymizeUser mocks base method.
+func (m *UserUsecase) AnonymizeUser(arg0 context.Context, arg1 types.Id) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", arg0, arg1)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *UserUsecaseMockRecorder) AnonymizeUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*UserUsecase)(nil).AnonymizeUser), arg0, arg1)
+}
+
 // ApplyQuests mocks base method.
-func (m *UserUsecase) ApplyQuests(arg0, arg1 types.Id) error {
+func (m *UserUsecase) ApplyQuests(arg0 context.Context, arg1, arg2 types.Id, arg3 uint32) (*user.QuestProgress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplyQuests", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ApplyQuests", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*user.QuestProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ApplyQuests indicates an expected call of ApplyQuests.
-func (mr *UserUsecaseMockRecorder) ApplyQuests(arg0, arg1 any) *gomock.Call {
+func (mr *UserUsecaseMockRecorder) ApplyQuests(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyQuests", reflect.TypeOf((*UserUsecase)(nil).ApplyQuests), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyQuests", reflect.TypeOf((*UserUsecase)(nil).ApplyQuests), arg0, arg1, arg2, arg3)
+}
+
+// ApplyQuestsIdempotent mocks base method.
+func (m *UserUsecase) ApplyQuestsIdempotent(arg0 context.Context, arg1 string, arg2, arg3 types.Id, arg4 uint32) (*user.QuestProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyQuestsIdempotent", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*user.QuestProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyQuestsIdempotent indicates an expected call of ApplyQuestsIdempotent.
+func (mr *UserUsecaseMockRecorder) ApplyQuestsIdempotent(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyQuestsIdempotent", reflect.TypeOf((*UserUsecase)(nil).ApplyQuestsIdempotent), arg0, arg1, arg2, arg3, arg4)
 }
 
 // CreateUser mocks base method.
-func (m *UserUsecase) CreateUser(arg0 string) (*user.User, error) {
+func (m *UserUsecase) CreateUser(arg0 context.Context, arg1, arg2 string) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0)
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *UserUsecaseMockRecorder) CreateUser(arg0 any) *gomock.Call {
+func (mr *UserUsecaseMockRecorder) CreateUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*UserUsecase)(nil).CreateUser), arg0, arg1, arg2)
+}
+
+// Debit mocks base method.
+func (m *UserUsecase) Debit(arg0 context.Context, arg1 types.Id, arg2 uint64, arg3 string) (*user.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*user.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Debit indicates an expected call of Debit.
+func (mr *UserUsecaseMockRecorder) Debit(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*UserUsecase)(nil).CreateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debit", reflect.TypeOf((*UserUsecase)(nil).Debit), arg0, arg1, arg2, arg3)
 }
 
 // DeleteUser mocks base method.
-func (m *UserUsecase) DeleteUser(arg0 types.Id) (*user.User, error) {
+func (m *UserUsecase) DeleteUser(arg0 context.Context, arg1 types.Id) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0)
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *UserUsecaseMockRecorder) DeleteUser(arg0 any) *gomock.Call {
+func (mr *UserUsecaseMockRecorder) DeleteUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*UserUsecase)(nil).DeleteUser), arg0, arg1)
+}
+
+// ExportUser mocks base method.
+func (m *UserUsecase) ExportUser(arg0 context.Context, arg1 types.Id) (*user.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUser", arg0, arg1)
+	ret0, _ := ret[0].(*user.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUser indicates an expected call of ExportUser.
+func (mr *UserUsecaseMockRecorder) ExportUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*UserUsecase)(nil).DeleteUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUser", reflect.TypeOf((*UserUsecase)(nil).ExportUser), arg0, arg1)
+}
+
+// GetAvailableQuests mocks base method.
+func (m *UserUsecase) GetAvailableQuests(arg0 context.Context, arg1 types.Id, arg2 *user.AvailableQuestsQuery) (*quest.QuestsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableQuests", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*quest.QuestsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableQuests indicates an expected call of GetAvailableQuests.
+func (mr *UserUsecaseMockRecorder) GetAvailableQuests(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableQuests", reflect.TypeOf((*UserUsecase)(nil).GetAvailableQuests), arg0, arg1, arg2)
+}
+
+// GetUser mocks base method.
+func (m *UserUsecase) GetUser(arg0 context.Context, arg1 types.Id) (*user.UserStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(*user.UserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *UserUsecaseMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*UserUsecase)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserAttempts mocks base method.
+func (m *UserUsecase) GetUserAttempts(arg0 context.Context, arg1 types.Id, arg2 *user.AttemptsQuery) (*user.AttemptsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAttempts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*user.AttemptsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAttempts indicates an expected call of GetUserAttempts.
+func (mr *UserUsecaseMockRecorder) GetUserAttempts(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAttempts", reflect.TypeOf((*UserUsecase)(nil).GetUserAttempts), arg0, arg1, arg2)
 }
 
 // GetUserHistory mocks base method.
-func (m *UserUsecase) GetUserHistory(arg0 types.Id) ([]user.HistoryRecord, error) {
+func (m *UserUsecase) GetUserHistory(arg0 context.Context, arg1 types.Id, arg2 *user.HistoryQuery) (*user.HistoryPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserHistory", arg0)
-	ret0, _ := ret[0].([]user.HistoryRecord)
+	ret := m.ctrl.Call(m, "GetUserHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*user.HistoryPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserHistory indicates an expected call of GetUserHistory.
-func (mr *UserUsecaseMockRecorder) GetUserHistory(arg0 any) *gomock.Call {
+func (mr *UserUsecaseMockRecorder) GetUserHistory(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHistory", reflect.TypeOf((*UserUsecase)(nil).GetUserHistory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHistory", reflect.TypeOf((*UserUsecase)(nil).GetUserHistory), arg0, arg1, arg2)
+}
+
+// GetUserProgress mocks base method.
+func (m *UserUsecase) GetUserProgress(arg0 context.Context, arg1 types.Id) ([]user.Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProgress", arg0, arg1)
+	ret0, _ := ret[0].([]user.Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProgress indicates an expected call of GetUserProgress.
+func (mr *UserUsecaseMockRecorder) GetUserProgress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProgress", reflect.TypeOf((*UserUsecase)(nil).GetUserProgress), arg0, arg1)
 }
 
 // GetUsers mocks base method.
-func (m *UserUsecase) GetUsers() ([]user.User, error) {
+func (m *UserUsecase) GetUsers(arg0 context.Context, arg1 *user.UsersQuery) (*user.UsersPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsers")
-	ret0, _ := ret[0].([]user.User)
+	ret := m.ctrl.Call(m, "GetUsers", arg0, arg1)
+	ret0, _ := ret[0].(*user.UsersPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUsers indicates an expected call of GetUsers.
-func (mr *UserUsecaseMockRecorder) GetUsers() *gomock.Call {
+func (mr *UserUsecaseMockRecorder) GetUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*UserUsecase)(nil).GetUsers), arg0, arg1)
+}
+
+// ReconcileBalance mocks base method.
+func (m *UserUsecase) ReconcileBalance(arg0 context.Context, arg1 types.Id) (*user.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileBalance", arg0, arg1)
+	ret0, _ := ret[0].(*user.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileBalance indicates an expected call of ReconcileBalance.
+func (mr *UserUsecaseMockRecorder) ReconcileBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileBalance", reflect.TypeOf((*UserUsecase)(nil).ReconcileBalance), arg0, arg1)
+}
+
+// RevokeQuest mocks base method.
+func (m *UserUsecase) RevokeQuest(arg0 context.Context, arg1, arg2 types.Id, arg3 string) (*user.Revocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeQuest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*user.Revocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeQuest indicates an expected call of RevokeQuest.
+func (mr *UserUsecaseMockRecorder) RevokeQuest(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeQuest", reflect.TypeOf((*UserUsecase)(nil).RevokeQuest), arg0, arg1, arg2, arg3)
+}
+
+// Transfer mocks base method.
+func (m *UserUsecase) Transfer(arg0 context.Context, arg1, arg2 types.Id, arg3 uint64) (*user.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*user.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *UserUsecaseMockRecorder) Transfer(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*UserUsecase)(nil).GetUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*UserUsecase)(nil).Transfer), arg0, arg1, arg2, arg3)
 }
 
 // UpdateUser mocks base method.
-func (m *UserUsecase) UpdateUser(arg0 types.Id, arg1 string) (*user.User, error) {
+func (m *UserUsecase) UpdateUser(arg0 context.Context, arg1 types.Id, arg2 string) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *UserUsecaseMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
+func (mr *UserUsecaseMockRecorder) UpdateUser(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*UserUsecase)(nil).UpdateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*UserUsecase)(nil).UpdateUser), arg0, arg1, arg2)
 }
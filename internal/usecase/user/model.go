@@ -1,16 +1,25 @@
 package user
 
 import (
+	"strconv"
+	stdtime "time"
+
+	"vk_quests/internal/pkg/page"
 	"vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/ledger"
+	qr "vk_quests/internal/repository/quest"
 	"vk_quests/internal/repository/user"
 	"vk_quests/internal/usecase/quest"
+	"vk_quests/pkg/slices"
 )
 
 type User struct {
-	ID      types.Id
-	Name    string
-	Balance uint64
+	ID           types.Id
+	Name         string
+	Balance      int64
+	ReferralCode string
+	ReferrerId   *types.Id // nil if user was not invited
 }
 
 func FromRepUser(u *user.User) *User {
@@ -19,22 +28,414 @@ func FromRepUser(u *user.User) *User {
 	}
 
 	return &User{
-		ID:      u.ID,
-		Name:    u.Name,
-		Balance: u.Balance,
+		ID:           u.ID,
+		Name:         u.Name,
+		Balance:      u.Balance,
+		ReferralCode: u.ReferralCode,
+		ReferrerId:   u.ReferrerId,
+	}
+}
+
+// Export is bundle of all data stored about user.
+type Export struct {
+	User      User
+	DeletedAt *time.FormattedTime // nil if user is not deleted
+	History   []HistoryRecord
+	Attempts  []AttemptRecord
+}
+
+func FromRepExport(e *user.Export) *Export {
+	return &Export{
+		User:      *FromRepUser(&e.User),
+		DeletedAt: e.DeletedAt,
+		History:   slices.Map(e.History, func(record user.HistoryRecord) HistoryRecord { return *FromRepHistory(&record) }),
+		Attempts:  slices.Map(e.Attempts, func(record user.AttemptRecord) AttemptRecord { return *FromRepAttempt(&record) }),
+	}
+}
+
+// TypeStats is aggregate of user completions of quests with one type.
+type TypeStats struct {
+	Completed uint64
+	Earned    uint64
+}
+
+// UserStats is user with aggregates of completion history.
+type UserStats struct {
+	User           User
+	Completed      uint64
+	Earned         uint64
+	LastCompletion *time.FormattedTime // nil if user has not completed any quest
+	ByType         map[types.QuestType]TypeStats
+}
+
+func FromRepUserStats(us *user.UserStats) *UserStats {
+	byType := make(map[types.QuestType]TypeStats, len(us.ByType))
+	for tp, stats := range us.ByType {
+		byType[tp] = TypeStats{
+			Completed: stats.Completed,
+			Earned:    stats.Earned,
+		}
+	}
+
+	return &UserStats{
+		User:           *FromRepUser(&us.User),
+		Completed:      us.Completed,
+		Earned:         us.Earned,
+		LastCompletion: us.LastCompletion,
+		ByType:         byType,
+	}
+}
+
+type UsersQuery struct {
+	NamePrefix string
+	MinBalance *int64
+	MaxBalance *int64
+	Sort       types.UsersSort
+	Order      page.Order
+	Cursor     string // empty means first page
+	Limit      uint64
+}
+
+// ToRepUsersQuery returns query of one more user than limit to find out if there is next page.
+func (uq *UsersQuery) ToRepUsersQuery() (*user.UsersQuery, error) {
+	var after *page.Cursor
+	if uq.Cursor != "" {
+		cursor, err := page.DecodeCursor(uq.Cursor, string(uq.Sort), uq.Order)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	return &user.UsersQuery{
+		NamePrefix: uq.NamePrefix,
+		MinBalance: uq.MinBalance,
+		MaxBalance: uq.MaxBalance,
+		Sort:       uq.Sort,
+		Order:      uq.Order,
+		After:      after,
+		Limit:      page.NormalizeLimit(uq.Limit) + 1,
+	}, nil
+}
+
+type UsersPage struct {
+	Users      []User
+	NextCursor string // empty if there is no next page
+}
+
+func usersCursor(u *user.User, sort types.UsersSort, order page.Order) *page.Cursor {
+	cursor := &page.Cursor{
+		Sort:  string(sort),
+		Order: order,
+		ID:    u.ID,
+	}
+
+	switch sort {
+	case types.UsersSortName:
+		cursor.Value = u.Name
+	case types.UsersSortBalance:
+		cursor.Value = strconv.FormatInt(u.Balance, 10)
 	}
+
+	return cursor
+}
+
+// QuestSnapshot is state of quest at the moment of its completion.
+type QuestSnapshot struct {
+	Name        string
+	Description string
+	Type        types.QuestType
 }
 
 type HistoryRecord struct {
-	Quest   *quest.Quest
-	Created time.FormattedTime
-	Balance uint64
+	Snapshot       *QuestSnapshot // nil for transfers
+	Award          types.Cost
+	BaseAward      types.Cost // award before boost multiplier was applied
+	Multiplier     float64
+	Source         types.HistorySource
+	CounterpartyId *types.Id    // second user of transfer
+	Reason         string       // reason of revocation for compensating records
+	Quest          *quest.Quest // current state of quest, nil if quest was deleted
+	Created        time.FormattedTime
+	Balance        int64
 }
 
 func FromRepHistory(hr *user.HistoryRecord) *HistoryRecord {
+	var snapshot *QuestSnapshot
+	if hr.Snapshot != nil {
+		snapshot = &QuestSnapshot{
+			Name:        hr.Snapshot.Name,
+			Description: hr.Snapshot.Description,
+			Type:        hr.Snapshot.Type,
+		}
+	}
+
 	return &HistoryRecord{
-		Quest:   quest.FromRepQuest(hr.Quest),
-		Created: hr.Created,
-		Balance: hr.Balance,
+		Snapshot:       snapshot,
+		Award:          hr.Award,
+		BaseAward:      hr.BaseAward,
+		Multiplier:     hr.Multiplier,
+		Source:         hr.Source,
+		CounterpartyId: hr.CounterpartyId,
+		Reason:         hr.Reason,
+		Quest:          quest.FromRepQuest(hr.Quest),
+		Created:        hr.Created,
+		Balance:        hr.Balance,
+	}
+}
+
+// historySort is sort of history cursors, history is always ordered by completion time.
+const historySort = "created"
+
+type HistoryQuery struct {
+	From      *stdtime.Time // inclusive
+	To        *stdtime.Time // exclusive
+	QuestType *types.QuestType
+	Order     page.Order
+	Cursor    string // empty means first page
+	Limit     uint64
+}
+
+// ToRepHistoryQuery returns query of one more record than limit to find out if there is next page.
+func (hq *HistoryQuery) ToRepHistoryQuery(userId types.Id) (*user.HistoryQuery, error) {
+	var after *page.Cursor
+	if hq.Cursor != "" {
+		cursor, err := page.DecodeCursor(hq.Cursor, historySort, hq.Order)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	return &user.HistoryQuery{
+		UserId:    userId,
+		From:      hq.From,
+		To:        hq.To,
+		QuestType: hq.QuestType,
+		Order:     hq.Order,
+		After:     after,
+		Limit:     page.NormalizeLimit(hq.Limit) + 1,
+	}, nil
+}
+
+type HistoryPage struct {
+	Records    []HistoryRecord
+	NextCursor string // empty if there is no next page
+}
+
+func historyCursor(hr *user.HistoryRecord, order page.Order) *page.Cursor {
+	return &page.Cursor{
+		Sort:  historySort,
+		Order: order,
+		Value: hr.Created.Format(stdtime.RFC3339Nano),
+		ID:    hr.ID,
+	}
+}
+
+type Revocation struct {
+	ID          types.Id
+	QuestId     types.Id
+	Snapshot    QuestSnapshot
+	Award       types.Cost
+	Completed   time.FormattedTime
+	Reason      string
+	Transaction Transaction // debit clawing back reward, its amount is less than award for partial clawback
+	Created     time.FormattedTime
+}
+
+func FromRepRevocation(r *user.Revocation) *Revocation {
+	if r == nil {
+		return nil
+	}
+
+	return &Revocation{
+		ID:      r.ID,
+		QuestId: r.QuestId,
+		Snapshot: QuestSnapshot{
+			Name:        r.Snapshot.Name,
+			Description: r.Snapshot.Description,
+			Type:        r.Snapshot.Type,
+		},
+		Award:       r.Award,
+		Completed:   r.Completed,
+		Reason:      r.Reason,
+		Transaction: *FromRepTransaction(&r.Transaction),
+		Created:     r.Created,
+	}
+}
+
+type AttemptRecord struct {
+	ID      types.Id
+	QuestId *types.Id // nil if quest was deleted
+	Success bool
+	Roll    *float64 // nil if success is guaranteed by pity
+	Created time.FormattedTime
+}
+
+func FromRepAttempt(ar *user.AttemptRecord) *AttemptRecord {
+	return &AttemptRecord{
+		ID:      ar.ID,
+		QuestId: ar.QuestId,
+		Success: ar.Attempt.Success,
+		Roll:    ar.Attempt.Roll,
+		Created: ar.Created,
+	}
+}
+
+// attemptsSort is sort of attempts cursors, attempts are always ordered by id.
+const attemptsSort = "id"
+
+type AttemptsQuery struct {
+	QuestId *types.Id
+	Order   page.Order
+	Cursor  string // empty means first page
+	Limit   uint64
+}
+
+// ToRepAttemptsQuery returns query of one more attempt than limit to find out if there is next page.
+func (aq *AttemptsQuery) ToRepAttemptsQuery(userId types.Id) (*user.AttemptsQuery, error) {
+	var after *page.Cursor
+	if aq.Cursor != "" {
+		cursor, err := page.DecodeCursor(aq.Cursor, attemptsSort, aq.Order)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	return &user.AttemptsQuery{
+		UserId:  userId,
+		QuestId: aq.QuestId,
+		Order:   aq.Order,
+		After:   after,
+		Limit:   page.NormalizeLimit(aq.Limit) + 1,
+	}, nil
+}
+
+type AttemptsPage struct {
+	Attempts   []AttemptRecord
+	NextCursor string // empty if there is no next page
+}
+
+func attemptsCursor(ar *user.AttemptRecord, order page.Order) *page.Cursor {
+	return &page.Cursor{
+		Sort:  attemptsSort,
+		Order: order,
+		ID:    ar.ID,
+	}
+}
+
+type AvailableQuestsQuery struct {
+	Cursor string // empty means first page
+	Limit  uint64
+}
+
+// ToRepAvailableQuestsQuery returns query of one more quest than limit to find out if there is next page.
+func (aq *AvailableQuestsQuery) ToRepAvailableQuestsQuery(userId types.Id) (*user.AvailableQuestsQuery, error) {
+	var after *page.Cursor
+	if aq.Cursor != "" {
+		cursor, err := page.DecodeCursor(aq.Cursor, string(types.QuestsSortID), page.Asc)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	return &user.AvailableQuestsQuery{
+		UserId: userId,
+		After:  after,
+		Limit:  page.NormalizeLimit(aq.Limit) + 1,
+	}, nil
+}
+
+func availableQuestsCursor(q *qr.Quest) *page.Cursor {
+	return &page.Cursor{
+		Sort:  string(types.QuestsSortID),
+		Order: page.Asc,
+		ID:    q.ID,
+	}
+}
+
+type Progress struct {
+	Quest   *quest.Quest
+	Step    uint32
+	Updated time.FormattedTime
+}
+
+func FromRepProgress(p *user.Progress) *Progress {
+	return &Progress{
+		Quest:   quest.FromRepQuest(p.Quest),
+		Step:    p.Step,
+		Updated: p.Updated,
+	}
+}
+
+// QuestProgress is progress of staged or counter quest after completion event.
+type QuestProgress struct {
+	Step  uint32
+	Total uint32
+}
+
+type Transaction struct {
+	ID             types.Id
+	UserId         types.Id
+	Kind           string
+	Amount         uint64
+	Reason         string
+	QuestId        *types.Id
+	CounterpartyId *types.Id
+	Balance        int64
+	Created        time.FormattedTime
+}
+
+func FromRepTransaction(t *ledger.Transaction) *Transaction {
+	if t == nil {
+		return nil
+	}
+
+	return &Transaction{
+		ID:             t.ID,
+		UserId:         t.UserId,
+		Kind:           string(t.Kind),
+		Amount:         t.Amount,
+		Reason:         t.Reason,
+		QuestId:        t.QuestId,
+		CounterpartyId: t.CounterpartyId,
+		Balance:        t.Balance,
+		Created:        t.Created,
+	}
+}
+
+type Transfer struct {
+	Debit  Transaction
+	Credit Transaction
+}
+
+func FromRepTransfer(t *ledger.Transfer) *Transfer {
+	if t == nil {
+		return nil
+	}
+
+	return &Transfer{
+		Debit:  *FromRepTransaction(&t.Debit),
+		Credit: *FromRepTransaction(&t.Credit),
+	}
+}
+
+type Reconciliation struct {
+	UserId types.Id
+	Cached int64
+	Ledger int64
+}
+
+func FromRepReconciliation(r *ledger.Reconciliation) *Reconciliation {
+	if r == nil {
+		return nil
+	}
+
+	return &Reconciliation{
+		UserId: r.UserId,
+		Cached: r.Cached,
+		Ledger: r.Ledger,
 	}
 }
@@ -1,47 +1,99 @@
 package user
 
 import (
+	"context"
 	"math/rand"
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	"vk_quests/internal/pkg/types"
+	"vk_quests/internal/repository/idempotency"
+	"vk_quests/internal/repository/ledger"
 	"vk_quests/internal/repository/quest"
 	"vk_quests/internal/repository/user"
+	qu "vk_quests/internal/usecase/quest"
 	"vk_quests/pkg/slices"
 )
 
-var rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+// lockedRandom is Random safe for concurrent use.
+type lockedRandom struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func NewRandom(seed int64) Random {
+	return &lockedRandom{rnd: rand.New(rand.NewSource(seed))}
+}
+
+func (lr *lockedRandom) Float64() float64 {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	return lr.rnd.Float64()
+}
 
-const CompleteChance = 0.5
+// Config holds settings of user usecase.
+type Config struct {
+	KeyTTL             time.Duration       // how long result of request with idempotency key is stored
+	DailyTransferLimit uint64              // max sum of transfers of one user during last day, 0 - no limit
+	DailyAttemptsLimit uint32              // max attempts of one random quest by user during last day, 0 - no limit
+	RevokePolicy       ledger.RevokePolicy // how spent reward of revoked completion is clawed back
+	DeletePolicy       user.DeletePolicy   // what deletion of user does with its data
+	Location           *time.Location      // timezone of calendar days of streak quests
+	Referral           user.Referral
+}
 
 type UserUsecase struct {
 	users  user.Repository
-	quests quest.Repository
+	ledger ledger.Repository
+	keys   idempotency.Repository
+	cfg    Config
+	rnd    Random
 }
 
-func NewUserUsecase(users user.Repository, quests quest.Repository) *UserUsecase {
+func NewUserUsecase(users user.Repository, ledger ledger.Repository, keys idempotency.Repository, cfg Config,
+	rnd Random) *UserUsecase {
 	return &UserUsecase{
 		users:  users,
-		quests: quests,
+		ledger: ledger,
+		keys:   keys,
+		cfg:    cfg,
+		rnd:    rnd,
 	}
 }
 
-func (uu *UserUsecase) CreateUser(name string) (*User, error) {
-	usr, err := uu.users.CreateUser(&user.User{
+func (uu *UserUsecase) CreateUser(ctx context.Context, name, referralCode string) (*User, error) {
+	usr, err := uu.users.CreateUser(ctx, &user.User{
 		Name: name,
-	})
+	}, referralCode)
+
+	return FromRepUser(usr), err
+}
+
+func (uu *UserUsecase) DeleteUser(ctx context.Context, id types.Id) (*User, error) {
+	usr, err := uu.users.DeleteUser(ctx, id, uu.cfg.DeletePolicy)
 
 	return FromRepUser(usr), err
 }
 
-func (uu *UserUsecase) DeleteUser(id types.Id) (*User, error) {
-	usr, err := uu.users.DeleteUser(id)
+func (uu *UserUsecase) AnonymizeUser(ctx context.Context, id types.Id) (*User, error) {
+	usr, err := uu.users.AnonymizeUser(ctx, id)
 
 	return FromRepUser(usr), err
 }
 
-func (uu *UserUsecase) UpdateUser(id types.Id, name string) (*User, error) {
-	usr, err := uu.users.UpdateUser(&user.User{
+func (uu *UserUsecase) ExportUser(ctx context.Context, id types.Id) (*Export, error) {
+	export, err := uu.users.ExportUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromRepExport(export), nil
+}
+
+func (uu *UserUsecase) UpdateUser(ctx context.Context, id types.Id, name string) (*User, error) {
+	usr, err := uu.users.UpdateUser(ctx, &user.User{
 		ID:   id,
 		Name: name,
 	})
@@ -49,41 +101,337 @@ func (uu *UserUsecase) UpdateUser(id types.Id, name string) (*User, error) {
 	return FromRepUser(usr), err
 }
 
-func (uu *UserUsecase) GetUsers() ([]User, error) {
-	usrs, err := uu.users.GetUsers()
+func (uu *UserUsecase) GetUsers(ctx context.Context, query *UsersQuery) (*UsersPage, error) {
+	repQuery, err := query.ToRepUsersQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	usrs, err := uu.users.GetUsers(ctx, repQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	return slices.Map(usrs, func(usr user.User) User { return *FromRepUser(&usr) }), nil
+	res := &UsersPage{}
+	if limit := int(repQuery.Limit) - 1; len(usrs) > limit {
+		usrs = usrs[:limit]
+		res.NextCursor = usersCursor(&usrs[limit-1], query.Sort, query.Order).Encode()
+	}
+
+	res.Users = slices.Map(usrs, func(usr user.User) User { return *FromRepUser(&usr) })
+
+	return res, nil
 }
 
-func (uu *UserUsecase) GetUserHistory(id types.Id) ([]HistoryRecord, error) {
-	history, err := uu.users.GetHistory(id)
+func (uu *UserUsecase) GetUser(ctx context.Context, id types.Id) (*UserStats, error) {
+	stats, err := uu.users.GetUser(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return slices.Map(history, func(record user.HistoryRecord) HistoryRecord { return *FromRepHistory(&record) }), nil
+	return FromRepUserStats(stats), nil
 }
 
-func (uu *UserUsecase) ApplyQuests(questId, userId types.Id) error {
-	qst, err := uu.quests.GetQuest(questId)
+func (uu *UserUsecase) GetUserHistory(ctx context.Context, id types.Id, query *HistoryQuery) (*HistoryPage, error) {
+	repQuery, err := query.ToRepHistoryQuery(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := uu.users.HasUser(userId); err != nil {
-		return err
+	history, err := uu.users.GetHistory(ctx, repQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &HistoryPage{}
+	if limit := int(repQuery.Limit) - 1; len(history) > limit {
+		history = history[:limit]
+		res.NextCursor = historyCursor(&history[limit-1], query.Order).Encode()
+	}
+
+	res.Records = slices.Map(history, func(record user.HistoryRecord) HistoryRecord { return *FromRepHistory(&record) })
+
+	return res, nil
+}
+
+func (uu *UserUsecase) ApplyQuests(ctx context.Context, questId, userId types.Id, amount uint32) (*QuestProgress, error) {
+	day := time.Now().In(uu.cfg.Location)
+	progress, err := uu.users.CompleteQuest(ctx, userId, questId, amount, day, uu.cfg.Referral, uu.checkCompletion)
+	if err != nil {
+		if errors.Is(err, user.ErrorAttemptFailed) {
+			return nil, QuestNotApplied
+		}
+		return nil, err
+	}
+
+	total := qu.FromRepQuest(progress.Quest).Total()
+	if total == 0 {
+		return nil, nil
+	}
+
+	finished := progress.Step >= total
+	// Streak quest pays reward at every milestone, not only at the last one
+	if progress.Quest.Type == types.STREAK {
+		_, finished = progress.Quest.MilestoneReward(progress.Step)
+	}
+
+	res := &QuestProgress{Step: progress.Step, Total: total}
+	if !finished {
+		return res, QuestStepApplied
+	}
+
+	return res, nil
+}
+
+func (uu *UserUsecase) ApplyQuestsIdempotent(ctx context.Context, key string, questId, userId types.Id,
+	amount uint32,
+) (*QuestProgress, error) {
+	storedKey, err := uu.keys.ReserveKey(ctx, &idempotency.Key{
+		Key:     key,
+		UserId:  userId,
+		QuestId: questId,
+		Amount:  amount,
+	}, uu.cfg.KeyTTL)
+	if err != nil {
+		if errors.Is(err, idempotency.ErrorKeyExists) {
+			return replayOutcome(storedKey, questId, userId, amount)
+		}
+		return nil, err
+	}
+
+	progress, applyErr := uu.ApplyQuests(ctx, questId, userId, amount)
+
+	// Key must be released or completed even if request was cancelled while quest was applied.
+	ctx = context.WithoutCancel(ctx)
+
+	outcome, conflict, stored := outcomeOf(applyErr)
+	if !stored {
+		// Outcome is not final, so repeated request must apply quest again.
+		if err := uu.keys.DeleteKey(ctx, key); err != nil {
+			return nil, errors.Wrapf(err, "can't release idempotency key after error: %s", applyErr)
+		}
+		return nil, applyErr
+	}
+
+	step, total := uint32(0), uint32(0)
+	if progress != nil {
+		step, total = progress.Step, progress.Total
+	}
+
+	if err := uu.keys.SetOutcome(ctx, key, outcome, conflict, step, total); err != nil {
+		return nil, errors.Wrapf(err, "can't store outcome %s of idempotency key", outcome)
+	}
+
+	return progress, applyErr
+}
+
+// conflictErrors are errors of ApplyQuests stored as Conflict outcome by their reasons.
+var conflictErrors = map[idempotency.ConflictReason]error{
+	idempotency.ConflictCompleted:      user.ErrorUserAlreadyCompleteQuest,
+	idempotency.ConflictStreakExtended: user.ErrorStreakAlreadyExtended,
+	idempotency.ConflictContributed:    user.ErrorAlreadyContributed,
+}
+
+// outcomeOf returns outcome of ApplyQuests, which can be stored for repeated requests,
+// and reason of conflict for Conflict outcome.
+func outcomeOf(err error) (idempotency.Outcome, idempotency.ConflictReason, bool) {
+	switch {
+	case err == nil:
+		return idempotency.Success, "", true
+	case errors.Is(err, QuestNotApplied):
+		return idempotency.Failure, "", true
+	case errors.Is(err, QuestStepApplied):
+		return idempotency.StepApplied, "", true
+	}
+
+	for reason, conflictErr := range conflictErrors {
+		if errors.Is(err, conflictErr) {
+			return idempotency.Conflict, reason, true
+		}
+	}
+
+	return "", "", false
+}
+
+func replayOutcome(key *idempotency.Key, questId, userId types.Id, amount uint32) (*QuestProgress, error) {
+	if key.QuestId != questId || key.UserId != userId || key.Amount != amount {
+		return nil, ErrorIdempotencyKeyMismatch
+	}
+
+	var progress *QuestProgress
+	if key.Total != 0 {
+		progress = &QuestProgress{Step: key.Step, Total: key.Total}
+	}
+
+	switch key.Outcome {
+	case idempotency.Success:
+		return progress, nil
+	case idempotency.Failure:
+		return nil, QuestNotApplied
+	case idempotency.StepApplied:
+		return progress, QuestStepApplied
+	case idempotency.Conflict:
+		if err, ok := conflictErrors[key.Conflict]; ok {
+			return nil, err
+		}
+		// Keys stored without reason of conflict are conflicts of completed quest
+		return nil, user.ErrorUserAlreadyCompleteQuest
+	default:
+		return nil, ErrorIdempotencyKeyInProgress
+	}
+}
+
+// checkCompletion is called by repository inside completion transaction with locked quest.
+func (uu *UserUsecase) checkCompletion(qst *quest.Quest, completions *user.Completions) (*user.Attempt, error) {
+	if err := checkCompletions(qst, completions); err != nil {
+		return nil, err
 	}
 
-	if err := uu.users.IsCompletedQuest(&user.User{ID: userId}, qst); err != nil {
+	if qst.Type != types.RANDOM {
+		return nil, nil
+	}
+
+	if uu.cfg.DailyAttemptsLimit != 0 && completions.DailyAttempts >= uu.cfg.DailyAttemptsLimit {
+		return nil, ErrorAttemptsLimitReached
+	}
+
+	// Pity guarantees completion after enough failed attempts in a row
+	if qst.Pity != 0 && completions.Failures >= qst.Pity {
+		return &user.Attempt{Success: true}, nil
+	}
+
+	roll := uu.rnd.Float64()
+
+	return &user.Attempt{Success: roll < qst.Probability, Roll: &roll}, nil
+}
+
+func checkCompletions(qst *quest.Quest, completions *user.Completions) error {
+	if qst.ArchivedAt != nil {
+		return errors.Wrapf(ErrorQuestArchived, "quest archived at %s", qst.ArchivedAt)
+	}
+
+	if err := checkWindow(qst, time.Now()); err != nil {
 		return err
 	}
 
-	if qst.Type == types.USUAL || rnd.Float64() > CompleteChance {
-		return uu.users.ApplyCost(&user.User{ID: userId}, qst)
+	if len(completions.MissingPrerequisites) > 0 {
+		return errors.Wrapf(ErrorPrerequisitesNotCompleted, "quests %v must be completed first",
+			completions.MissingPrerequisites)
+	}
+
+	if qst.MaxCompletions != 0 && completions.Count >= uint64(qst.MaxCompletions) {
+		return user.ErrorUserAlreadyCompleteQuest
+	}
+
+	if completions.Count > 0 && completions.Elapsed < qst.Cooldown {
+		return &CooldownError{RetryAfter: qst.Cooldown - completions.Elapsed}
+	}
+
+	return nil
+}
+
+// checkWindow checks that quest can be completed at the moment now.
+func checkWindow(qst *quest.Quest, now time.Time) error {
+	if qst.StartsAt != nil && now.Before(qst.StartsAt.Time) {
+		return errors.Wrapf(ErrorQuestNotActive, "quest starts at %s", qst.StartsAt)
+	}
+
+	if qst.EndsAt != nil && !now.Before(qst.EndsAt.Time) {
+		return errors.Wrapf(ErrorQuestNotActive, "quest ended at %s", qst.EndsAt)
+	}
+
+	return nil
+}
+
+func (uu *UserUsecase) RevokeQuest(ctx context.Context, userId, questId types.Id, reason string) (*Revocation, error) {
+	revocation, err := uu.users.RevokeQuest(ctx, userId, questId, reason, uu.cfg.RevokePolicy, uu.cfg.Referral)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromRepRevocation(revocation), nil
+}
+
+func (uu *UserUsecase) GetUserAttempts(ctx context.Context, id types.Id, query *AttemptsQuery) (*AttemptsPage, error) {
+	repQuery, err := query.ToRepAttemptsQuery(id)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := uu.users.GetAttempts(ctx, repQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AttemptsPage{}
+	if limit := int(repQuery.Limit) - 1; len(attempts) > limit {
+		attempts = attempts[:limit]
+		res.NextCursor = attemptsCursor(&attempts[limit-1], query.Order).Encode()
+	}
+
+	res.Attempts = slices.Map(attempts, func(record user.AttemptRecord) AttemptRecord { return *FromRepAttempt(&record) })
+
+	return res, nil
+}
+
+func (uu *UserUsecase) GetAvailableQuests(ctx context.Context, id types.Id, query *AvailableQuestsQuery) (*qu.QuestsPage, error) {
+	repQuery, err := query.ToRepAvailableQuestsQuery(id)
+	if err != nil {
+		return nil, err
+	}
+
+	quests, err := uu.users.GetAvailableQuests(ctx, repQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &qu.QuestsPage{}
+	if limit := int(repQuery.Limit) - 1; len(quests) > limit {
+		quests = quests[:limit]
+		res.NextCursor = availableQuestsCursor(&quests[limit-1]).Encode()
+	}
+
+	res.Quests = slices.Map(quests, func(q quest.Quest) qu.Quest { return *qu.FromRepQuest(&q) })
+
+	return res, nil
+}
+
+func (uu *UserUsecase) GetUserProgress(ctx context.Context, id types.Id) ([]Progress, error) {
+	progress, err := uu.users.GetProgress(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(progress, func(record user.Progress) Progress { return *FromRepProgress(&record) }), nil
+}
+
+func (uu *UserUsecase) Debit(ctx context.Context, userId types.Id, amount uint64, reason string) (*Transaction, error) {
+	transaction, err := uu.ledger.Debit(ctx, userId, amount, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromRepTransaction(transaction), nil
+}
+
+func (uu *UserUsecase) Transfer(ctx context.Context, from, to types.Id, amount uint64) (*Transfer, error) {
+	if from == to {
+		return nil, ErrorSelfTransfer
+	}
+
+	transfer, err := uu.ledger.Transfer(ctx, from, to, amount, uu.cfg.DailyTransferLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromRepTransfer(transfer), nil
+}
+
+func (uu *UserUsecase) ReconcileBalance(ctx context.Context, userId types.Id) (*Reconciliation, error) {
+	reconciliation, err := uu.ledger.Reconcile(ctx, userId)
+	if err != nil {
+		return nil, err
 	}
 
-	return QuestNotApplied
+	return FromRepReconciliation(reconciliation), nil
 }
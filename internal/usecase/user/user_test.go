@@ -1,17 +1,23 @@
 package user
 
 import (
-	"math/rand"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"github.com/pkg/errors"
 	"go.uber.org/mock/gomock"
 
+	"vk_quests/internal/pkg/page"
+	pkgtime "vk_quests/internal/pkg/time"
 	"vk_quests/internal/pkg/types"
+	ir "vk_quests/internal/repository/idempotency"
+	mri "vk_quests/internal/repository/idempotency/mocks"
+	lr "vk_quests/internal/repository/ledger"
+	mrl "vk_quests/internal/repository/ledger/mocks"
 	qr "vk_quests/internal/repository/quest"
-	mrq "vk_quests/internal/repository/quest/mocks"
 	ur "vk_quests/internal/repository/user"
 	mru "vk_quests/internal/repository/user/mocks"
 	qu "vk_quests/internal/usecase/quest"
@@ -19,19 +25,42 @@ import (
 
 var testError = errors.New("test error")
 
+var testConfig = Config{
+	KeyTTL:             time.Hour,
+	DailyTransferLimit: 100,
+	DailyAttemptsLimit: 10,
+	RevokePolicy:       lr.RevokePartial,
+	DeletePolicy:       ur.DeleteAnonymize,
+	Location:           time.FixedZone("UTC+3", 3*60*60),
+	Referral:           ur.Referral{QuestId: 7, Bonus: 10},
+}
+
+// stubRandom returns the same roll every time.
+type stubRandom struct {
+	roll float64
+}
+
+func (sr *stubRandom) Float64() float64 {
+	return sr.roll
+}
+
 type UserUsecaseSuite struct {
 	suite.Suite
 	userUsecase *UserUsecase
-	mockQuest   *mrq.QuestRepository
+	random      *stubRandom
 	mockUser    *mru.UserRepository
+	mockLedger  *mrl.LedgerRepository
+	mockKeys    *mri.IdempotencyRepository
 	gmc         *gomock.Controller
 }
 
 func (uus *UserUsecaseSuite) BeforeEach(t provider.T) {
 	uus.gmc = gomock.NewController(t)
-	uus.mockQuest = mrq.NewQuestRepository(uus.gmc)
 	uus.mockUser = mru.NewUserRepository(uus.gmc)
-	uus.userUsecase = NewUserUsecase(uus.mockUser, uus.mockQuest)
+	uus.mockLedger = mrl.NewLedgerRepository(uus.gmc)
+	uus.mockKeys = mri.NewIdempotencyRepository(uus.gmc)
+	uus.random = &stubRandom{}
+	uus.userUsecase = NewUserUsecase(uus.mockUser, uus.mockLedger, uus.mockKeys, testConfig, uus.random)
 }
 
 func (uus *UserUsecaseSuite) AfterEach(t provider.T) {
@@ -41,16 +70,19 @@ func (uus *UserUsecaseSuite) AfterEach(t provider.T) {
 func (uus *UserUsecaseSuite) TestCreateUserFunction(t provider.T) {
 	t.Title("CreateUser function of user usecase")
 	t.NewStep("Init test data")
+	referrerId := types.Id(2)
 	user := &User{
-		ID:      1,
-		Name:    "User",
-		Balance: 30,
+		ID:           1,
+		Name:         "User",
+		Balance:      30,
+		ReferralCode: "3f9a1c07be",
 	}
 
 	repositoryUser := &ur.User{
-		ID:      user.ID,
-		Name:    user.Name,
-		Balance: user.Balance,
+		ID:           user.ID,
+		Name:         user.Name,
+		Balance:      user.Balance,
+		ReferralCode: user.ReferralCode,
 	}
 
 	repositoryOnlyNameUser := &ur.User{
@@ -59,20 +91,43 @@ func (uus *UserUsecaseSuite) TestCreateUserFunction(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().CreateUser(repositoryOnlyNameUser).Return(repositoryUser, nil).Times(1)
+		uus.mockUser.EXPECT().CreateUser(context.Background(), repositoryOnlyNameUser, "").Return(repositoryUser, nil).Times(1)
 
 		t.NewStep("Check result")
-		usr, err := uus.userUsecase.CreateUser(user.Name)
+		usr, err := uus.userUsecase.CreateUser(context.Background(), user.Name, "")
 		t.Require().NoError(err)
 		t.Require().Equal(user, usr)
 	})
 
+	t.WithNewStep("Referred user execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		referredUser := *repositoryUser
+		referredUser.ReferrerId = &referrerId
+		uus.mockUser.EXPECT().CreateUser(context.Background(), repositoryOnlyNameUser, "a1b2c3d4e5").
+			Return(&referredUser, nil).Times(1)
+
+		t.NewStep("Check result")
+		usr, err := uus.userUsecase.CreateUser(context.Background(), user.Name, "a1b2c3d4e5")
+		t.Require().NoError(err)
+		t.Require().Equal(&referrerId, usr.ReferrerId)
+	})
+
+	t.WithNewStep("Referral code not found error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CreateUser(context.Background(), repositoryOnlyNameUser, "unknown").
+			Return(nil, ur.ErrorReferralCodeNotFound).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.CreateUser(context.Background(), user.Name, "unknown")
+		t.Require().ErrorIs(err, ur.ErrorReferralCodeNotFound)
+	})
+
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().CreateUser(repositoryOnlyNameUser).Return(nil, testError).Times(1)
+		uus.mockUser.EXPECT().CreateUser(context.Background(), repositoryOnlyNameUser, "").Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := uus.userUsecase.CreateUser(user.Name)
+		_, err := uus.userUsecase.CreateUser(context.Background(), user.Name, "")
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -94,20 +149,115 @@ func (uus *UserUsecaseSuite) TestDeleteUserFunction(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().DeleteUser(user.ID).Return(repositoryUser, nil).Times(1)
+		uus.mockUser.EXPECT().DeleteUser(context.Background(), user.ID, testConfig.DeletePolicy).Return(repositoryUser, nil).Times(1)
+
+		t.NewStep("Check result")
+		usr, err := uus.userUsecase.DeleteUser(context.Background(), user.ID)
+		t.Require().NoError(err)
+		t.Require().Equal(user, usr)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().DeleteUser(context.Background(), user.ID, testConfig.DeletePolicy).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.DeleteUser(context.Background(), user.ID)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestAnonymizeUserFunction(t provider.T) {
+	t.Title("AnonymizeUser function of user usecase")
+	t.NewStep("Init test data")
+	user := &User{
+		ID:      1,
+		Balance: 30,
+	}
+
+	repositoryUser := &ur.User{
+		ID:      user.ID,
+		Balance: user.Balance,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().AnonymizeUser(context.Background(), user.ID).Return(repositoryUser, nil).Times(1)
 
 		t.NewStep("Check result")
-		usr, err := uus.userUsecase.DeleteUser(user.ID)
+		usr, err := uus.userUsecase.AnonymizeUser(context.Background(), user.ID)
 		t.Require().NoError(err)
 		t.Require().Equal(user, usr)
 	})
 
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().DeleteUser(user.ID).Return(nil, testError).Times(1)
+		uus.mockUser.EXPECT().AnonymizeUser(context.Background(), user.ID).Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.AnonymizeUser(context.Background(), user.ID)
+		t.Require().ErrorIs(err, ur.ErrorUserNotFound)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestExportUserFunction(t provider.T) {
+	t.Title("ExportUser function of user usecase")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	deletedAt := pkgtime.MustParse("01.02.2025 - 00:00:00")
+	created := pkgtime.MustParse("15.01.2025 - 10:00:00")
+	questId := types.Id(2)
+	roll := 0.7
+
+	repositoryExport := &ur.Export{
+		User:      ur.User{ID: userId, Name: "User", Balance: 10},
+		DeletedAt: &deletedAt,
+		History: []ur.HistoryRecord{
+			{
+				ID:       3,
+				Snapshot: &ur.QuestSnapshot{Name: "Quest", Description: "good Quest", Type: types.USUAL},
+				Award:    10,
+				Created:  created,
+				Balance:  10,
+			},
+		},
+		Attempts: []ur.AttemptRecord{
+			{ID: 4, QuestId: &questId, Attempt: ur.Attempt{Success: false, Roll: &roll}, Created: created},
+		},
+	}
+
+	export := &Export{
+		User:      User{ID: userId, Name: "User", Balance: 10},
+		DeletedAt: &deletedAt,
+		History: []HistoryRecord{
+			{
+				Snapshot: &QuestSnapshot{Name: "Quest", Description: "good Quest", Type: types.USUAL},
+				Award:    10,
+				Created:  created,
+				Balance:  10,
+			},
+		},
+		Attempts: []AttemptRecord{
+			{ID: 4, QuestId: &questId, Success: false, Roll: &roll, Created: created},
+		},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().ExportUser(context.Background(), userId).Return(repositoryExport, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := uus.userUsecase.ExportUser(context.Background(), userId)
+		t.Require().NoError(err)
+		t.Require().Equal(export, res)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().ExportUser(context.Background(), userId).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := uus.userUsecase.DeleteUser(user.ID)
+		_, err := uus.userUsecase.ExportUser(context.Background(), userId)
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -134,20 +284,20 @@ func (uus *UserUsecaseSuite) TestUpdateUserFunction(t provider.T) {
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().UpdateUser(repositoryWithoutBalanceUser).Return(repositoryUser, nil).Times(1)
+		uus.mockUser.EXPECT().UpdateUser(context.Background(), repositoryWithoutBalanceUser).Return(repositoryUser, nil).Times(1)
 
 		t.NewStep("Check result")
-		usr, err := uus.userUsecase.UpdateUser(user.ID, user.Name)
+		usr, err := uus.userUsecase.UpdateUser(context.Background(), user.ID, user.Name)
 		t.Require().NoError(err)
 		t.Require().Equal(user, usr)
 	})
 
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().UpdateUser(repositoryWithoutBalanceUser).Return(nil, testError).Times(1)
+		uus.mockUser.EXPECT().UpdateUser(context.Background(), repositoryWithoutBalanceUser).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := uus.userUsecase.UpdateUser(user.ID, user.Name)
+		_, err := uus.userUsecase.UpdateUser(context.Background(), user.ID, user.Name)
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -167,34 +317,154 @@ func (uus *UserUsecaseSuite) TestGetUsersFunction(t provider.T) {
 			Name:    user.Name,
 			Balance: user.Balance,
 		},
+		{
+			ID:      2,
+			Name:    "Next user",
+			Balance: 40,
+		},
+	}
+
+	query := &UsersQuery{
+		NamePrefix: "U",
+		Sort:       types.UsersSortName,
+		Order:      page.Asc,
+		Limit:      1,
+	}
+	repositoryQuery := &ur.UsersQuery{
+		NamePrefix: query.NamePrefix,
+		Sort:       query.Sort,
+		Order:      query.Order,
+		Limit:      2,
+	}
+	cursor := &page.Cursor{
+		Sort:  string(types.UsersSortName),
+		Order: page.Asc,
+		Value: user.Name,
+		ID:    user.ID,
 	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().GetUsers().Return(repositoryUser, nil).Times(1)
+		uus.mockUser.EXPECT().GetUsers(context.Background(), repositoryQuery).Return(repositoryUser, nil).Times(1)
 
 		t.NewStep("Check result")
-		usr, err := uus.userUsecase.GetUsers()
+		usersPage, err := uus.userUsecase.GetUsers(context.Background(), query)
 		t.Require().NoError(err)
-		t.Require().Equal([]User{*user}, usr)
+		t.Require().Equal([]User{*user}, usersPage.Users)
+		t.Require().Equal(cursor.Encode(), usersPage.NextCursor)
+	})
+
+	t.WithNewStep("Last page execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		nextQuery := *query
+		nextQuery.Cursor = cursor.Encode()
+		nextRepositoryQuery := *repositoryQuery
+		nextRepositoryQuery.After = cursor
+		uus.mockUser.EXPECT().GetUsers(context.Background(), &nextRepositoryQuery).Return(repositoryUser[1:], nil).Times(1)
+
+		t.NewStep("Check result")
+		usersPage, err := uus.userUsecase.GetUsers(context.Background(), &nextQuery)
+		t.Require().NoError(err)
+		t.Require().Len(usersPage.Users, 1)
+		t.Require().Empty(usersPage.NextCursor)
+	})
+
+	t.WithNewStep("Cursor of other sort execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		otherQuery := *query
+		otherQuery.Order = page.Desc
+		otherQuery.Cursor = cursor.Encode()
+		_, err := uus.userUsecase.GetUsers(context.Background(), &otherQuery)
+		t.Require().ErrorIs(err, page.ErrorInvalidCursor)
+	})
+
+	t.WithNewStep("Malformed cursor execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		otherQuery := *query
+		otherQuery.Cursor = "not a cursor"
+		_, err := uus.userUsecase.GetUsers(context.Background(), &otherQuery)
+		t.Require().ErrorIs(err, page.ErrorInvalidCursor)
 	})
 
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().GetUsers().Return(nil, testError).Times(1)
+		uus.mockUser.EXPECT().GetUsers(context.Background(), repositoryQuery).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := uus.userUsecase.GetUsers()
+		_, err := uus.userUsecase.GetUsers(context.Background(), query)
 		t.Require().ErrorIs(err, testError)
 	})
 }
 
+func (uus *UserUsecaseSuite) TestGetUserFunction(t provider.T) {
+	t.Title("GetUser function of user usecase")
+	t.NewStep("Init test data")
+	last := pkgtime.FormattedTime{Time: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}
+	stats := &UserStats{
+		User: User{
+			ID:      1,
+			Name:    "User",
+			Balance: 40,
+		},
+		Completed:      3,
+		Earned:         40,
+		LastCompletion: &last,
+		ByType: map[types.QuestType]TypeStats{
+			types.USUAL:  {Completed: 2, Earned: 30},
+			types.STAGED: {Completed: 1, Earned: 10},
+		},
+	}
+
+	repositoryStats := &ur.UserStats{
+		User: ur.User{
+			ID:      stats.User.ID,
+			Name:    stats.User.Name,
+			Balance: stats.User.Balance,
+		},
+		Completed:      stats.Completed,
+		Earned:         stats.Earned,
+		LastCompletion: stats.LastCompletion,
+		ByType: map[types.QuestType]ur.TypeStats{
+			types.USUAL:  {Completed: 2, Earned: 30},
+			types.STAGED: {Completed: 1, Earned: 10},
+		},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().GetUser(context.Background(), stats.User.ID).Return(repositoryStats, nil).Times(1)
+
+		t.NewStep("Check result")
+		usr, err := uus.userUsecase.GetUser(context.Background(), stats.User.ID)
+		t.Require().NoError(err)
+		t.Require().Equal(stats, usr)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().GetUser(context.Background(), stats.User.ID).Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.GetUser(context.Background(), stats.User.ID)
+		t.Require().ErrorIs(err, ur.ErrorUserNotFound)
+	})
+}
+
 func (uus *UserUsecaseSuite) TestGetUserHistoryFunction(t provider.T) {
 	t.Title("GetUserHistory function of user usecase")
 	t.NewStep("Init test data")
 	userId := types.Id(1)
+	senderId := types.Id(3)
 	history := []HistoryRecord{
 		{
+			Snapshot: &QuestSnapshot{
+				Name:        "Old name",
+				Description: "old description",
+				Type:        types.USUAL,
+			},
+			Award:      10,
+			BaseAward:  5,
+			Multiplier: 2,
 			Quest: &qu.Quest{
 				ID:   1,
 				Name: "Not null",
@@ -202,41 +472,203 @@ func (uus *UserUsecaseSuite) TestGetUserHistoryFunction(t provider.T) {
 			Balance: 30,
 		},
 		{
-			Quest:   nil,
-			Balance: 25,
+			Award:          4,
+			BaseAward:      4,
+			Multiplier:     1,
+			Source:         types.SourceTransferIn,
+			CounterpartyId: &senderId,
+			Quest:          nil,
+			Balance:        25,
 		},
 	}
 
+	created := time.Date(2024, 1, 10, 12, 30, 0, 500, time.UTC)
 	repositoryHistory := []ur.HistoryRecord{
 		{
+			ID: 7,
+			Snapshot: &ur.QuestSnapshot{
+				Name:        history[0].Snapshot.Name,
+				Description: history[0].Snapshot.Description,
+				Type:        history[0].Snapshot.Type,
+			},
+			Award:      history[0].Award,
+			BaseAward:  history[0].BaseAward,
+			Multiplier: history[0].Multiplier,
 			Quest: &qr.Quest{
 				ID:   history[0].Quest.ID,
 				Name: history[0].Quest.Name,
 			},
+			Created: pkgtime.FormattedTime{Time: created},
 			Balance: history[0].Balance,
 		},
 		{
-			Quest:   nil,
-			Balance: history[1].Balance,
+			ID:             6,
+			Award:          history[1].Award,
+			BaseAward:      history[1].BaseAward,
+			Multiplier:     history[1].Multiplier,
+			Source:         history[1].Source,
+			CounterpartyId: &senderId,
+			Quest:          nil,
+			Balance:        history[1].Balance,
 		},
 	}
+	history[0].Created = repositoryHistory[0].Created
+
+	query := &HistoryQuery{
+		Order: page.Desc,
+		Limit: 1,
+	}
+	repositoryQuery := &ur.HistoryQuery{
+		UserId: userId,
+		Order:  query.Order,
+		Limit:  2,
+	}
+	cursor := &page.Cursor{
+		Sort:  "created",
+		Order: page.Desc,
+		Value: "2024-01-10T12:30:00.0000005Z",
+		ID:    7,
+	}
 
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().GetHistory(userId).Return(repositoryHistory, nil).Times(1)
+		uus.mockUser.EXPECT().GetHistory(context.Background(), repositoryQuery).Return(repositoryHistory, nil).Times(1)
 
 		t.NewStep("Check result")
-		hist, err := uus.userUsecase.GetUserHistory(userId)
+		historyPage, err := uus.userUsecase.GetUserHistory(context.Background(), userId, query)
 		t.Require().NoError(err)
-		t.Require().Equal(history, hist)
+		t.Require().Equal(history[:1], historyPage.Records)
+		t.Require().Equal(cursor.Encode(), historyPage.NextCursor)
+	})
+
+	t.WithNewStep("Last page execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		nextQuery := *query
+		nextQuery.Cursor = cursor.Encode()
+		nextRepositoryQuery := *repositoryQuery
+		nextRepositoryQuery.After = cursor
+		uus.mockUser.EXPECT().GetHistory(context.Background(), &nextRepositoryQuery).
+			Return(repositoryHistory[1:], nil).Times(1)
+
+		t.NewStep("Check result")
+		historyPage, err := uus.userUsecase.GetUserHistory(context.Background(), userId, &nextQuery)
+		t.Require().NoError(err)
+		t.Require().Equal(history[1:], historyPage.Records)
+		t.Require().Empty(historyPage.NextCursor)
+	})
+
+	t.WithNewStep("Cursor of other order execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		otherQuery := *query
+		otherQuery.Order = page.Asc
+		otherQuery.Cursor = cursor.Encode()
+		_, err := uus.userUsecase.GetUserHistory(context.Background(), userId, &otherQuery)
+		t.Require().ErrorIs(err, page.ErrorInvalidCursor)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().GetHistory(context.Background(), repositoryQuery).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.GetUserHistory(context.Background(), userId, query)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestGetUserAttemptsFunction(t provider.T) {
+	t.Title("GetUserAttempts function of user usecase")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	questId := types.Id(4)
+	roll := 0.8
+
+	repositoryAttempts := []ur.AttemptRecord{
+		{
+			ID:      7,
+			QuestId: &questId,
+			Attempt: ur.Attempt{Success: false, Roll: &roll},
+		},
+		{
+			ID:      6,
+			QuestId: nil,
+			Attempt: ur.Attempt{Success: true},
+		},
+	}
+
+	attempts := []AttemptRecord{
+		{
+			ID:      7,
+			QuestId: &questId,
+			Success: false,
+			Roll:    &roll,
+		},
+		{
+			ID:      6,
+			QuestId: nil,
+			Success: true,
+		},
+	}
+
+	query := &AttemptsQuery{
+		QuestId: &questId,
+		Order:   page.Desc,
+		Limit:   1,
+	}
+	repositoryQuery := &ur.AttemptsQuery{
+		UserId:  userId,
+		QuestId: &questId,
+		Order:   query.Order,
+		Limit:   2,
+	}
+	cursor := &page.Cursor{
+		Sort:  "id",
+		Order: page.Desc,
+		ID:    7,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().GetAttempts(context.Background(), repositoryQuery).Return(repositoryAttempts, nil).Times(1)
+
+		t.NewStep("Check result")
+		attemptsPage, err := uus.userUsecase.GetUserAttempts(context.Background(), userId, query)
+		t.Require().NoError(err)
+		t.Require().Equal(attempts[:1], attemptsPage.Attempts)
+		t.Require().Equal(cursor.Encode(), attemptsPage.NextCursor)
+	})
+
+	t.WithNewStep("Last page execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		nextQuery := *query
+		nextQuery.Cursor = cursor.Encode()
+		nextRepositoryQuery := *repositoryQuery
+		nextRepositoryQuery.After = cursor
+		uus.mockUser.EXPECT().GetAttempts(context.Background(), &nextRepositoryQuery).
+			Return(repositoryAttempts[1:], nil).Times(1)
+
+		t.NewStep("Check result")
+		attemptsPage, err := uus.userUsecase.GetUserAttempts(context.Background(), userId, &nextQuery)
+		t.Require().NoError(err)
+		t.Require().Equal(attempts[1:], attemptsPage.Attempts)
+		t.Require().Empty(attemptsPage.NextCursor)
+	})
+
+	t.WithNewStep("Cursor of other order execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		otherQuery := *query
+		otherQuery.Order = page.Asc
+		otherQuery.Cursor = cursor.Encode()
+		_, err := uus.userUsecase.GetUserAttempts(context.Background(), userId, &otherQuery)
+		t.Require().ErrorIs(err, page.ErrorInvalidCursor)
 	})
 
 	t.WithNewStep("Repository error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockUser.EXPECT().GetHistory(userId).Return(nil, testError).Times(1)
+		uus.mockUser.EXPECT().GetAttempts(context.Background(), repositoryQuery).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		_, err := uus.userUsecase.GetUserHistory(userId)
+		_, err := uus.userUsecase.GetUserAttempts(context.Background(), userId, query)
 		t.Require().ErrorIs(err, testError)
 	})
 }
@@ -245,104 +677,944 @@ func (uus *UserUsecaseSuite) TestApplyQuestsFunction(t provider.T) {
 	t.Title("ApplyQuests function of user usecase")
 	t.NewStep("Init test data")
 	quest := &qu.Quest{
-		ID:          1,
-		Name:        "Quest",
-		Description: "good Quest",
-		Cost:        10,
-		Type:        types.USUAL,
+		ID:             1,
+		Name:           "Quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.USUAL,
+		MaxCompletions: 1,
 	}
 
 	randomQuest := &qu.Quest{
-		ID:          1,
-		Name:        "Quest",
-		Description: "good Quest",
-		Cost:        10,
-		Type:        types.RANDOM,
+		ID:             1,
+		Name:           "Quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.RANDOM,
+		MaxCompletions: 1,
 	}
 
 	repositoryQuest := &qr.Quest{
-		ID:          quest.ID,
-		Name:        quest.Name,
-		Description: quest.Description,
-		Cost:        quest.Cost,
-		Type:        quest.Type,
+		ID:             quest.ID,
+		Name:           quest.Name,
+		Description:    quest.Description,
+		Cost:           quest.Cost,
+		Type:           quest.Type,
+		MaxCompletions: quest.MaxCompletions,
 	}
 
 	repositoryRandomQuest := &qr.Quest{
-		ID:          randomQuest.ID,
-		Name:        randomQuest.Name,
-		Description: randomQuest.Description,
-		Cost:        randomQuest.Cost,
-		Type:        randomQuest.Type,
+		ID:             randomQuest.ID,
+		Name:           randomQuest.Name,
+		Description:    randomQuest.Description,
+		Cost:           randomQuest.Cost,
+		Type:           randomQuest.Type,
+		MaxCompletions: randomQuest.MaxCompletions,
+		Probability:    0.25,
+		Pity:           3,
+	}
+
+	stagedQuest := &qu.Quest{
+		ID:             2,
+		Name:           "Staged quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.STAGED,
+		Steps:          []string{"first", "second"},
+		MaxCompletions: 1,
+	}
+
+	repositoryStagedQuest := &qr.Quest{
+		ID:             stagedQuest.ID,
+		Name:           stagedQuest.Name,
+		Description:    stagedQuest.Description,
+		Cost:           stagedQuest.Cost,
+		Type:           stagedQuest.Type,
+		Steps:          stagedQuest.Steps,
+		MaxCompletions: stagedQuest.MaxCompletions,
+	}
+
+	repositoryCounterQuest := &qr.Quest{
+		ID:             4,
+		Name:           "Counter quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.COUNTER,
+		MaxCompletions: 1,
+		Target:         10,
+	}
+
+	repositoryStreakQuest := &qr.Quest{
+		ID:          5,
+		Name:        "Streak quest",
+		Description: "good Quest",
+		Type:        types.STREAK,
+		Milestones:  []qr.Milestone{{Days: 3, Reward: 10}, {Days: 7, Reward: 30}},
+	}
+
+	// Streak days are counted in usecase timezone
+	inLocation := gomock.Cond(func(day any) bool { return day.(time.Time).Location() == testConfig.Location })
+
+	repeatableQuest := &qu.Quest{
+		ID:             3,
+		Name:           "Daily quest",
+		Description:    "good Quest",
+		Cost:           10,
+		Type:           types.USUAL,
+		MaxCompletions: 0,
+		Cooldown:       time.Hour,
+	}
+
+	repositoryRepeatableQuest := &qr.Quest{
+		ID:             repeatableQuest.ID,
+		Name:           repeatableQuest.Name,
+		Description:    repeatableQuest.Description,
+		Cost:           repeatableQuest.Cost,
+		Type:           repeatableQuest.Type,
+		MaxCompletions: repeatableQuest.MaxCompletions,
+		Cooldown:       repeatableQuest.Cooldown,
 	}
 
 	userId := types.Id(1)
 
+	completeQuest := func(
+		qst *qr.Quest, completions *ur.Completions, progress *ur.Progress,
+	) func(context.Context, types.Id, types.Id, uint32, time.Time, ur.Referral, ur.CompletionCheck) (*ur.Progress, error) {
+		return func(_ context.Context, _, _ types.Id, _ uint32, _ time.Time, _ ur.Referral,
+			check ur.CompletionCheck) (*ur.Progress, error) {
+			attempt, err := check(qst, completions)
+			if err != nil {
+				return nil, err
+			}
+			if attempt != nil && !attempt.Success {
+				return nil, ur.ErrorAttemptFailed
+			}
+			return progress, nil
+		}
+	}
+
 	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockQuest.EXPECT().GetQuest(quest.ID).Return(repositoryQuest, nil).Times(1)
-		uus.mockUser.EXPECT().HasUser(userId).Return(nil).Times(1)
-		uus.mockUser.EXPECT().IsCompletedQuest(&ur.User{ID: userId}, repositoryQuest).Return(nil).Times(1)
-		uus.mockUser.EXPECT().ApplyCost(&ur.User{ID: userId}, repositoryQuest).Return(nil).Times(1)
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, quest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(repositoryQuest, &ur.Completions{}, &ur.Progress{Quest: repositoryQuest})).Times(1)
 
 		t.NewStep("Check result")
-		err := uus.userUsecase.ApplyQuests(quest.ID, userId)
+		progress, err := uus.userUsecase.ApplyQuests(context.Background(), quest.ID, userId, 1)
 		t.Require().NoError(err)
+		t.Require().Nil(progress)
 	})
 
-	t.WithNewStep("Repository GetQuest method error", func(t provider.StepCtx) {
+	t.WithNewStep("Repository CompleteQuest method error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockQuest.EXPECT().GetQuest(quest.ID).Return(nil, testError).Times(1)
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, quest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		err := uus.userUsecase.ApplyQuests(quest.ID, userId)
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), quest.ID, userId, 1)
 		t.Require().ErrorIs(err, testError)
 	})
 
-	t.WithNewStep("Repository HasUser method error", func(t provider.StepCtx) {
+	t.WithNewStep("Completions limit reached error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, quest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(repositoryQuest, &ur.Completions{Count: 1, Elapsed: time.Hour}, nil)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), quest.ID, userId, 1)
+		t.Require().ErrorIs(err, ur.ErrorUserAlreadyCompleteQuest)
+	})
+
+	t.WithNewStep("Prerequisites not completed error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, quest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(repositoryQuest, &ur.Completions{MissingPrerequisites: []types.Id{5}}, nil)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), quest.ID, userId, 1)
+		t.Require().ErrorIs(err, ErrorPrerequisitesNotCompleted)
+	})
+
+	t.WithNewStep("Quest archived error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		archivedAt := pkgtime.FormattedTime{Time: time.Now().Add(-time.Hour)}
+		archivedQuest := *repositoryQuest
+		archivedQuest.ArchivedAt = &archivedAt
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, quest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(&archivedQuest, &ur.Completions{}, nil)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), quest.ID, userId, 1)
+		t.Require().ErrorIs(err, ErrorQuestArchived)
+	})
+
+	t.WithNewStep("Quest not started error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		startsAt := pkgtime.FormattedTime{Time: time.Now().Add(time.Hour)}
+		futureQuest := *repositoryQuest
+		futureQuest.StartsAt = &startsAt
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, quest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(&futureQuest, &ur.Completions{}, nil)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), quest.ID, userId, 1)
+		t.Require().ErrorIs(err, ErrorQuestNotActive)
+	})
+
+	t.WithNewStep("Quest ended error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		startsAt := pkgtime.FormattedTime{Time: time.Now().Add(-2 * time.Hour)}
+		endsAt := pkgtime.FormattedTime{Time: time.Now().Add(-time.Hour)}
+		pastQuest := *repositoryQuest
+		pastQuest.StartsAt, pastQuest.EndsAt = &startsAt, &endsAt
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, quest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(&pastQuest, &ur.Completions{}, nil)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), quest.ID, userId, 1)
+		t.Require().ErrorIs(err, ErrorQuestNotActive)
+	})
+
+	t.WithNewStep("Correct quest inside window execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		startsAt := pkgtime.FormattedTime{Time: time.Now().Add(-time.Hour)}
+		endsAt := pkgtime.FormattedTime{Time: time.Now().Add(time.Hour)}
+		activeQuest := *repositoryQuest
+		activeQuest.StartsAt, activeQuest.EndsAt = &startsAt, &endsAt
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, quest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(&activeQuest, &ur.Completions{}, &ur.Progress{Quest: &activeQuest})).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), quest.ID, userId, 1)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Correct repeatable quest execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, repeatableQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryRepeatableQuest,
+				&ur.Completions{Count: 5, Elapsed: 2 * time.Hour},
+				&ur.Progress{Quest: repositoryRepeatableQuest},
+			)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), repeatableQuest.ID, userId, 1)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Repeatable quest cooldown active error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, repeatableQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryRepeatableQuest,
+				&ur.Completions{Count: 5, Elapsed: 15 * time.Minute},
+				nil,
+			)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), repeatableQuest.ID, userId, 1)
+		t.Require().ErrorIs(err, ErrorQuestCooldownActive)
+		var cooldownErr *CooldownError
+		t.Require().ErrorAs(err, &cooldownErr)
+		t.Require().Equal(45*time.Minute, cooldownErr.RetryAfter)
+	})
+
+	t.WithNewStep("Correct staged quest intermediate step", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, stagedQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryStagedQuest,
+				&ur.Completions{},
+				&ur.Progress{Quest: repositoryStagedQuest, Step: 1},
+			)).Times(1)
+
+		t.NewStep("Check result")
+		progress, err := uus.userUsecase.ApplyQuests(context.Background(), stagedQuest.ID, userId, 1)
+		t.Require().ErrorIs(err, QuestStepApplied)
+		t.Require().EqualValues(&QuestProgress{Step: 1, Total: 2}, progress)
+	})
+
+	t.WithNewStep("Correct staged quest last step", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, stagedQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryStagedQuest,
+				&ur.Completions{},
+				&ur.Progress{Quest: repositoryStagedQuest, Step: 2},
+			)).Times(1)
+
+		t.NewStep("Check result")
+		progress, err := uus.userUsecase.ApplyQuests(context.Background(), stagedQuest.ID, userId, 1)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&QuestProgress{Step: 2, Total: 2}, progress)
+	})
+
+	t.WithNewStep("Correct counter quest intermediate amount", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockQuest.EXPECT().GetQuest(quest.ID).Return(repositoryQuest, nil).Times(1)
-		uus.mockUser.EXPECT().HasUser(userId).Return(testError).Times(1)
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, repositoryCounterQuest.ID, uint32(4), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryCounterQuest,
+				&ur.Completions{},
+				&ur.Progress{Quest: repositoryCounterQuest, Step: 4},
+			)).Times(1)
 
 		t.NewStep("Check result")
-		err := uus.userUsecase.ApplyQuests(quest.ID, userId)
+		progress, err := uus.userUsecase.ApplyQuests(context.Background(), repositoryCounterQuest.ID, userId, 4)
+		t.Require().ErrorIs(err, QuestStepApplied)
+		t.Require().EqualValues(&QuestProgress{Step: 4, Total: 10}, progress)
+	})
+
+	t.WithNewStep("Correct counter quest target reached", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, repositoryCounterQuest.ID, uint32(6), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryCounterQuest,
+				&ur.Completions{},
+				&ur.Progress{Quest: repositoryCounterQuest, Step: 10},
+			)).Times(1)
+
+		t.NewStep("Check result")
+		progress, err := uus.userUsecase.ApplyQuests(context.Background(), repositoryCounterQuest.ID, userId, 6)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&QuestProgress{Step: 10, Total: 10}, progress)
+	})
+
+	t.WithNewStep("Correct streak quest without milestone", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, repositoryStreakQuest.ID, uint32(1), inLocation, testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryStreakQuest,
+				&ur.Completions{},
+				&ur.Progress{Quest: repositoryStreakQuest, Step: 2},
+			)).Times(1)
+
+		t.NewStep("Check result")
+		progress, err := uus.userUsecase.ApplyQuests(context.Background(), repositoryStreakQuest.ID, userId, 1)
+		t.Require().ErrorIs(err, QuestStepApplied)
+		t.Require().EqualValues(&QuestProgress{Step: 2, Total: 7}, progress)
+	})
+
+	t.WithNewStep("Correct streak quest milestone reached", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, repositoryStreakQuest.ID, uint32(1), inLocation, testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryStreakQuest,
+				&ur.Completions{},
+				&ur.Progress{Quest: repositoryStreakQuest, Step: 3},
+			)).Times(1)
+
+		t.NewStep("Check result")
+		progress, err := uus.userUsecase.ApplyQuests(context.Background(), repositoryStreakQuest.ID, userId, 1)
+		t.Require().NoError(err)
+		t.Require().EqualValues(&QuestProgress{Step: 3, Total: 7}, progress)
+	})
+
+	t.WithNewStep("Streak already extended today", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, repositoryStreakQuest.ID, uint32(1), inLocation, testConfig.Referral, gomock.Any()).
+			Return(nil, ur.ErrorStreakAlreadyExtended).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), repositoryStreakQuest.ID, userId, 1)
+		t.Require().ErrorIs(err, ur.ErrorStreakAlreadyExtended)
+	})
+
+	t.WithNewStep("Correct random quest failure", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.random.roll = 0.25
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, randomQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(repositoryRandomQuest, &ur.Completions{}, nil)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), randomQuest.ID, userId, 1)
+		t.Require().ErrorIs(err, QuestNotApplied)
+	})
+
+	t.WithNewStep("Correct random quest success", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.random.roll = 0.2
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, randomQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryRandomQuest,
+				&ur.Completions{Failures: 1},
+				&ur.Progress{Quest: repositoryRandomQuest},
+			)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), randomQuest.ID, userId, 1)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Correct random quest failure before pity", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.random.roll = 0.99
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, randomQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(repositoryRandomQuest, &ur.Completions{Failures: 2}, nil)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), randomQuest.ID, userId, 1)
+		t.Require().ErrorIs(err, QuestNotApplied)
+	})
+
+	t.WithNewStep("Correct random quest guaranteed by pity", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.random.roll = 0.99
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, randomQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(
+				repositoryRandomQuest,
+				&ur.Completions{Failures: 3},
+				&ur.Progress{Quest: repositoryRandomQuest},
+			)).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), randomQuest.ID, userId, 1)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Random quest daily attempts limit error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.random.roll = 0
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, randomQuest.ID, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			DoAndReturn(completeQuest(repositoryRandomQuest, &ur.Completions{DailyAttempts: testConfig.DailyAttemptsLimit}, nil)).
+			Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuests(context.Background(), randomQuest.ID, userId, 1)
+		t.Require().ErrorIs(err, ErrorAttemptsLimitReached)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestApplyQuestsIdempotentFunction(t provider.T) {
+	t.Title("ApplyQuestsIdempotent function of user usecase")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	questId := types.Id(2)
+
+	key := &ir.Key{
+		Key:     "key",
+		UserId:  userId,
+		QuestId: questId,
+		Amount:  1,
+	}
+
+	storedKey := func(outcome ir.Outcome) *ir.Key {
+		return &ir.Key{
+			Key:     key.Key,
+			UserId:  userId,
+			QuestId: questId,
+			Amount:  key.Amount,
+			Outcome: outcome,
+			Created: time.Now(),
+		}
+	}
+
+	quest := &qr.Quest{
+		ID:             questId,
+		Type:           types.USUAL,
+		MaxCompletions: 1,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(storedKey(ir.Pending), nil).Times(1)
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, questId, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			Return(&ur.Progress{Quest: quest}, nil).Times(1)
+		uus.mockKeys.EXPECT().SetOutcome(gomock.Any(), key.Key, ir.Success, ir.ConflictReason(""), uint32(0), uint32(0)).Return(nil).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
+		t.Require().NoError(err)
+	})
+
+	t.WithNewStep("Correct store of final outcomes", func(t provider.StepCtx) {
+		for _, outcome := range []struct {
+			err      error
+			outcome  ir.Outcome
+			conflict ir.ConflictReason
+		}{
+			{err: QuestNotApplied, outcome: ir.Failure},
+			{err: QuestStepApplied, outcome: ir.StepApplied},
+			{err: ur.ErrorUserAlreadyCompleteQuest, outcome: ir.Conflict, conflict: ir.ConflictCompleted},
+			{err: ur.ErrorStreakAlreadyExtended, outcome: ir.Conflict, conflict: ir.ConflictStreakExtended},
+			{err: ur.ErrorAlreadyContributed, outcome: ir.Conflict, conflict: ir.ConflictContributed},
+		} {
+			t.NewStep("Init mock")
+			uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(storedKey(ir.Pending), nil).Times(1)
+			uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, questId, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).Return(nil, outcome.err).Times(1)
+			uus.mockKeys.EXPECT().SetOutcome(gomock.Any(), key.Key, outcome.outcome, outcome.conflict, uint32(0), uint32(0)).Return(nil).Times(1)
+
+			t.NewStep("Check result")
+			_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
+			t.Require().ErrorIs(err, outcome.err)
+		}
+	})
+
+	t.WithNewStep("Correct store of quest progress", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		counterQuest := &qr.Quest{ID: questId, Type: types.COUNTER, Target: 10}
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(storedKey(ir.Pending), nil).Times(1)
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, questId, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			Return(&ur.Progress{Quest: counterQuest, Step: 3}, nil).Times(1)
+		uus.mockKeys.EXPECT().SetOutcome(gomock.Any(), key.Key, ir.StepApplied, ir.ConflictReason(""), uint32(3), uint32(10)).Return(nil).Times(1)
+
+		t.NewStep("Check result")
+		progress, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
+		t.Require().ErrorIs(err, QuestStepApplied)
+		t.Require().EqualValues(&QuestProgress{Step: 3, Total: 10}, progress)
+	})
+
+	t.WithNewStep("Not final outcome releases key", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(storedKey(ir.Pending), nil).Times(1)
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, questId, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).Return(nil, testError).Times(1)
+		uus.mockKeys.EXPECT().DeleteKey(gomock.Any(), key.Key).Return(nil).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
 		t.Require().ErrorIs(err, testError)
 	})
 
-	t.WithNewStep("Repository IsCompletedQuest method error", func(t provider.StepCtx) {
+	t.WithNewStep("Repository DeleteKey method error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockQuest.EXPECT().GetQuest(quest.ID).Return(repositoryQuest, nil).Times(1)
-		uus.mockUser.EXPECT().HasUser(userId).Return(nil).Times(1)
-		uus.mockUser.EXPECT().IsCompletedQuest(&ur.User{ID: userId}, repositoryQuest).Return(testError).Times(1)
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(storedKey(ir.Pending), nil).Times(1)
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, questId, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).Return(nil, ur.ErrorUserNotFound).Times(1)
+		uus.mockKeys.EXPECT().DeleteKey(gomock.Any(), key.Key).Return(testError).Times(1)
 
 		t.NewStep("Check result")
-		err := uus.userUsecase.ApplyQuests(quest.ID, userId)
+		_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
 		t.Require().ErrorIs(err, testError)
 	})
 
-	t.WithNewStep("Repository GetQuest method error", func(t provider.StepCtx) {
+	t.WithNewStep("Repository SetOutcome method error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		uus.mockQuest.EXPECT().GetQuest(quest.ID).Return(repositoryQuest, nil).Times(1)
-		uus.mockUser.EXPECT().HasUser(userId).Return(nil).Times(1)
-		uus.mockUser.EXPECT().IsCompletedQuest(&ur.User{ID: userId}, repositoryQuest).Return(nil).Times(1)
-		uus.mockUser.EXPECT().ApplyCost(&ur.User{ID: userId}, repositoryQuest).Return(testError).Times(1)
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(storedKey(ir.Pending), nil).Times(1)
+		uus.mockUser.EXPECT().CompleteQuest(context.Background(), userId, questId, uint32(1), gomock.Any(), testConfig.Referral, gomock.Any()).
+			Return(&ur.Progress{Quest: quest}, nil).Times(1)
+		uus.mockKeys.EXPECT().SetOutcome(gomock.Any(), key.Key, ir.Success, ir.ConflictReason(""), uint32(0), uint32(0)).Return(testError).Times(1)
 
 		t.NewStep("Check result")
-		err := uus.userUsecase.ApplyQuests(quest.ID, userId)
+		_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
 		t.Require().ErrorIs(err, testError)
 	})
 
-	t.WithNewStep("Correct random quest failure", func(t provider.StepCtx) {
+	t.WithNewStep("Repository ReserveKey method error", func(t provider.StepCtx) {
 		t.NewStep("Init mock")
-		rnd = rand.New(rand.NewSource(6))
-		uus.mockQuest.EXPECT().GetQuest(randomQuest.ID).Return(repositoryRandomQuest, nil).Times(1)
-		uus.mockUser.EXPECT().HasUser(userId).Return(nil).Times(1)
-		uus.mockUser.EXPECT().IsCompletedQuest(&ur.User{ID: userId}, repositoryRandomQuest).Return(nil).Times(1)
-		uus.mockUser.EXPECT().ApplyCost(&ur.User{ID: userId}, repositoryRandomQuest).Return(nil).Times(1)
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(nil, testError).Times(1)
 
 		t.NewStep("Check result")
-		err := uus.userUsecase.ApplyQuests(randomQuest.ID, userId)
-		t.Require().ErrorIs(err, QuestNotApplied)
+		_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
+		t.Require().ErrorIs(err, testError)
+	})
+
+	t.WithNewStep("Correct replay of stored outcomes", func(t provider.StepCtx) {
+		for _, outcome := range []struct {
+			outcome  ir.Outcome
+			conflict ir.ConflictReason
+			err      error
+		}{
+			{outcome: ir.Success, err: nil},
+			{outcome: ir.Failure, err: QuestNotApplied},
+			{outcome: ir.StepApplied, err: QuestStepApplied},
+			{outcome: ir.Conflict, conflict: ir.ConflictCompleted, err: ur.ErrorUserAlreadyCompleteQuest},
+			{outcome: ir.Conflict, conflict: ir.ConflictStreakExtended, err: ur.ErrorStreakAlreadyExtended},
+			{outcome: ir.Conflict, conflict: ir.ConflictContributed, err: ur.ErrorAlreadyContributed},
+			{outcome: ir.Pending, err: ErrorIdempotencyKeyInProgress},
+		} {
+			t.NewStep("Init mock")
+			replayedKey := storedKey(outcome.outcome)
+			replayedKey.Conflict = outcome.conflict
+			uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(replayedKey, ir.ErrorKeyExists).Times(1)
+
+			t.NewStep("Check result")
+			_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
+			if outcome.err == nil {
+				t.Require().NoError(err)
+			} else {
+				t.Require().ErrorIs(err, outcome.err)
+			}
+		}
+	})
+
+	t.WithNewStep("Correct replay of stored progress", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		stepKey := storedKey(ir.StepApplied)
+		stepKey.Step, stepKey.Total = 3, 10
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(stepKey, ir.ErrorKeyExists).Times(1)
+
+		t.NewStep("Check result")
+		progress, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
+		t.Require().ErrorIs(err, QuestStepApplied)
+		t.Require().EqualValues(&QuestProgress{Step: 3, Total: 10}, progress)
+	})
+
+	t.WithNewStep("Key used with other amount", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		otherKey := storedKey(ir.Success)
+		otherKey.Amount = 5
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(otherKey, ir.ErrorKeyExists).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
+		t.Require().ErrorIs(err, ErrorIdempotencyKeyMismatch)
+	})
+
+	t.WithNewStep("Key used with other parameters", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		otherKey := storedKey(ir.Success)
+		otherKey.QuestId = questId + 1
+		uus.mockKeys.EXPECT().ReserveKey(context.Background(), key, testConfig.KeyTTL).Return(otherKey, ir.ErrorKeyExists).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ApplyQuestsIdempotent(context.Background(), key.Key, questId, userId, 1)
+		t.Require().ErrorIs(err, ErrorIdempotencyKeyMismatch)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestGetUserProgressFunction(t provider.T) {
+	t.Title("GetUserProgress function of user usecase")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	progress := []Progress{
+		{
+			Quest: &qu.Quest{
+				ID:    1,
+				Name:  "Staged",
+				Type:  types.STAGED,
+				Steps: []string{"first", "second"},
+			},
+			Step: 1,
+		},
+	}
+
+	repositoryProgress := []ur.Progress{
+		{
+			Quest: &qr.Quest{
+				ID:    progress[0].Quest.ID,
+				Name:  progress[0].Quest.Name,
+				Type:  progress[0].Quest.Type,
+				Steps: progress[0].Quest.Steps,
+			},
+			Step: progress[0].Step,
+		},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().GetProgress(context.Background(), userId).Return(repositoryProgress, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := uus.userUsecase.GetUserProgress(context.Background(), userId)
+		t.Require().NoError(err)
+		t.Require().Equal(progress, res)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().GetProgress(context.Background(), userId).Return(nil, testError).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.GetUserProgress(context.Background(), userId)
+		t.Require().ErrorIs(err, testError)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestGetAvailableQuestsFunction(t provider.T) {
+	t.Title("GetAvailableQuests function of user usecase")
+	t.NewStep("Init test data")
+	userId := types.Id(1)
+	repositoryQuests := []qr.Quest{
+		{ID: 2, Name: "First", Type: types.USUAL, MaxCompletions: 1},
+		{ID: 4, Name: "Second", Type: types.USUAL, MaxCompletions: 1, Prerequisites: []types.Id{2}},
+	}
+	quests := []qu.Quest{
+		{ID: 2, Name: "First", Type: types.USUAL, MaxCompletions: 1},
+		{ID: 4, Name: "Second", Type: types.USUAL, MaxCompletions: 1, Prerequisites: []types.Id{2}},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().GetAvailableQuests(context.Background(), &ur.AvailableQuestsQuery{
+			UserId: userId,
+			Limit:  page.DefaultLimit + 1,
+		}).Return(repositoryQuests, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := uus.userUsecase.GetAvailableQuests(context.Background(), userId, &AvailableQuestsQuery{})
+		t.Require().NoError(err)
+		t.Require().Equal(&qu.QuestsPage{Quests: quests}, res)
+	})
+
+	t.WithNewStep("Correct execute with next page", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		cursor := &page.Cursor{Sort: string(types.QuestsSortID), Order: page.Asc, ID: 1}
+		uus.mockUser.EXPECT().GetAvailableQuests(context.Background(), &ur.AvailableQuestsQuery{
+			UserId: userId,
+			After:  cursor,
+			Limit:  2,
+		}).Return(repositoryQuests, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := uus.userUsecase.GetAvailableQuests(context.Background(), userId, &AvailableQuestsQuery{
+			Cursor: cursor.Encode(),
+			Limit:  1,
+		})
+		t.Require().NoError(err)
+		t.Require().Equal(&qu.QuestsPage{
+			Quests:     quests[:1],
+			NextCursor: (&page.Cursor{Sort: string(types.QuestsSortID), Order: page.Asc, ID: 2}).Encode(),
+		}, res)
+	})
+
+	t.WithNewStep("Invalid cursor error", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.GetAvailableQuests(context.Background(), userId, &AvailableQuestsQuery{Cursor: "qwerty"})
+		t.Require().ErrorIs(err, page.ErrorInvalidCursor)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().GetAvailableQuests(context.Background(), gomock.Any()).
+			Return(nil, ur.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.GetAvailableQuests(context.Background(), userId, &AvailableQuestsQuery{})
+		t.Require().ErrorIs(err, ur.ErrorUserNotFound)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestRevokeQuestFunction(t provider.T) {
+	t.Title("RevokeQuest function of user usecase")
+	t.NewStep("Init test data")
+	completed := pkgtime.FormattedTime{Time: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	created := pkgtime.FormattedTime{Time: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}
+	userId, questId := types.Id(1), types.Id(2)
+	reason := "fraud"
+
+	revocation := &Revocation{
+		ID:      4,
+		QuestId: questId,
+		Snapshot: QuestSnapshot{
+			Name:        "Quest",
+			Description: "usual quest",
+			Type:        types.USUAL,
+		},
+		Award:     15,
+		Completed: completed,
+		Reason:    reason,
+		Transaction: Transaction{
+			ID:      3,
+			UserId:  userId,
+			Kind:    string(lr.Debit),
+			Amount:  10,
+			Reason:  lr.QuestRevoke,
+			QuestId: &questId,
+			Balance: 0,
+			Created: created,
+		},
+		Created: created,
+	}
+
+	repositoryRevocation := &ur.Revocation{
+		ID:      revocation.ID,
+		QuestId: questId,
+		Snapshot: ur.QuestSnapshot{
+			Name:        revocation.Snapshot.Name,
+			Description: revocation.Snapshot.Description,
+			Type:        revocation.Snapshot.Type,
+		},
+		Award:     revocation.Award,
+		Completed: completed,
+		Reason:    reason,
+		Transaction: lr.Transaction{
+			ID:      revocation.Transaction.ID,
+			UserId:  userId,
+			Kind:    lr.Debit,
+			Amount:  revocation.Transaction.Amount,
+			Reason:  lr.QuestRevoke,
+			QuestId: &questId,
+			Balance: 0,
+			Created: created,
+		},
+		Created: created,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().RevokeQuest(context.Background(), userId, questId, reason, testConfig.RevokePolicy,
+			testConfig.Referral).
+			Return(repositoryRevocation, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := uus.userUsecase.RevokeQuest(context.Background(), userId, questId, reason)
+		t.Require().NoError(err)
+		t.Require().Equal(revocation, res)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockUser.EXPECT().RevokeQuest(context.Background(), userId, questId, reason, testConfig.RevokePolicy,
+			testConfig.Referral).
+			Return(nil, ur.ErrorCompletionNotFound).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.RevokeQuest(context.Background(), userId, questId, reason)
+		t.Require().ErrorIs(err, ur.ErrorCompletionNotFound)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestDebitFunction(t provider.T) {
+	t.Title("Debit function of user usecase")
+	t.NewStep("Init test data")
+	created := pkgtime.FormattedTime{Time: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}
+	transaction := &Transaction{
+		ID:      2,
+		UserId:  1,
+		Kind:    string(lr.Debit),
+		Amount:  15,
+		Reason:  "shop",
+		Balance: 5,
+		Created: created,
+	}
+
+	repositoryTransaction := &lr.Transaction{
+		ID:      transaction.ID,
+		UserId:  transaction.UserId,
+		Kind:    lr.Debit,
+		Amount:  transaction.Amount,
+		Reason:  transaction.Reason,
+		Balance: transaction.Balance,
+		Created: created,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockLedger.EXPECT().Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason).
+			Return(repositoryTransaction, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := uus.userUsecase.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().NoError(err)
+		t.Require().Equal(transaction, res)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockLedger.EXPECT().Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason).
+			Return(nil, lr.ErrorInsufficientFunds).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.Debit(context.Background(), transaction.UserId, transaction.Amount, transaction.Reason)
+		t.Require().ErrorIs(err, lr.ErrorInsufficientFunds)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestTransferFunction(t provider.T) {
+	t.Title("Transfer function of user usecase")
+	t.NewStep("Init test data")
+	created := pkgtime.FormattedTime{Time: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}
+	from, to := types.Id(1), types.Id(2)
+	amount := uint64(15)
+	transfer := &Transfer{
+		Debit: Transaction{
+			ID:             3,
+			UserId:         from,
+			Kind:           string(lr.Debit),
+			Amount:         amount,
+			Reason:         lr.TransferReason,
+			CounterpartyId: &to,
+			Balance:        5,
+			Created:        created,
+		},
+		Credit: Transaction{
+			ID:             4,
+			UserId:         to,
+			Kind:           string(lr.Credit),
+			Amount:         amount,
+			Reason:         lr.TransferReason,
+			CounterpartyId: &from,
+			Balance:        40,
+			Created:        created,
+		},
+	}
+
+	repositoryTransfer := &lr.Transfer{
+		Debit: lr.Transaction{
+			ID:             3,
+			UserId:         from,
+			Kind:           lr.Debit,
+			Amount:         amount,
+			Reason:         lr.TransferReason,
+			CounterpartyId: &to,
+			Balance:        5,
+			Created:        created,
+		},
+		Credit: lr.Transaction{
+			ID:             4,
+			UserId:         to,
+			Kind:           lr.Credit,
+			Amount:         amount,
+			Reason:         lr.TransferReason,
+			CounterpartyId: &from,
+			Balance:        40,
+			Created:        created,
+		},
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockLedger.EXPECT().Transfer(context.Background(), from, to, amount, uint64(testConfig.DailyTransferLimit)).
+			Return(repositoryTransfer, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := uus.userUsecase.Transfer(context.Background(), from, to, amount)
+		t.Require().NoError(err)
+		t.Require().Equal(transfer, res)
+	})
+
+	t.WithNewStep("Self transfer execute", func(t provider.StepCtx) {
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.Transfer(context.Background(), from, from, amount)
+		t.Require().ErrorIs(err, ErrorSelfTransfer)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockLedger.EXPECT().Transfer(context.Background(), from, to, amount, uint64(testConfig.DailyTransferLimit)).
+			Return(nil, lr.ErrorTransferLimitExceeded).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.Transfer(context.Background(), from, to, amount)
+		t.Require().ErrorIs(err, lr.ErrorTransferLimitExceeded)
+	})
+}
+
+func (uus *UserUsecaseSuite) TestReconcileBalanceFunction(t provider.T) {
+	t.Title("ReconcileBalance function of user usecase")
+	t.NewStep("Init test data")
+	reconciliation := &Reconciliation{
+		UserId: 1,
+		Cached: 25,
+		Ledger: 20,
+	}
+
+	t.WithNewStep("Correct execute", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockLedger.EXPECT().Reconcile(context.Background(), reconciliation.UserId).
+			Return(&lr.Reconciliation{UserId: 1, Cached: 25, Ledger: 20}, nil).Times(1)
+
+		t.NewStep("Check result")
+		res, err := uus.userUsecase.ReconcileBalance(context.Background(), reconciliation.UserId)
+		t.Require().NoError(err)
+		t.Require().Equal(reconciliation, res)
+	})
+
+	t.WithNewStep("Repository error", func(t provider.StepCtx) {
+		t.NewStep("Init mock")
+		uus.mockLedger.EXPECT().Reconcile(context.Background(), reconciliation.UserId).
+			Return(nil, lr.ErrorUserNotFound).Times(1)
+
+		t.NewStep("Check result")
+		_, err := uus.userUsecase.ReconcileBalance(context.Background(), reconciliation.UserId)
+		t.Require().ErrorIs(err, lr.ErrorUserNotFound)
 	})
 }
 
@@ -1,11 +1,24 @@
 CREATE TABLE IF NOT EXISTS users
 (
-    id      bigserial   not null primary key,
-    name    text unique not null,
-    balance bigint      not null default 0 check (balance >= 0)
+    id         bigserial   not null primary key,
+    name       text        not null, -- уникально среди неудалённых пользователей, пустое у обезличенных
+    balance    bigint      not null default 0,
+    -- Допустимый долг: растёт только при отзыве награды с политикой negative, остальные списания требуют баланса
+    overdraft  bigint      not null default 0 check (overdraft >= 0),
+    deleted_at timestamptz null, -- удалённый пользователь скрыт, но его история и журнал операций сохраняются
+    referral_code text     not null unique default substr(md5(random()::text || clock_timestamp()::text), 1, 10),
+    -- Пригласивший пользователь задаётся только при создании, поэтому он всегда создан раньше приглашённого и цепочки приглашений не замыкаются
+    referrer_id bigint     null references users (id) on delete SET NULL,
+    referral_rewarded_at timestamptz null, -- время выплаты бонуса за приглашение, бонус выплачивается один раз
+    CONSTRAINT users_self_referral_check CHECK (referrer_id != id),
+    CONSTRAINT users_balance_check CHECK (balance >= -overdraft)
 );
 
-CREATE TYPE quest_type as ENUM ('usual', 'random');
+CREATE UNIQUE INDEX IF NOT EXISTS users_active_name_idx ON users (name) WHERE deleted_at IS NULL;
+
+CREATE TYPE quest_type as ENUM ('usual', 'random', 'staged', 'counter', 'streak', 'team');
+
+CREATE TYPE reward_split as ENUM ('equal', 'fixed');
 
 CREATE TABLE IF NOT EXISTS quests
 (
@@ -13,15 +26,223 @@ CREATE TABLE IF NOT EXISTS quests
     name        text      not null unique,
     description text      not null,
     cost        bigint    not null check (cost >= 0 and cost <= 1000),
-    type        quest_type not null
+    type        quest_type not null,
+    steps       text[]    not null default '{}',
+    max_completions integer not null default 1 check (max_completions >= 0),
+    cooldown    bigint    not null default 0 check (cooldown >= 0), -- seconds
+    prerequisites bigint[] not null default '{}', -- задачи, которые нужно выполнить до этой
+    starts_at   timestamptz null, -- задача засчитывается только в период [starts_at, ends_at)
+    ends_at     timestamptz null,
+    probability double precision not null default 0.5 check (probability >= 0 and probability <= 1), -- вероятность выполнения случайной задачи
+    pity        integer   not null default 0 check (pity >= 0), -- случайная задача выполняется после pity неудач подряд, 0 - без гарантии
+    target      integer   not null default 0 check (target >= 0), -- сумма, которую нужно накопить для выполнения задачи-счётчика, или число участников командной задачи
+    milestone_days    integer[] not null default '{}', -- длины серии дней, на которых задача-серия выплачивает награду
+    milestone_rewards bigint[]  not null default '{}', -- награды за соответствующие длины серии
+    reward_split reward_split not null default 'equal', -- награда командной задачи делится поровну или выплачивается каждому участнику
+    archived_at timestamptz null, -- архивная задача скрыта из списков и не выполняется, null - задача не в архиве
+    CONSTRAINT staged_steps_check CHECK (type != 'staged' or cardinality(steps) > 0),
+    CONSTRAINT counter_target_check CHECK (type != 'counter' or target > 0),
+    CONSTRAINT streak_milestones_check CHECK (type != 'streak' or cardinality(milestone_days) > 0),
+    CONSTRAINT team_target_check CHECK (type != 'team' or target > 0),
+    CONSTRAINT quests_milestones_check CHECK (cardinality(milestone_days) = cardinality(milestone_rewards)),
+    CONSTRAINT quests_window_check CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS users_name_idx ON users (name, id);
+CREATE INDEX IF NOT EXISTS users_balance_idx ON users (balance, id);
+CREATE INDEX IF NOT EXISTS quests_name_idx ON quests (name, id);
+CREATE INDEX IF NOT EXISTS quests_cost_idx ON quests (cost, id);
+-- Индекс для поиска задач, зависящих от удаляемой
+CREATE INDEX IF NOT EXISTS quests_prerequisites_idx ON quests USING gin (prerequisites);
+-- Индексы для фильтрации по префиксу имени через LIKE
+CREATE INDEX IF NOT EXISTS users_name_prefix_idx ON users (name text_pattern_ops);
+CREATE INDEX IF NOT EXISTS quests_name_prefix_idx ON quests (name text_pattern_ops);
+
+-- Категории, по которым клиент группирует задачи
+CREATE TABLE IF NOT EXISTS categories
+(
+    id          bigserial not null primary key,
+    name        text      not null unique,
+    description text      not null default ''
+);
+
+CREATE TABLE IF NOT EXISTS quest_categories
+(
+    quest_id    bigint not null references quests (id) on delete cascade,
+    category_id bigint not null references categories (id) on delete cascade,
+    primary key (quest_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS quest_categories_category_idx ON quest_categories (category_id, quest_id);
+
+-- Произвольные метки задач
+CREATE TABLE IF NOT EXISTS quest_tags
+(
+    quest_id bigint not null references quests (id) on delete cascade,
+    tag      text   not null check (tag != ''),
+    primary key (quest_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS quest_tags_tag_idx ON quest_tags (tag, quest_id);
+
+-- Команды пользователей для командных задач
+CREATE TABLE IF NOT EXISTS teams
+(
+    id      bigserial not null primary key,
+    name    text      not null unique,
+    created timestamp not null default now()
+);
+
+CREATE TABLE IF NOT EXISTS team_members
+(
+    user_id bigint    not null primary key references users (id) on delete cascade, -- пользователь состоит не более чем в одной команде
+    team_id bigint    not null references teams (id) on delete cascade,
+    joined  timestamp not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS team_members_team_idx ON team_members (team_id, user_id);
+
+-- Вклады участников в текущее выполнение командной задачи, удаляются при её выполнении командой или выходе участника
+CREATE TABLE IF NOT EXISTS team_contributions
+(
+    team_id  bigint    not null references teams (id) on delete cascade,
+    quest_id bigint    not null references quests (id) on delete cascade,
+    user_id  bigint    not null references users (id) on delete cascade,
+    created  timestamp not null default now(),
+    primary key (team_id, quest_id, user_id)
+);
+
+-- Акции, умножающие награды за выполнение задач в заданный период, например «двойные очки на выходных».
+-- Пустые quest_id, category_id и quest_type не ограничивают акцию, из нескольких подходящих акций применяется наибольший множитель
+CREATE TABLE IF NOT EXISTS boosts
+(
+    id          bigserial        not null primary key,
+    name        text             not null,
+    multiplier  double precision not null check (multiplier >= 1 and multiplier <= 10),
+    starts_at   timestamptz      not null,
+    ends_at     timestamptz      not null,
+    quest_id    bigint           null references quests (id) on delete cascade,
+    category_id bigint           null references categories (id) on delete cascade,
+    quest_type  quest_type       null,
+    CONSTRAINT boosts_window_check CHECK (starts_at < ends_at)
 );
 
+CREATE INDEX IF NOT EXISTS boosts_window_idx ON boosts (ends_at, starts_at);
+
+-- Источник изменения баланса в истории: выполнение задачи, бонус за приглашение, входящий или исходящий перевод
+-- или отзыв выполнения задачи
+CREATE TYPE history_source as ENUM ('quest', 'referral', 'transfer_in', 'transfer_out', 'revoke');
+
 CREATE TABLE IF NOT EXISTS balance_history
 (
     id      bigserial not null primary key,
     user_id bigint    not null references users (id) on delete cascade,
     quest_id bigint    null references quests (id) on delete SET NULL,
+    award             bigint     not null, -- для переводов - переведённая сумма, для отзыва - списанная сумма
+    base_award        bigint     not null, -- награда до применения множителя акции
+    multiplier        double precision not null default 1, -- множитель акции, 1 если акция не применялась
+    -- Состояние задания на момент выполнения, не меняется при изменении или удалении задания, пустое у переводов
+    quest_name        text       null,
+    quest_description text       null,
+    quest_type        quest_type null,
+    source  history_source not null default 'quest', -- бонус за приглашение хранит задачу, выполнение которой его принесло
+    counterparty_id bigint null references users (id) on delete SET NULL, -- второй участник перевода
+    reason     text      null, -- причина отзыва у записей отзыва
+    revoked_at timestamp null, -- время отзыва выполнения, отозванное выполнение не учитывается в выполнениях задачи
     created timestamp not null default now(),
     balance bigint    not null,
-    CONSTRAINT quest_unique UNIQUE NULLS NOT DISTINCT (user_id, quest_id)
+    CONSTRAINT balance_history_snapshot_check CHECK (
+        source IN ('transfer_in', 'transfer_out')
+            OR (quest_name IS NOT NULL AND quest_description IS NOT NULL AND quest_type IS NOT NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS balance_history_user_quest_idx ON balance_history (user_id, quest_id, created);
+CREATE INDEX IF NOT EXISTS balance_history_user_created_idx ON balance_history (user_id, created, id);
+
+-- Очки рейтинга: сумма наград за выполнение задач из balance_history за период, обновляется вместе с историей
+CREATE TYPE leaderboard_period as ENUM ('all', 'week', 'month');
+
+CREATE TABLE IF NOT EXISTS leaderboard_scores
+(
+    period       leaderboard_period not null,
+    period_start timestamp          not null, -- начало недели или месяца, 'epoch' для всего времени
+    user_id      bigint             not null references users (id) on delete cascade,
+    score        bigint             not null default 0,
+    primary key (period, period_start, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS leaderboard_scores_rank_idx ON leaderboard_scores (period, period_start, score DESC, user_id);
+-- Индекс для рейтинга по текущему балансу
+CREATE INDEX IF NOT EXISTS users_active_balance_idx ON users (balance DESC, id) WHERE deleted_at IS NULL;
+
+-- Заполнение очков по уже накопленной истории
+INSERT INTO leaderboard_scores (period, period_start, user_id, score)
+SELECT 'all'::leaderboard_period, 'epoch'::timestamp, user_id, sum(award) FROM balance_history WHERE source = 'quest' GROUP BY user_id
+UNION ALL
+SELECT 'week', date_trunc('week', created), user_id, sum(award) FROM balance_history WHERE source = 'quest' GROUP BY 2, user_id
+UNION ALL
+SELECT 'month', date_trunc('month', created), user_id, sum(award) FROM balance_history WHERE source = 'quest' GROUP BY 2, user_id
+ON CONFLICT DO NOTHING;
+
+-- Журнал операций с балансом: users.balance хранит сумму начислений за вычетом списаний
+CREATE TYPE transaction_kind as ENUM ('credit', 'debit');
+
+CREATE TABLE IF NOT EXISTS transactions
+(
+    id              bigserial        not null primary key,
+    user_id         bigint           not null references users (id) on delete cascade,
+    kind            transaction_kind not null,
+    amount          bigint           not null check (amount >= 0),
+    reason          text             not null,
+    quest_id        bigint           null references quests (id) on delete SET NULL,
+    counterparty_id bigint           null references users (id) on delete SET NULL, -- второй участник перевода
+    balance         bigint           not null, -- баланс пользователя после операции
+    created         timestamp        not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS transactions_user_idx ON transactions (user_id, created, id);
+
+-- Начальный остаток пользователей, баланс которых накоплен до появления журнала, иначе сверка обнулит их баланс
+INSERT INTO transactions (user_id, kind, amount, reason, balance)
+SELECT id, 'credit', balance, 'opening_balance', balance FROM users u
+WHERE balance > 0 AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.user_id = u.id);
+
+CREATE TABLE IF NOT EXISTS quest_progress
+(
+    user_id  bigint    not null references users (id) on delete cascade,
+    quest_id bigint    not null references quests (id) on delete cascade,
+    step     integer   not null default 0 check (step >= 0), -- шаг многошаговой задачи, накопленная сумма задачи-счётчика или длина серии
+    day      date      null, -- последний день серии в часовом поясе сервиса
+    updated  timestamp not null default now(),
+    primary key (user_id, quest_id)
+);
+
+-- Попытки выполнить случайную задачу, неудачи после последней успешной попытки учитываются в pity
+CREATE TABLE IF NOT EXISTS quest_attempts
+(
+    id       bigserial        not null primary key,
+    user_id  bigint           not null references users (id) on delete cascade,
+    quest_id bigint           null references quests (id) on delete SET NULL,
+    success  boolean          not null,
+    roll     double precision null, -- выпавшее значение, null если выполнение гарантировано pity
+    created  timestamp        not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS quest_attempts_user_quest_idx ON quest_attempts (user_id, quest_id, created);
+CREATE INDEX IF NOT EXISTS quest_attempts_user_idx ON quest_attempts (user_id, id);
+
+CREATE TYPE idempotency_outcome as ENUM ('pending', 'success', 'failure', 'step', 'conflict');
+
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    key      text                not null primary key,
+    user_id  bigint              not null,
+    quest_id bigint              not null,
+    amount   integer             not null default 1, -- количество, переданное в запросе
+    outcome  idempotency_outcome not null default 'pending',
+    conflict text                null, -- причина конфликта при outcome = 'conflict', чтобы повтор вернул ту же ошибку
+    step     integer             not null default 0, -- прогресс задания после выполнения запроса
+    total    integer             not null default 0, -- 0 если задание не отслеживает прогресс
+    created  timestamp           not null default now()
 );